@@ -5,9 +5,12 @@ package action
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 )
 
 // Client provides access to the action facade.
@@ -30,6 +33,17 @@ func (c *Client) Actions(arg params.Entities) (params.ActionResults, error) {
 	return results, err
 }
 
+// ActionOutputs takes a list of ActionTags, and returns the full Action
+// for each ID, including output too large to be returned by Actions.
+func (c *Client) ActionOutputs(arg params.Entities) (params.ActionResults, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ActionResults{}, errors.NotSupportedf("ActionOutputs")
+	}
+	results := params.ActionResults{}
+	err := c.facade.FacadeCall("ActionOutputs", arg, &results)
+	return results, err
+}
+
 // FindActionTagsByPrefix takes a list of string prefixes and finds
 // corresponding ActionTags that match that prefix.
 func (c *Client) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsResults, error) {
@@ -48,6 +62,61 @@ func (c *Client) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return results, err
 }
 
+// EnqueueApplicationActions takes a list of ApplicationActions and queues
+// each up to be executed by all the units of its application, returning
+// the aggregate result for each.
+func (c *Client) EnqueueApplicationActions(arg params.ApplicationActions) (params.ActionBatchResults, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ActionBatchResults{}, errors.NotSupportedf("EnqueueApplicationActions")
+	}
+	results := params.ActionBatchResults{}
+	err := c.facade.FacadeCall("EnqueueApplicationActions", arg, &results)
+	return results, err
+}
+
+// ActionBatches returns the aggregate results of the action batches with
+// the given ids.
+func (c *Client) ActionBatches(arg params.ActionBatchIds) (params.ActionBatchResults, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ActionBatchResults{}, errors.NotSupportedf("ActionBatches")
+	}
+	results := params.ActionBatchResults{}
+	err := c.facade.FacadeCall("ActionBatches", arg, &results)
+	return results, err
+}
+
+// AddActionSchedules adds schedules that periodically enqueue actions
+// on their receivers.
+func (c *Client) AddActionSchedules(arg params.ActionSchedules) (params.ErrorResults, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ErrorResults{}, errors.NotSupportedf("AddActionSchedules")
+	}
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("AddActionSchedules", arg, &results)
+	return results, err
+}
+
+// ActionSchedules returns all the action schedules in the model.
+func (c *Client) ActionSchedules() (params.ActionScheduleResults, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ActionScheduleResults{}, errors.NotSupportedf("ActionSchedules")
+	}
+	results := params.ActionScheduleResults{}
+	err := c.facade.FacadeCall("ActionSchedules", nil, &results)
+	return results, err
+}
+
+// RemoveActionSchedules removes the action schedules with the given
+// names.
+func (c *Client) RemoveActionSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ErrorResults{}, errors.NotSupportedf("RemoveActionSchedules")
+	}
+	results := params.ErrorResults{}
+	err := c.facade.FacadeCall("RemoveActionSchedules", arg, &results)
+	return results, err
+}
+
 // FindActionsByNames takes a list of action names and returns actions for
 // every name.
 func (c *Client) FindActionsByNames(arg params.FindActionsByNames) (params.ActionsByNames, error) {
@@ -118,3 +187,28 @@ func (c *Client) ApplicationCharmActions(arg params.Entity) (map[string]params.A
 	}
 	return result.Actions, nil
 }
+
+// WatchActionProgress returns a watcher that reports on action log messages.
+// The result strings are JSON encoded params.ActionMessage values.
+func (c *Client) WatchActionProgress(actionId string) (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("WatchActionProgress")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewActionTag(actionId).String()}},
+	}
+	err := c.facade.FacadeCall("WatchActionsProgress", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
@@ -127,3 +127,23 @@ func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults [
 		},
 	)
 }
+
+func (s *actionSuite) TestWatchActionProgressError(c *gc.C) {
+	const actionId = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "WatchActionsProgress")
+			c.Assert(paramsIn, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "action-" + actionId}},
+			})
+			result := resp.(*params.StringsWatchResults)
+			result.Results = []params.StringsWatchResult{{
+				Error: &params.Error{Message: "boom"},
+			}}
+			return nil
+		},
+	)
+	defer cleanup()
+	_, err := s.client.WatchActionProgress(actionId)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SchedulerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&SchedulerSuite{})
+
+func (s *SchedulerSuite) TestRunDueSchedules(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "RunDueSchedules",
+		Results:       params.ErrorResult{},
+	})
+	api := actionscheduler.NewAPI(caller)
+	err := api.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller.CallCount, gc.Equals, 1)
+}
+
+func (s *SchedulerSuite) TestRunDueSchedulesResultError(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "RunDueSchedules",
+		Results: params.ErrorResult{
+			Error: &params.Error{Message: "boom"},
+		},
+	})
+	api := actionscheduler.NewAPI(caller)
+	err := api.RunDueSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *SchedulerSuite) TestRunDueSchedulesCallError(c *gc.C) {
+	caller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "ActionScheduler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "RunDueSchedules",
+		Results:       params.ErrorResult{},
+		Error:         errors.New("kaboom"),
+	})
+	api := actionscheduler.NewAPI(caller)
+	err := api.RunDueSchedules()
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
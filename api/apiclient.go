@@ -130,12 +130,13 @@ type state struct {
 	// access it safely.
 	loggedIn int32
 
-	// tag, password, macaroons and nonce hold the cached login
-	// credentials. These are only valid if loggedIn is 1.
+	// tag, password, macaroons, nonce and idToken hold the cached
+	// login credentials. These are only valid if loggedIn is 1.
 	tag       string
 	password  string
 	macaroons []macaroon.Slice
 	nonce     string
+	idToken   string
 
 	// serverRootAddress holds the cached API server address and port used
 	// to login.
@@ -242,6 +243,7 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 		password:     info.Password,
 		macaroons:    info.Macaroons,
 		nonce:        info.Nonce,
+		idToken:      info.IDToken,
 		tlsConfig:    dialResult.tlsConfig,
 		bakeryClient: bakeryClient,
 		modelTag:     info.ModelTag,
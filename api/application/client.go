@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/storage"
 )
 
@@ -816,3 +817,122 @@ func (c *Client) ResolveUnitErrors(units []string, all, retry bool) error {
 	}
 	return errors.Trace(results.Combine())
 }
+
+// UnitsHookExecutions returns the hook history of each of the given
+// units, newest first.
+func (c *Client) UnitsHookExecutions(units []string) ([]params.HookExecutionsResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("UnitsHookExecutions not supported by this version of Juju")
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(units))}
+	for i, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return nil, errors.NotValidf("unit name %q", unit)
+		}
+		args.Entities[i].Tag = names.NewUnitTag(unit).String()
+	}
+	var results params.HookExecutionsResults
+	err := c.facade.FacadeCall("UnitsHookExecutions", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// GrantApplication grants the named role to a user on the specified
+// applications. The role may be one added with AddRole, or one of the
+// application access levels.
+func (c *Client) GrantApplication(user, role string, applications ...string) error {
+	return c.modifyApplicationUser(params.GrantApplicationAccess, user, role, applications)
+}
+
+// RevokeApplication revokes any role granted to a user on the specified
+// applications.
+func (c *Client) RevokeApplication(user string, applications ...string) error {
+	return c.modifyApplicationUser(params.RevokeApplicationAccess, user, "", applications)
+}
+
+func (c *Client) modifyApplicationUser(action params.ApplicationAction, user, role string, applications []string) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("application access not supported by this version of Juju")
+	}
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	userTag := names.NewUserTag(user)
+
+	var args params.ModifyApplicationAccessRequest
+	for _, application := range applications {
+		if !names.IsValidApplication(application) {
+			return errors.NotValidf("application name %q", application)
+		}
+		args.Changes = append(args.Changes, params.ModifyApplicationAccess{
+			UserTag:        userTag.String(),
+			Action:         action,
+			Role:           role,
+			ApplicationTag: names.NewApplicationTag(application).String(),
+		})
+	}
+
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyApplicationAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+	return result.Combine()
+}
+
+// AddRole adds a role made up of the given capabilities to the model.
+func (c *Client) AddRole(role permission.Role) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("AddRole not supported by this version of Juju")
+	}
+	arg := params.ApplicationRole{Name: role.Name}
+	for _, capability := range role.Capabilities {
+		arg.Capabilities = append(arg.Capabilities, string(capability))
+	}
+	args := params.ApplicationRoles{Roles: []params.ApplicationRole{arg}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddRoles", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveRole removes the named role from the model.
+func (c *Client) RemoveRole(name string) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("RemoveRole not supported by this version of Juju")
+	}
+	args := params.ApplicationRoleNames{Names: []string{name}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveRoles", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Roles returns the roles added to the model.
+func (c *Client) Roles() ([]permission.Role, error) {
+	if c.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("Roles not supported by this version of Juju")
+	}
+	var result params.ApplicationRoles
+	if err := c.facade.FacadeCall("Roles", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	roles := make([]permission.Role, len(result.Roles))
+	for i, r := range result.Roles {
+		roles[i].Name = r.Name
+		for _, capability := range r.Capabilities {
+			roles[i].Capabilities = append(roles[i].Capabilities, permission.Capability(capability))
+		}
+	}
+	return roles, nil
+}
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -19,6 +21,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -1121,3 +1124,154 @@ func (s *applicationSuite) TestResolveUnitErrorsAll(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *applicationSuite) TestUnitsHookExecutions(c *gc.C) {
+	started := time.Date(2018, 6, 15, 10, 30, 0, 0, time.UTC)
+	execution := params.HookExecution{
+		Kind:     "hook",
+		Name:     "install",
+		Started:  started,
+		Finished: started.Add(time.Second),
+	}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(request, gc.Equals, "UnitsHookExecutions")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+			})
+			result := response.(*params.HookExecutionsResults)
+			result.Results = []params.HookExecutionsResult{{
+				Executions: []params.HookExecution{execution},
+			}}
+			return nil
+		},
+		BestVersion: 8,
+	})
+	results, err := client.UnitsHookExecutions([]string{"mysql/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.HookExecutionsResult{{
+		Executions: []params.HookExecution{execution},
+	}})
+}
+
+func (s *applicationSuite) TestUnitsHookExecutionsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	})
+	_, err := client.UnitsHookExecutions([]string{"mysql/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestGrantApplication(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(request, gc.Equals, "ModifyApplicationAccess")
+			c.Check(a, jc.DeepEquals, params.ModifyApplicationAccessRequest{
+				Changes: []params.ModifyApplicationAccess{{
+					UserTag:        "user-bob",
+					Action:         params.GrantApplicationAccess,
+					Role:           "operator",
+					ApplicationTag: "application-mysql",
+				}},
+			})
+			result := response.(*params.ErrorResults)
+			result.Results = []params.ErrorResult{{}}
+			return nil
+		},
+		BestVersion: 9,
+	})
+	err := client.GrantApplication("bob", "operator", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestRevokeApplication(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(request, gc.Equals, "ModifyApplicationAccess")
+			c.Check(a, jc.DeepEquals, params.ModifyApplicationAccessRequest{
+				Changes: []params.ModifyApplicationAccess{{
+					UserTag:        "user-bob",
+					Action:         params.RevokeApplicationAccess,
+					ApplicationTag: "application-mysql",
+				}},
+			})
+			result := response.(*params.ErrorResults)
+			result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+			return nil
+		},
+		BestVersion: 9,
+	})
+	err := client.RevokeApplication("bob", "mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestApplicationAccessNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	})
+	err := client.GrantApplication("bob", "config", "mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.Roles()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestAddRole(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(request, gc.Equals, "AddRoles")
+			c.Check(a, jc.DeepEquals, params.ApplicationRoles{
+				Roles: []params.ApplicationRole{{
+					Name:         "operator",
+					Capabilities: []string{"Application.SetApplicationsConfig", "Action.Enqueue"},
+				}},
+			})
+			result := response.(*params.ErrorResults)
+			result.Results = []params.ErrorResult{{}}
+			return nil
+		},
+		BestVersion: 9,
+	})
+	err := client.AddRole(permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestRemoveRole(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(request, gc.Equals, "RemoveRoles")
+			c.Check(a, jc.DeepEquals, params.ApplicationRoleNames{Names: []string{"operator"}})
+			result := response.(*params.ErrorResults)
+			result.Results = []params.ErrorResult{{}}
+			return nil
+		},
+		BestVersion: 9,
+	})
+	err := client.RemoveRole("operator")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestRoles(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(request, gc.Equals, "Roles")
+			result := response.(*params.ApplicationRoles)
+			result.Roles = []params.ApplicationRole{{
+				Name:         "operator",
+				Capabilities: []string{"Application.SetApplicationsConfig", "Action.Enqueue"},
+			}}
+			return nil
+		},
+		BestVersion: 9,
+	})
+	roles, err := client.Roles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roles, jc.DeepEquals, []permission.Role{{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"},
+	}})
+}
@@ -0,0 +1,182 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+const (
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultDevicePollInterval is how long to wait between polls of
+	// the token endpoint if the identity provider doesn't say.
+	defaultDevicePollInterval = 5 * time.Second
+)
+
+// DeviceLogin obtains an ID token from an OpenID Connect identity
+// provider using the OAuth 2.0 device authorization grant (RFC 8628),
+// in which the user approves the login in a web browser, possibly on
+// another device.
+type DeviceLogin struct {
+	// IssuerURL holds the URL of the identity provider.
+	IssuerURL string
+
+	// ClientID holds the OAuth 2.0 client ID to obtain the token for.
+	ClientID string
+
+	// Scopes holds the scopes to request.
+	Scopes []string
+
+	// Client is used to make requests to the identity provider. If
+	// it is nil, http.DefaultClient is used.
+	Client *http.Client
+
+	// Clock is used to wait between polls of the identity provider.
+	// If it is nil, clock.WallClock is used.
+	Clock clock.Clock
+
+	// Prompt is called to ask the user to visit the verification URI
+	// and enter the user code.
+	Prompt func(verificationURI, userCode string) error
+}
+
+// deviceAuthorization holds the response to a device authorization
+// request.
+type deviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// tokenResponse holds the response to a token request, which holds
+// either the tokens or an error code.
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// IDToken prompts the user to approve the login and waits for them to do
+// so, returning the ID token issued to them.
+func (l *DeviceLogin) IDToken() (string, error) {
+	var discovery struct {
+		DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+		TokenEndpoint               string `json:"token_endpoint"`
+	}
+	discoveryURL := strings.TrimSuffix(l.IssuerURL, "/") + "/.well-known/openid-configuration"
+	resp, err := l.client().Get(discoveryURL)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot get identity provider configuration")
+	}
+	err = decodeResponse(resp, http.StatusOK, &discovery)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot get identity provider configuration")
+	}
+	if discovery.DeviceAuthorizationEndpoint == "" {
+		return "", errors.NotSupportedf("device login with identity provider %q", l.IssuerURL)
+	}
+
+	var auth deviceAuthorization
+	resp, err = l.client().PostForm(discovery.DeviceAuthorizationEndpoint, url.Values{
+		"client_id": {l.ClientID},
+		"scope":     {strings.Join(l.Scopes, " ")},
+	})
+	if err != nil {
+		return "", errors.Annotate(err, "cannot start device login")
+	}
+	if err := decodeResponse(resp, http.StatusOK, &auth); err != nil {
+		return "", errors.Annotate(err, "cannot start device login")
+	}
+	verificationURI := auth.VerificationURIComplete
+	if verificationURI == "" {
+		verificationURI = auth.VerificationURI
+	}
+	if err := l.Prompt(verificationURI, auth.UserCode); err != nil {
+		return "", errors.Trace(err)
+	}
+	return l.pollToken(discovery.TokenEndpoint, auth)
+}
+
+// pollToken polls the token endpoint until the user has approved or
+// denied the device authorization, or it expires.
+func (l *DeviceLogin) pollToken(tokenEndpoint string, auth deviceAuthorization) (string, error) {
+	clk := l.Clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	var expired <-chan time.Time
+	if auth.ExpiresIn > 0 {
+		expired = clk.After(time.Duration(auth.ExpiresIn) * time.Second)
+	}
+	for {
+		select {
+		case <-clk.After(interval):
+		case <-expired:
+			return "", errors.New("device login expired before it was approved")
+		}
+		resp, err := l.client().PostForm(tokenEndpoint, url.Values{
+			"grant_type":  {deviceCodeGrantType},
+			"device_code": {auth.DeviceCode},
+			"client_id":   {l.ClientID},
+		})
+		if err != nil {
+			return "", errors.Annotate(err, "cannot get token")
+		}
+		var token tokenResponse
+		err = json.NewDecoder(resp.Body).Decode(&token)
+		resp.Body.Close()
+		if err != nil {
+			return "", errors.Annotate(err, "cannot decode token response")
+		}
+		switch token.Error {
+		case "":
+			if token.IDToken == "" {
+				return "", errors.New("identity provider did not issue an ID token")
+			}
+			return token.IDToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return "", errors.New("device login was denied")
+		case "expired_token":
+			return "", errors.New("device login expired before it was approved")
+		default:
+			if token.ErrorDescription != "" {
+				return "", errors.Errorf("cannot get token: %s (%s)", token.Error, token.ErrorDescription)
+			}
+			return "", errors.Errorf("cannot get token: %s", token.Error)
+		}
+	}
+}
+
+func (l *DeviceLogin) client() *http.Client {
+	if l.Client != nil {
+		return l.Client
+	}
+	return http.DefaultClient
+}
+
+func decodeResponse(resp *http.Response, expectCode int, v interface{}) error {
+	defer resp.Body.Close()
+	if resp.StatusCode != expectCode {
+		return errors.Errorf("unexpected response %q", resp.Status)
+	}
+	return errors.Trace(json.NewDecoder(resp.Body).Decode(v))
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/authentication"
+	"github.com/juju/juju/apiserver/authentication/oidctest"
+)
+
+type DeviceLoginSuite struct {
+	testing.IsolationSuite
+	issuer *oidctest.Issuer
+}
+
+var _ = gc.Suite(&DeviceLoginSuite{})
+
+func (s *DeviceLoginSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.issuer = oidctest.NewIssuer("juju")
+	s.AddCleanup(func(*gc.C) { s.issuer.Close() })
+}
+
+// pollClock is a clock whose short waits, between polls of the token
+// endpoint, end immediately and whose long waits never end.
+type pollClock struct {
+	clock.Clock
+}
+
+func (pollClock) After(d time.Duration) <-chan time.Time {
+	if d > time.Minute {
+		return nil
+	}
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+func (s *DeviceLoginSuite) login(prompt func(string, string) error) (string, error) {
+	login := &authentication.DeviceLogin{
+		IssuerURL: s.issuer.URL,
+		ClientID:  "juju",
+		Scopes:    []string{"openid", "email"},
+		Clock:     pollClock{clock.WallClock},
+		Prompt:    prompt,
+	}
+	return login.IDToken()
+}
+
+func visit(verificationURI, userCode string) error {
+	resp, err := http.Get(verificationURI)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (s *DeviceLoginSuite) TestIDToken(c *gc.C) {
+	s.issuer.SetUser("alice", "devs")
+	var gotCode string
+	token, err := s.login(func(verificationURI, userCode string) error {
+		gotCode = userCode
+		c.Check(verificationURI, gc.Equals, s.issuer.URL+"/verify?user_code="+userCode)
+		return visit(verificationURI, userCode)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gotCode, gc.Equals, "CODE-0001")
+	c.Assert(token, gc.Not(gc.Equals), "")
+}
+
+func (s *DeviceLoginSuite) TestIDTokenDenied(c *gc.C) {
+	_, err := s.login(visit)
+	c.Assert(err, gc.ErrorMatches, "device login was denied")
+}
+
+func (s *DeviceLoginSuite) TestIDTokenWrongClient(c *gc.C) {
+	login := &authentication.DeviceLogin{
+		IssuerURL: s.issuer.URL,
+		ClientID:  "other",
+		Prompt:    visit,
+	}
+	_, err := login.IDToken()
+	c.Assert(err, gc.ErrorMatches, `cannot start device login: unexpected response "401 Unauthorized"`)
+}
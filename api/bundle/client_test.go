@@ -0,0 +1,64 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type bundleMockSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&bundleMockSuite{})
+
+func newClient(f basetesting.APICallerFunc, version int) *bundle.Client {
+	return bundle.NewClient(basetesting.BestVersionCaller{f, version})
+}
+
+func (s *bundleMockSuite) TestExportBundle(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Bundle")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ExportBundle")
+		c.Assert(a, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.StringResult{})
+		*(result.(*params.StringResult)) = params.StringResult{
+			Result: "applications: {}\n",
+		}
+		return nil
+	}, 2)
+	out, err := client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "applications: {}\n")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *bundleMockSuite) TestExportBundleError(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		*(result.(*params.StringResult)) = params.StringResult{
+			Error: &params.Error{Message: "FAIL"},
+		}
+		return nil
+	}, 2)
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *bundleMockSuite) TestExportBundleNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	}, 1)
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support ExportBundle")
+}
@@ -38,6 +38,20 @@ type Client struct {
 	base.ClientFacade
 	facade base.FacadeCaller
 	st     *state
+
+	// application, if set, names the application charms are being
+	// added for; see ForApplication.
+	application string
+}
+
+// ForApplication returns a copy of the client which adds charms in
+// order to upgrade the named application. This allows users granted
+// upgrade-charm access on the application, but without write access to
+// the model, to add the charm they are upgrading it to.
+func (c *Client) ForApplication(application string) *Client {
+	client := *c
+	client.application = application
+	return &client
 }
 
 // Status returns the status of the juju model.
@@ -401,8 +415,9 @@ func (c *Client) validateCharmVersion(ch charm.Charm) error {
 // satisfying params.IsCodeUnauthorized will be returned.
 func (c *Client) AddCharm(curl *charm.URL, channel csparams.Channel) error {
 	args := params.AddCharm{
-		URL:     curl.String(),
-		Channel: string(channel),
+		URL:         curl.String(),
+		Channel:     string(channel),
+		Application: c.application,
 	}
 	if err := c.facade.FacadeCall("AddCharm", args, nil); err != nil {
 		return errors.Trace(err)
@@ -424,6 +439,7 @@ func (c *Client) AddCharmWithAuthorization(curl *charm.URL, channel csparams.Cha
 		URL:                curl.String(),
 		Channel:            string(channel),
 		CharmStoreMacaroon: csMac,
+		Application:        c.application,
 	}
 	if err := c.facade.FacadeCall("AddCharmWithAuthorization", args, nil); err != nil {
 		return errors.Trace(err)
@@ -613,3 +629,11 @@ func websocketDialWithErrors(dialer WebsocketDialer, urlStr string, requestHeade
 func (c *Client) WatchDebugLog(args common.DebugLogParams) (<-chan common.LogMessage, error) {
 	return common.StreamDebugLog(c.st, args)
 }
+
+// ExportDebugLog returns the log records already logged that match the
+// filtering specified in the DebugLogParams, as gzipped JSON lines holding
+// one params.LogMessage each. The tailing and backlog parameters are
+// ignored.
+func (c *Client) ExportDebugLog(args common.DebugLogParams) (io.ReadCloser, error) {
+	return openURI(c.st, "/logexport", args.URLQuery())
+}
@@ -5,6 +5,7 @@ package api_test
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -369,6 +370,46 @@ func (s *clientSuite) TestWatchDebugLogConnected(c *gc.C) {
 	c.Assert(messages, gc.NotNil)
 }
 
+func (s *clientSuite) TestExportDebugLog(c *gc.C) {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	err := logger.Log([]state.LogRecord{{
+		Time:    t0,
+		Entity:  names.NewMachineTag("0"),
+		Level:   loggo.INFO,
+		Module:  "juju.worker",
+		Message: "too early",
+	}, {
+		Time:    t0.Add(time.Hour),
+		Entity:  names.NewMachineTag("0"),
+		Level:   loggo.INFO,
+		Module:  "juju.worker",
+		Message: "just right",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	reader, err := client.ExportDebugLog(common.DebugLogParams{
+		StartTime: t0.Add(time.Minute),
+		EndTime:   t0.Add(2 * time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+
+	zr, err := gzip.NewReader(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(zr)
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	c.Assert(lines, gc.HasLen, 1)
+	var msg params.LogMessage
+	err = json.Unmarshal([]byte(lines[0]), &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(msg.Entity, gc.Equals, "machine-0")
+	c.Assert(msg.Message, gc.Equals, "just right")
+}
+
 func (s *clientSuite) TestConnectStreamRequiresSlashPathPrefix(c *gc.C) {
 	reader, err := s.APIState.ConnectStream("foo", nil)
 	c.Assert(err, gc.ErrorMatches, `cannot make API path from non-slash-prefixed path "foo"`)
@@ -443,16 +484,20 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	s.PatchValue(api.WebsocketDial, catcher.recordLocation)
 
 	params := common.DebugLogParams{
-		IncludeEntity: []string{"a", "b"},
-		IncludeModule: []string{"c", "d"},
-		ExcludeEntity: []string{"e", "f"},
-		ExcludeModule: []string{"g", "h"},
-		Limit:         100,
-		Backlog:       200,
-		Level:         loggo.ERROR,
-		Replay:        true,
-		NoTail:        true,
-		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		IncludeEntity:  []string{"a", "b"},
+		IncludeModule:  []string{"c", "d"},
+		ExcludeEntity:  []string{"e", "f"},
+		ExcludeModule:  []string{"g", "h"},
+		IncludeMessage: []string{"^i"},
+		ExcludeMessage: []string{"j$"},
+		AgentType:      []string{"unit", "controller"},
+		Limit:          100,
+		Backlog:        200,
+		Level:          loggo.ERROR,
+		Replay:         true,
+		NoTail:         true,
+		StartTime:      time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		EndTime:        time.Date(2016, 11, 30, 12, 48, 0, 0, time.UTC),
 	}
 
 	client := s.APIState.Client()
@@ -464,16 +509,20 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 
 	values := connectURL.Query()
 	c.Assert(values, jc.DeepEquals, url.Values{
-		"includeEntity": params.IncludeEntity,
-		"includeModule": params.IncludeModule,
-		"excludeEntity": params.ExcludeEntity,
-		"excludeModule": params.ExcludeModule,
-		"maxLines":      {"100"},
-		"backlog":       {"200"},
-		"level":         {"ERROR"},
-		"replay":        {"true"},
-		"noTail":        {"true"},
-		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+		"includeEntity":  params.IncludeEntity,
+		"includeModule":  params.IncludeModule,
+		"excludeEntity":  params.ExcludeEntity,
+		"excludeModule":  params.ExcludeModule,
+		"includeMessage": params.IncludeMessage,
+		"excludeMessage": params.ExcludeMessage,
+		"agentType":      params.AgentType,
+		"maxLines":       {"100"},
+		"backlog":        {"200"},
+		"level":          {"ERROR"},
+		"replay":         {"true"},
+		"noTail":         {"true"},
+		"startTime":      {"2016-11-30T11:48:00.0000001Z"},
+		"endTime":        {"2016-11-30T12:48:00Z"},
 	})
 }
 
@@ -558,6 +607,36 @@ func (s *clientSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	c.Assert(err, gc.Equals, someErr) // Confirms that the correct facade was called
 }
 
+func (s *clientSuite) TestAddCharmForApplication(c *gc.C) {
+	var calls []interface{}
+	client := s.APIState.Client().ForApplication("wordpress")
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			calls = append(calls, args)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	curl := charm.MustParseURL("cs:quantal/wordpress-3")
+	err := client.AddCharm(curl, "stable")
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.AddCharmWithAuthorization(curl, "stable", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, jc.DeepEquals, []interface{}{
+		params.AddCharm{
+			URL:         curl.String(),
+			Channel:     "stable",
+			Application: "wordpress",
+		},
+		params.AddCharmWithAuthorization{
+			URL:         curl.String(),
+			Channel:     "stable",
+			Application: "wordpress",
+		},
+	})
+}
+
 func (s *clientSuite) TestWebsocketDialWithErrorsJSON(c *gc.C) {
 	errorResult := params.ErrorResult{
 		Error: servercommon.ServerError(errors.New("kablooie")),
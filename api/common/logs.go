@@ -34,6 +34,17 @@ type DebugLogParams struct {
 	// ExcludeModule lists logging modules to exclude from the resposne. If a
 	// module is specified, all the submodules are also excluded.
 	ExcludeModule []string
+	// IncludeMessage lists regular expressions matched against the log
+	// message. If any are set, only messages matching one of them are
+	// included.
+	IncludeMessage []string
+	// ExcludeMessage lists regular expressions matched against the log
+	// message. Messages matching any of them are excluded.
+	ExcludeMessage []string
+	// AgentType lists the kinds of agent whose messages are included: any
+	// of "machine", "unit", "application" or "controller". If none are set
+	// messages from all agents are included.
+	AgentType []string
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
 	// sent down the connection until the client closes the connection.
@@ -53,14 +64,21 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means only records with a log time on or before
+	// EndTime will be returned. Once EndTime has passed the server stops
+	// tailing the log.
+	EndTime time.Time
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
 	attrs := url.Values{
-		"includeEntity": args.IncludeEntity,
-		"includeModule": args.IncludeModule,
-		"excludeEntity": args.ExcludeEntity,
-		"excludeModule": args.ExcludeModule,
+		"includeEntity":  args.IncludeEntity,
+		"includeModule":  args.IncludeModule,
+		"excludeEntity":  args.ExcludeEntity,
+		"excludeModule":  args.ExcludeModule,
+		"includeMessage": args.IncludeMessage,
+		"excludeMessage": args.ExcludeMessage,
+		"agentType":      args.AgentType,
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
@@ -80,6 +98,9 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
 	return attrs
 }
 
@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+
+	"github.com/google/go-querystring/query"
+	"github.com/gorilla/websocket"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+)
+
+const auditLogPath = "/audit-log"
+
+// AuditLogStream reads audit records streamed from the controller.
+type AuditLogStream interface {
+	// Next returns the next matching record, or io.EOF once all of
+	// them have been read.
+	Next() (auditlog.Record, error)
+
+	// Close closes the stream.
+	Close() error
+}
+
+type auditLogStream struct {
+	stream base.Stream
+}
+
+// OpenAuditLog opens a stream of the audit records that match the
+// query. Only the records held by the controller machine the client
+// is connected to are returned; if the controller has more than one
+// machine, the query must name it.
+func (c *Client) OpenAuditLog(q params.AuditLogQuery) (AuditLogStream, error) {
+	attrs, err := query.Values(q)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to generate URL query from query")
+	}
+	stream, err := c.facade.RawAPICaller().ConnectControllerStream(auditLogPath, attrs, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot connect to %s", auditLogPath)
+	}
+	return &auditLogStream{stream: stream}, nil
+}
+
+// Next is part of the AuditLogStream interface.
+func (s *auditLogStream) Next() (auditlog.Record, error) {
+	var record auditlog.Record
+	if err := s.stream.ReadJSON(&record); err != nil {
+		if websocket.IsCloseError(errors.Cause(err), websocket.CloseNormalClosure) {
+			return auditlog.Record{}, io.EOF
+		}
+		return auditlog.Record{}, errors.Trace(err)
+	}
+	return record, nil
+}
+
+// Close is part of the AuditLogStream interface.
+func (s *auditLogStream) Close() error {
+	return errors.Trace(s.stream.Close())
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+)
+
+type auditLogSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) TestOpenAuditLog(c *gc.C) {
+	records := []auditlog.Record{{
+		Conversation: &auditlog.Conversation{Who: "bob", ConversationID: "c1"},
+	}, {
+		Request: &auditlog.Request{ConversationID: "c1", Facade: "Client", Method: "FullStatus"},
+	}}
+	stream := &fakeAuditStream{records: records}
+	caller := &streamCaller{stream: stream}
+	client := controller.NewClient(caller)
+
+	auditStream, err := client.OpenAuditLog(params.AuditLogQuery{
+		Who:    "bob",
+		Facade: "Client",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	caller.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"ConnectControllerStream", []interface{}{"/audit-log", url.Values{
+			"who":    {"bob"},
+			"facade": {"Client"},
+		}},
+	}})
+
+	record, err := auditStream.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(record, jc.DeepEquals, records[0])
+	record, err = auditStream.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(record, jc.DeepEquals, records[1])
+	_, err = auditStream.Next()
+	c.Assert(err, gc.Equals, io.EOF)
+
+	c.Assert(auditStream.Close(), jc.ErrorIsNil)
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+func (s *auditLogSuite) TestOpenAuditLogError(c *gc.C) {
+	caller := &streamCaller{}
+	caller.stub.SetErrors(errors.New("boom"))
+	client := controller.NewClient(caller)
+	_, err := client.OpenAuditLog(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "cannot connect to /audit-log: boom")
+}
+
+type streamCaller struct {
+	apitesting.APICallerFunc
+	stub   jujutesting.Stub
+	stream base.Stream
+}
+
+func (c *streamCaller) ConnectControllerStream(path string, attrs url.Values, headers http.Header) (base.Stream, error) {
+	c.stub.AddCall("ConnectControllerStream", path, attrs)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return c.stream, nil
+}
+
+type fakeAuditStream struct {
+	base.Stream
+	records []auditlog.Record
+	closed  bool
+}
+
+func (s *fakeAuditStream) ReadJSON(v interface{}) error {
+	if len(s.records) == 0 {
+		return &websocket.CloseError{Code: websocket.CloseNormalClosure}
+	}
+	data, err := json.Marshal(s.records[0])
+	if err != nil {
+		return err
+	}
+	s.records = s.records[1:]
+	return json.Unmarshal(data, v)
+}
+
+func (s *fakeAuditStream) Close() error {
+	s.closed = true
+	return nil
+}
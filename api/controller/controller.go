@@ -297,30 +297,86 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	result, err := c.initiateMigration(spec, false)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationDryRun checks whether the specified model could be migrated,
+// without starting a migration. It returns the problems that would
+// cause the migration to fail; none means it is expected to succeed.
+func (c *Client) MigrationDryRun(spec MigrationSpec) ([]string, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("migration dry run")
+	}
+	result, err := c.initiateMigration(spec, true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Problems, nil
+}
+
+func (c *Client) initiateMigration(spec MigrationSpec, dryRun bool) (params.InitiateMigrationResult, error) {
+	var empty params.InitiateMigrationResult
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return empty, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	targetInfo, err := spec.targetInfo()
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return empty, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	args := params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
-			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
-			TargetInfo: params.MigrationTargetInfo{
-				ControllerTag: names.NewControllerTag(spec.TargetControllerUUID).String(),
-				Addrs:         spec.TargetAddrs,
-				CACert:        spec.TargetCACert,
-				AuthTag:       names.NewUserTag(spec.TargetUser).String(),
-				Password:      spec.TargetPassword,
-				Macaroons:     macsJSON,
-			},
+			ModelTag:   names.NewModelTag(spec.ModelUUID).String(),
+			TargetInfo: targetInfo,
+			DryRun:     dryRun,
 		}},
 	}
 	response := params.InitiateMigrationResults{}
 	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return empty, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return empty, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return empty, errors.Trace(result.Error)
+	}
+	return result, nil
+}
+
+// CloneModel creates a copy of the model specified by spec, with the
+// given name, on the target controller; this may be the controller the
+// client is connected to. If topologyOnly is true, the copy has the
+// model's applications and relations but no machines or units. The
+// UUID of the new model is returned.
+func (c *Client) CloneModel(spec MigrationSpec, name string, topologyOnly bool) (string, error) {
+	if c.BestAPIVersion() < 7 {
+		return "", errors.NotSupportedf("cloning models")
+	}
+	if err := spec.Validate(); err != nil {
+		return "", errors.Annotatef(err, "client-side validation failed")
+	}
+	targetInfo, err := spec.targetInfo()
+	if err != nil {
+		return "", errors.Annotatef(err, "client-side validation failed")
+	}
+
+	args := params.CloneModelArgs{
+		Specs: []params.CloneModelSpec{{
+			ModelTag:     names.NewModelTag(spec.ModelUUID).String(),
+			Name:         name,
+			TargetInfo:   targetInfo,
+			TopologyOnly: topologyOnly,
+		}},
+	}
+	var response params.CloneModelResults
+	if err := c.facade.FacadeCall("CloneModels", args, &response); err != nil {
 		return "", errors.Trace(err)
 	}
 	if len(response.Results) != 1 {
@@ -330,7 +386,26 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 	if result.Error != nil {
 		return "", errors.Trace(result.Error)
 	}
-	return result.MigrationId, nil
+	tag, err := names.ParseModelTag(result.ModelTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return tag.Id(), nil
+}
+
+func (s *MigrationSpec) targetInfo() (params.MigrationTargetInfo, error) {
+	macsJSON, err := macaroonsToJSON(s.TargetMacaroons)
+	if err != nil {
+		return params.MigrationTargetInfo{}, errors.Trace(err)
+	}
+	return params.MigrationTargetInfo{
+		ControllerTag: names.NewControllerTag(s.TargetControllerUUID).String(),
+		Addrs:         s.TargetAddrs,
+		CACert:        s.TargetCACert,
+		AuthTag:       names.NewUserTag(s.TargetUser).String(),
+		Password:      s.TargetPassword,
+		Macaroons:     macsJSON,
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
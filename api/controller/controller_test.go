@@ -170,6 +170,101 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestMigrationDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.InitiateMigrationResults)
+			*out = params.InitiateMigrationResults{
+				Results: []params.InitiateMigrationResult{{
+					Problems: []string{"target prechecks failed: boom"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	problems, err := client.MigrationDryRun(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(problems, jc.DeepEquals, []string{"target prechecks failed: boom"})
+
+	args := specToArgs(spec)
+	args.Specs[0].DryRun = true
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigration", []interface{}{args}},
+	})
+}
+
+func (s *Suite) TestMigrationDryRunNotSupported(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	_, err := client.MigrationDryRun(makeSpec())
+	c.Check(err, gc.ErrorMatches, "migration dry run not supported")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestCloneModel(c *gc.C) {
+	var stub jujutesting.Stub
+	newUUID := randomUUID()
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.CloneModelResults)
+			*out = params.CloneModelResults{
+				Results: []params.CloneModelResult{{
+					ModelTag: names.NewModelTag(newUUID).String(),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 7,
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	uuid, err := client.CloneModel(spec, "staging", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(uuid, gc.Equals, newUUID)
+
+	migrationArgs := specToArgs(spec)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.CloneModels", []interface{}{params.CloneModelArgs{
+			Specs: []params.CloneModelSpec{{
+				ModelTag:     migrationArgs.Specs[0].ModelTag,
+				Name:         "staging",
+				TargetInfo:   migrationArgs.Specs[0].TargetInfo,
+				TopologyOnly: true,
+			}},
+		}}},
+	})
+}
+
+func (s *Suite) TestCloneModelError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.CloneModelResults)
+			*out = params.CloneModelResults{
+				Results: []params.CloneModelResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 7,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.CloneModel(makeSpec(), "staging", false)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestCloneModelNotSupported(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	_, err := client.CloneModel(makeSpec(), "staging", false)
+	c.Check(err, gc.ErrorMatches, "cloning models not supported")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
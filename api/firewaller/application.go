@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 )
 
@@ -75,3 +76,34 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// EgressRules returns the rules that allow outgoing traffic from the
+// application's units. If there are none, outgoing traffic is not
+// restricted.
+func (s *Application) EgressRules() ([]network.EgressRule, error) {
+	if s.st.BestAPIVersion() < 6 {
+		// Egress rules were added in Firewaller v6, so older
+		// controllers have none.
+		return nil, nil
+	}
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetEgressRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	var rules []network.EgressRule
+	for _, rule := range result.Rules {
+		rules = append(rules, rule.NetworkEgressRule())
+	}
+	return rules, nil
+}
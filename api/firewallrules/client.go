@@ -5,9 +5,11 @@ package firewallrules
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 )
 
 // Client allows access to the firewall rules API end point.
@@ -52,3 +54,58 @@ func (c *Client) ListFirewallRules() ([]params.FirewallRule, error) {
 	}
 	return results.Rules, nil
 }
+
+// SetEgressRules replaces the egress rules of the application. Setting
+// no rules removes any restriction on the application's egress.
+func (c *Client) SetEgressRules(application string, rules []network.EgressRule) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("egress rules")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	arg := params.ApplicationEgressRules{
+		ApplicationTag: names.NewApplicationTag(application).String(),
+		Rules:          make([]params.EgressRule, len(rules)),
+	}
+	for i, rule := range rules {
+		arg.Rules[i] = params.FromNetworkEgressRule(rule)
+	}
+	args := params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{arg},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetEgressRules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// EgressRules returns the egress rules of the application.
+func (c *Client) EgressRules(application string) ([]network.EgressRule, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("egress rules")
+	}
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.EgressRulesResults
+	if err := c.facade.FacadeCall("EgressRules", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	var rules []network.EgressRule
+	for _, rule := range result.Rules {
+		rules = append(rules, rule.NetworkEgressRule())
+	}
+	return rules, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/firewallrules"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
 
@@ -141,3 +142,90 @@ func (s *FirewallRulesSuite) TestListError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, "fail")
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *FirewallRulesSuite) TestSetEgressRules(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetEgressRules")
+			c.Check(a, jc.DeepEquals, params.ApplicationEgressRulesArgs{
+				Args: []params.ApplicationEgressRules{{
+					ApplicationTag: "application-mysql",
+					Rules: []params.EgressRule{{
+						Protocol:         "tcp",
+						FromPort:         443,
+						ToPort:           443,
+						DestinationCIDRs: []string{"10.0.0.0/8"},
+					}},
+				}},
+			})
+			if results, ok := result.(*params.ErrorResults); ok {
+				results.Results = []params.ErrorResult{{
+					Error: common.ServerError(errors.New("fail"))}}
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	err := client.SetEgressRules("mysql", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *FirewallRulesSuite) TestSetEgressRulesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := firewallrules.NewClient(apiCaller)
+	err := client.SetEgressRules("mysql", nil)
+	c.Assert(err, gc.ErrorMatches, "egress rules not supported")
+	c.Assert(errors.IsNotSupported(err), jc.IsTrue)
+}
+
+func (s *FirewallRulesSuite) TestEgressRules(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "EgressRules")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-mysql"}},
+			})
+			if results, ok := result.(*params.EgressRulesResults); ok {
+				results.Results = []params.EgressRulesResult{{
+					Rules: []params.EgressRule{{
+						Protocol:         "udp",
+						FromPort:         53,
+						ToPort:           53,
+						DestinationCIDRs: []string{"10.0.0.2/32"},
+					}},
+				}}
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	rules, err := client.EgressRules("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+}
@@ -75,6 +75,7 @@ func (doer httpRequestDoer) DoWithBody(req *http.Request, body io.ReadSeeker) (*
 		doer.st.password,
 		doer.st.nonce,
 		doer.st.macaroons,
+		doer.st.idToken,
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -90,22 +91,25 @@ func (doer httpRequestDoer) DoWithBody(req *http.Request, body io.ReadSeeker) (*
 	})
 }
 
-// AuthHTTPRequest adds Juju auth info (username, password, nonce, macaroons)
-// to the given HTTP request, suitable for sending to a Juju API server.
+// AuthHTTPRequest adds Juju auth info (username, password, nonce, macaroons,
+// ID token) to the given HTTP request, suitable for sending to a Juju API
+// server.
 func AuthHTTPRequest(req *http.Request, info *Info) error {
 	var tag string
 	if info.Tag != nil {
 		tag = info.Tag.String()
 	}
-	return authHTTPRequest(req, tag, info.Password, info.Nonce, info.Macaroons)
+	return authHTTPRequest(req, tag, info.Password, info.Nonce, info.Macaroons, info.IDToken)
 }
 
-func authHTTPRequest(req *http.Request, tag, password, nonce string, macaroons []macaroon.Slice) error {
+func authHTTPRequest(req *http.Request, tag, password, nonce string, macaroons []macaroon.Slice, idToken string) error {
 	if tag != "" {
 		// Note that password may be empty here; we still
 		// want to pass the tag along. An empty password
 		// indicates that we're using macaroon authentication.
 		req.SetBasicAuth(tag, password)
+	} else if idToken != "" {
+		req.Header.Set("Authorization", "Bearer "+idToken)
 	}
 	if nonce != "" {
 		req.Header.Set(params.MachineNonceHeader, nonce)
@@ -63,7 +63,8 @@ type Info struct {
 	// to use after connecting -- if any -- and should probably be extracted.
 
 	// SkipLogin, if true, skips the Login call on connection. It is an
-	// error to set Tag, Password, or Macaroons if SkipLogin is true.
+	// error to set Tag, Password, Macaroons or IDToken if SkipLogin is
+	// true.
 	SkipLogin bool `yaml:"-"`
 
 	// Tag holds the name of the entity that is connecting.
@@ -78,6 +79,10 @@ type Info struct {
 	// authenticate with the API server.
 	Macaroons []macaroon.Slice `yaml:",omitempty"`
 
+	// IDToken holds an OpenID Connect ID token that may be used
+	// to authenticate with the API server when Tag is nil.
+	IDToken string `yaml:",omitempty"`
+
 	// Nonce holds the nonce used when provisioning the machine. Used
 	// only by the machine agent.
 	Nonce string `yaml:",omitempty"`
@@ -115,6 +120,9 @@ func (info *Info) Validate() error {
 		if len(info.Macaroons) > 0 {
 			return errors.NotValidf("specifying Macaroons and SkipLogin")
 		}
+		if info.IDToken != "" {
+			return errors.NotValidf("specifying IDToken and SkipLogin")
+		}
 	}
 	return nil
 }
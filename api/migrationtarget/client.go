@@ -60,6 +60,23 @@ func (c *Client) Import(bytes []byte) error {
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
+// ValidateImport asks the target controller whether the serialized
+// model could be imported, without leaving it imported. Each problem
+// found is returned as an error; none means the import would succeed.
+func (c *Client) ValidateImport(bytes []byte) ([]error, error) {
+	var result params.ErrorResults
+	serialized := params.SerializedModel{Bytes: bytes}
+	err := c.caller.FacadeCall("ValidateImport", serialized, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var problems []error
+	for _, res := range result.Results {
+		problems = append(problems, errors.New(res.Error.Message))
+	}
+	return problems, nil
+}
+
 // Abort removes all data relating to a previously imported model.
 func (c *Client) Abort(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
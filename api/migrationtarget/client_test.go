@@ -174,6 +174,34 @@ func (s *ClientSuite) TestCheckMachines(c *gc.C) {
 	s.AssertModelCall(c, &stub, names.NewModelTag("django"), "CheckMachines", err, false)
 }
 
+func (s *ClientSuite) TestValidateImport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		target, ok := result.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		*target = params.ErrorResults{Results: []params.ErrorResult{
+			{Error: &params.Error{Message: `cloud "nowhere" not found`}},
+		}}
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+	problems, err := client.ValidateImport([]byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Assert(problems[0], gc.ErrorMatches, `cloud "nowhere" not found`)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ValidateImport", []interface{}{"", params.SerializedModel{Bytes: []byte("foo")}}},
+	})
+}
+
+func (s *ClientSuite) TestValidateImportError(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.ValidateImport([]byte("foo"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+	stub.CheckCall(c, 0, "MigrationTarget.ValidateImport", "", params.SerializedModel{Bytes: []byte("foo")})
+}
+
 func (s *ClientSuite) TestUploadCharm(c *gc.C) {
 	const charmBody = "charming"
 	curl := charm.MustParseURL("cs:~user/foo-2")
@@ -45,6 +45,12 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 		Macaroons:   macaroons,
 		CLIArgs:     utils.CommandString(os.Args...),
 	}
+	if tag == nil {
+		// Without a tag, an ID token obtained from the
+		// controller's OpenID Connect identity provider
+		// may be used instead of macaroons.
+		request.IDToken = st.idToken
+	}
 	// If we are in developer mode, add the stack location as user data to the
 	// login request. This will allow the apiserver to connect connection ids
 	// to the particular place that initiated the connection.
@@ -144,6 +150,17 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 	return nil
 }
 
+// OIDCLoginConfig returns the details of the OpenID Connect identity
+// provider that the controller accepts ID tokens from. It may be called
+// on a connection that has not logged in.
+func OIDCLoginConfig(caller base.APICaller) (params.OIDCLoginConfig, error) {
+	var result params.OIDCLoginConfig
+	if err := caller.APICall("Admin", 3, "", "OIDCLoginConfig", nil, &result); err != nil {
+		return params.OIDCLoginConfig{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
 type loginResultParams struct {
 	tag              names.Tag
 	modelTag         string
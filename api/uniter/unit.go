@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -775,6 +777,58 @@ func (u *Unit) AddStorage(constraints map[string][]params.StorageConstraints) er
 	return results.Combine()
 }
 
+// RecordHookExecutions adds the given hook, action and commands
+// executions to the unit's hook history.
+func (u *Unit) RecordHookExecutions(executions []params.HookExecution) error {
+	if u.st.facade.BestAPIVersion() < 10 {
+		return errors.NotImplementedf("RecordHookExecutions() (need V10+)")
+	}
+	args := params.UnitsHookExecutions{
+		Units: []params.UnitHookExecutions{{
+			Tag:        u.tag.String(),
+			Executions: executions,
+		}},
+	}
+	var results params.ErrorResults
+	err := u.st.facade.FacadeCall("RecordHookExecutions", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
+// HookTimeout returns how long the unit's hooks may run for before they
+// are killed, as set by the hook-timeout option in its application's
+// config. Zero means there is no limit.
+func (u *Unit) HookTimeout() (time.Duration, error) {
+	if u.st.facade.BestAPIVersion() < 10 {
+		return 0, errors.NotImplementedf("HookTimeout() (need V10+)")
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("HookTimeouts", args, &results)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.Result == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(result.Result)
+	if err != nil {
+		return 0, errors.Annotate(err, "parsing hook timeout")
+	}
+	return timeout, nil
+}
+
 // NetworkInfo returns network interfaces/addresses for specified bindings.
 func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error) {
 	var results params.NetworkInfoResults
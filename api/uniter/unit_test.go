@@ -469,6 +469,43 @@ func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	c.Assert(curl.String(), gc.Equals, s.wordpressCharm.String())
 }
 
+func (s *unitSuite) TestRecordHookExecutions(c *gc.C) {
+	started := time.Date(2018, 6, 15, 10, 30, 0, 0, time.UTC)
+	err := s.apiUnit.RecordHookExecutions([]params.HookExecution{{
+		Kind:     "hook",
+		Name:     "install",
+		Started:  started,
+		Finished: started.Add(time.Second),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.wordpressUnit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, jc.DeepEquals, []state.HookExecution{{
+		Kind:     state.HookExecutionHook,
+		Name:     "install",
+		Started:  started,
+		Finished: started.Add(time.Second),
+	}})
+}
+
+func (s *unitSuite) TestHookTimeout(c *gc.C) {
+	timeout, err := s.apiUnit.HookTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, time.Duration(0))
+
+	err = s.wordpressApplication.UpdateApplicationConfig(application.ConfigAttributes{
+		"hook-timeout": "30m",
+	}, nil, environschema.Fields{
+		"hook-timeout": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	timeout, err = s.apiUnit.HookTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, 30*time.Minute)
+}
+
 func (s *unitSuite) TestNetworkInfo(c *gc.C) {
 	var called int
 	relId := 2
@@ -72,6 +72,25 @@ func (a *admin) RedirectInfo() (params.RedirectInfoResult, error) {
 	return params.RedirectInfoResult{}, fmt.Errorf("not redirected")
 }
 
+// OIDCLoginConfig returns the details of the OpenID Connect identity
+// provider that users may log in with ID tokens from. It returns a not
+// found error if OpenID Connect login is not configured.
+func (a *admin) OIDCLoginConfig() (params.OIDCLoginConfig, error) {
+	controllerCfg, err := a.root.state.ControllerConfig()
+	if err != nil {
+		return params.OIDCLoginConfig{}, errors.Trace(err)
+	}
+	issuerURL := controllerCfg.OIDCIssuerURL()
+	if issuerURL == "" {
+		return params.OIDCLoginConfig{}, errors.NotFoundf("OIDC login configuration")
+	}
+	return params.OIDCLoginConfig{
+		IssuerURL: issuerURL,
+		ClientID:  controllerCfg.OIDCClientID(),
+		Scopes:    controllerCfg.OIDCScopes(),
+	}, nil
+}
+
 var MaintenanceNoLoginError = errors.New("login failed - maintenance in progress")
 var errAlreadyLoggedIn = errors.New("already logged in")
 
@@ -234,7 +253,7 @@ func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 
 	switch result.tag.(type) {
 	case nil:
-		// Macaroon logins are always for users.
+		// Macaroon and ID token logins are always for users.
 	case names.UserTag:
 		if result.tag.Id() == api.AnonymousUsername && len(req.Macaroons) == 0 {
 			result.anonymousLogin = true
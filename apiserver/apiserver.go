@@ -465,6 +465,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	debugLogHandler := newDebugLogDBHandler(
 		httpCtxt, srv.authenticator,
 		tagKindAuthorizer{names.MachineTagKind, names.UserTagKind})
+	logExportHandler := &logExportHandler{ctxt: httpCtxt}
 	pubsubHandler := newPubSubHandler(httpCtxt, srv.shared.centralHub)
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
@@ -555,6 +556,18 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		stateAuthFunc: httpCtxt.stateForMigrationImporting,
 	}
 	backupHandler := &backupHandler{ctxt: httpCtxt}
+	auditLogHandler := &auditLogHandler{
+		logDir:    srv.logDir,
+		machineId: srv.tag.Id(),
+		stopCh:    httpCtxt.stop(),
+		controllerMachineIds: func() ([]string, error) {
+			info, err := srv.shared.statePool.SystemState().ControllerInfo()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return info.MachineIds, nil
+		},
+	}
 	registerHandler := &registerUserHandler{ctxt: httpCtxt}
 	guiArchiveHandler := &guiArchiveHandler{ctxt: httpCtxt}
 	guiVersionHandler := &guiVersionHandler{ctxt: httpCtxt}
@@ -591,6 +604,12 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		// The authentication is handled within the debugLogHandler in order
 		// for discharge required errors to be handled correctly.
 		unauthenticated: true,
+	}, {
+		pattern:    modelRoutePrefix + "/logexport",
+		methods:    []string{"GET"},
+		handler:    logExportHandler,
+		tracked:    true,
+		authorizer: tagKindAuthorizer{names.UserTagKind},
 	}, {
 		pattern:    modelRoutePrefix + "/logsink",
 		handler:    logSinkHandler,
@@ -663,6 +682,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		tracked:         true,
 		unauthenticated: true,
 		noModelUUID:     true,
+	}, {
+		pattern:    "/audit-log",
+		handler:    auditLogHandler,
+		tracked:    true,
+		authorizer: controllerAdminAuthorizer,
 	}, {
 		pattern:         "/register",
 		handler:         registerHandler,
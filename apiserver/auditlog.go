@@ -0,0 +1,159 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/schema"
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/core/auditlog"
+)
+
+var errAuditLogStopped = errors.New("apiserver stopping")
+
+// auditLogHandler streams the records from this controller's audit
+// log files that match the query. Records written by other
+// controllers in an HA cluster are in their own files, so a query
+// must name the machine it's for if there is more than one.
+type auditLogHandler struct {
+	logDir    string
+	machineId string
+	stopCh    <-chan struct{}
+
+	// controllerMachineIds returns the ids of the controller
+	// machines.
+	controllerMachineIds func() ([]string, error)
+}
+
+// ServeHTTP will serve up connections as a websocket for the
+// audit-log API. The first message is an error result, as for the
+// other streaming endpoints; it's followed by a message for each
+// matching auditlog.Record. The connection is closed normally once
+// all the records have been sent.
+//
+// Args for the HTTP request are as described by params.AuditLogQuery.
+func (h *auditLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+		query, filter, err := auditLogFilter(req)
+		if err != nil {
+			h.sendError(conn, err)
+			return
+		}
+		if err := h.checkMachine(query.Machine); err != nil {
+			h.sendError(conn, err)
+			return
+		}
+		files, err := auditlog.LogFiles(h.logDir)
+		if err != nil {
+			h.sendError(conn, errors.Annotate(err, "finding audit log files"))
+			return
+		}
+		h.sendError(conn, nil)
+
+		if err := h.sendRecords(conn, files, filter); err != nil {
+			if isBrokenPipe(err) {
+				logger.Tracef("audit-log handler stopped (client disconnected)")
+			} else if err != errAuditLogStopped {
+				logger.Errorf("audit-log handler error: %v", err)
+			}
+			return
+		}
+		conn.WriteControl(
+			gorillaws.CloseMessage,
+			gorillaws.FormatCloseMessage(gorillaws.CloseNormalClosure, ""),
+			time.Now().Add(websocket.WriteWait),
+		)
+	}
+	websocket.Serve(w, req, handler)
+}
+
+func (h *auditLogHandler) sendRecords(conn *websocket.Conn, files []string, filter auditlog.Filter) error {
+	recordFilter := auditlog.NewRecordFilter(filter)
+	for _, path := range files {
+		err := auditlog.ReadLogFile(path, func(record auditlog.Record) error {
+			select {
+			case <-h.stopCh:
+				return errAuditLogStopped
+			default:
+			}
+			for _, out := range recordFilter.Match(record) {
+				if err := conn.WriteJSON(out); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
+		})
+		if errors.Cause(err) == errAuditLogStopped {
+			return errAuditLogStopped
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// checkMachine returns an error unless the query is for the audit log
+// held by this machine. The machine may only be left out if there are
+// no other controller machines.
+func (h *auditLogHandler) checkMachine(machineId string) error {
+	if machineId != "" {
+		if machineId != h.machineId {
+			return errors.NotFoundf("audit log of controller machine %s on machine %s", machineId, h.machineId)
+		}
+		return nil
+	}
+	ids, err := h.controllerMachineIds()
+	if err != nil {
+		return errors.Annotate(err, "getting controller machines")
+	}
+	if len(ids) > 1 {
+		return errors.Errorf(
+			"controller has %d machines, each with its own audit log; the machine to query must be specified",
+			len(ids),
+		)
+	}
+	return nil
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *auditLogHandler) sendError(conn *websocket.Conn, err error) {
+	if sendErr := conn.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", sendErr)
+		conn.Close()
+	}
+}
+
+func auditLogFilter(req *http.Request) (params.AuditLogQuery, auditlog.Filter, error) {
+	var query params.AuditLogQuery
+	if err := schema.NewDecoder().Decode(&query, req.URL.Query()); err != nil {
+		return query, auditlog.Filter{}, errors.Annotate(err, "decoding schema")
+	}
+	filter := auditlog.Filter{
+		Who:            query.Who,
+		ModelUUID:      query.ModelUUID,
+		ConversationID: query.ConversationID,
+		Facade:         query.Facade,
+		Method:         query.Method,
+	}
+	var err error
+	if query.After != "" {
+		if filter.After, err = time.Parse(time.RFC3339, query.After); err != nil {
+			return query, auditlog.Filter{}, errors.NotValidf("after time %q", query.After)
+		}
+	}
+	if query.Before != "" {
+		if filter.Before, err = time.Parse(time.RFC3339, query.Before); err != nil {
+			return query, auditlog.Filter{}, errors.NotValidf("before time %q", query.Before)
+		}
+	}
+	return query, filter, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/websocket/websockettest"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type auditLogSuite struct {
+	apiserverBaseSuite
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.apiserverBaseSuite.SetUpTest(c)
+
+	log := auditlog.NewLogFile(s.config.LogDir, 300, 10)
+	defer log.Close()
+	for _, record := range []auditlog.Record{{
+		Conversation: &auditlog.Conversation{Who: "bob", ConversationID: "c1", When: "2018-06-01T12:00:00Z"},
+	}, {
+		Request: &auditlog.Request{ConversationID: "c1", RequestID: 1, Facade: "Client", Method: "FullStatus", When: "2018-06-01T12:00:01Z"},
+	}, {
+		Conversation: &auditlog.Conversation{Who: "mary", ConversationID: "c2", When: "2018-06-01T12:01:00Z"},
+	}, {
+		Request: &auditlog.Request{ConversationID: "c2", RequestID: 1, Facade: "Application", Method: "Deploy", When: "2018-06-01T12:01:01Z"},
+	}, {
+		Errors: &auditlog.ResponseErrors{ConversationID: "c2", RequestID: 1, When: "2018-06-01T12:01:02Z"},
+	}} {
+		var err error
+		switch {
+		case record.Conversation != nil:
+			err = log.AddConversation(*record.Conversation)
+		case record.Request != nil:
+			err = log.AddRequest(*record.Request)
+		case record.Errors != nil:
+			err = log.AddResponse(*record.Errors)
+		}
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *auditLogSuite) auditLogURL(query url.Values) string {
+	u := s.URL("/audit-log", query)
+	u.Scheme = "wss"
+	return u.String()
+}
+
+func (s *auditLogSuite) TestRejectsNonAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "sekrit"})
+	header := utils.BasicAuthHeader(user.Tag().String(), "sekrit")
+	conn, resp, err := dialWebsocketFromURL(c, s.auditLogURL(nil), header)
+	c.Assert(err, gc.Equals, websocket.ErrBadHandshake)
+	c.Assert(conn, gc.IsNil)
+	defer resp.Body.Close()
+	c.Check(resp.StatusCode, gc.Equals, http.StatusForbidden)
+	out, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Matches, "authorization failed: user .* is not a controller admin\n")
+}
+
+func (s *auditLogSuite) TestInvalidQuery(c *gc.C) {
+	conn := s.dial(c, url.Values{"after": {"yesterday"}})
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, `after time "yesterday" not valid`)
+}
+
+func (s *auditLogSuite) TestAllRecords(c *gc.C) {
+	conn := s.dial(c, nil)
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	records := readAuditRecords(c, conn)
+	c.Assert(records, gc.HasLen, 5)
+}
+
+func (s *auditLogSuite) TestFilterByUser(c *gc.C) {
+	conn := s.dial(c, url.Values{"who": {"bob"}})
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	records := readAuditRecords(c, conn)
+	c.Assert(records, gc.HasLen, 2)
+	c.Assert(records[0].Conversation.Who, gc.Equals, "bob")
+	c.Assert(records[1].Request.Method, gc.Equals, "FullStatus")
+}
+
+func (s *auditLogSuite) TestFilterByMethod(c *gc.C) {
+	conn := s.dial(c, url.Values{"facade": {"Application"}, "method": {"Deploy"}})
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	records := readAuditRecords(c, conn)
+	c.Assert(records, gc.HasLen, 3)
+	c.Assert(records[0].Conversation.Who, gc.Equals, "mary")
+	c.Assert(records[1].Request.Method, gc.Equals, "Deploy")
+	c.Assert(records[2].Errors, gc.NotNil)
+}
+
+func (s *auditLogSuite) TestRequiresMachineWithHA(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	conn := s.dial(c, nil)
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn,
+		"controller has 3 machines, each with its own audit log; the machine to query must be specified")
+
+	conn = s.dial(c, url.Values{"machine": {"1"}})
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, "audit log of controller machine 1 on machine 0 not found")
+
+	conn = s.dial(c, url.Values{"machine": {"0"}})
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+	records := readAuditRecords(c, conn)
+	c.Assert(records, gc.HasLen, 5)
+}
+
+func (s *auditLogSuite) dial(c *gc.C, query url.Values) *websocket.Conn {
+	header := utils.BasicAuthHeader(s.Owner.String(), ownerPassword)
+	conn, _, err := dialWebsocketFromURL(c, s.auditLogURL(query), header)
+	c.Assert(err, jc.ErrorIsNil)
+	return conn
+}
+
+// readAuditRecords reads records until the server closes the
+// connection.
+func readAuditRecords(c *gc.C, conn *websocket.Conn) []auditlog.Record {
+	var records []auditlog.Record
+	for {
+		var record auditlog.Record
+		err := conn.ReadJSON(&record)
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			return records
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		records = append(records, record)
+	}
+}
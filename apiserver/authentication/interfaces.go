@@ -21,3 +21,10 @@ type EntityAuthenticator interface {
 type EntityFinder interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 }
+
+// GroupAccessGranter is implemented by entity finders that can grant
+// access to a user based on the groups their identity provider says
+// they are members of.
+type GroupAccessGranter interface {
+	GrantGroupAccess(user names.UserTag, groups []string) error
+}
@@ -0,0 +1,276 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// oidcKeyRefreshInterval is the minimum time between fetches of an
+// identity provider's signing keys, so that tokens signed with unknown
+// keys cannot be used to make us hammer the identity provider.
+const oidcKeyRefreshInterval = time.Minute
+
+// OIDCIdentity holds the identity asserted by a verified ID token.
+type OIDCIdentity struct {
+	// User is the tag of the user the token was issued to.
+	User names.UserTag
+
+	// Groups holds the names of the groups the user is a member of.
+	Groups []string
+}
+
+// OIDCAuthenticator authenticates users by the OpenID Connect ID tokens
+// issued to them by a trusted identity provider.
+type OIDCAuthenticator struct {
+	// IssuerURL holds the URL of the identity provider. ID tokens
+	// must have been issued by it.
+	IssuerURL string
+
+	// ClientID holds the OAuth 2.0 client ID that ID tokens must
+	// have been issued to.
+	ClientID string
+
+	// GroupsClaim holds the name of the ID token claim listing the
+	// groups the user is a member of.
+	GroupsClaim string
+
+	// UsernameClaim holds the name of the ID token claim holding the
+	// name of the user.
+	UsernameClaim string
+
+	// UserDomain holds the domain users are mapped into, so that
+	// they are named "<username>@<domain>". Keeping the identity
+	// provider's users in their own domain stops them from claiming
+	// the names of users from other identity providers.
+	UserDomain string
+
+	// HTTPClient is used to fetch the identity provider's signing
+	// keys. If it is nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// Clock is used to limit how often the signing keys are fetched.
+	Clock clock.Clock
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// Authenticate authenticates the user the ID token in the login request
+// was issued to. If the entity finder implements GroupAccessGranter, it
+// is first given the groups the user is a member of so that it can
+// grant them access.
+func (a *OIDCAuthenticator) Authenticate(entityFinder EntityFinder, _ names.Tag, req params.LoginRequest) (state.Entity, error) {
+	if req.IDToken == "" {
+		return nil, errors.Trace(common.ErrNoCreds)
+	}
+	identity, err := a.Verify(req.IDToken)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if granter, ok := entityFinder.(GroupAccessGranter); ok {
+		if err := granter.GrantGroupAccess(identity.User, identity.Groups); err != nil {
+			return nil, errors.Annotatef(err, "cannot grant group access to %q", identity.User.Id())
+		}
+	}
+	entity, err := entityFinder.FindEntity(identity.User)
+	if errors.IsNotFound(err) {
+		return nil, errors.Trace(common.ErrBadCreds)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return entity, nil
+}
+
+// Verify checks that the ID token was signed by the identity provider,
+// was issued to the client and has not expired, and that the user's
+// email address has been verified, and returns the identity it asserts.
+func (a *OIDCAuthenticator) Verify(idToken string) (OIDCIdentity, error) {
+	token, err := jwt.Parse(idToken, a.signingKey)
+	if err != nil {
+		return OIDCIdentity{}, errors.Annotate(err, "invalid ID token")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return OIDCIdentity{}, errors.New("invalid ID token: unexpected claims")
+	}
+	if iss, _ := claims["iss"].(string); strings.TrimSuffix(iss, "/") != strings.TrimSuffix(a.IssuerURL, "/") {
+		return OIDCIdentity{}, errors.Errorf("ID token issued by %q, expected %q", iss, a.IssuerURL)
+	}
+	if !hasAudience(claims, a.ClientID) {
+		return OIDCIdentity{}, errors.Errorf("ID token not issued to client %q", a.ClientID)
+	}
+	if _, ok := claims["exp"]; !ok {
+		return OIDCIdentity{}, errors.New("ID token has no expiry time")
+	}
+	if verified, _ := claims["email_verified"].(bool); !verified {
+		return OIDCIdentity{}, errors.New("ID token email address is not verified")
+	}
+	username, _ := claims[a.UsernameClaim].(string)
+	if username == "" {
+		return OIDCIdentity{}, errors.Errorf("ID token has no %q claim", a.UsernameClaim)
+	}
+	if !names.IsValidUserName(username) {
+		return OIDCIdentity{}, errors.Errorf("%q is an invalid user name", username)
+	}
+	user := names.NewLocalUserTag(username).WithDomain(a.UserDomain)
+	if user.IsLocal() {
+		return OIDCIdentity{}, errors.Errorf("OIDC users cannot be mapped to local user %q", user.Id())
+	}
+	return OIDCIdentity{
+		User:   user,
+		Groups: stringsClaim(claims[a.GroupsClaim]),
+	}, nil
+}
+
+// signingKey is a jwt.Keyfunc that returns the identity provider's key
+// the token was signed with.
+func (a *OIDCAuthenticator) signingKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, errors.Errorf("unexpected signing method %q", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if key := a.lookupKey(kid); key != nil {
+		return key, nil
+	}
+	now := a.Clock.Now()
+	if !a.fetchedAt.IsZero() && now.Sub(a.fetchedAt) < oidcKeyRefreshInterval {
+		return nil, errors.NotFoundf("signing key %q", kid)
+	}
+	keys, err := a.fetchKeys()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot fetch identity provider signing keys")
+	}
+	a.keys = keys
+	a.fetchedAt = now
+	if key := a.lookupKey(kid); key != nil {
+		return key, nil
+	}
+	return nil, errors.NotFoundf("signing key %q", kid)
+}
+
+// lookupKey returns the cached key with the given ID. If the ID is empty
+// and there is only one key, that key is returned.
+func (a *OIDCAuthenticator) lookupKey(kid string) *rsa.PublicKey {
+	if kid == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key
+		}
+	}
+	return a.keys[kid]
+}
+
+// fetchKeys fetches the identity provider's RSA signing keys, keyed by
+// key ID, from the JWKS endpoint named in its discovery document.
+func (a *OIDCAuthenticator) fetchKeys() (map[string]*rsa.PublicKey, error) {
+	var discovery struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	discoveryURL := strings.TrimSuffix(a.IssuerURL, "/") + "/.well-known/openid-configuration"
+	if err := a.getJSON(discoveryURL, &discovery); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, errors.New("no jwks_uri in discovery document")
+	}
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := a.getJSON(discovery.JWKSURI, &jwks); err != nil {
+		return nil, errors.Trace(err)
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.N, "="))
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid modulus for key %q", k.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.E, "="))
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid exponent for key %q", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+func (a *OIDCAuthenticator) getJSON(url string, v interface{}) error {
+	client := a.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("cannot get %q: %s", url, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return errors.Annotatef(err, "cannot decode %q", url)
+	}
+	return nil
+}
+
+// hasAudience reports whether the aud claim, which may be a string or a
+// list of strings, includes the given client ID.
+func hasAudience(claims jwt.MapClaims, clientID string) bool {
+	for _, aud := range stringsClaim(claims["aud"]) {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// stringsClaim returns the value of a claim that may be a string or a
+// list of strings.
+func stringsClaim(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
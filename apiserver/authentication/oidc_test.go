@@ -0,0 +1,195 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication_test
+
+import (
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/authentication/oidctest"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type oidcAuthenticatorSuite struct {
+	testing.IsolationSuite
+	issuer        *oidctest.Issuer
+	authenticator *authentication.OIDCAuthenticator
+}
+
+var _ = gc.Suite(&oidcAuthenticatorSuite{})
+
+func (s *oidcAuthenticatorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.issuer = oidctest.NewIssuer("juju")
+	s.AddCleanup(func(*gc.C) { s.issuer.Close() })
+	s.authenticator = &authentication.OIDCAuthenticator{
+		IssuerURL:     s.issuer.URL,
+		ClientID:      "juju",
+		GroupsClaim:   "groups",
+		UsernameClaim: "sub",
+		UserDomain:    "oidc",
+		Clock:         clock.WallClock,
+	}
+}
+
+type groupEntityFinder struct {
+	testing.Stub
+	entity state.Entity
+}
+
+func (f *groupEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
+	f.MethodCall(f, "FindEntity", tag)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.entity, nil
+}
+
+func (f *groupEntityFinder) GrantGroupAccess(user names.UserTag, groups []string) error {
+	f.MethodCall(f, "GrantGroupAccess", user, groups)
+	return f.NextErr()
+}
+
+func (s *oidcAuthenticatorSuite) TestVerify(c *gc.C) {
+	token := s.issuer.IDToken("alice", []string{"admins", "devs"}, time.Hour)
+	identity, err := s.authenticator.Verify(token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(identity, jc.DeepEquals, authentication.OIDCIdentity{
+		User:   names.NewUserTag("alice@oidc"),
+		Groups: []string{"admins", "devs"},
+	})
+}
+
+func (s *oidcAuthenticatorSuite) TestVerifyUsernameClaim(c *gc.C) {
+	s.authenticator.UsernameClaim = "preferred_username"
+	s.authenticator.UserDomain = "sso"
+	token := s.issuer.SignedToken(jwt.MapClaims{
+		"iss":                s.issuer.URL,
+		"aud":                "juju",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"sub":                "0123456789",
+		"preferred_username": "alice",
+		"email_verified":     true,
+	})
+	identity, err := s.authenticator.Verify(token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(identity.User, gc.Equals, names.NewUserTag("alice@sso"))
+}
+
+func (s *oidcAuthenticatorSuite) TestVerifyInvalidUsername(c *gc.C) {
+	// Users cannot choose names in the domains of other identity
+	// providers.
+	token := s.issuer.IDToken("bob@external", nil, time.Hour)
+	_, err := s.authenticator.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `"bob@external" is an invalid user name`)
+}
+
+func (s *oidcAuthenticatorSuite) TestVerifyNoUsername(c *gc.C) {
+	s.authenticator.UsernameClaim = "preferred_username"
+	token := s.issuer.IDToken("alice", nil, time.Hour)
+	_, err := s.authenticator.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `ID token has no "preferred_username" claim`)
+}
+
+func (s *oidcAuthenticatorSuite) TestVerifyExpired(c *gc.C) {
+	token := s.issuer.IDToken("alice", nil, -time.Minute)
+	_, err := s.authenticator.Verify(token)
+	c.Assert(err, gc.ErrorMatches, "invalid ID token: .*expired.*")
+}
+
+func (s *oidcAuthenticatorSuite) TestVerifyWrongAudience(c *gc.C) {
+	s.authenticator.ClientID = "other"
+	token := s.issuer.IDToken("alice", nil, time.Hour)
+	_, err := s.authenticator.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `ID token not issued to client "other"`)
+}
+
+func (s *oidcAuthenticatorSuite) TestVerifyWrongIssuer(c *gc.C) {
+	token := s.issuer.SignedToken(jwt.MapClaims{
+		"iss":            "https://elsewhere.example.com",
+		"aud":            "juju",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"sub":            "alice",
+		"email_verified": true,
+	})
+	_, err := s.authenticator.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `ID token issued by "https://elsewhere.example.com", expected ".*"`)
+}
+
+func (s *oidcAuthenticatorSuite) TestVerifySignedByOtherIssuer(c *gc.C) {
+	other := oidctest.NewIssuer("juju")
+	defer other.Close()
+	token := other.IDToken("alice", nil, time.Hour)
+	_, err := s.authenticator.Verify(token)
+	c.Assert(err, gc.ErrorMatches, "invalid ID token: .*verification error")
+}
+
+func (s *oidcAuthenticatorSuite) TestVerifyUnverifiedEmail(c *gc.C) {
+	token := s.issuer.SignedToken(jwt.MapClaims{
+		"iss":            s.issuer.URL,
+		"aud":            []string{"juju", "other"},
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"sub":            "alice",
+		"email":          "alice@example.com",
+		"email_verified": false,
+	})
+	_, err := s.authenticator.Verify(token)
+	c.Assert(err, gc.ErrorMatches, "ID token email address is not verified")
+}
+
+func (s *oidcAuthenticatorSuite) TestVerifyEmailVerifiedMissing(c *gc.C) {
+	token := s.issuer.SignedToken(jwt.MapClaims{
+		"iss":   s.issuer.URL,
+		"aud":   "juju",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"sub":   "alice",
+		"email": "alice@example.com",
+	})
+	_, err := s.authenticator.Verify(token)
+	c.Assert(err, gc.ErrorMatches, "ID token email address is not verified")
+}
+
+func (s *oidcAuthenticatorSuite) TestVerifyLocalDomain(c *gc.C) {
+	s.authenticator.UserDomain = "local"
+	token := s.issuer.IDToken("admin", nil, time.Hour)
+	_, err := s.authenticator.Verify(token)
+	c.Assert(err, gc.ErrorMatches, `OIDC users cannot be mapped to local user "admin"`)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticate(c *gc.C) {
+	entity := &state.User{}
+	finder := &groupEntityFinder{entity: entity}
+	token := s.issuer.IDToken("alice", []string{"devs"}, time.Hour)
+	result, err := s.authenticator.Authenticate(finder, nil, params.LoginRequest{IDToken: token})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, entity)
+	alice := names.NewUserTag("alice@oidc")
+	finder.CheckCalls(c, []testing.StubCall{
+		{"GrantGroupAccess", []interface{}{alice, []string{"devs"}}},
+		{"FindEntity", []interface{}{alice}},
+	})
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateNoAccess(c *gc.C) {
+	finder := &groupEntityFinder{}
+	finder.SetErrors(nil, errors.NotFoundf("model or controller user"))
+	token := s.issuer.IDToken("alice", nil, time.Hour)
+	_, err := s.authenticator.Authenticate(finder, nil, params.LoginRequest{IDToken: token})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateNoToken(c *gc.C) {
+	_, err := s.authenticator.Authenticate(&groupEntityFinder{}, nil, params.LoginRequest{})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrNoCreds)
+}
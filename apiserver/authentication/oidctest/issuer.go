@@ -0,0 +1,208 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package oidctest provides a stub OpenID Connect identity provider for
+// testing logins with ID tokens.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	keyID = "oidctest"
+
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// Issuer is a stub OpenID Connect identity provider. It publishes a
+// discovery document and signing keys, mints ID tokens on demand and
+// implements the OAuth 2.0 device authorization grant.
+type Issuer struct {
+	*httptest.Server
+
+	// ClientID is the client ID that ID tokens are issued to.
+	ClientID string
+
+	key *rsa.PrivateKey
+
+	mu      sync.Mutex
+	user    string
+	groups  []string
+	devices map[string]*device
+}
+
+// device records a pending device authorization.
+type device struct {
+	userCode string
+	approved bool
+	denied   bool
+}
+
+// NewIssuer returns a new Issuer serving on a local HTTP server, issuing
+// ID tokens to the given client ID. The Issuer should be closed when it
+// is no longer needed.
+func NewIssuer(clientID string) *Issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	i := &Issuer{
+		ClientID: clientID,
+		key:      key,
+		devices:  make(map[string]*device),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", i.serveDiscovery)
+	mux.HandleFunc("/keys", i.serveKeys)
+	mux.HandleFunc("/device", i.serveDevice)
+	mux.HandleFunc("/verify", i.serveVerify)
+	mux.HandleFunc("/token", i.serveToken)
+	i.Server = httptest.NewServer(mux)
+	return i
+}
+
+// SetUser sets the identity of the user that approves device
+// authorizations, and is so issued ID tokens by the device flow.
+func (i *Issuer) SetUser(user string, groups ...string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.user = user
+	i.groups = groups
+}
+
+// IDToken returns an ID token for the user with the given subject and
+// groups, expiring after the given duration. The user's email address
+// is verified.
+func (i *Issuer) IDToken(user string, groups []string, expiry time.Duration) string {
+	return i.SignedToken(jwt.MapClaims{
+		"iss":            i.URL,
+		"sub":            user,
+		"aud":            i.ClientID,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(expiry).Unix(),
+		"email":          user + "@example.com",
+		"email_verified": true,
+		"groups":         groups,
+	})
+}
+
+// SignedToken returns a token holding the given claims, signed with the
+// issuer's key.
+func (i *Issuer) SignedToken(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	s, err := token.SignedString(i.key)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (i *Issuer) serveDiscovery(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                        i.URL,
+		"jwks_uri":                      i.URL + "/keys",
+		"device_authorization_endpoint": i.URL + "/device",
+		"token_endpoint":                i.URL + "/token",
+	})
+}
+
+func (i *Issuer) serveKeys(w http.ResponseWriter, req *http.Request) {
+	pub := i.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (i *Issuer) serveDevice(w http.ResponseWriter, req *http.Request) {
+	if req.FormValue("client_id") != i.ClientID {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	n := len(i.devices) + 1
+	deviceCode := fmt.Sprintf("device-%d", n)
+	userCode := fmt.Sprintf("CODE-%04d", n)
+	i.devices[deviceCode] = &device{userCode: userCode}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"device_code":               deviceCode,
+		"user_code":                 userCode,
+		"verification_uri":          i.URL + "/verify",
+		"verification_uri_complete": i.URL + "/verify?user_code=" + userCode,
+		"expires_in":                600,
+		"interval":                  1,
+	})
+}
+
+// serveVerify approves the device authorization with the user code
+// given in the request, as the user set with SetUser, or denies it if
+// no user has been set.
+func (i *Issuer) serveVerify(w http.ResponseWriter, req *http.Request) {
+	userCode := req.FormValue("user_code")
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, d := range i.devices {
+		if d.userCode == userCode {
+			d.approved = i.user != ""
+			d.denied = i.user == ""
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+	http.NotFound(w, req)
+}
+
+func (i *Issuer) serveToken(w http.ResponseWriter, req *http.Request) {
+	if req.FormValue("grant_type") != deviceCodeGrantType {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+	if req.FormValue("client_id") != i.ClientID {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	d, ok := i.devices[req.FormValue("device_code")]
+	switch {
+	case !ok:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "expired_token"})
+	case d.denied:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "access_denied"})
+	case !d.approved:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "authorization_pending"})
+	default:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     i.IDToken(i.user, i.groups, time.Hour),
+		})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
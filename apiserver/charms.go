@@ -88,6 +88,9 @@ func (h *charmsHandler) ServeUnsupported(w http.ResponseWriter, r *http.Request)
 	return errors.Trace(emitUnsupportedMethodErr(r.Method))
 }
 
+// ServePost handles local charm uploads. Any user with access to the
+// model may upload a charm; it is only used once an application is
+// deployed or upgraded to it, which the Application facade authorizes.
 func (h *charmsHandler) ServePost(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != "POST" {
 		return errors.Trace(emitUnsupportedMethodErr(r.Method))
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apitesting "github.com/juju/juju/apiserver/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
@@ -201,6 +202,29 @@ func (s *charmsSuite) TestUploadBumpsRevision(c *gc.C) {
 	c.Assert(sch.BundleSha256(), gc.Not(gc.Equals), "")
 }
 
+func (s *charmsSuite) TestUploadAllowedWithoutModelWriteAccess(c *gc.C) {
+	// Uploading a local charm only requires access to the model; a user
+	// granted upgrade-charm on an application may then upgrade it to the
+	// uploaded charm.
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "hunter2",
+		Access:   permission.ReadAccess,
+	})
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	f, err := os.Open(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "POST",
+		URL:         s.charmsURI("?series=quantal"),
+		ContentType: "application/zip",
+		Body:        f,
+		Tag:         user.Tag().String(),
+		Password:    "hunter2",
+	})
+	s.assertUploadResponse(c, resp, "local:quantal/dummy-1")
+}
+
 func (s *charmsSuite) TestUploadVersion(c *gc.C) {
 	// Add the dummy charm with version "juju-2.4-beta3-146-g725cfd3-dirty".
 	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
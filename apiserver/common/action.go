@@ -57,6 +57,29 @@ func TagToActionReceiverFn(findEntity func(names.Tag) (state.Entity, error)) fun
 	}
 }
 
+// EnqueueActions queues up each action to be executed by its designated
+// ActionReceiver, returning the params.ActionResult for each enqueued
+// action, or an error if there was a problem enqueueing it.
+func EnqueueActions(args params.Actions, tagToActionReceiver func(tag string) (state.ActionReceiver, error)) params.ActionResults {
+	response := params.ActionResults{Results: make([]params.ActionResult, len(args.Actions))}
+	for i, action := range args.Actions {
+		currentResult := &response.Results[i]
+		receiver, err := tagToActionReceiver(action.Receiver)
+		if err != nil {
+			currentResult.Error = ServerError(err)
+			continue
+		}
+		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		if err != nil {
+			currentResult.Error = ServerError(err)
+			continue
+		}
+
+		response.Results[i] = MakeActionResult(receiver.Tag(), enqueued)
+	}
+	return response
+}
+
 // AuthAndActionFromTagFn takes in an authorizer function and a function that can fetch action by tags from state
 // and returns a function that can fetch an action from state by id and check the authorization.
 func AuthAndActionFromTagFn(canAccess AuthFunc, getActionByTag func(names.ActionTag) (state.Action, error)) func(string) (state.Action, error) {
@@ -131,6 +154,28 @@ func FinishActions(args params.ActionExecutionResults, actionFn func(string) (st
 	return results
 }
 
+// LogActionsMessages records the progress messages against the specified actions.
+// It's a helper function currently used by the uniter.
+// It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
+func LogActionsMessages(args params.ActionMessageParams, actionFn func(string) (state.Action, error)) params.ErrorResults {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Messages))}
+
+	for i, arg := range args.Messages {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+		err = action.Log(arg.Value)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+	}
+
+	return results
+}
+
 // Actions returns the Actions by Tags passed in and ensures that the receiver asking for
 // them is the same one that has the action.
 // It's a helper function currently used by the uniter and by machineactions.
@@ -246,5 +291,7 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 		Enqueued:  action.Enqueued(),
 		Started:   action.Started(),
 		Completed: action.Completed(),
+
+		OutputOverflow: action.ResultsOverflow(),
 	}
 }
@@ -62,6 +62,37 @@ func HasPermission(
 	return true, nil
 }
 
+type applicationCapabilitiesFunc func(appName string, user names.UserTag) ([]permission.Capability, error)
+
+// HasApplicationCapability returns true if the specified user has been
+// granted the specified capability on the target application, by way of
+// the role granted to them on it.
+func HasApplicationCapability(
+	capabilitiesGetter applicationCapabilitiesFunc, utag names.Tag,
+	capability permission.Capability, target names.Tag,
+) (bool, error) {
+	if target.Kind() != names.ApplicationTagKind {
+		return false, nil
+	}
+	if err := permission.ValidateCapability(capability); err != nil {
+		return false, nil
+	}
+	userTag, ok := utag.(names.UserTag)
+	if !ok {
+		return false, nil
+	}
+	capabilities, err := capabilitiesGetter(target.Id(), userTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Annotatef(err, "while obtaining %s user", target.Kind())
+	}
+	for _, c := range capabilities {
+		if c == capability {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // GetPermission returns the permission a user has on te specified target.
 func GetPermission(accessGetter userAccessFunc, userTag names.UserTag, target names.Tag) (permission.Access, error) {
 	userAccess, err := accessGetter(userTag, target)
@@ -221,3 +221,48 @@ func (r *PermissionSuite) TestEveryoneAtExternal(c *gc.C) {
 		c.Assert(hasPermission, gc.Equals, t.expected)
 	}
 }
+
+func (r *PermissionSuite) TestHasApplicationCapability(c *gc.C) {
+	var gotApp string
+	var gotUser names.UserTag
+	capabilitiesGetter := func(appName string, user names.UserTag) ([]permission.Capability, error) {
+		gotApp, gotUser = appName, user
+		return []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"}, nil
+	}
+	user := names.NewUserTag("validuser")
+	target := names.NewApplicationTag("mysql")
+
+	hasCapability, err := common.HasApplicationCapability(capabilitiesGetter, user, "Action.Enqueue", target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hasCapability, jc.IsTrue)
+	c.Assert(gotApp, gc.Equals, "mysql")
+	c.Assert(gotUser, gc.Equals, user)
+
+	hasCapability, err = common.HasApplicationCapability(capabilitiesGetter, user, "Action.EnqueueApplicationActions", target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hasCapability, jc.IsFalse)
+
+	// Only facade methods that may be granted on applications are
+	// capabilities.
+	hasCapability, err = common.HasApplicationCapability(capabilitiesGetter, user, "Application.Deploy", target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hasCapability, jc.IsFalse)
+
+	hasCapability, err = common.HasApplicationCapability(capabilitiesGetter, user, "Action.Enqueue", testing.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hasCapability, jc.IsFalse)
+
+	hasCapability, err = common.HasApplicationCapability(capabilitiesGetter, names.NewMachineTag("0"), "Action.Enqueue", target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hasCapability, jc.IsFalse)
+}
+
+func (r *PermissionSuite) TestHasApplicationCapabilityError(c *gc.C) {
+	capabilitiesGetter := func(string, names.UserTag) ([]permission.Capability, error) {
+		return nil, errors.New("boom")
+	}
+	hasCapability, err := common.HasApplicationCapability(
+		capabilitiesGetter, names.NewUserTag("validuser"), "Action.Enqueue", names.NewApplicationTag("mysql"))
+	c.Assert(err, gc.ErrorMatches, "while obtaining application user: boom")
+	c.Assert(hasCapability, jc.IsFalse)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -82,6 +83,14 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 time, only send lines logged at or after this time
+//   endTime -> string - RFC3339 time, only send lines logged at or before this time
+//      - if endTime is in the future, the request finishes at endTime
+//   includeMessage -> []string - regular expressions matched against the log messages
+//      - if none are set, then all lines are considered included
+//   excludeMessage -> []string - regular expressions for messages to exclude from the response
+//   agentType -> []string - one of [machine, unit, application, controller],
+//      - only send lines logged by these kinds of agent
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -111,6 +120,13 @@ func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			socket.sendError(err)
 			return
 		}
+		if params.hasAgentType(controllerAgentType) {
+			params.controllerMachines, err = controllerMachines(st.State)
+			if err != nil {
+				socket.sendError(err)
+				return
+			}
+		}
 
 		if err := h.handle(st, params, socket, h.ctxt.stop()); err != nil {
 			if isBrokenPipe(err) {
@@ -184,6 +200,26 @@ type debugLogParams struct {
 	excludeEntity []string
 	includeModule []string
 	excludeModule []string
+
+	endTime        time.Time
+	includeMessage []string
+	excludeMessage []string
+	agentTypes     []string
+
+	// controllerMachines holds the ids of the controller machines,
+	// if the request filters on the controller agent type.
+	controllerMachines []string
+}
+
+// hasAgentType returns whether the request filters on the given agent
+// type.
+func (p debugLogParams) hasAgentType(agentType string) bool {
+	for _, t := range p.agentTypes {
+		if t == agentType {
+			return true
+		}
+	}
+	return false
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -239,6 +275,30 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		params.endTime = endTime
+	}
+
+	for _, value := range append(queryMap["includeMessage"], queryMap["excludeMessage"]...) {
+		if _, err := regexp.Compile(value); err != nil {
+			return params, errors.Errorf("message filter %q is not a valid regular expression", value)
+		}
+	}
+	params.includeMessage = queryMap["includeMessage"]
+	params.excludeMessage = queryMap["excludeMessage"]
+
+	for _, value := range queryMap["agentType"] {
+		if !isValidAgentType(value) {
+			return params, errors.Errorf("agent type %q is not one of %q, %q, %q, %q",
+				value, machineAgentType, unitAgentType, applicationAgentType, controllerAgentType)
+		}
+	}
+	params.agentTypes = queryMap["agentType"]
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
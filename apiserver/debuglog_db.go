@@ -5,8 +5,10 @@ package apiserver
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/httpcontext"
 	"github.com/juju/juju/apiserver/params"
@@ -37,11 +39,20 @@ func handleDebugLogDBRequest(
 	// Indicate that all is well.
 	socket.sendOk()
 
+	// When tailing until a time in the future, stop once that time
+	// has come.
+	var ended <-chan time.Time
+	if !params.NoTail && !reqParams.endTime.IsZero() {
+		ended = time.After(reqParams.endTime.Sub(time.Now()))
+	}
+
 	var lineCount uint
 	for {
 		select {
 		case <-stop:
 			return nil
+		case <-ended:
+			return nil
 		case rec, ok := <-tailer.Logs():
 			if !ok {
 				return errors.Annotate(tailer.Err(), "tailer stopped")
@@ -69,13 +80,74 @@ func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 		ExcludeEntity: reqParams.excludeEntity,
 		IncludeModule: reqParams.includeModule,
 		ExcludeModule: reqParams.excludeModule,
+
+		EndTime:        reqParams.endTime,
+		IncludeMessage: reqParams.includeMessage,
+		ExcludeMessage: reqParams.excludeMessage,
+		AgentEntity:    makeAgentEntities(reqParams),
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
 	}
+	if !reqParams.endTime.IsZero() && reqParams.endTime.Before(time.Now()) {
+		// Nothing logged from now on will be wanted.
+		params.NoTail = true
+	}
 	return params
 }
 
+const (
+	machineAgentType     = "machine"
+	unitAgentType        = "unit"
+	applicationAgentType = "application"
+	controllerAgentType  = "controller"
+)
+
+func isValidAgentType(agentType string) bool {
+	switch agentType {
+	case machineAgentType, unitAgentType, applicationAgentType, controllerAgentType:
+		return true
+	}
+	return false
+}
+
+// makeAgentEntities returns the entity patterns matching the agent types
+// in the request, or nil if the request doesn't filter on agent type.
+func makeAgentEntities(reqParams debugLogParams) []string {
+	if len(reqParams.agentTypes) == 0 {
+		return nil
+	}
+	entities := []string{}
+	for _, agentType := range reqParams.agentTypes {
+		switch agentType {
+		case machineAgentType:
+			entities = append(entities, names.MachineTagKind+"-*")
+		case unitAgentType:
+			entities = append(entities, names.UnitTagKind+"-*")
+		case applicationAgentType:
+			entities = append(entities, names.ApplicationTagKind+"-*")
+		case controllerAgentType:
+			for _, id := range reqParams.controllerMachines {
+				entities = append(entities, names.NewMachineTag(id).String())
+			}
+		}
+	}
+	return entities
+}
+
+// controllerMachines returns the ids of the controller machines if st
+// is the controller model. No other model has controller agents.
+func controllerMachines(st *state.State) ([]string, error) {
+	if !st.IsController() {
+		return nil, nil
+	}
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info.MachineIds, nil
+}
+
 func formatLogRecord(r *state.LogRecord) *params.LogMessage {
 	return &params.LogMessage{
 		Entity:    r.Entity.String(),
@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/juju/loggo"
@@ -91,6 +92,83 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestParamConversionFilters(c *gc.C) {
+	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
+	reqParams := debugLogParams{
+		endTime:            t1,
+		includeMessage:     []string{"^foo"},
+		excludeMessage:     []string{"bar$"},
+		agentTypes:         []string{"unit", "controller"},
+		controllerMachines: []string{"0", "1"},
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+
+		c.Assert(params.EndTime, gc.Equals, t1)
+		// The end time has passed, so there's nothing to tail.
+		c.Assert(params.NoTail, jc.IsTrue)
+		c.Assert(params.IncludeMessage, jc.DeepEquals, []string{"^foo"})
+		c.Assert(params.ExcludeMessage, jc.DeepEquals, []string{"bar$"})
+		c.Assert(params.AgentEntity, jc.DeepEquals, []string{"unit-*", "machine-0", "machine-1"})
+
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestParamConversionNoControllerMachines(c *gc.C) {
+	params := makeLogTailerParams(debugLogParams{
+		agentTypes: []string{"controller"},
+	})
+	// No entity can match.
+	c.Assert(params.AgentEntity, gc.NotNil)
+	c.Assert(params.AgentEntity, gc.HasLen, 0)
+}
+
+func (s *debugLogDBIntSuite) TestReadFilterParams(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"endTime":        {"2016-11-30T10:51:00Z"},
+		"includeMessage": {"^foo", "baz"},
+		"excludeMessage": {"bar$"},
+		"agentType":      {"machine", "application"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.endTime, gc.Equals, time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC))
+	c.Assert(params.includeMessage, jc.DeepEquals, []string{"^foo", "baz"})
+	c.Assert(params.excludeMessage, jc.DeepEquals, []string{"bar$"})
+	c.Assert(params.agentTypes, jc.DeepEquals, []string{"machine", "application"})
+}
+
+func (s *debugLogDBIntSuite) TestReadFilterParamsErrors(c *gc.C) {
+	for i, test := range []struct {
+		query url.Values
+		err   string
+	}{{
+		query: url.Values{"endTime": {"yesterday"}},
+		err:   `end time "yesterday" is not a valid time in RFC3339 format`,
+	}, {
+		query: url.Values{"includeMessage": {"foo("}},
+		err:   `message filter "foo\(" is not a valid regular expression`,
+	}, {
+		query: url.Values{"excludeMessage": {"[bar"}},
+		err:   `message filter "\[bar" is not a valid regular expression`,
+	}, {
+		query: url.Values{"agentType": {"model"}},
+		err:   `agent type "model" is not one of "machine", "unit", "application", "controller"`,
+	}} {
+		c.Logf("test %d: %v", i, test.query)
+		_, err := readDebugLogParams(test.query)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	// Set up a fake log tailer with a 2 log records ready to send.
 	tailer := newFakeLogTailer()
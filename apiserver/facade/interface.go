@@ -117,6 +117,11 @@ type Authorizer interface {
 	// target by the given user.
 	UserHasPermission(user names.UserTag, operation permission.Access, target names.Tag) (bool, error)
 
+	// HasCapability reports whether the authenticated entity has been
+	// granted the given capability, a facade method, on the target
+	// application.
+	HasCapability(capability permission.Capability, target names.Tag) (bool, error)
+
 	// ConnectedModel returns the UUID of the model to which the API
 	// connection was made.
 	ConnectedModel() string
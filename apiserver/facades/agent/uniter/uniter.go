@@ -35,7 +35,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v8) of the Uniter API.
+// UniterAPI implements the latest version (v10) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -62,9 +62,19 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV9 adds LogActionsMessages.
+type UniterAPIV9 struct {
+	UniterAPI
+}
+
+// UniterAPIV8 adds SetPodSpec.
+type UniterAPIV8 struct {
+	UniterAPIV9
+}
+
 // UniterAPIV7 adds CMR support to NetworkInfo.
 type UniterAPIV7 struct {
-	UniterAPI
+	UniterAPIV8
 }
 
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
@@ -242,14 +252,36 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// NewUniterAPIV9 creates an instance of the V9 uniter API.
+func NewUniterAPIV9(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV9, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV9{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV8 creates an instance of the V8 uniter API.
+func NewUniterAPIV8(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV8, error) {
+	uniterAPI, err := NewUniterAPIV9(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{
+		UniterAPIV9: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV7 creates an instance of the V7 uniter API.
 func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV8(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV7{
-		UniterAPI: *uniterAPI,
+		UniterAPIV8: *uniterAPI,
 	}, nil
 }
 
@@ -860,6 +892,100 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 	return result, nil
 }
 
+// RecordHookExecutions adds the given hook, action and commands
+// executions to the hook history of each unit.
+func (u *UniterAPI) RecordHookExecutions(args params.UnitsHookExecutions) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Units)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Units {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		executions := make([]state.HookExecution, len(arg.Executions))
+		for j, e := range arg.Executions {
+			executions[j] = state.HookExecution{
+				Kind:       state.HookExecutionKind(e.Kind),
+				Name:       e.Name,
+				Relation:   e.Relation,
+				RemoteUnit: e.RemoteUnit,
+				Started:    e.Started,
+				Finished:   e.Finished,
+				ExitCode:   e.ExitCode,
+				Error:      e.Error,
+			}
+		}
+		err = unit.AddHookExecutions(executions...)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// HookTimeouts returns, for each given unit, the hook-timeout set in its
+// application's config. An empty result means the unit's hooks may run
+// for as long as they like.
+func (u *UniterAPI) HookTimeouts(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		timeout, err := u.hookTimeout(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = timeout
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) hookTimeout(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return config.GetString(application.HookTimeoutConfigOptionName, ""), nil
+}
+
 // OpenPorts sets the policy of the port range with protocol to be
 // opened, for all given units.
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
@@ -1111,6 +1237,22 @@ func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.Er
 	return common.FinishActions(args, actionFn), nil
 }
 
+// LogActionsMessages records the action progress messages.
+func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -2427,6 +2569,15 @@ func (u *UniterAPIV6) NetworkInfo(args params.NetworkInfoParams) (params.Network
 // SetPodSpec isn't on the v7 API.
 func (u *UniterAPIV7) SetPodSpec(_, _ struct{}) {}
 
+// LogActionsMessages isn't on the v8 API.
+func (u *UniterAPIV8) LogActionsMessages(_, _ struct{}) {}
+
+// RecordHookExecutions isn't on the v9 API.
+func (u *UniterAPIV9) RecordHookExecutions(_, _ struct{}) {}
+
+// HookTimeouts isn't on the v9 API.
+func (u *UniterAPIV9) HookTimeouts(_, _ struct{}) {}
+
 // SetPodSpec sets the pod specs for a set of applications.
 func (u *UniterAPI) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -903,6 +903,69 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestRecordHookExecutions(c *gc.C) {
+	started := time.Date(2018, 6, 15, 10, 30, 0, 0, time.UTC)
+	execution := params.HookExecution{
+		Kind:       "hook",
+		Name:       "db-relation-changed",
+		Relation:   "db:0",
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ExitCode:   1,
+		Error:      "exit status 1",
+	}
+	args := params.UnitsHookExecutions{Units: []params.UnitHookExecutions{
+		{Tag: "unit-mysql-0", Executions: []params.HookExecution{execution}},
+		{Tag: "unit-wordpress-0", Executions: []params.HookExecution{execution}},
+		{Tag: "unit-foo-42", Executions: []params.HookExecution{execution}},
+	}}
+	result, err := s.uniter.RecordHookExecutions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	executions, err := s.wordpressUnit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, jc.DeepEquals, []state.HookExecution{{
+		Kind:       state.HookExecutionHook,
+		Name:       "db-relation-changed",
+		Relation:   "db:0",
+		RemoteUnit: "mysql/0",
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ExitCode:   1,
+		Error:      "exit status 1",
+	}})
+}
+
+func (s *uniterSuite) TestHookTimeouts(c *gc.C) {
+	conf := map[string]interface{}{application.HookTimeoutConfigOptionName: "30m"}
+	fields := map[string]environschema.Attr{application.HookTimeoutConfigOptionName: {Type: environschema.Tstring}}
+	err := s.wordpress.UpdateApplicationConfig(conf, nil, fields, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.HookTimeouts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: "30m"},
+			{Error: apiservertesting.ServerError(`"application-wordpress" is not a valid unit tag`)},
+		},
+	})
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -1690,6 +1753,32 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: action.ActionTag().String(), Value: "hello"},
+			{Tag: "action-foo", Value: "hello"},
+		},
+	}
+	res, err := s.uniter.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 2)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, gc.NotNil)
+
+	running, err := s.wordpressUnit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, gc.HasLen, 1)
+	messages := running[0].Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message(), gc.Equals, "hello")
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 // ActionAPI implements the client API for interacting with Actions
@@ -23,6 +24,20 @@ type ActionAPI struct {
 	check      *common.BlockChecker
 }
 
+// APIv2 provides the Action API facade for version 2.
+type APIv2 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV2 returns an initialized ActionAPI for version 2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
 // NewActionAPI returns an initialized ActionAPI
 func NewActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
@@ -65,6 +80,28 @@ func (a *ActionAPI) checkCanWrite() error {
 	return nil
 }
 
+// canWrite reports whether the user has write access to the model.
+func (a *ActionAPI) canWrite() (bool, error) {
+	err := a.checkCanWrite()
+	if err == common.ErrPerm {
+		return false, nil
+	}
+	return err == nil, errors.Trace(err)
+}
+
+// checkCanRunAction checks that the user has been granted the capability
+// to call the named facade method on the named application.
+func (a *ActionAPI) checkCanRunAction(appName string, capability permission.Capability) error {
+	canRun, err := a.authorizer.HasCapability(capability, names.NewApplicationTag(appName))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRun {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (a *ActionAPI) checkCanAdmin() error {
 	canAdmin, err := a.authorizer.HasPermission(permission.AdminAccess, a.model.ModelTag())
 	if err != nil {
@@ -115,6 +152,44 @@ func (a *ActionAPI) Actions(arg params.Entities) (params.ActionResults, error) {
 	return response, nil
 }
 
+// ActionOutputs takes a list of ActionTags, and returns the full Action
+// for each ID, including any output that was too large to be returned
+// by Actions.
+func (a *ActionAPI) ActionOutputs(arg params.Entities) (params.ActionResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionResults{}, errors.Trace(err)
+	}
+
+	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Entities))}
+	for i, entity := range arg.Entities {
+		currentResult := &response.Results[i]
+		actionTag, err := names.ParseActionTag(entity.Tag)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		action, err := a.model.ActionByTag(actionTag)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		output, err := action.FullResults()
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		response.Results[i] = common.MakeActionResult(receiverTag, action)
+		response.Results[i].Output = output
+		response.Results[i].OutputOverflow = false
+	}
+	return response, nil
+}
+
 // FindActionTagsByPrefix takes a list of string prefixes and finds
 // corresponding ActionTags that match that prefix.
 func (a *ActionAPI) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsResults, error) {
@@ -176,7 +251,8 @@ func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.Ac
 // enqueued Action, or an error if there was a problem enqueueing the
 // Action.
 func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
-	if err := a.checkCanWrite(); err != nil {
+	canWrite, err := a.canWrite()
+	if err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
 
@@ -185,21 +261,231 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	}
 
 	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
-	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Actions))}
+	if !canWrite {
+		// Users without write access to the model may still run
+		// actions on the units of applications they have been
+		// granted the capability to do so on.
+		findReceiver := tagToActionReceiver
+		tagToActionReceiver = func(tag string) (state.ActionReceiver, error) {
+			unitTag, err := names.ParseUnitTag(tag)
+			if err != nil {
+				return nil, common.ErrPerm
+			}
+			appName, err := names.UnitApplication(unitTag.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := a.checkCanRunAction(appName, "Action.Enqueue"); err != nil {
+				return nil, err
+			}
+			return findReceiver(tag)
+		}
+	}
+	return common.EnqueueActions(arg, tagToActionReceiver), nil
+}
+
+// EnqueueApplicationActions queues each action on all the units of its
+// application. The actions are released to units no more than the
+// requested concurrency at a time, and the remaining actions are
+// cancelled once the requested number of failures is reached. The
+// aggregate result for each application action is returned.
+func (a *ActionAPI) EnqueueApplicationActions(arg params.ApplicationActions) (params.ActionBatchResults, error) {
+	canWrite, err := a.canWrite()
+	if err != nil {
+		return params.ActionBatchResults{}, errors.Trace(err)
+	}
+
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionBatchResults{}, errors.Trace(err)
+	}
+
+	response := params.ActionBatchResults{Results: make([]params.ActionBatchResult, len(arg.Actions))}
 	for i, action := range arg.Actions {
 		currentResult := &response.Results[i]
-		receiver, err := tagToActionReceiver(action.Receiver)
+		appTag, err := names.ParseApplicationTag(action.ApplicationTag)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		if !canWrite {
+			if err := a.checkCanRunAction(appTag.Id(), "Action.EnqueueApplicationActions"); err != nil {
+				currentResult.Error = common.ServerError(err)
+				continue
+			}
+		}
+		app, err := a.state.Application(appTag.Id())
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		batch, err := app.AddActionBatch(state.ActionBatchArgs{
+			Name:           action.Name,
+			Parameters:     action.Parameters,
+			MaxConcurrency: action.MaxConcurrency,
+			StopOnFailures: action.StopOnFailures,
+		})
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		result, err := makeActionBatchResult(batch)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		response.Results[i] = result
+	}
+	return response, nil
+}
+
+// ActionBatches returns the aggregate results of the action batches
+// with the given ids.
+func (a *ActionAPI) ActionBatches(arg params.ActionBatchIds) (params.ActionBatchResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionBatchResults{}, errors.Trace(err)
+	}
+
+	response := params.ActionBatchResults{Results: make([]params.ActionBatchResult, len(arg.Ids))}
+	for i, id := range arg.Ids {
+		currentResult := &response.Results[i]
+		batch, err := a.model.ActionBatch(id)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		result, err := makeActionBatchResult(batch)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
+		response.Results[i] = result
+	}
+	return response, nil
+}
+
+// makeActionBatchResult builds the aggregate result for an action
+// batch. The batch is running while any of its actions are pending or
+// running; otherwise it is aborted, failed or completed.
+func makeActionBatchResult(batch *state.ActionBatch) (params.ActionBatchResult, error) {
+	actions, err := batch.Actions()
+	if err != nil {
+		return params.ActionBatchResult{}, errors.Trace(err)
+	}
+	result := params.ActionBatchResult{
+		Id:             batch.Id(),
+		ApplicationTag: names.NewApplicationTag(batch.Application()).String(),
+		Name:           batch.Name(),
+		MaxConcurrency: batch.MaxConcurrency(),
+		StopOnFailures: batch.StopOnFailures(),
+		Enqueued:       batch.Enqueued(),
+		Summary:        make(map[string]int),
+	}
+	var inFlight, failed bool
+	for _, action := range actions {
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
+		if err != nil {
+			return params.ActionBatchResult{}, errors.Trace(err)
+		}
+		result.Actions = append(result.Actions, common.MakeActionResult(receiverTag, action))
+		result.Summary[string(action.Status())]++
+		switch action.Status() {
+		case state.ActionPending, state.ActionRunning:
+			inFlight = true
+		case state.ActionFailed:
+			failed = true
+		}
+	}
+	switch {
+	case inFlight:
+		result.Status = params.ActionRunning
+	case batch.Aborted():
+		result.Status = params.ActionBatchAborted
+	case failed:
+		result.Status = params.ActionFailed
+	default:
+		result.Status = params.ActionCompleted
+	}
+	return result, nil
+}
+
+// AddActionSchedules adds schedules that enqueue an action on their
+// receivers whenever their cron expressions fire.
+func (a *ActionAPI) AddActionSchedules(arg params.ActionSchedules) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
 
-		response.Results[i] = common.MakeActionResult(receiver.Tag(), enqueued)
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
+	response := params.ErrorResults{Results: make([]params.ErrorResult, len(arg.Schedules))}
+	for i, schedule := range arg.Schedules {
+		args := state.ActionScheduleArgs{
+			Name:       schedule.Name,
+			Action:     schedule.Action,
+			Parameters: schedule.Parameters,
+			Schedule:   schedule.Schedule,
+		}
+		var err error
+		for _, receiverTag := range schedule.Receivers {
+			var receiver state.ActionReceiver
+			receiver, err = tagToActionReceiver(receiverTag)
+			if err != nil {
+				break
+			}
+			args.Receivers = append(args.Receivers, receiver.Tag())
+		}
+		if err == nil {
+			_, err = a.model.AddActionSchedule(args)
+		}
+		response.Results[i].Error = common.ServerError(err)
+	}
+	return response, nil
+}
+
+// ActionSchedules returns all the action schedules in the model.
+func (a *ActionAPI) ActionSchedules() (params.ActionScheduleResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	schedules, err := a.model.AllActionSchedules()
+	if err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	response := params.ActionScheduleResults{Results: make([]params.ActionSchedule, len(schedules))}
+	for i, schedule := range schedules {
+		response.Results[i] = params.ActionSchedule{
+			Name:       schedule.Name(),
+			Action:     schedule.Action(),
+			Receivers:  schedule.Receivers(),
+			Parameters: schedule.Parameters(),
+			Schedule:   schedule.Schedule(),
+			Created:    schedule.Created(),
+			LastRun:    schedule.LastRun(),
+			NextRun:    schedule.NextRun(),
+		}
+	}
+	return response, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given
+// names. Actions already enqueued by the schedules are not affected.
+func (a *ActionAPI) RemoveActionSchedules(arg params.ActionScheduleNames) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	response := params.ErrorResults{Results: make([]params.ErrorResult, len(arg.Names))}
+	for i, name := range arg.Names {
+		err := a.model.RemoveActionSchedule(name)
+		response.Results[i].Error = common.ServerError(err)
 	}
 	return response, nil
 }
@@ -404,3 +690,56 @@ func runningActions(ar state.ActionReceiver) ([]params.ActionResult, error) {
 func completedActions(ar state.ActionReceiver) ([]params.ActionResult, error) {
 	return common.ConvertActions(ar, ar.CompletedActions)
 }
+
+// WatchActionsProgress isn't on the v2 API.
+func (a *APIv2) WatchActionsProgress(_, _ struct{}) {}
+
+// EnqueueApplicationActions isn't on the v2 API.
+func (a *APIv2) EnqueueApplicationActions(_, _ struct{}) {}
+
+// ActionBatches isn't on the v2 API.
+func (a *APIv2) ActionBatches(_, _ struct{}) {}
+
+// ActionOutputs isn't on the v2 API.
+func (a *APIv2) ActionOutputs(_, _ struct{}) {}
+
+// AddActionSchedules isn't on the v2 API.
+func (a *APIv2) AddActionSchedules(_, _ struct{}) {}
+
+// ActionSchedules isn't on the v2 API.
+func (a *APIv2) ActionSchedules(_, _ struct{}) {}
+
+// RemoveActionSchedules isn't on the v2 API.
+func (a *APIv2) RemoveActionSchedules(_, _ struct{}) {}
+
+// WatchActionsProgress creates a watcher that reports on action log messages.
+func (a *ActionAPI) WatchActionsProgress(actions params.Entities) (params.StringsWatchResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(actions.Entities)),
+	}
+	for i, arg := range actions.Entities {
+		result := &results.Results[i]
+		actionTag, err := names.ParseActionTag(arg.Tag)
+		if err != nil {
+			result.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		if _, err := a.model.ActionByTag(actionTag); err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		w := a.state.WatchActionLogs(actionTag.Id())
+		// Consume the initial event and forward it to the result.
+		if changes, ok := <-w.Changes(); ok {
+			result.StringsWatcherId = a.resources.Register(w)
+			result.Changes = changes
+		} else {
+			result.Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results, nil
+}
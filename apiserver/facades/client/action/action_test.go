@@ -5,6 +5,7 @@ package action_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/juju/errors"
@@ -764,3 +765,193 @@ func stringify(r params.ActionResult) string {
 	}
 	return fmt.Sprintf("%s-%s-%#v-%s-%s-%#v", a.Tag, a.Name, a.Parameters, r.Status, r.Message, r.Output)
 }
+
+func (s *actionSuite) TestWatchActionProgress(c *gc.C) {
+	api, err := action.NewActionAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	added, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	running, err := added.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = running.Log("hello")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.WatchActionsProgress(params.Entities{
+		Entities: []params.Entity{
+			{Tag: added.ActionTag().String()},
+			{Tag: "unit-wordpress-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].StringsWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[0].Changes, gc.HasLen, 1)
+	c.Assert(results.Results[0].Changes[0], jc.Contains, `"message":"hello"`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, common.ErrBadId.Error())
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+}
+
+func (s *actionSuite) TestEnqueueApplicationActions(c *gc.C) {
+	arg := params.ApplicationActions{
+		Actions: []params.ApplicationAction{{
+			ApplicationTag: s.wordpress.Tag().String(),
+			Name:           "fakeaction",
+			MaxConcurrency: 1,
+			StopOnFailures: 1,
+		}, {
+			ApplicationTag: "application-missing",
+			Name:           "fakeaction",
+		}, {
+			ApplicationTag: s.wordpressUnit.Tag().String(),
+			Name:           "fakeaction",
+		}},
+	}
+	res, err := s.action.EnqueueApplicationActions(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+
+	result := res.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Id, gc.Not(gc.Equals), "")
+	c.Assert(result.ApplicationTag, gc.Equals, s.wordpress.Tag().String())
+	c.Assert(result.Name, gc.Equals, "fakeaction")
+	c.Assert(result.Status, gc.Equals, params.ActionRunning)
+	c.Assert(result.MaxConcurrency, gc.Equals, 1)
+	c.Assert(result.StopOnFailures, gc.Equals, 1)
+	c.Assert(result.Summary, jc.DeepEquals, map[string]int{"pending": 1})
+	c.Assert(result.Actions, gc.HasLen, 1)
+	c.Assert(result.Actions[0].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Assert(res.Results[2].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid application tag`)
+
+	// Finishing the action completes the batch.
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, jc.ErrorIsNil)
+
+	batches, err := s.action.ActionBatches(params.ActionBatchIds{
+		Ids: []string{result.Id, "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(batches.Results, gc.HasLen, 2)
+	c.Assert(batches.Results[0].Error, gc.IsNil)
+	c.Assert(batches.Results[0].Status, gc.Equals, params.ActionFailed)
+	c.Assert(batches.Results[0].Summary, jc.DeepEquals, map[string]int{"failed": 1})
+	c.Assert(batches.Results[1].Error, gc.ErrorMatches, `action batch "missing" not found`)
+}
+
+func (s *actionSuite) TestEnqueueApplicationAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("run-action-application-wordpress")
+	api, err := action.NewActionAPI(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := api.Enqueue(params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.machine0.Tag().String(), Name: "fakeaction"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[0].Action.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(res.Results[2].Error, gc.ErrorMatches, "permission denied")
+
+	batchRes, err := api.EnqueueApplicationActions(params.ApplicationActions{
+		Actions: []params.ApplicationAction{{
+			ApplicationTag: s.wordpress.Tag().String(),
+			Name:           "fakeaction",
+		}, {
+			ApplicationTag: s.mysql.Tag().String(),
+			Name:           "fakeaction",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(batchRes.Results, gc.HasLen, 2)
+	c.Assert(batchRes.Results[0].Error, gc.IsNil)
+	c.Assert(batchRes.Results[1].Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *actionSuite) TestActionOutputs(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"max-action-output-size": "1K",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := map[string]interface{}{"out": strings.Repeat("x", 2048)}
+	added, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = added.Finish(state.ActionResults{Status: state.ActionCompleted, Results: output})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entities := params.Entities{Entities: []params.Entity{
+		{Tag: added.ActionTag().String()},
+		{Tag: "unit-wordpress-0"},
+	}}
+	actions, err := s.action.Actions(entities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions.Results[0].OutputOverflow, jc.IsTrue)
+	c.Assert(actions.Results[0].Output, gc.HasLen, 0)
+
+	outputs, err := s.action.ActionOutputs(entities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(outputs.Results, gc.HasLen, 2)
+	c.Assert(outputs.Results[0].Error, gc.IsNil)
+	c.Assert(outputs.Results[0].Status, gc.Equals, params.ActionCompleted)
+	c.Assert(outputs.Results[0].OutputOverflow, jc.IsFalse)
+	c.Assert(outputs.Results[0].Output, jc.DeepEquals, output)
+	c.Assert(outputs.Results[1].Error, gc.ErrorMatches, common.ErrBadId.Error())
+}
+
+func (s *actionSuite) TestActionSchedules(c *gc.C) {
+	res, err := s.action.AddActionSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       "nightly",
+			Action:     "fakeaction",
+			Receivers:  []string{s.wordpressUnit.Tag().String()},
+			Parameters: map[string]interface{}{"foo": "bar"},
+			Schedule:   "0 2 * * *",
+		}, {
+			Name:      "missing",
+			Action:    "fakeaction",
+			Receivers: []string{"unit-missing-0"},
+			Schedule:  "@daily",
+		}, {
+			Name:      "bad",
+			Action:    "fakeaction",
+			Receivers: []string{s.wordpressUnit.Tag().String()},
+			Schedule:  "sometimes",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, `unit-missing-0 not found`)
+	c.Assert(res.Results[2].Error, gc.ErrorMatches, `cannot add action schedule: cron expression "sometimes" .* not valid`)
+
+	schedules, err := s.action.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules.Results, gc.HasLen, 1)
+	schedule := schedules.Results[0]
+	c.Assert(schedule.Name, gc.Equals, "nightly")
+	c.Assert(schedule.Action, gc.Equals, "fakeaction")
+	c.Assert(schedule.Receivers, jc.DeepEquals, []string{s.wordpressUnit.Tag().String()})
+	c.Assert(schedule.Parameters, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+	c.Assert(schedule.Schedule, gc.Equals, "0 2 * * *")
+	c.Assert(schedule.NextRun.IsZero(), jc.IsFalse)
+
+	removed, err := s.action.RemoveActionSchedules(params.ActionScheduleNames{
+		Names: []string{"nightly", "nightly"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 2)
+	c.Assert(removed.Results[0].Error, gc.IsNil)
+	c.Assert(removed.Results[1].Error, gc.ErrorMatches, `action schedule "nightly" not found`)
+}
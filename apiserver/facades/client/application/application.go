@@ -9,6 +9,8 @@ package application
 import (
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -57,6 +59,16 @@ type APIv6 struct {
 
 // APIv7 provides the Application API facade for version 7.
 type APIv7 struct {
+	*APIv8
+}
+
+// APIv8 provides the Application API facade for version 8.
+type APIv8 struct {
+	*APIv9
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
 	*APIBase
 }
 
@@ -117,13 +129,33 @@ func NewFacadeV6(ctx facade.Context) (*APIv6, error) {
 // NewFacadeV7 provides the signature required for facade registration
 // for version 7.
 func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV8(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv7{api}, nil
 }
 
+// NewFacadeV8 provides the signature required for facade registration
+// for version 8.
+func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
+	api, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{api}, nil
+}
+
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	model, err := ctx.State().Model()
 	if err != nil {
@@ -192,6 +224,41 @@ func (api *APIBase) checkCanWrite() error {
 	return api.checkPermission(api.modelTag, permission.WriteAccess)
 }
 
+func (api *APIBase) checkCanAdmin() error {
+	return api.checkPermission(api.modelTag, permission.AdminAccess)
+}
+
+// checkCanWriteApplication checks that the user may call the facade
+// method named by the capability on the named application, either
+// because they have write access to the model or because they have been
+// granted the capability on the application.
+func (api *APIBase) checkCanWriteApplication(appName string, capability permission.Capability) error {
+	if err := api.checkCanWrite(); err != common.ErrPerm {
+		return err
+	}
+	if !names.IsValidApplication(appName) {
+		return common.ErrPerm
+	}
+	allowed, err := api.authorizer.HasCapability(capability, names.NewApplicationTag(appName))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// checkCanChangeApplicationConfig checks that the user may change the
+// application-level settings of applications, as opposed to their charm
+// config. Settings such as trust, which gives the charm the model's cloud
+// credential, change what Juju allows the application to do, so they may
+// only be changed by model administrators, never by way of a role
+// granted on the application.
+func (api *APIBase) checkCanChangeApplicationConfig() error {
+	return api.checkCanAdmin()
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *APIBase) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -311,7 +378,7 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		return AddTrustSchemaAndDefaults(hookTimeoutFields, nil)
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -319,6 +386,10 @@ func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, s
 	if err != nil {
 		return nil, nil, err
 	}
+	schema, err = AddHookTimeoutSchema(schema)
+	if err != nil {
+		return nil, nil, err
+	}
 	return AddTrustSchemaAndDefaults(schema, defaults)
 }
 
@@ -414,6 +485,9 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := validateHookTimeout(appConfigAttrs); err != nil {
+		return errors.Trace(err)
+	}
 
 	var settings = make(charm.Settings)
 	if len(args.ConfigYAML) > 0 {
@@ -621,7 +695,10 @@ func (api *APIBase) updateOneApplicationSeries(arg params.UpdateSeriesArg) error
 
 // SetCharm sets the charm for a given for the application.
 func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(args.ApplicationName, "Application.SetCharm"); err != nil {
+		return err
+	}
+	if err := api.checkCanSetCharmOptions(args); err != nil {
 		return err
 	}
 	// when forced units in error, don't block
@@ -649,6 +726,23 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 	)
 }
 
+// checkCanSetCharmOptions checks that the user may make the changes,
+// besides upgrading the charm, that SetCharm is asked to make with it.
+// Changing the charm config requires the same access as changing it
+// with SetApplicationsConfig, while changing storage constraints or
+// forcing the upgrade requires write access to the model.
+func (api *APIBase) checkCanSetCharmOptions(args params.ApplicationSetCharm) error {
+	if len(args.StorageConstraints) > 0 || args.ForceUnits || args.ForceSeries {
+		if err := api.checkCanWrite(); err != nil {
+			return err
+		}
+	}
+	if len(args.ConfigSettings) > 0 || args.ConfigSettingsYAML != "" {
+		return api.checkCanWriteApplication(args.ApplicationName, "Application.SetApplicationsConfig")
+	}
+	return nil
+}
+
 // GetConfig returns the charm config for each of the
 // applications asked for.
 func (api *APIBase) GetConfig(args params.Entities) (params.ApplicationGetConfigResults, error) {
@@ -822,7 +916,7 @@ func (api *APIBase) GetCharmURL(args params.ApplicationGet) (params.StringResult
 // It does not unset values that are set to an empty string.
 // Unset should be used for that.
 func (api *APIBase) Set(p params.ApplicationSet) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(p.ApplicationName, "Application.Set"); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -848,7 +942,7 @@ func (api *APIBase) Set(p params.ApplicationSet) error {
 
 // Unset implements the server side of Client.Unset.
 func (api *APIBase) Unset(p params.ApplicationUnset) error {
-	if err := api.checkCanWrite(); err != nil {
+	if err := api.checkCanWriteApplication(p.ApplicationName, "Application.Unset"); err != nil {
 		return err
 	}
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -1084,6 +1178,11 @@ func (api *APIBase) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyU
 		if !unit.IsPrincipal() {
 			return nil, errors.Errorf("unit %q is a subordinate", name)
 		}
+		if api.modelType == state.ModelTypeCAAS {
+			if err := api.checkCAASUnitRemoval(unit); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 		var info params.DestroyUnitInfo
 		if api.modelType == state.ModelTypeIAAS {
 			storage, err := storagecommon.UnitStorage(api.storageAccess, unit.UnitTag())
@@ -1125,6 +1224,55 @@ func (api *APIBase) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyU
 	return params.DestroyUnitResults{results}, nil
 }
 
+// checkCAASUnitRemoval returns an error if the unit may not be removed
+// yet. The pods of applications with storage are managed by a
+// StatefulSet, which always removes the pods with the highest ordinals
+// when scaled down; those pods are associated with the highest numbered
+// units, so the units must be removed in that order too.
+func (api *APIBase) checkCAASUnitRemoval(unit Unit) error {
+	storage, err := storagecommon.UnitStorage(api.storageAccess, unit.UnitTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(storage) == 0 {
+		return nil
+	}
+	appName, err := names.UnitApplication(unit.UnitTag().Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	last := unit.UnitTag()
+	for _, u := range units {
+		if u.Life() != state.Alive {
+			continue
+		}
+		if unitNumber(u.UnitTag()) > unitNumber(last) {
+			last = u.UnitTag()
+		}
+	}
+	if last != unit.UnitTag() {
+		return errors.Errorf(
+			"units of application %q have storage and must be removed highest numbered first, starting with %q",
+			appName, last.Id(),
+		)
+	}
+	return nil
+}
+
+func unitNumber(tag names.UnitTag) int {
+	id := tag.Id()
+	n, _ := strconv.Atoi(id[strings.LastIndex(id, "/")+1:])
+	return n
+}
+
 // Destroy destroys a given application, local or remote.
 //
 // NOTE(axw) this exists only for backwards compatibility,
@@ -1691,14 +1839,15 @@ func (u *APIv5) SetApplicationsConfig(_, _ struct{}) {}
 // Unset should be used for that.
 func (api *APIBase) SetApplicationsConfig(args params.ApplicationConfigSetArgs) (params.ErrorResults, error) {
 	var result params.ErrorResults
-	if err := api.checkCanWrite(); err != nil {
-		return result, errors.Trace(err)
-	}
 	if err := api.check.ChangeAllowed(); err != nil {
 		return result, errors.Trace(err)
 	}
 	result.Results = make([]params.ErrorResult, len(args.Args))
 	for i, arg := range args.Args {
+		if err := api.checkCanWriteApplication(arg.ApplicationName, "Application.SetApplicationsConfig"); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		err := api.setApplicationConfig(arg)
 		result.Results[i].Error = common.ServerError(err)
 	}
@@ -1721,6 +1870,12 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	}
 
 	if len(appConfigAttrs) > 0 {
+		if err := api.checkCanChangeApplicationConfig(); err != nil {
+			return err
+		}
+		if err := validateHookTimeout(appConfigAttrs); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, schema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -1748,14 +1903,15 @@ func (u *APIv5) UnsetApplicationsConfig(_, _ struct{}) {}
 // UnsetApplicationsConfig implements the server side of Application.UnsetApplicationsConfig.
 func (api *APIBase) UnsetApplicationsConfig(args params.ApplicationConfigUnsetArgs) (params.ErrorResults, error) {
 	var result params.ErrorResults
-	if err := api.checkCanWrite(); err != nil {
-		return result, errors.Trace(err)
-	}
 	if err := api.check.ChangeAllowed(); err != nil {
 		return result, errors.Trace(err)
 	}
 	result.Results = make([]params.ErrorResult, len(args.Args))
 	for i, arg := range args.Args {
+		if err := api.checkCanWriteApplication(arg.ApplicationName, "Application.UnsetApplicationsConfig"); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		err := api.unsetApplicationConfig(arg)
 		result.Results[i].Error = common.ServerError(err)
 	}
@@ -1785,6 +1941,9 @@ func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
 	}
 
 	if len(appConfigKeys) > 0 {
+		if err := api.checkCanChangeApplicationConfig(); err != nil {
+			return err
+		}
 		if err := app.UpdateApplicationConfig(nil, appConfigKeys, schema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -1839,3 +1998,162 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 	}
 	return result, nil
 }
+
+// UnitsHookExecutions isn't on the v7 API.
+func (u *APIv7) UnitsHookExecutions(_, _ struct{}) {}
+
+// UnitsHookExecutions returns the hook history of each of the given
+// units, newest first.
+func (api *APIBase) UnitsHookExecutions(args params.Entities) (params.HookExecutionsResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HookExecutionsResults{}, errors.Trace(err)
+	}
+	results := params.HookExecutionsResults{
+		Results: make([]params.HookExecutionsResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		executions, err := unit.HookExecutions()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Executions = make([]params.HookExecution, len(executions))
+		for j, e := range executions {
+			results.Results[i].Executions[j] = params.HookExecution{
+				Kind:       string(e.Kind),
+				Name:       e.Name,
+				Relation:   e.Relation,
+				RemoteUnit: e.RemoteUnit,
+				Started:    e.Started,
+				Finished:   e.Finished,
+				ExitCode:   e.ExitCode,
+				Error:      e.Error,
+			}
+		}
+	}
+	return results, nil
+}
+
+// ModifyApplicationAccess isn't on the v8 API.
+func (u *APIv8) ModifyApplicationAccess(_, _ struct{}) {}
+
+// ModifyApplicationAccess grants or revokes roles on applications. Only
+// model administrators may do so.
+func (api *APIBase) ModifyApplicationAccess(args params.ModifyApplicationAccessRequest) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	if err := api.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Changes {
+		err := api.modifyOneApplicationAccess(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *APIBase) modifyOneApplicationAccess(arg params.ModifyApplicationAccess) error {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	userTag, err := names.ParseUserTag(arg.UserTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch arg.Action {
+	case params.GrantApplicationAccess:
+		return api.backend.SetApplicationAccess(appTag.Id(), userTag, arg.Role)
+	case params.RevokeApplicationAccess:
+		return api.backend.RemoveApplicationAccess(appTag.Id(), userTag)
+	}
+	return errors.NotValidf("application access action %q", arg.Action)
+}
+
+// AddRoles isn't on the v8 API.
+func (u *APIv8) AddRoles(_, _ struct{}) {}
+
+// AddRoles adds the given roles to the model. Each role's capabilities
+// may name application access levels, standing for all of their
+// capabilities. Only model administrators may add roles.
+func (api *APIBase) AddRoles(args params.ApplicationRoles) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Roles)),
+	}
+	if err := api.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Roles {
+		capabilities, err := permission.ParseCapabilities(arg.Capabilities...)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = api.backend.AddRole(permission.Role{Name: arg.Name, Capabilities: capabilities})
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// RemoveRoles isn't on the v8 API.
+func (u *APIv8) RemoveRoles(_, _ struct{}) {}
+
+// RemoveRoles removes the named roles from the model. Only model
+// administrators may do so.
+func (api *APIBase) RemoveRoles(args params.ApplicationRoleNames) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	if err := api.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.RemoveAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, name := range args.Names {
+		err := api.backend.RemoveRole(name)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// Roles isn't on the v8 API.
+func (u *APIv8) Roles(_, _ struct{}) {}
+
+// Roles returns the roles added to the model.
+func (api *APIBase) Roles() (params.ApplicationRoles, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationRoles{}, errors.Trace(err)
+	}
+	roles, err := api.backend.AllRoles()
+	if err != nil {
+		return params.ApplicationRoles{}, errors.Trace(err)
+	}
+	result := params.ApplicationRoles{
+		Roles: make([]params.ApplicationRole, len(roles)),
+	}
+	for i, role := range roles {
+		result.Roles[i].Name = role.Name
+		for _, capability := range role.Capabilities {
+			result.Roles[i].Capabilities = append(result.Roles[i].Capabilities, string(capability))
+		}
+	}
+	return result, nil
+}
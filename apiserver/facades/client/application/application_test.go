@@ -23,14 +23,17 @@ import (
 	"gopkg.in/macaroon.v2-unstable"
 	"gopkg.in/mgo.v2"
 
+	apiapplication "github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	statetesting "github.com/juju/juju/state/testing"
@@ -47,7 +50,7 @@ type applicationSuite struct {
 	apiservertesting.CharmStoreSuite
 	commontesting.BlockHelper
 
-	applicationAPI *application.APIv7
+	applicationAPI *application.APIv9
 	application    *state.Application
 	authorizer     *apiservertesting.FakeAuthorizer
 }
@@ -84,7 +87,7 @@ func (s *applicationSuite) TearDownTest(c *gc.C) {
 	s.JujuConnSuite.TearDownTest(c)
 }
 
-func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv7 {
+func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv9 {
 	resources := common.NewResources()
 	resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
 	storageAccess, err := application.GetStorageState(s.State)
@@ -103,7 +106,7 @@ func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv7 {
 		application.DeployApplication,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &application.APIv7{api}
+	return &application.APIv9{api}
 }
 
 func (s *applicationSuite) TestGetConfig(c *gc.C) {
@@ -708,6 +711,39 @@ func (s *applicationSuite) TestAddCharm(c *gc.C) {
 	s.assertUploaded(c, storage, sch.StoragePath(), sch.BundleSha256())
 }
 
+func (s *applicationSuite) TestAddCharmForApplicationUpgradeCharmAccess(c *gc.C) {
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:   "upgrader",
+		Access: permission.ReadAccess,
+	})
+	err := s.State.SetApplicationAccess("wordpress", user.UserTag(), string(permission.UpgradeCharmAccess))
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl, _ := s.UploadCharm(c, "precise/wordpress-3", "wordpress")
+	st := s.OpenAPIAs(c, user.UserTag(), "password")
+	defer st.Close()
+	client := st.Client()
+
+	// Without naming the application, model write access is needed.
+	err = client.AddCharm(curl, csparams.StableChannel)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	err = client.ForApplication("mysql").AddCharm(curl, csparams.StableChannel)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	err = client.ForApplication("wordpress").AddCharm(curl, csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The user can now upgrade the application to the charm.
+	err = apiapplication.NewClient(st).SetCharm(apiapplication.SetCharmConfig{
+		ApplicationName: "wordpress",
+		CharmID:         charmstore.CharmID{URL: curl},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *applicationSuite) TestAddCharmWithAuthorization(c *gc.C) {
 	// Upload a new charm to the charm store.
 	curl, _ := s.UploadCharm(c, "cs:~restricted/precise/wordpress-3", "wordpress")
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -23,6 +25,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
@@ -39,7 +42,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv7
+	api          *application.APIv9
 }
 
 var _ = gc.Suite(&ApplicationSuite{})
@@ -61,7 +64,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv7{api}
+	s.api = &application.APIv9{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -237,6 +240,49 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmApplicationAccess(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("upgrade-charm-application-postgresql"))
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.applications["postgresql"].CheckCallNames(c, "SetCharm")
+}
+
+func (s *ApplicationSuite) TestSetCharmApplicationAccessOptions(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("upgrade-charm-application-postgresql"))
+	size := uint64(1024)
+	for i, args := range []params.ApplicationSetCharm{{
+		ConfigSettings: map[string]string{"stringOption": "value"},
+	}, {
+		ConfigSettingsYAML: "postgresql:\n  stringOption: value\n",
+	}, {
+		StorageConstraints: map[string]params.StorageConstraints{"a": {Size: &size}},
+	}, {
+		ForceUnits: true,
+	}, {
+		ForceSeries: true,
+	}} {
+		c.Logf("test %d", i)
+		args.ApplicationName = "postgresql"
+		args.CharmURL = "cs:postgresql"
+		err := s.api.SetCharm(args)
+		c.Check(err, gc.ErrorMatches, "permission denied")
+	}
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetCharmPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("config-application-postgresql"))
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestDestroyRelation(c *gc.C) {
 	err := s.api.DestroyRelation(params.DestroyRelation{Endpoints: []string{"a", "b"}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -442,6 +488,27 @@ func (s *ApplicationSuite) TestDestroyUnit(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestDestroyUnitCAASWithStorage(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{UnitTag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`units of application "postgresql" have storage and must be removed highest numbered first, starting with "postgresql/1"`)
+
+	// Once the higher numbered unit is gone, the unit may be removed.
+	s.backend.applications["postgresql"].units[1].life = state.Dying
+	results, err = s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{UnitTag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyUnitResult{{
+		Info: &params.DestroyUnitInfo{},
+	}})
+}
+
 func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	args := params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
@@ -1058,6 +1125,8 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, err = application.AddHookTimeoutSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1067,6 +1136,17 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	app.CheckCall(c, 1, "UpdateCharmConfig", charm.Settings{"stringOption": "stringVal"})
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidHookTimeout(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"hook-timeout": "forever"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `hook-timeout value "forever" not valid`)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
 	_, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
@@ -1077,14 +1157,60 @@ func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 
 func (s *ApplicationSuite) TestSetApplicationConfigPermissionDenied(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("fred"))
-	_, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
 		}}})
-	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
 	s.application.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigApplicationAccess(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("config-application-postgresql"))
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"stringOption": "stringVal"},
+		}, {
+			ApplicationName: "postgresql-subordinate",
+			Config:          map[string]string{"stringOption": "stringVal"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "permission denied")
+	s.backend.applications["postgresql"].CheckCallNames(c, "UpdateCharmConfig")
+	s.backend.applications["postgresql-subordinate"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigApplicationSettingsRequireAdmin(c *gc.C) {
+	for _, user := range []string{"config-application-postgresql", "write"} {
+		c.Logf("user %q", user)
+		s.setAPIUser(c, names.NewUserTag(user))
+		result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+			Args: []params.ApplicationConfigSet{{
+				ApplicationName: "postgresql",
+				Config: map[string]string{
+					"trust":        "true",
+					"stringOption": "stringVal",
+				},
+			}}})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
+		s.backend.applications["postgresql"].CheckNoCalls(c)
+
+		result, err = s.api.UnsetApplicationsConfig(params.ApplicationConfigUnsetArgs{
+			Args: []params.ApplicationUnset{{
+				ApplicationName: "postgresql",
+				Options:         []string{"trust"},
+			}}})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
+		s.backend.applications["postgresql"].CheckNoCalls(c)
+	}
+}
+
 func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	result, err := s.api.UnsetApplicationsConfig(params.ApplicationConfigUnsetArgs{
@@ -1102,6 +1228,8 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, err = application.AddHookTimeoutSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1120,12 +1248,13 @@ func (s *ApplicationSuite) TestBlockUnsetApplicationConfig(c *gc.C) {
 
 func (s *ApplicationSuite) TestUnsetApplicationConfigPermissionDenied(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("fred"))
-	_, err := s.api.UnsetApplicationsConfig(params.ApplicationConfigUnsetArgs{
+	result, err := s.api.UnsetApplicationsConfig(params.ApplicationConfigUnsetArgs{
 		Args: []params.ApplicationUnset{{
 			ApplicationName: "postgresql",
 			Options:         []string{"option"},
 		}}})
-	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
 	s.application.CheckNoCalls(c)
 }
 
@@ -1184,6 +1313,169 @@ func (s *ApplicationSuite) TestResolveUnitErrorsPermissionDenied(c *gc.C) {
 	s.application.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestUnitsHookExecutions(c *gc.C) {
+	started := time.Date(2018, 6, 15, 10, 30, 0, 0, time.UTC)
+	s.backend.applications["postgresql"].units[0].executions = []state.HookExecution{{
+		Kind:       state.HookExecutionHook,
+		Name:       "db-relation-changed",
+		Relation:   "db:2",
+		RemoteUnit: "wordpress/0",
+		Started:    started,
+		Finished:   started.Add(time.Second),
+		ExitCode:   1,
+		Error:      "exit status 1",
+	}}
+
+	results, err := s.api.UnitsHookExecutions(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-0"}, {Tag: "application-postgresql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.HookExecutionsResults{
+		Results: []params.HookExecutionsResult{{
+			Executions: []params.HookExecution{{
+				Kind:       "hook",
+				Name:       "db-relation-changed",
+				Relation:   "db:2",
+				RemoteUnit: "wordpress/0",
+				Started:    started,
+				Finished:   started.Add(time.Second),
+				ExitCode:   1,
+				Error:      "exit status 1",
+			}},
+		}, {
+			Error: &params.Error{Message: `"application-postgresql" is not a valid unit tag`},
+		}},
+	})
+}
+
+func (s *ApplicationSuite) TestUnitsHookExecutionsPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.UnitsHookExecutions(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestModifyApplicationAccess(c *gc.C) {
+	results, err := s.api.ModifyApplicationAccess(params.ModifyApplicationAccessRequest{
+		Changes: []params.ModifyApplicationAccess{{
+			UserTag:        "user-bob",
+			Action:         params.GrantApplicationAccess,
+			Role:           "operator",
+			ApplicationTag: "application-postgresql",
+		}, {
+			UserTag:        "user-bob",
+			Action:         params.RevokeApplicationAccess,
+			ApplicationTag: "application-postgresql",
+		}, {
+			UserTag:        "user-bob",
+			Action:         "destroy",
+			ApplicationTag: "application-postgresql",
+		}, {
+			UserTag:        "user-bob",
+			Action:         params.GrantApplicationAccess,
+			Role:           "operator",
+			ApplicationTag: "unit-postgresql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Message: `application access action "destroy" not valid`}},
+			{Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`}},
+		},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"SetApplicationAccess", []interface{}{"postgresql", names.NewUserTag("bob"), "operator"}},
+		{"RemoveApplicationAccess", []interface{}{"postgresql", names.NewUserTag("bob")}},
+	})
+}
+
+func (s *ApplicationSuite) TestModifyApplicationAccessPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("write"))
+	_, err := s.api.ModifyApplicationAccess(params.ModifyApplicationAccessRequest{
+		Changes: []params.ModifyApplicationAccess{{
+			UserTag:        "user-bob",
+			Action:         params.GrantApplicationAccess,
+			Role:           "operator",
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAddRoles(c *gc.C) {
+	results, err := s.api.AddRoles(params.ApplicationRoles{
+		Roles: []params.ApplicationRole{{
+			Name:         "operator",
+			Capabilities: []string{"Application.SetApplicationsConfig", "Action.Enqueue"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "AddRole", permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"},
+	})
+}
+
+func (s *ApplicationSuite) TestAddRolesApplicationAccessLevels(c *gc.C) {
+	results, err := s.api.AddRoles(params.ApplicationRoles{
+		Roles: []params.ApplicationRole{{
+			Name:         "operator",
+			Capabilities: []string{"run-action", "Application.SetCharm"},
+		}, {
+			Name:         "deployer",
+			Capabilities: []string{"Application.Deploy"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"Application.Deploy" application capability not valid`)
+	s.backend.CheckCalls(c, []testing.StubCall{{"AddRole", []interface{}{permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Action.Enqueue", "Action.EnqueueApplicationActions", "Application.SetCharm"},
+	}}}})
+}
+
+func (s *ApplicationSuite) TestRemoveRoles(c *gc.C) {
+	results, err := s.api.RemoveRoles(params.ApplicationRoleNames{
+		Names: []string{"operator"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "RemoveRole", "operator")
+	s.blockChecker.CheckCallNames(c, "RemoveAllowed")
+}
+
+func (s *ApplicationSuite) TestRolesPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("read"))
+	_, err := s.api.AddRoles(params.ApplicationRoles{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = s.api.RemoveRoles(params.ApplicationRoleNames{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestRoles(c *gc.C) {
+	s.backend.roles = []permission.Role{{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"},
+	}}
+	result, err := s.api.Roles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ApplicationRoles{
+		Roles: []params.ApplicationRole{{
+			Name:         "operator",
+			Capabilities: []string{"Application.SetApplicationsConfig", "Action.Enqueue"},
+		}},
+	})
+}
+
 func (s *ApplicationSuite) TestCAASExposeWithoutHostname(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	err := s.api.Expose(params.ApplicationExpose{
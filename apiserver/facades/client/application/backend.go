@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 )
@@ -43,6 +44,11 @@ type Backend interface {
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
+	SetApplicationAccess(appName string, user names.UserTag, role string) error
+	RemoveApplicationAccess(appName string, user names.UserTag) error
+	AddRole(permission.Role) error
+	RemoveRole(string) error
+	AllRoles() ([]permission.Role, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -122,6 +128,7 @@ type Unit interface {
 	IsPrincipal() bool
 	Life() state.Life
 	Resolve(retryHooks bool) error
+	HookExecutions() ([]state.HookExecution, error)
 
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
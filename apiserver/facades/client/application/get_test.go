@@ -29,7 +29,7 @@ import (
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
-	applicationAPI *application.APIv7
+	applicationAPI *application.APIv9
 	authorizer     apiservertesting.FakeAuthorizer
 }
 
@@ -57,7 +57,7 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		application.DeployApplication,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv7{api}
+	s.applicationAPI = &application.APIv9{api}
 }
 
 func (s *getSuite) TestClientApplicationGetSmoketestV4(c *gc.C) {
@@ -119,6 +119,11 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmoketest(c *gc.C) {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"hook-timeout": map[string]interface{}{
+				"description": "Maximum time a hook may run before it is killed, e.g. 30m",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
 			"trust": map[string]interface{}{
 				"default":     false,
 				"description": "Does this application have access to trusted credentials",
@@ -141,6 +146,8 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmoketest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 
+	schemaFields, err = application.AddHookTimeoutSchema(schemaFields)
+	c.Assert(err, jc.ErrorIsNil)
 	schemaFields, defaults, err = application.AddTrustSchemaAndDefaults(schemaFields, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -194,9 +201,9 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmoketest(c *gc.C) {
 		application.DeployApplication,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV7 := &application.APIv7{api}
+	apiV9 := &application.APIv9{api}
 
-	results, err := apiV7.Get(params.ApplicationGet{"wordpress"})
+	results, err := apiV9.Get(params.ApplicationGet{"wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, params.ApplicationGetResults{
 		Application: "wordpress",
@@ -267,6 +274,11 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"hook-timeout": map[string]interface{}{
+				"description": "Maximum time a hook may run before it is killed, e.g. 30m",
+				"source":      "unset",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -325,6 +337,11 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"hook-timeout": map[string]interface{}{
+				"description": "Maximum time a hook may run before it is killed, e.g. 30m",
+				"source":      "unset",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -342,6 +359,11 @@ var getTests = []struct {
 		CharmConfig: map[string]interface{}{},
 		Series:      "quantal",
 		ApplicationConfig: map[string]interface{}{
+			"hook-timeout": map[string]interface{}{
+				"description": "Maximum time a hook may run before it is killed, e.g. 30m",
+				"source":      "unset",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
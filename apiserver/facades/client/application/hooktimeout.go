@@ -0,0 +1,68 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+)
+
+// HookTimeoutConfigOptionName is the option name used to set how long an
+// application's hooks may run for in application configuration.
+const HookTimeoutConfigOptionName = "hook-timeout"
+
+var hookTimeoutFields = environschema.Fields{
+	HookTimeoutConfigOptionName: {
+		Description: "Maximum time a hook may run before it is killed, e.g. 30m",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// AddHookTimeoutSchema adds the hook timeout schema field to an existing set
+// of schema fields.
+func AddHookTimeoutSchema(extra environschema.Fields) (environschema.Fields, error) {
+	fields := make(environschema.Fields)
+	for name, field := range hookTimeoutFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := hookTimeoutFields[name]; ok {
+			return nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	return fields, nil
+}
+
+// ParseHookTimeout parses the value of the hook-timeout option. An empty
+// value, like a zero duration, means hooks may run for as long as they
+// like.
+func ParseHookTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.NotValidf("%s value %q", HookTimeoutConfigOptionName, value)
+	}
+	if timeout < 0 {
+		return 0, errors.NotValidf("negative %s %q", HookTimeoutConfigOptionName, value)
+	}
+	return timeout, nil
+}
+
+// validateHookTimeout returns an error if the hook-timeout option in the
+// given application config attributes is set to something other than a
+// duration.
+func validateHookTimeout(attrs map[string]interface{}) error {
+	value, ok := attrs[HookTimeoutConfigOptionName].(string)
+	if !ok {
+		return nil
+	}
+	_, err := ParseHookTimeout(value)
+	return errors.Trace(err)
+}
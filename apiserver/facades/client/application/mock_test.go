@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
@@ -239,6 +240,7 @@ type mockBackend struct {
 	storageInstances           map[string]*mockStorage
 	storageInstanceFilesystems map[string]*mockFilesystem
 	controllers                map[string]crossmodel.ControllerInfo
+	roles                      []permission.Role
 }
 
 type mockFilesystemAccess struct {
@@ -473,6 +475,31 @@ func (m *mockBackend) SaveController(controllerInfo crossmodel.ControllerInfo, m
 	return &mockExternalController{controllerInfo.ControllerTag.Id(), controllerInfo}, nil
 }
 
+func (m *mockBackend) SetApplicationAccess(appName string, user names.UserTag, role string) error {
+	m.MethodCall(m, "SetApplicationAccess", appName, user, role)
+	return m.NextErr()
+}
+
+func (m *mockBackend) RemoveApplicationAccess(appName string, user names.UserTag) error {
+	m.MethodCall(m, "RemoveApplicationAccess", appName, user)
+	return m.NextErr()
+}
+
+func (m *mockBackend) AddRole(role permission.Role) error {
+	m.MethodCall(m, "AddRole", role)
+	return m.NextErr()
+}
+
+func (m *mockBackend) RemoveRole(name string) error {
+	m.MethodCall(m, "RemoveRole", name)
+	return m.NextErr()
+}
+
+func (m *mockBackend) AllRoles() ([]permission.Role, error) {
+	m.MethodCall(m, "AllRoles")
+	return m.roles, m.NextErr()
+}
+
 type mockBlockChecker struct {
 	jtesting.Stub
 }
@@ -534,13 +561,20 @@ func (r *mockRelation) Destroy() error {
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag names.UnitTag
+	tag        names.UnitTag
+	life       state.Life
+	executions []state.HookExecution
 }
 
 func (u *mockUnit) UnitTag() names.UnitTag {
 	return u.tag
 }
 
+func (u *mockUnit) Life() state.Life {
+	u.MethodCall(u, "Life")
+	return u.life
+}
+
 func (u *mockUnit) IsPrincipal() bool {
 	u.MethodCall(u, "IsPrincipal")
 	u.PopNoErr()
@@ -567,6 +601,11 @@ func (u *mockUnit) Resolve(retryHooks bool) error {
 	return u.NextErr()
 }
 
+func (u *mockUnit) HookExecutions() ([]state.HookExecution, error) {
+	u.MethodCall(u, "HookExecutions")
+	return u.executions, u.NextErr()
+}
+
 type mockStorageAttachment struct {
 	state.StorageAttachment
 	jtesting.Stub
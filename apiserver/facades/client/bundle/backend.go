@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"github.com/juju/errors"
+	names "gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+)
+
+// Backend contains the state.State methods used in this package,
+// allowing stubs to be created for testing.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ModelTag() names.ModelTag
+	DefaultSeries() (string, error)
+	AllMachines() ([]*state.Machine, error)
+	AllApplications() ([]*state.Application, error)
+	AllRelations() ([]*state.Relation, error)
+	AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error)
+	Annotations(entity state.GlobalEntity) (map[string]string, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend creates a backend for the facade to use.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (st stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(st.State.ModelUUID())
+}
+
+func (st stateShim) DefaultSeries() (string, error) {
+	model, err := st.State.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	series, _ := cfg.DefaultSeries()
+	return series, nil
+}
+
+func (st stateShim) AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error) {
+	return state.NewApplicationOffers(st.State).AllApplicationOffers()
+}
+
+func (st stateShim) Annotations(entity state.GlobalEntity) (map[string]string, error) {
+	model, err := st.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model.Annotations(entity)
+}
@@ -5,50 +5,95 @@
 package bundle
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/juju/bundlechanges"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
 
-// NewFacade provides the required signature for facade registration.
-func NewFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
-	return NewBundle(auth)
+// NewFacadeV1 provides the signature required for facade registration
+// version 1.
+func NewFacadeV1(ctx facade.Context) (*APIv1, error) {
+	api, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
+// NewFacadeV2 provides the signature required for facade registration
+// version 2.
+func NewFacadeV2(ctx facade.Context) (*APIv2, error) {
+	return NewBundleAPI(NewStateBackend(ctx.State()), ctx.Auth())
 }
 
-// NewBundle creates and returns a new Bundle API facade.
-func NewBundle(auth facade.Authorizer) (Bundle, error) {
+// NewBundleAPI creates and returns a new Bundle API facade.
+func NewBundleAPI(backend Backend, auth facade.Authorizer) (*APIv2, error) {
 	if !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
-	return &bundleAPI{}, nil
+	return &APIv2{&BundleAPI{
+		backend:    backend,
+		authorizer: auth,
+	}}, nil
 }
 
-// Bundle defines the API endpoint used to retrieve bundle changes.
-type Bundle interface {
-	// GetChanges returns the list of changes required to deploy the given
-	// bundle data.
-	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
+// BundleAPI implements the Bundle interface and is the concrete
+// implementation of the API end point.
+type BundleAPI struct {
+	backend    Backend
+	authorizer facade.Authorizer
 }
 
-// bundleAPI implements the Bundle interface and is the concrete implementation
-// of the API end point.
-type bundleAPI struct{}
+// APIv2 provides the Bundle API facade for version 2.
+type APIv2 struct {
+	*BundleAPI
+}
+
+// APIv1 provides the Bundle API facade for version 1.
+type APIv1 struct {
+	*APIv2
+}
+
+// ExportBundle isn't on the V1 API. The API reflection code in
+// rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+func (*APIv1) ExportBundle(_, _ struct{}) {}
+
+func (b *BundleAPI) checkCanRead() error {
+	isAdmin, err := b.authorizer.HasPermission(permission.SuperuserAccess, b.backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	canRead, err := b.authorizer.HasPermission(permission.ReadAccess, b.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin && !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
 
 // GetChanges returns the list of changes required to deploy the given bundle
 // data. The changes are sorted by requirements, so that they can be applied in
 // order.
-func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
+func (b *BundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
 	var results params.BundleChangesResults
 	data, err := charm.ReadBundleData(strings.NewReader(args.BundleDataYAML))
 	if err != nil {
@@ -96,3 +141,262 @@ func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	}
 	return results, nil
 }
+
+// bundleOutput is the YAML representation of an exported bundle. It
+// mirrors charm.BundleData.
+type bundleOutput struct {
+	Series       string                        `yaml:"series,omitempty"`
+	Applications map[string]*applicationOutput `yaml:"applications"`
+	Machines     map[string]*charm.MachineSpec `yaml:"machines,omitempty"`
+	Relations    [][]string                    `yaml:"relations,omitempty"`
+}
+
+// applicationOutput is the YAML representation of a single application
+// within an exported bundle.
+type applicationOutput struct {
+	Charm            string                 `yaml:"charm"`
+	Series           string                 `yaml:"series,omitempty"`
+	NumUnits         int                    `yaml:"num_units,omitempty"`
+	To               []string               `yaml:"to,omitempty"`
+	Expose           bool                   `yaml:"expose,omitempty"`
+	Options          map[string]interface{} `yaml:"options,omitempty"`
+	Annotations      map[string]string      `yaml:"annotations,omitempty"`
+	Constraints      string                 `yaml:"constraints,omitempty"`
+	EndpointBindings map[string]string      `yaml:"bindings,omitempty"`
+}
+
+// ExportBundle exports the current model configuration as a bundle,
+// returning the YAML representation in the result. Bundles cannot
+// express application offers, so any offers in the model are left out
+// and listed in a comment at the top of the result instead.
+func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
+	fail := func(err error) (params.StringResult, error) {
+		return params.StringResult{}, common.ServerError(err)
+	}
+
+	if err := b.checkCanRead(); err != nil {
+		return fail(err)
+	}
+
+	data, err := b.bundleData()
+	if err != nil {
+		return fail(err)
+	}
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return fail(errors.Annotate(err, "cannot serialise bundle"))
+	}
+	offers, err := b.offerNames(data)
+	if err != nil {
+		return fail(err)
+	}
+	var header string
+	if len(offers) > 0 {
+		header = "# Offers cannot be expressed in a bundle and were not exported:\n"
+		for _, offer := range offers {
+			header += "#   " + offer + "\n"
+		}
+	}
+	return params.StringResult{Result: header + string(out)}, nil
+}
+
+// offerNames returns the names of the offers made for the applications
+// in the exported bundle, in the form "<offer> (application <application>)".
+func (b *BundleAPI) offerNames(data *bundleOutput) ([]string, error) {
+	offers, err := b.backend.AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, offer := range offers {
+		if data.Applications[offer.ApplicationName] == nil {
+			continue
+		}
+		names = append(names, fmt.Sprintf("%s (application %s)", offer.OfferName, offer.ApplicationName))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (b *BundleAPI) bundleData() (*bundleOutput, error) {
+	defaultSeries, err := b.backend.DefaultSeries()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data := &bundleOutput{
+		Series:       defaultSeries,
+		Applications: make(map[string]*applicationOutput),
+		Machines:     make(map[string]*charm.MachineSpec),
+	}
+
+	machines, err := b.backend.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, m := range machines {
+		// Containers are expressed through unit placement
+		// directives, so only top level machines are declared.
+		if _, isContainer := m.ParentId(); isContainer {
+			continue
+		}
+		if m.IsManager() {
+			continue
+		}
+		spec := &charm.MachineSpec{}
+		if m.Series() != defaultSeries {
+			spec.Series = m.Series()
+		}
+		cons, err := m.Constraints()
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if !constraints.IsEmpty(&cons) {
+			spec.Constraints = cons.String()
+		}
+		data.Machines[m.Id()] = spec
+	}
+
+	applications, err := b.backend.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, app := range applications {
+		spec, err := b.applicationOutput(app, defaultSeries, data.Machines)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", app.Name())
+		}
+		data.Applications[app.Name()] = spec
+	}
+
+	relations, err := b.backend.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		endpoints := rel.Endpoints()
+		// Peer relations are established automatically, and relations
+		// to remote applications cannot be expressed in a bundle.
+		if len(endpoints) != 2 {
+			continue
+		}
+		if data.Applications[endpoints[0].ApplicationName] == nil ||
+			data.Applications[endpoints[1].ApplicationName] == nil {
+			continue
+		}
+		data.Relations = append(data.Relations, []string{
+			endpoints[0].String(),
+			endpoints[1].String(),
+		})
+	}
+	return data, nil
+}
+
+func (b *BundleAPI) applicationOutput(
+	app *state.Application,
+	defaultSeries string,
+	machines map[string]*charm.MachineSpec,
+) (*applicationOutput, error) {
+	curl, _ := app.CharmURL()
+	if curl == nil {
+		return nil, errors.NotValidf("application without a charm")
+	}
+	spec := &applicationOutput{
+		Charm:  curl.String(),
+		Expose: app.IsExposed(),
+	}
+	if app.Series() != defaultSeries {
+		spec.Series = app.Series()
+	}
+
+	// Only options that differ from the charm defaults are recorded,
+	// keeping the exported bundle close to what a user would write.
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defaults := ch.Config().DefaultSettings()
+	options, err := app.CharmConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, value := range options {
+		if def, ok := defaults[name]; ok && reflect.DeepEqual(def, value) {
+			continue
+		}
+		if spec.Options == nil {
+			spec.Options = make(map[string]interface{})
+		}
+		spec.Options[name] = value
+	}
+
+	annotations, err := b.backend.Annotations(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(annotations) > 0 {
+		spec.Annotations = annotations
+	}
+
+	bindings, err := app.EndpointBindings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if spec.EndpointBindings == nil {
+			spec.EndpointBindings = make(map[string]string)
+		}
+		spec.EndpointBindings[endpoint] = space
+	}
+
+	// Subordinate applications have neither units of their own nor
+	// constraints; they follow their principals around.
+	if !app.IsPrincipal() {
+		return spec, nil
+	}
+
+	cons, err := app.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !constraints.IsEmpty(&cons) {
+		spec.Constraints = cons.String()
+	}
+
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec.NumUnits = len(units)
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if to, ok := placement(machineId, machines); ok {
+			spec.To = append(spec.To, to)
+		}
+	}
+	sort.Strings(spec.To)
+	return spec, nil
+}
+
+// placement returns the bundle placement directive for a unit
+// assigned to the machine with the given id. Units on containers
+// are placed into a new container of the same type on the host.
+// If the host machine is not part of the bundle, false is returned
+// and the unit is left for the deployer to place.
+func placement(machineId string, machines map[string]*charm.MachineSpec) (string, bool) {
+	parts := strings.Split(machineId, "/")
+	if _, ok := machines[parts[0]]; !ok {
+		return "", false
+	}
+	if len(parts) < 3 {
+		return machineId, true
+	}
+	return fmt.Sprintf("%s:%s", parts[len(parts)-2], parts[0]), true
+}
@@ -4,19 +4,27 @@
 package bundle_test
 
 import (
+	"strings"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/bundle"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type bundleSuite struct {
 	coretesting.BaseSuite
-	facade bundle.Bundle
+	facade *bundle.APIv2
 }
 
 var _ = gc.Suite(&bundleSuite{})
@@ -26,7 +34,7 @@ func (s *bundleSuite) SetUpTest(c *gc.C) {
 	auth := apiservertesting.FakeAuthorizer{
 		Tag: names.NewUserTag("who"),
 	}
-	facade, err := bundle.NewBundle(auth)
+	facade, err := bundle.NewBundleAPI(nil, auth)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 }
@@ -222,3 +230,140 @@ func (s *bundleSuite) TestGetChangesBundleEndpointBindingsSuccess(c *gc.C) {
 		}
 	}
 }
+
+type exportBundleSuite struct {
+	statetesting.StateSuite
+	facade *bundle.APIv2
+}
+
+var _ = gc.Suite(&exportBundleSuite{})
+
+func (s *exportBundleSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	auth := apiservertesting.FakeAuthorizer{
+		Tag:      s.Owner,
+		AdminTag: s.Owner,
+	}
+	facade, err := bundle.NewBundleAPI(bundle.NewStateBackend(s.State), auth)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *exportBundleSuite) TestExportBundlePermissionDenied(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("who"),
+	}
+	facade, err := bundle.NewBundleAPI(bundle.NewStateBackend(s.State), auth)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *exportBundleSuite) TestExportBundleEmptyModel(c *gc.C) {
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications, gc.HasLen, 0)
+	c.Assert(data.Machines, gc.HasLen, 0)
+}
+
+func (s *exportBundleSuite) TestExportBundle(c *gc.C) {
+	m0 := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	m1 := s.Factory.MakeMachine(c, nil)
+	lxd := s.Factory.MakeMachineNested(c, m1.Id(), nil)
+
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:        "mysql",
+		Charm:       s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+		Constraints: constraints.MustParse("cores=2"),
+	})
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+		CharmConfig: map[string]interface{}{
+			"blog-title": "Exported",
+		},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: m0})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: lxd})
+	err := wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	e1, err := mysql.Endpoint("server")
+	c.Assert(err, jc.ErrorIsNil)
+	e2, err := wordpress.Endpoint("db")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeRelation(c, &factory.RelationParams{
+		Endpoints: []state.Endpoint{e1, e2},
+	})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Machines, jc.DeepEquals, map[string]*charm.MachineSpec{
+		m0.Id(): {Constraints: "mem=4096M"},
+		m1.Id(): {},
+	})
+	c.Assert(data.Applications, gc.HasLen, 2)
+
+	mysqlSpec := data.Applications["mysql"]
+	curl, _ := mysql.CharmURL()
+	c.Assert(mysqlSpec.Charm, gc.Equals, curl.String())
+	c.Assert(mysqlSpec.NumUnits, gc.Equals, 1)
+	c.Assert(mysqlSpec.To, jc.DeepEquals, []string{m0.Id()})
+	c.Assert(mysqlSpec.Constraints, gc.Equals, "cores=2")
+	c.Assert(mysqlSpec.Expose, jc.IsFalse)
+
+	wordpressSpec := data.Applications["wordpress"]
+	c.Assert(wordpressSpec.NumUnits, gc.Equals, 1)
+	c.Assert(wordpressSpec.To, jc.DeepEquals, []string{"lxd:" + m1.Id()})
+	c.Assert(wordpressSpec.Expose, jc.IsTrue)
+	c.Assert(wordpressSpec.Options, jc.DeepEquals, map[string]interface{}{
+		"blog-title": "Exported",
+	})
+
+	c.Assert(data.Relations, jc.DeepEquals, [][]string{
+		{"mysql:server", "wordpress:db"},
+	})
+
+	// The exported bundle must be deployable as is.
+	err = data.Verify(nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *exportBundleSuite) TestExportBundleOffers(c *gc.C) {
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	_, err := state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: mysql.Name(),
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           s.Owner.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, jc.HasPrefix, `
+# Offers cannot be expressed in a bundle and were not exported:
+#   hosted-mysql (application mysql)
+`[1:])
+	c.Assert(result.Result, gc.Not(jc.Contains), "offers:")
+
+	// Offers must not leave the bundle undeployable.
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications, gc.HasLen, 1)
+	err = data.Verify(nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -102,6 +102,27 @@ func (c *Client) checkCanWrite() error {
 	return nil
 }
 
+// checkCanAddCharm checks that the user may add a charm, either because
+// they have write access to the model, or because the charm is being
+// added to upgrade the named application and they have been granted the
+// capability to call the facade method on it.
+func (c *Client) checkCanAddCharm(appName string, capability permission.Capability) error {
+	if err := c.checkCanWrite(); err != common.ErrPerm || appName == "" {
+		return err
+	}
+	if !names.IsValidApplication(appName) {
+		return common.ErrPerm
+	}
+	allowed, err := c.api.auth.HasCapability(capability, names.NewApplicationTag(appName))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (c *Client) checkIsAdmin() error {
 	isAdmin, err := c.api.auth.HasPermission(permission.SuperuserAccess, c.api.stateAccessor.ControllerTag())
 	if err != nil {
@@ -662,7 +683,7 @@ func (c *Client) FindTools(args params.FindToolsParams) (params.FindToolsResult,
 }
 
 func (c *Client) AddCharm(args params.AddCharm) error {
-	if err := c.checkCanWrite(); err != nil {
+	if err := c.checkCanAddCharm(args.Application, "Client.AddCharm"); err != nil {
 		return err
 	}
 
@@ -678,8 +699,13 @@ func (c *Client) AddCharm(args params.AddCharm) error {
 //
 // The authorization macaroon, args.CharmStoreMacaroon, may be
 // omitted, in which case this call is equivalent to AddCharm.
+//
+// Local charms are uploaded over HTTP instead, which any user with
+// access to the model may do; a user granted upgrade-charm on an
+// application may then upgrade it to the uploaded charm with
+// Application.SetCharm.
 func (c *Client) AddCharmWithAuthorization(args params.AddCharmWithAuthorization) error {
-	if err := c.checkCanWrite(); err != nil {
+	if err := c.checkCanAddCharm(args.Application, "Client.AddCharmWithAuthorization"); err != nil {
 		return err
 	}
 
@@ -0,0 +1,394 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"net/url"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/tools"
+)
+
+// CloneModels creates a copy of each of the specified models, with a
+// new UUID and name, on the same or another controller.
+func (c *ControllerAPI) CloneModels(args params.CloneModelArgs) (params.CloneModelResults, error) {
+	out := params.CloneModelResults{
+		Results: make([]params.CloneModelResult, len(args.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range args.Specs {
+		tag, err := c.cloneOneModel(spec)
+		if err != nil {
+			out.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		out.Results[i].ModelTag = tag.String()
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) cloneOneModel(spec params.CloneModelSpec) (names.ModelTag, error) {
+	var empty names.ModelTag
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return empty, errors.Annotate(err, "model tag")
+	}
+	if !names.IsValidModelName(spec.Name) {
+		return empty, errors.NotValidf("model name %q", spec.Name)
+	}
+	if modelTag == c.state.ControllerModelTag() {
+		return empty, errors.NotSupportedf("cloning the controller model")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return empty, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return empty, errors.NotFoundf("model")
+	}
+
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	return cloneModel(hostedState.State, &targetInfo, spec.Name, spec.TopologyOnly)
+}
+
+// cloneModel copies the model's topology into a new model with the
+// given name on the target controller, which may be this one. The copy
+// is made with the same machinery as a migration: the model is exported
+// without its machines and units, imported into the target controller,
+// and its charms and resources uploaded there before it is activated.
+//
+// Unless only the topology is wanted, the machines and units are then
+// added to the new model with the API, so that they're provisioned
+// afresh rather than sharing the source model's cloud instances.
+var cloneModel = func(st *state.State, targetInfo *coremigration.TargetInfo, name string, topologyOnly bool) (names.ModelTag, error) {
+	var empty names.ModelTag
+	model, err := st.ExportPartial(state.ExportConfig{
+		SkipMachinesAndUnits: true,
+		SkipStatusHistory:    true,
+	})
+	if err != nil {
+		return empty, errors.Annotate(err, "exporting model")
+	}
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	model.UpdateConfig(map[string]interface{}{
+		config.NameKey: name,
+		config.UUIDKey: uuid.String(),
+	})
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	resources, err := cloneResources(st)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return empty, errors.Annotate(err, "connecting to target controller")
+	}
+	defer conn.Close()
+	client := migrationtarget.NewClient(conn)
+	if err := client.Import(bytes); err != nil {
+		return empty, errors.Annotate(err, "importing model into target controller")
+	}
+	source := &cloneSource{st}
+	uploader := &cloneUploader{client, uuid.String()}
+	err = migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:             cloneCharms(model),
+		CharmDownloader:    source,
+		CharmUploader:      uploader,
+		ToolsDownloader:    source,
+		ToolsUploader:      uploader,
+		Resources:          resources,
+		ResourceDownloader: source,
+		ResourceUploader:   uploader,
+	})
+	if err == nil {
+		err = client.Activate(uuid.String())
+	}
+	if err != nil {
+		if abortErr := client.Abort(uuid.String()); abortErr != nil {
+			logger.Errorf("removing partially cloned model: %v", abortErr)
+		}
+		return empty, errors.Annotate(err, "transferring model to target controller")
+	}
+
+	newModelTag := names.NewModelTag(uuid.String())
+	if topologyOnly {
+		return newModelTag, nil
+	}
+	modelInfo := targetToAPIInfo(targetInfo)
+	modelInfo.ModelTag = newModelTag
+	modelConn, err := api.Open(modelInfo, api.DefaultDialOpts())
+	if err != nil {
+		return empty, errors.Annotate(err, "connecting to new model")
+	}
+	defer modelConn.Close()
+	if err := cloneLayout(st, modelConn); err != nil {
+		return empty, errors.Annotatef(err, "adding machines and units to model %q", name)
+	}
+	return newModelTag, nil
+}
+
+// cloneCharms returns the URLs of the charms used by the exported
+// model.
+func cloneCharms(model description.Model) []string {
+	seen := make(map[string]bool)
+	var curls []string
+	for _, app := range model.Applications() {
+		curl := app.CharmURL()
+		if !seen[curl] {
+			seen[curl] = true
+			curls = append(curls, curl)
+		}
+	}
+	return curls
+}
+
+// cloneResources returns the application resources to upload to the
+// clone of the model.
+func cloneResources(st *state.State) ([]coremigration.SerializedModelResource, error) {
+	resources, err := st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var out []coremigration.SerializedModelResource
+	for _, app := range applications {
+		appResources, err := resources.ListResources(app.Name())
+		if err != nil {
+			return nil, errors.Annotatef(err, "listing resources for %s", app.Name())
+		}
+		for _, res := range appResources.Resources {
+			out = append(out, coremigration.SerializedModelResource{
+				ApplicationRevision: res,
+			})
+		}
+	}
+	return out, nil
+}
+
+// cloneLayout adds machines and units to the new model, through the
+// given connection to it, that match those of the source model.
+func cloneLayout(st *state.State, conn api.Connection) error {
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machineIds := make(map[string]string)
+	if model.Type() == state.ModelTypeIAAS {
+		if machineIds, err = cloneMachines(st, machinemanager.NewClient(conn)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	applications, err := st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appClient := application.NewClient(conn)
+	for _, app := range applications {
+		// Subordinate units follow their principals.
+		if !app.IsPrincipal() {
+			continue
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(units) == 0 {
+			continue
+		}
+		if model.Type() == state.ModelTypeCAAS {
+			_, err := appClient.AddUnits(application.AddUnitsParams{
+				ApplicationName: app.Name(),
+				NumUnits:        len(units),
+			})
+			if err != nil {
+				return errors.Annotatef(err, "adding units to %s", app.Name())
+			}
+			continue
+		}
+		// Add the units in order, so that unit numbers in the new
+		// model match up where possible.
+		unitNames := make([]string, len(units))
+		unitsByName := make(map[string]*state.Unit)
+		for i, unit := range units {
+			unitNames[i] = unit.Name()
+			unitsByName[unit.Name()] = unit
+		}
+		naturalsort.Sort(unitNames)
+		for _, unitName := range unitNames {
+			unit := unitsByName[unitName]
+			args := application.AddUnitsParams{
+				ApplicationName: app.Name(),
+				NumUnits:        1,
+			}
+			if machineId, err := unit.AssignedMachineId(); err == nil {
+				args.Placement = []*instance.Placement{{
+					Scope:     instance.MachineScope,
+					Directive: machineIds[machineId],
+				}}
+			} else if !errors.IsNotAssigned(err) {
+				return errors.Trace(err)
+			}
+			if _, err := appClient.AddUnits(args); err != nil {
+				return errors.Annotatef(err, "adding unit for %s", unit.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// cloneMachines adds a machine to the new model for each machine in the
+// source model, with the same series and constraints, and containers
+// placed on the same hosts. It returns the new machine id for each of
+// the source model's machines.
+func cloneMachines(st *state.State, client *machinemanager.Client) (map[string]string, error) {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineIds := make(map[string]string)
+	// AllMachines returns hosts before their containers, so each
+	// container's host has always been added by the time it's needed.
+	for _, m := range machines {
+		cons, err := m.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		args := params.AddMachineParams{
+			Series:      m.Series(),
+			Constraints: cons,
+			Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		}
+		if parentId, ok := m.ParentId(); ok {
+			args.ParentId = machineIds[parentId]
+			args.ContainerType = m.ContainerType()
+		}
+		results, err := client.AddMachines([]params.AddMachineParams{args})
+		if err != nil {
+			return nil, errors.Annotatef(err, "adding machine for %s", m.Id())
+		}
+		if len(results) != 1 {
+			return nil, errors.Errorf("expected 1 result, got %d", len(results))
+		}
+		if results[0].Error != nil {
+			return nil, errors.Annotatef(results[0].Error, "adding machine for %s", m.Id())
+		}
+		machineIds[m.Id()] = results[0].Machine
+	}
+	return machineIds, nil
+}
+
+// cloneSource reads the binaries of the model being cloned from state.
+// A clone has no machines, so no agent binaries are ever needed.
+type cloneSource struct {
+	st *state.State
+}
+
+// OpenCharm is part of migration.CharmDownloader.
+func (s *cloneSource) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	ch, err := s.st.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor := storage.NewStorage(s.st.ModelUUID(), s.st.MongoSession())
+	reader, _, err := stor.Get(ch.StoragePath())
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading charm %s", curl)
+	}
+	return reader, nil
+}
+
+// OpenURI is part of migration.ToolsDownloader.
+func (s *cloneSource) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	return nil, errors.NotSupportedf("cloning agent binaries %q", uri)
+}
+
+// OpenResource is part of migration.ResourceDownloader.
+func (s *cloneSource) OpenResource(appName, name string) (io.ReadCloser, error) {
+	resources, err := s.st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, reader, err := resources.OpenResource(appName, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return reader, nil
+}
+
+// cloneUploader uploads binaries to the clone of a model in the target
+// controller.
+type cloneUploader struct {
+	client    *migrationtarget.Client
+	modelUUID string
+}
+
+// UploadCharm is part of migration.CharmUploader.
+func (u *cloneUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadTools is part of migration.ToolsUploader.
+func (u *cloneUploader) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, r, vers, additionalSeries...)
+}
+
+// UploadResource is part of migration.ResourceUploader.
+func (u *cloneUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, content)
+}
+
+// SetPlaceholderResource is part of migration.ResourceUploader.
+func (u *cloneUploader) SetPlaceholderResource(res resource.Resource) error {
+	return u.client.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource is part of migration.ResourceUploader.
+func (u *cloneUploader) SetUnitResource(unitName string, res resource.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unitName, res)
+}
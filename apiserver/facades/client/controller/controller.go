@@ -7,8 +7,10 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/txn"
@@ -45,11 +47,23 @@ type ControllerAPI struct {
 	hub        facade.Hub
 }
 
+// ControllerAPIv6 provides the v6 Controller API. The only difference
+// between this and v7 is that v6 doesn't have the CloneModels method.
+type ControllerAPIv6 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv5 provides the v5 Controller API. The only difference
+// between this and v6 is that v5 can't do dry run migrations.
+type ControllerAPIv5 struct {
+	*ControllerAPIv6
+}
+
 // ControllerAPIv4 provides the v4 Controller API. The only difference
 // between this and v5 is that v4 doesn't have the
 // UpdateControllerConfig method.
 type ControllerAPIv4 struct {
-	*ControllerAPI
+	*ControllerAPIv5
 }
 
 // ControllerAPIv3 provides the v3 Controller API.
@@ -57,8 +71,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv5 creates a new ControllerAPIv5.
-func NewControllerAPIv5(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -76,6 +90,24 @@ func NewControllerAPIv5(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv6 creates a new ControllerAPIv6.
+func NewControllerAPIv6(ctx facade.Context) (*ControllerAPIv6, error) {
+	v7, err := NewControllerAPIv7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv6{v7}, nil
+}
+
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v6, err := NewControllerAPIv6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v6}, nil
+}
+
 // NewControllerAPIv4 creates a new ControllerAPIv4.
 func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
 	v5, err := NewControllerAPIv5(ctx)
@@ -417,74 +449,111 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 	for i, spec := range reqArgs.Specs {
 		result := &out.Results[i]
 		result.ModelTag = spec.ModelTag
-		id, err := c.initiateOneMigration(spec)
+		id, problems, err := c.initiateOneMigration(spec)
 		if err != nil {
 			result.Error = common.ServerError(err)
 		} else {
 			result.MigrationId = id
+			result.Problems = problems
 		}
 	}
 	return out, nil
 }
 
-func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
+// initiateOneMigration starts the migration described by spec and
+// returns its id. For a dry run no migration is started; instead the
+// problems that would prevent the migration succeeding are returned.
+func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, []string, error) {
 	modelTag, err := names.ParseModelTag(spec.ModelTag)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", nil, errors.Annotate(err, "model tag")
 	}
 
 	// Ensure the model exists.
 	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
+		return "", nil, errors.Annotate(err, "reading model")
 	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+		return "", nil, errors.NotFoundf("model")
 	}
 
 	hostedState, err := c.statePool.Get(modelTag.Id())
 	if err != nil {
-		return "", errors.Trace(err)
+		return "", nil, errors.Trace(err)
 	}
 	defer hostedState.Release()
 
-	// Construct target info.
-	specTarget := spec.TargetInfo
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+
+	if spec.DryRun {
+		problems, err := runMigrationDryRun(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		return "", problems, nil
+	}
+
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return mig.Id(), nil, nil
+}
+
+// InitiateMigration attempts to begin the migration of one or more
+// models to other controllers. The v5 API doesn't support dry runs, so
+// they are refused rather than being mistaken for real migrations.
+func (c *ControllerAPIv5) InitiateMigration(reqArgs params.InitiateMigrationArgs) (
+	params.InitiateMigrationResults, error,
+) {
+	for _, spec := range reqArgs.Specs {
+		if spec.DryRun {
+			return params.InitiateMigrationResults{}, errors.NotSupportedf("dry run migrations")
+		}
+	}
+	return c.ControllerAPI.InitiateMigration(reqArgs)
+}
+
+// CloneModels isn't on the v6 API.
+func (c *ControllerAPIv6) CloneModels(_, _ struct{}) {}
+
+// makeTargetInfo converts the target controller details given to the
+// API into the form used for connecting to it.
+func makeTargetInfo(specTarget params.MigrationTargetInfo) (coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	return coremigration.TargetInfo{
 		ControllerTag: controllerTag,
 		Addrs:         specTarget.Addrs,
 		CACert:        specTarget.CACert,
 		AuthTag:       authTag,
 		Password:      specTarget.Password,
 		Macaroons:     macs,
-	}
-
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
-	if err != nil {
-		return "", errors.Trace(err)
-	}
-	return mig.Id(), nil
+	}, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -598,6 +667,98 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationDryRun checks whether the model could be migrated to the
+// target controller, without starting a migration. Unlike the prechecks
+// it doesn't stop at the first problem: the source and target prechecks
+// are run, the model's charms are checked, and the exported model is
+// trial imported into the target controller, with every problem found
+// being returned.
+var runMigrationDryRun = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) ([]string, error) {
+	var problems []string
+	addProblem := func(err error, message string) {
+		problems = append(problems, errors.Annotate(err, message).Error())
+	}
+
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	if err := migration.SourcePrecheck(backend, modelPresence, controllerPresence); err != nil {
+		addProblem(err, "source prechecks failed")
+	}
+	charmProblems, err := migrationCharmProblems(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	problems = append(problems, charmProblems...)
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		addProblem(err, "connect to target controller")
+		return problems, nil
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := migrationtarget.NewClient(conn)
+	if err := client.Prechecks(modelInfo); err != nil {
+		addProblem(err, "target prechecks failed")
+	}
+
+	// Check the model can be exported and imported.
+	bytes, err := migration.ExportModel(st)
+	if err != nil {
+		addProblem(err, "exporting model")
+		return problems, nil
+	}
+	importProblems, err := client.ValidateImport(bytes)
+	if params.IsCodeNotImplemented(err) {
+		problems = append(problems, "target controller is too old to validate the model import")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "validating model import")
+	}
+	for _, problem := range importProblems {
+		addProblem(problem, "import validation failed")
+	}
+	return problems, nil
+}
+
+// migrationCharmProblems reports the charms used by the model whose
+// archives the source controller doesn't hold, and so can't be
+// transferred to the target controller.
+func migrationCharmProblems(st *state.State) ([]string, error) {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving applications")
+	}
+	var problems []string
+	seen := set.NewStrings()
+	for _, app := range applications {
+		curl, _ := app.CharmURL()
+		if seen.Contains(curl.String()) {
+			continue
+		}
+		seen.Add(curl.String())
+		ch, err := st.Charm(curl)
+		if errors.IsNotFound(err) {
+			problems = append(problems, fmt.Sprintf("charm %s used by %s not found", curl, app.Name()))
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "retrieving charm %s", curl)
+		}
+		if !ch.IsUploaded() {
+			problems = append(problems, fmt.Sprintf("charm %s used by %s has not been uploaded", curl, app.Name()))
+		}
+	}
+	return problems, nil
+}
+
 func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
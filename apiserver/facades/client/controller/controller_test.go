@@ -25,6 +25,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
@@ -64,7 +65,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 	}
 	s.hub = pubsub.NewStructuredHub(nil)
 
-	controller, err := controller.NewControllerAPIv5(
+	controller, err := controller.NewControllerAPIv7(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -570,6 +571,171 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) dryRunArgs(c *gc.C, st *state.State) params.InitiateMigrationArgs {
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	return params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+			DryRun: true,
+		}},
+	}
+}
+
+func (s *controllerSuite) TestInitiateMigrationDryRun(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetPrecheckResult(s, errors.New("prechecks shouldn't be run"))
+	controller.SetDryRunResult(s, []string{"source prechecks failed: boom", "target prechecks failed: bang"}, nil)
+
+	out, err := s.controller.InitiateMigration(s.dryRunArgs(c, st))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	result := out.Results[0]
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.MigrationId, gc.Equals, "")
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"source prechecks failed: boom",
+		"target prechecks failed: bang",
+	})
+
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestInitiateMigrationDryRunError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetDryRunResult(s, nil, errors.New("boom"))
+
+	out, err := s.controller.InitiateMigration(s.dryRunArgs(c, st))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *controllerSuite) TestInitiateMigrationDryRunNotSupportedV5(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	api, err := controller.NewControllerAPIv5(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      s.authorizer,
+			Hub_:       s.hub,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.InitiateMigration(s.dryRunArgs(c, st))
+	c.Assert(err, gc.ErrorMatches, "dry run migrations not supported")
+}
+
+func (s *controllerSuite) cloneArgs(c *gc.C, st *state.State, name string) params.CloneModelArgs {
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	return params.CloneModelArgs{
+		Specs: []params.CloneModelSpec{{
+			ModelTag: m.ModelTag().String(),
+			Name:     name,
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: s.State.ControllerTag().String(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+			TopologyOnly: true,
+		}},
+	}
+}
+
+func (s *controllerSuite) TestCloneModels(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	newTag := names.NewModelTag(utils.MustNewUUID().String())
+	var gotName string
+	var gotTopologyOnly bool
+	controller.SetCloneModelFunc(s, func(
+		cloneSt *state.State, targetInfo *coremigration.TargetInfo, name string, topologyOnly bool,
+	) (names.ModelTag, error) {
+		c.Check(cloneSt.ModelUUID(), gc.Equals, st.ModelUUID())
+		c.Check(targetInfo.Addrs, jc.DeepEquals, []string{"1.1.1.1:1111"})
+		c.Check(targetInfo.AuthTag.String(), gc.Equals, "user-admin1")
+		gotName = name
+		gotTopologyOnly = topologyOnly
+		return newTag, nil
+	})
+
+	out, err := s.controller.CloneModels(s.cloneArgs(c, st, "staging"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, jc.DeepEquals, []params.CloneModelResult{{
+		ModelTag: newTag.String(),
+	}})
+	c.Check(gotName, gc.Equals, "staging")
+	c.Check(gotTopologyOnly, jc.IsTrue)
+}
+
+func (s *controllerSuite) TestCloneModelsBadName(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	out, err := s.controller.CloneModels(s.cloneArgs(c, st, "Not_Valid"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, `model name "Not_Valid" not valid`)
+}
+
+func (s *controllerSuite) TestCloneModelsControllerModel(c *gc.C) {
+	out, err := s.controller.CloneModels(s.cloneArgs(c, s.State, "staging"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "cloning the controller model not supported")
+}
+
+func (s *controllerSuite) TestCloneModelsError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetCloneModelFunc(s, func(*state.State, *coremigration.TargetInfo, string, bool) (names.ModelTag, error) {
+		return names.ModelTag{}, errors.New("boom")
+	})
+	out, err := s.controller.CloneModels(s.cloneArgs(c, st, "staging"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *controllerSuite) TestCloneModelsRequiresAdmin(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{Tag: user.Tag()}
+	endpoint, err := controller.NewControllerAPIv7(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+			Hub_:       s.hub,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.CloneModels(s.cloneArgs(c, st, "staging"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -931,7 +1097,7 @@ func (s *controllerSuite) TestConfigSetRequiresSuperUser(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: user.Tag(),
 	}
-	endpoint, err := controller.NewControllerAPIv5(
+	endpoint, err := controller.NewControllerAPIv7(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -4,6 +4,8 @@
 package controller
 
 import (
+	"gopkg.in/juju/names.v2"
+
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
@@ -18,3 +20,13 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetDryRunResult(p patcher, problems []string, err error) {
+	p.PatchValue(&runMigrationDryRun, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) ([]string, error) {
+		return problems, err
+	})
+}
+
+func SetCloneModelFunc(p patcher, f func(*state.State, *migration.TargetInfo, string, bool) (names.ModelTag, error)) {
+	p.PatchValue(&cloneModel, f)
+}
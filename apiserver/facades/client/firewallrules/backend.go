@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
@@ -17,6 +18,16 @@ type Backend interface {
 	ModelTag() names.ModelTag
 	SaveFirewallRule(state.FirewallRule) error
 	ListFirewallRules() ([]*state.FirewallRule, error)
+	Application(string) (Application, error)
+}
+
+// Application defines a subset of the functionality provided by the
+// state.Application type, as required by the firewallrules facade. For
+// details on the methods, see the methods on state.Application with
+// the same names.
+type Application interface {
+	EgressRules() []network.EgressRule
+	SetEgressRules([]network.EgressRule) error
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -57,3 +68,11 @@ func (s stateShim) ListFirewallRules() ([]*state.FirewallRule, error) {
 	api := state.NewFirewallRules(s.State)
 	return api.AllRules()
 }
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
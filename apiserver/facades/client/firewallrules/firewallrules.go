@@ -11,30 +11,58 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.firewallrules")
 
-// API provides the firewallrules facade APIs for v1.
+// APIv1 provides the firewallrules facade APIs for v1.
+type APIv1 struct {
+	*API
+}
+
+// API provides the firewallrules facade APIs for v2.
 type API struct {
-	backend    Backend
-	authorizer facade.Authorizer
-	check      BlockChecker
+	backend     Backend
+	authorizer  facade.Authorizer
+	check       BlockChecker
+	newEnviron  func() (environs.Environ, error)
+	callContext context.ProviderCallContext
 }
 
-// NewFacade provides the signature required for facade registration.
-func NewFacade(ctx facade.Context) (*API, error) {
+// NewFacade provides the signature required for facade registration
+// for version 1.
+func NewFacade(ctx facade.Context) (*APIv1, error) {
+	api, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
+// NewFacadeV2 provides the signature required for facade registration
+// for version 2.
+func NewFacadeV2(ctx facade.Context) (*API, error) {
 	backend, err := NewStateBackend(ctx.State())
 	if err != nil {
 		return nil, errors.Annotate(err, "getting state")
 	}
 	blockChecker := common.NewBlockChecker(ctx.State())
+	newEnviron := func() (environs.Environ, error) {
+		return stateenvirons.GetNewEnvironFunc(environs.New)(ctx.State())
+	}
 	return NewAPI(
 		backend,
 		ctx.Auth(),
 		blockChecker,
+		newEnviron,
+		state.CallContext(ctx.State()),
 	)
 }
 
@@ -43,14 +71,18 @@ func NewAPI(
 	backend Backend,
 	authorizer facade.Authorizer,
 	blockChecker BlockChecker,
+	newEnviron func() (environs.Environ, error),
+	callContext context.ProviderCallContext,
 ) (*API, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
 	}
 	return &API{
-		backend:    backend,
-		authorizer: authorizer,
-		check:      blockChecker,
+		backend:     backend,
+		authorizer:  authorizer,
+		check:       blockChecker,
+		newEnviron:  newEnviron,
+		callContext: callContext,
 	}, nil
 }
 
@@ -115,3 +147,106 @@ func (api *API) ListFirewallRules() (params.ListFirewallRulesResults, error) {
 	}
 	return listResults, nil
 }
+
+// SetEgressRules isn't on the v1 API.
+func (u *APIv1) SetEgressRules(_, _ struct{}) {}
+
+// SetEgressRules replaces the egress rules of the specified applications.
+// Rules may only be set if the model's instances can enforce them, but
+// may always be cleared.
+func (api *API) SetEgressRules(args params.ApplicationEgressRulesArgs) (params.ErrorResults, error) {
+	var errResults params.ErrorResults
+	if err := api.checkAdmin(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+	for _, arg := range args.Args {
+		if len(arg.Rules) == 0 {
+			continue
+		}
+		if err := api.checkEgressRulesSupported(); err != nil {
+			return errResults, errors.Trace(err)
+		}
+		break
+	}
+
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		results[i].Error = common.ServerError(api.setEgressRules(arg))
+	}
+	errResults.Results = results
+	return errResults, nil
+}
+
+// checkEgressRulesSupported returns an error satisfying
+// errors.IsNotSupported if the firewaller cannot enforce egress rules on
+// the model's instances.
+func (api *API) checkEgressRulesSupported() error {
+	env, err := api.newEnviron()
+	if err != nil {
+		return errors.Annotate(err, "opening environment")
+	}
+	if mode := env.Config().FirewallMode(); mode != config.FwInstance {
+		return errors.NotSupportedf("egress rules with firewall mode %q", mode)
+	}
+	if !environs.SupportsEgressRules(api.callContext, env) {
+		return errors.NotSupportedf("egress rules on this cloud")
+	}
+	return nil
+}
+
+func (api *API) setEgressRules(arg params.ApplicationEgressRules) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rules := make([]network.EgressRule, len(arg.Rules))
+	for i, rule := range arg.Rules {
+		rules[i] = rule.NetworkEgressRule()
+	}
+	logger.Debugf("setting egress rules for %v to %v", tag, rules)
+	return app.SetEgressRules(rules)
+}
+
+// EgressRules isn't on the v1 API.
+func (u *APIv1) EgressRules(_, _ struct{}) {}
+
+// EgressRules returns the egress rules of the specified applications.
+func (api *API) EgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	var results params.EgressRulesResults
+	if err := api.checkCanRead(); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.EgressRulesResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		rules, err := api.egressRules(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Rules = rules
+	}
+	return results, nil
+}
+
+func (api *API) egressRules(tagString string) ([]params.EgressRule, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules []params.EgressRule
+	for _, rule := range app.EgressRules() {
+		rules = append(rules, params.FromNetworkEgressRule(rule))
+	}
+	return rules, nil
+}
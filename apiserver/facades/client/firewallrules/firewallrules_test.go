@@ -13,6 +13,10 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -23,6 +27,7 @@ type FirewallRulesSuite struct {
 	backend mockBackend
 
 	blockChecker mockBlockChecker
+	environ      mockEnviron
 	authorizer   apiservertesting.FakeAuthorizer
 	api          *firewallrules.API
 }
@@ -31,15 +36,31 @@ var _ = gc.Suite(&FirewallRulesSuite{})
 
 func (s *FirewallRulesSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	s.authorizer.Tag = user
+	s.newAPI(c)
+}
+
+func (s *FirewallRulesSuite) newAPI(c *gc.C) {
 	api, err := firewallrules.NewAPI(
 		&s.backend,
 		s.authorizer,
 		&s.blockChecker,
+		func() (environs.Environ, error) {
+			return &s.environ, nil
+		},
+		context.NewCloudCallContext(),
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
 }
 
+func (s *FirewallRulesSuite) setFirewallMode(c *gc.C, mode string) {
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"firewall-mode": mode,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.environ.config = cfg
+}
+
 func (s *FirewallRulesSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.JujuOSEnvSuite.SetUpTest(c)
@@ -49,15 +70,14 @@ func (s *FirewallRulesSuite) SetUpTest(c *gc.C) {
 	s.backend = mockBackend{
 		modelUUID: coretesting.ModelTag.Id(),
 		rules:     make(map[string]state.FirewallRule),
+		applications: map[string]*mockApplication{
+			"mysql": {},
+		},
 	}
 	s.blockChecker = mockBlockChecker{}
-	api, err := firewallrules.NewAPI(
-		&s.backend,
-		s.authorizer,
-		&s.blockChecker,
-	)
-	c.Assert(err, jc.ErrorIsNil)
-	s.api = api
+	s.environ = mockEnviron{egressSupport: true}
+	s.setFirewallMode(c, config.FwInstance)
+	s.newAPI(c)
 }
 
 func (s *FirewallRulesSuite) TearDownTest(c *gc.C) {
@@ -120,3 +140,113 @@ func (s *FirewallRulesSuite) TestListFirewallRulesPermission(c *gc.C) {
 	_, err := s.api.ListFirewallRules()
 	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
 }
+
+func (s *FirewallRulesSuite) TestSetEgressRules(c *gc.C) {
+	result, err := s.api.SetEgressRules(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{{
+			ApplicationTag: "application-mysql",
+			Rules: []params.EgressRule{{
+				Protocol:         "tcp",
+				FromPort:         443,
+				ToPort:           443,
+				DestinationCIDRs: []string{"10.0.0.0/8"},
+			}},
+		}, {
+			ApplicationTag: "application-wordpress",
+		}, {
+			ApplicationTag: "mysql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "wordpress" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"mysql" is not a valid tag`)
+	c.Assert(s.backend.applications["mysql"].egressRules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+}
+
+func (s *FirewallRulesSuite) TestSetEgressRulesNotSupported(c *gc.C) {
+	s.environ.egressSupport = false
+	_, err := s.api.SetEgressRules(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{{
+			ApplicationTag: "application-mysql",
+			Rules:          []params.EgressRule{{Protocol: "tcp", FromPort: 443, ToPort: 443}},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "egress rules on this cloud not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(s.backend.applications["mysql"].egressRules, gc.HasLen, 0)
+}
+
+func (s *FirewallRulesSuite) TestSetEgressRulesGlobalFirewallMode(c *gc.C) {
+	s.setFirewallMode(c, config.FwGlobal)
+	_, err := s.api.SetEgressRules(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{{
+			ApplicationTag: "application-mysql",
+			Rules:          []params.EgressRule{{Protocol: "tcp", FromPort: 443, ToPort: 443}},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `egress rules with firewall mode "global" not supported`)
+	c.Assert(s.backend.applications["mysql"].egressRules, gc.HasLen, 0)
+}
+
+func (s *FirewallRulesSuite) TestClearEgressRulesNotSupported(c *gc.C) {
+	s.environ.egressSupport = false
+	s.backend.applications["mysql"].egressRules = []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	}
+	result, err := s.api.SetEgressRules(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{{
+			ApplicationTag: "application-mysql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	c.Assert(s.backend.applications["mysql"].egressRules, gc.HasLen, 0)
+}
+
+func (s *FirewallRulesSuite) TestSetEgressRulesPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("mary"))
+	_, err := s.api.SetEgressRules(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{{
+			ApplicationTag: "application-mysql",
+			Rules:          []params.EgressRule{{Protocol: "tcp", FromPort: 443, ToPort: 443}},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+	c.Assert(s.backend.applications["mysql"].egressRules, gc.HasLen, 0)
+}
+
+func (s *FirewallRulesSuite) TestSetEgressRulesBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetEgressRules(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{{
+			ApplicationTag: "application-mysql",
+			Rules:          []params.EgressRule{{Protocol: "tcp", FromPort: 443, ToPort: 443}},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	c.Assert(s.backend.applications["mysql"].egressRules, gc.HasLen, 0)
+}
+
+func (s *FirewallRulesSuite) TestEgressRules(c *gc.C) {
+	s.backend.applications["mysql"].egressRules = []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	}
+	result, err := s.api.EgressRules(params.Entities{
+		Entities: []params.Entity{{"application-mysql"}, {"application-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0], jc.DeepEquals, params.EgressRulesResult{
+		Rules: []params.EgressRule{{
+			Protocol:         "udp",
+			FromPort:         53,
+			ToPort:           53,
+			DestinationCIDRs: []string{"10.0.0.2/32"},
+		}},
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "wordpress" not found`)
+}
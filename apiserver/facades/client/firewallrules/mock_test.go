@@ -4,10 +4,15 @@
 package firewallrules_test
 
 import (
+	"github.com/juju/errors"
 	jtesting "github.com/juju/testing"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
@@ -15,8 +20,9 @@ type mockBackend struct {
 	jtesting.Stub
 	firewallrules.Backend
 
-	modelUUID string
-	rules     map[string]state.FirewallRule
+	modelUUID    string
+	rules        map[string]state.FirewallRule
+	applications map[string]*mockApplication
 }
 
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
@@ -47,6 +53,31 @@ func (m *mockBackend) ListFirewallRules() ([]*state.FirewallRule, error) {
 	}, nil
 }
 
+func (m *mockBackend) Application(name string) (firewallrules.Application, error) {
+	m.MethodCall(m, "Application", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	app, ok := m.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+type mockApplication struct {
+	egressRules []network.EgressRule
+}
+
+func (a *mockApplication) EgressRules() []network.EgressRule {
+	return a.egressRules
+}
+
+func (a *mockApplication) SetEgressRules(rules []network.EgressRule) error {
+	a.egressRules = rules
+	return nil
+}
+
 type mockBlockChecker struct {
 	jtesting.Stub
 }
@@ -55,3 +86,17 @@ func (c *mockBlockChecker) ChangeAllowed() error {
 	c.MethodCall(c, "ChangeAllowed")
 	return c.NextErr()
 }
+
+type mockEnviron struct {
+	environs.Environ
+	config        *config.Config
+	egressSupport bool
+}
+
+func (e *mockEnviron) Config() *config.Config {
+	return e.config
+}
+
+func (e *mockEnviron) SupportsEgressRules(context.ProviderCallContext) (bool, error) {
+	return e.egressSupport, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.actionscheduler")
+
+// API implements the API used by the action scheduler worker to run
+// the actions of action schedules that have fired.
+type API struct {
+	st    *state.State
+	model *state.Model
+}
+
+// NewAPI creates a new server-side ActionScheduler API end point.
+func NewAPI(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{st: st, model: m}, nil
+}
+
+// RunDueSchedules enqueues the actions of the action schedules that
+// are due, in the same way as the Action facade's Enqueue, and moves
+// each schedule on to its next run. Failures to enqueue an action on
+// one of a schedule's receivers are logged, and do not prevent the
+// other receivers from running the action.
+func (api *API) RunDueSchedules() (params.ErrorResult, error) {
+	if err := api.runDueSchedules(); err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ErrorResult{}, nil
+}
+
+func (api *API) runDueSchedules() error {
+	schedules, err := api.model.DueActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tagToActionReceiver := common.TagToActionReceiverFn(api.st.FindEntity)
+	for _, schedule := range schedules {
+		advanced, err := schedule.Advance()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !advanced {
+			// Another controller has already run this schedule.
+			continue
+		}
+		args := params.Actions{Actions: make([]params.Action, len(schedule.Receivers()))}
+		for i, receiver := range schedule.Receivers() {
+			args.Actions[i] = params.Action{
+				Receiver:   receiver,
+				Name:       schedule.Action(),
+				Parameters: schedule.Parameters(),
+			}
+		}
+		results := common.EnqueueActions(args, tagToActionReceiver)
+		for i, result := range results.Results {
+			if result.Error != nil {
+				logger.Warningf(
+					"action schedule %q cannot enqueue %q on %s: %v",
+					schedule.Name(), schedule.Action(), args.Actions[i].Receiver, result.Error,
+				)
+			}
+		}
+	}
+	return nil
+}
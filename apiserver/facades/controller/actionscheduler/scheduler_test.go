@@ -0,0 +1,96 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type schedulerSuite struct {
+	jujutesting.JujuConnSuite
+
+	clock *testing.Clock
+	api   *actionscheduler.API
+	unit  *state.Unit
+}
+
+var _ = gc.Suite(&schedulerSuite{})
+
+func (s *schedulerSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 6, 15, 10, 30, 0, 0, time.UTC))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api, err = actionscheduler.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Controller: true,
+		Tag:        names.NewMachineTag("0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+}
+
+func (s *schedulerSuite) TestNewAPIRequiresController(c *gc.C) {
+	_, err := actionscheduler.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *schedulerSuite) TestRunDueSchedules(c *gc.C) {
+	_, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:      "hourly",
+		Action:    "fakeaction",
+		Receivers: []names.Tag{s.unit.Tag(), names.NewUnitTag("missing/0")},
+		Schedule:  "@hourly",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing is due yet.
+	result, err := s.api.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.assertPendingActions(c, 0)
+
+	s.clock.Advance(45 * time.Minute)
+	result, err = s.api.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.assertPendingActions(c, 1)
+
+	// The schedule only fires once per run.
+	result, err = s.api.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.assertPendingActions(c, 1)
+
+	schedule, err := s.Model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastRun().Equal(s.clock.Now()), jc.IsTrue)
+}
+
+func (s *schedulerSuite) assertPendingActions(c *gc.C, expected int) {
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, expected)
+	for _, action := range actions {
+		c.Assert(action.Name(), gc.Equals, "fakeaction")
+	}
+}
@@ -5,6 +5,8 @@ package caasunitprovisioner
 
 import (
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -417,9 +419,14 @@ func (a *Facade) updateUnitsFromCloud(app Application, unitUpdates []params.Appl
 	stateUnitsById := make(map[string]Unit)
 	cloudUnitsById := make(map[string]params.ApplicationUnitParams)
 
-	// Record all unit provider ids known to exist in the cloud.
+	// Record all unit provider ids known to exist in the cloud, and
+	// the ids by which earlier versions of the provider knew them.
+	legacyIds := make(map[string]string)
 	for _, u := range unitUpdates {
 		cloudUnitsById[u.ProviderId] = u
+		if u.LegacyProviderId != "" {
+			legacyIds[u.LegacyProviderId] = u.ProviderId
+		}
 	}
 
 	stateUnitExistsInCloud := func(providerId string) bool {
@@ -460,6 +467,12 @@ func (a *Facade) updateUnitsFromCloud(app Application, unitUpdates []params.Appl
 			continue
 		}
 
+		if id, ok := legacyIds[providerId]; ok {
+			// The unit was associated with its pod by an earlier
+			// version of the provider; the new id is recorded
+			// when the unit is updated.
+			providerId = id
+		}
 		if providerId == "" {
 			logger.Debugf("unit %q is not associated with any pod", u.Name())
 			unitInfo.unassociatedUnits = append(unitInfo.unassociatedUnits, u)
@@ -477,19 +490,22 @@ func (a *Facade) updateUnitsFromCloud(app Application, unitUpdates []params.Appl
 		}
 	}
 
-	// Do it in sorted order so it's deterministic for tests.
+	// Do it in sorted order so it's deterministic for tests, and so
+	// that the pods of a StatefulSet are associated with units in
+	// unit number order.
 	var ids []string
 	for id := range cloudUnitsById {
 		ids = append(ids, id)
 	}
-	sort.Strings(ids)
+	sort.Sort(providerIds(ids))
+	sort.Sort(unitsByNumber(unitInfo.unassociatedUnits))
 
 	// Sort extra ids also to guarantee order.
 	var extraIds []string
 	for id := range extraStateIds {
 		extraIds = append(extraIds, id)
 	}
-	sort.Strings(extraIds)
+	sort.Sort(providerIds(extraIds))
 	extraIdIndex := 0
 
 	for _, id := range ids {
@@ -532,6 +548,49 @@ func (a *Facade) updateUnitsFromCloud(app Application, unitUpdates []params.Appl
 	return a.updateStateUnits(app, unitInfo)
 }
 
+// providerIds sorts provider ids. The ids of the pods of a StatefulSet
+// end with the pod's ordinal, and are sorted by it; the pods with the
+// highest ordinals are the ones removed when the StatefulSet is scaled
+// down.
+type providerIds []string
+
+func (ids providerIds) Len() int      { return len(ids) }
+func (ids providerIds) Swap(i, j int) { ids[i], ids[j] = ids[j], ids[i] }
+func (ids providerIds) Less(i, j int) bool {
+	iPrefix, iOrdinal, iOk := splitOrdinal(ids[i])
+	jPrefix, jOrdinal, jOk := splitOrdinal(ids[j])
+	if iOk && jOk && iPrefix == jPrefix {
+		return iOrdinal < jOrdinal
+	}
+	return ids[i] < ids[j]
+}
+
+func splitOrdinal(id string) (string, int, bool) {
+	i := strings.LastIndex(id, "-")
+	if i < 0 {
+		return "", 0, false
+	}
+	ordinal, err := strconv.Atoi(id[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return id[:i], ordinal, true
+}
+
+// unitsByNumber sorts units of a single application by unit number.
+type unitsByNumber []Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i].Name()) < unitNumber(u[j].Name())
+}
+
+func unitNumber(name string) int {
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+	return n
+}
+
 type updateStateUnitParams struct {
 	stateUnitsInCloud  map[string]Unit
 	addedCloudUnits    []params.ApplicationUnitParams
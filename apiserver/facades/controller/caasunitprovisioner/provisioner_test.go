@@ -301,6 +301,83 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnits(c *gc.C) {
 	})
 }
 
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsLegacyProviderId(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uid-1"}, life: state.Alive},
+		&mockUnit{name: "gitlab/1", containerInfo: &mockContainerInfo{providerId: "uid-0"}, life: state.Alive},
+	}
+
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "juju-gitlab-0", LegacyProviderId: "uid-0", Address: "address-0",
+			Status: "running", Info: "message"},
+		{ProviderId: "juju-gitlab-1", LegacyProviderId: "uid-1", Address: "address-1",
+			Status: "running", Info: "message"},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	// The units keep the pods they were associated with by UID.
+	s.st.application.CheckCallNames(c, "Life")
+	s.st.application.units[0].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId:  strPtr("juju-gitlab-1"),
+		Address:     strPtr("address-1"),
+		Ports:       &[]string(nil),
+		UnitStatus:  &status.StatusInfo{Status: status.Active, Message: "message"},
+		AgentStatus: &status.StatusInfo{Status: status.Idle},
+	})
+	s.st.application.units[1].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId:  strPtr("juju-gitlab-0"),
+		Address:     strPtr("address-0"),
+		Ports:       &[]string(nil),
+		UnitStatus:  &status.StatusInfo{Status: status.Active, Message: "message"},
+		AgentStatus: &status.StatusInfo{Status: status.Idle},
+	})
+}
+
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsStatefulOrdinals(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/10", life: state.Alive},
+		&mockUnit{name: "gitlab/9", life: state.Alive},
+	}
+
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "juju-gitlab-10", Address: "address-10", Status: "running"},
+		{ProviderId: "juju-gitlab-9", Address: "address-9", Status: "running"},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	// Pods are associated with units in ordinal order, so that
+	// the highest numbered unit has the pod removed first when
+	// the StatefulSet is scaled down.
+	s.st.application.units[0].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId:  strPtr("juju-gitlab-10"),
+		Address:     strPtr("address-10"),
+		Ports:       &[]string(nil),
+		UnitStatus:  &status.StatusInfo{Status: status.Active},
+		AgentStatus: &status.StatusInfo{Status: status.Idle},
+	})
+	s.st.application.units[1].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId:  strPtr("juju-gitlab-9"),
+		Address:     strPtr("address-9"),
+		Ports:       &[]string(nil),
+		UnitStatus:  &status.StatusInfo{Status: status.Active},
+		AgentStatus: &status.StatusInfo{Status: status.Idle},
+	})
+}
+
 func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsNotAlive(c *gc.C) {
 	s.st.application.units = []caasunitprovisioner.Unit{
 		&mockUnit{name: "gitlab/0", life: state.Alive},
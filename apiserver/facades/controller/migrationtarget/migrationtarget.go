@@ -4,15 +4,19 @@
 package migrationtarget
 
 import (
+	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/juju/collections/set"
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
@@ -35,11 +39,26 @@ type API struct {
 	callContext context.ProviderCallContext
 }
 
+// APIV1 implements the V1 version of the API facade. It doesn't have
+// the ValidateImport method.
+type APIV1 struct {
+	*API
+}
+
 // NewFacade is used for API registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	return NewAPI(ctx, stateenvirons.GetNewEnvironFunc(environs.New), state.CallContext(ctx.State()))
 }
 
+// NewFacadeV1 is used for V1 API registration.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, callCtx context.ProviderCallContext) (*API, error) {
@@ -117,6 +136,113 @@ func (api *API) Import(serialized params.SerializedModel) error {
 	return err
 }
 
+// ValidateImport checks whether a serialized model could be imported
+// into the receiving controller. The model is imported and then
+// immediately removed again, so nothing is left behind. Each problem
+// found is reported as an error result; no results means the import
+// would succeed.
+func (api *API) ValidateImport(serialized params.SerializedModel) (params.ErrorResults, error) {
+	var empty params.ErrorResults
+	model, err := description.Deserialize(serialized.Bytes)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+
+	var results []params.ErrorResult
+	cld, err := api.state.Cloud(model.Cloud())
+	if errors.IsNotFound(err) {
+		results = append(results, errorResult("cloud %q not found", model.Cloud()))
+	} else if err != nil {
+		return empty, errors.Trace(err)
+	} else if region := model.CloudRegion(); region != "" && !hasRegion(cld, region) {
+		results = append(results, errorResult("cloud %q has no region %q", model.Cloud(), region))
+	}
+
+	// Importing a model adds its credential if the target controller
+	// doesn't have it, so note whether it needs removing afterwards.
+	var addedCredential *names.CloudCredentialTag
+	if creds := model.CloudCredential(); creds != nil {
+		credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+		if !names.IsValidCloudCredential(credID) {
+			return empty, errors.NotValidf("model credential id %q", credID)
+		}
+		credTag := names.NewCloudCredentialTag(credID)
+		existing, err := api.state.CloudCredential(credTag)
+		if errors.IsNotFound(err) {
+			addedCredential = &credTag
+		} else if err != nil {
+			return empty, errors.Trace(err)
+		} else if existing.AuthType != creds.AuthType() {
+			results = append(results, errorResult("credential %q auth type mismatch: %q != %q",
+				credID, existing.AuthType, creds.AuthType()))
+		} else if !reflect.DeepEqual(existing.Attributes, creds.Attributes()) {
+			results = append(results, errorResult("credential %q attributes don't match", credID))
+		} else if existing.Revoked {
+			results = append(results, errorResult("credential %q is revoked", credID))
+		}
+	}
+	modelUUID := model.Tag().Id()
+	if exists, err := api.state.ModelExists(modelUUID); err != nil {
+		return empty, errors.Trace(err)
+	} else if exists {
+		results = append(results, errorResult("importing model: model %s already exists", modelUUID))
+	}
+	if len(results) > 0 {
+		// The import would fail for the reasons already found.
+		return params.ErrorResults{Results: results}, nil
+	}
+
+	_, st, err := api.state.Import(model)
+	if err == nil {
+		st.Close()
+	} else {
+		results = append(results, params.ErrorResult{
+			Error: common.ServerError(errors.Annotate(err, "importing model")),
+		})
+	}
+	// Whether or not the import succeeded, it may have added the model
+	// and its credential, so remove whatever it left behind.
+	if err := api.removeValidatedModel(modelUUID, addedCredential); err != nil {
+		return empty, errors.Trace(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// removeValidatedModel removes the model imported, in whole or in part,
+// by ValidateImport, as Abort would, along with its credential if that
+// was added by the import.
+func (api *API) removeValidatedModel(modelUUID string, addedCredential *names.CloudCredentialTag) error {
+	exists, err := api.state.ModelExists(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		st, err := api.pool.Get(modelUUID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer st.Release()
+		if err := st.RemoveImportingModelDocs(); err != nil {
+			return errors.Annotate(err, "removing validated model")
+		}
+	}
+	if addedCredential != nil {
+		if err := api.state.RemoveCloudCredential(*addedCredential); err != nil {
+			return errors.Annotate(err, "removing validated model credential")
+		}
+	}
+	return nil
+}
+
+func hasRegion(cld cloud.Cloud, name string) bool {
+	for _, region := range cld.Regions {
+		if region.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (api *API) getModel(modelTag string) (*state.Model, func(), error) {
 	tag, err := names.ParseModelTag(modelTag)
 	if err != nil {
@@ -314,3 +440,6 @@ func (api *API) CACert() (params.BytesResult, error) {
 	caCert, _ := cfg.CACert()
 	return params.BytesResult{Result: []byte(caCert)}, nil
 }
+
+// ValidateImport isn't on the V1 API.
+func (*APIV1) ValidateImport(_, _ struct{}) {}
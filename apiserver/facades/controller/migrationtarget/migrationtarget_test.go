@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
@@ -62,7 +63,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 }
 
 func (s *Suite) TestFacadeRegistered(c *gc.C) {
-	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 1)
+	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 2)
 	c.Assert(err, jc.ErrorIsNil)
 
 	api, err := factory(&facadetest.Context{
@@ -74,6 +75,19 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
+func (s *Suite) TestFacadeRegisteredV1(c *gc.C) {
+	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV1))
+}
+
 func (s *Suite) TestNotUser(c *gc.C) {
 	s.authorizer.Tag = names.NewMachineTag("0")
 	_, err := s.newAPI(nil)
@@ -139,6 +153,99 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestValidateImport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+
+	results, err := api.ValidateImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+
+	// The validated model isn't left behind.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsFalse)
+}
+
+func (s *Suite) TestValidateImportReportsProblems(c *gc.C) {
+	api := s.mustNewAPI(c)
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"name": "some-model",
+		"uuid": utils.MustNewUUID().String(),
+	})
+	bytes, err := description.Serialize(description.NewModel(description.ModelArgs{
+		Type:               model.Type(),
+		Owner:              model.Owner(),
+		Config:             model.Config(),
+		LatestToolsVersion: model.LatestToolsVersion(),
+		Cloud:              "nowhere",
+		CloudRegion:        "somewhere",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.ValidateImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cloud "nowhere" not found`)
+}
+
+func (s *Suite) TestValidateImportFailureRemovesPartialModel(c *gc.C) {
+	api := s.mustNewAPI(c)
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	uuid := utils.MustNewUUID().String()
+	model.UpdateConfig(map[string]interface{}{
+		"name": "some-model",
+		"uuid": uuid,
+	})
+	// An unknown block type passes the checks made before importing,
+	// but fails the import once the model has been created.
+	failing := description.NewModel(description.ModelArgs{
+		Type:               model.Type(),
+		Owner:              model.Owner(),
+		Config:             model.Config(),
+		LatestToolsVersion: model.LatestToolsVersion(),
+		Cloud:              model.Cloud(),
+		CloudRegion:        model.CloudRegion(),
+		Blocks:             map[string]string{"no-such-block": "nope"},
+	})
+	failing.SetCloudCredential(description.CloudCredentialArgs{
+		Owner:    s.Owner,
+		Cloud:    names.NewCloudTag(model.Cloud()),
+		Name:     "validation",
+		AuthType: "empty",
+	})
+	bytes, err := description.Serialize(failing)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.ValidateImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `importing model: base model aspects: unknown block type: "no-such-block"`)
+
+	// Neither the partially imported model nor the credential added
+	// for it are left behind.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsFalse)
+	credTag := names.NewCloudCredentialTag(model.Cloud() + "/" + s.Owner.Id() + "/validation")
+	_, err = s.State.CloudCredential(credTag)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *Suite) TestValidateImportExistingModel(c *gc.C) {
+	api := s.mustNewAPI(c)
+	bytes, err := migration.ExportModel(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.ValidateImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "importing model: .*already exists")
+}
+
 func (s *Suite) TestAbort(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
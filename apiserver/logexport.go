@@ -0,0 +1,108 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// logExportHandler handles requests to export the log records of a
+// model.
+type logExportHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP sends all the log records of the model matching the request
+// as gzipped JSON lines, one params.LogMessage per line. Unlike the
+// debug-log endpoint it does not wait for new records to be logged.
+//
+// The args for the HTTP request are the filtering args of the debug-log
+// endpoint: includeEntity, excludeEntity, includeModule, excludeModule,
+// includeMessage, excludeMessage, agentType, level, startTime, endTime
+// and maxLines.
+func (h *logExportHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	st, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		h.sendError(resp, err)
+		return
+	}
+	defer st.Release()
+
+	reqParams, err := readDebugLogParams(req.URL.Query())
+	if err != nil {
+		h.sendError(resp, errors.NewBadRequest(err, ""))
+		return
+	}
+	reqParams.fromTheStart = true
+	reqParams.noTail = true
+	if reqParams.hasAgentType(controllerAgentType) {
+		reqParams.controllerMachines, err = controllerMachines(st.State)
+		if err != nil {
+			h.sendError(resp, err)
+			return
+		}
+	}
+
+	tailer, err := newLogTailer(st, makeLogTailerParams(reqParams))
+	if err != nil {
+		h.sendError(resp, err)
+		return
+	}
+	defer tailer.Stop()
+
+	resp.Header().Set("Content-Type", "application/gzip")
+	resp.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", "logs-"+st.ModelUUID()+".jsonl.gz"))
+	resp.WriteHeader(http.StatusOK)
+	// Once the records are being sent there's no way to report an
+	// error to the client other than cutting the archive short,
+	// which it will notice when decompressing it.
+	if err := writeLogExport(resp, tailer, reqParams.maxLines, h.ctxt.stop()); err != nil {
+		logger.Errorf("log export failed: %v", err)
+	}
+}
+
+// writeLogExport writes the records from the tailer to w as gzipped JSON
+// lines, stopping once the tailer has no more records or maxLines (if
+// non-zero) have been written. The gzip stream is only completed if all
+// the records were written.
+func writeLogExport(w io.Writer, tailer state.LogTailer, maxLines uint, stop <-chan struct{}) error {
+	zw := gzip.NewWriter(w)
+	encoder := json.NewEncoder(zw)
+	var lineCount uint
+	for {
+		select {
+		case <-stop:
+			return errors.New("export interrupted")
+		case rec, ok := <-tailer.Logs():
+			if !ok {
+				if err := tailer.Err(); err != nil {
+					return errors.Annotate(err, "tailer stopped")
+				}
+				return errors.Trace(zw.Close())
+			}
+			if err := encoder.Encode(formatLogRecord(rec)); err != nil {
+				return errors.Annotate(err, "sending failed")
+			}
+			lineCount++
+			if maxLines > 0 && lineCount == maxLines {
+				return errors.Trace(zw.Close())
+			}
+		}
+	}
+}
+
+func (h *logExportHandler) sendError(w http.ResponseWriter, err error) {
+	if err := sendError(w, err); err != nil {
+		logger.Errorf("%v", err)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type logExportSuite struct {
+	apiserverBaseSuite
+}
+
+var _ = gc.Suite(&logExportSuite{})
+
+func (s *logExportSuite) SetUpTest(c *gc.C) {
+	s.apiserverBaseSuite.SetUpTest(c)
+
+	t0 := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+	err := logger.Log([]state.LogRecord{{
+		Time:     t0,
+		Entity:   names.NewMachineTag("0"),
+		Level:    loggo.INFO,
+		Module:   "juju.worker",
+		Location: "worker.go:1",
+		Message:  "first",
+	}, {
+		Time:     t0.Add(time.Minute),
+		Entity:   names.NewUnitTag("mysql/0"),
+		Level:    loggo.ERROR,
+		Module:   "juju.worker.uniter",
+		Location: "uniter.go:2",
+		Message:  "second",
+	}, {
+		Time:     t0.Add(2 * time.Minute),
+		Entity:   names.NewMachineTag("1"),
+		Level:    loggo.WARNING,
+		Module:   "juju.worker",
+		Location: "worker.go:3",
+		Message:  "third",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *logExportSuite) exportURL(query url.Values) string {
+	return s.URL("/model/"+s.State.ModelUUID()+"/logexport", query).String()
+}
+
+func (s *logExportSuite) readExport(c *gc.C, resp *http.Response) []params.LogMessage {
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, "application/gzip")
+
+	zr, err := gzip.NewReader(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	var messages []params.LogMessage
+	scanner := bufio.NewScanner(zr)
+	for scanner.Scan() {
+		var msg params.LogMessage
+		err := json.Unmarshal(scanner.Bytes(), &msg)
+		c.Assert(err, jc.ErrorIsNil)
+		messages = append(messages, msg)
+	}
+	c.Assert(scanner.Err(), jc.ErrorIsNil)
+	return messages
+}
+
+func (s *logExportSuite) TestRequiresAuth(c *gc.C) {
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.exportURL(nil),
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusUnauthorized)
+}
+
+func (s *logExportSuite) TestExportAll(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.exportURL(nil),
+	})
+	messages := s.readExport(c, resp)
+	c.Assert(messages, gc.HasLen, 3)
+	c.Check(messages[0].Entity, gc.Equals, "machine-0")
+	c.Check(messages[0].Message, gc.Equals, "first")
+	c.Check(messages[0].Severity, gc.Equals, "INFO")
+	c.Check(messages[0].Module, gc.Equals, "juju.worker")
+	c.Check(messages[0].Location, gc.Equals, "worker.go:1")
+	c.Check(messages[1].Message, gc.Equals, "second")
+	c.Check(messages[2].Message, gc.Equals, "third")
+}
+
+func (s *logExportSuite) TestExportTimeWindow(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL: s.exportURL(url.Values{
+			"startTime": {"2018-06-01T10:00:30Z"},
+			"endTime":   {"2018-06-01T10:01:30Z"},
+		}),
+	})
+	messages := s.readExport(c, resp)
+	c.Assert(messages, gc.HasLen, 1)
+	c.Check(messages[0].Message, gc.Equals, "second")
+	c.Check(messages[0].Timestamp.Equal(time.Date(2018, 6, 1, 10, 1, 0, 0, time.UTC)), jc.IsTrue)
+}
+
+func (s *logExportSuite) TestExportFiltered(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL: s.exportURL(url.Values{
+			"agentType":      {"machine"},
+			"excludeMessage": {"^fir"},
+		}),
+	})
+	messages := s.readExport(c, resp)
+	c.Assert(messages, gc.HasLen, 1)
+	c.Check(messages[0].Message, gc.Equals, "third")
+}
+
+func (s *logExportSuite) TestBadParams(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.exportURL(url.Values{"endTime": {"tomorrow"}}),
+	})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusBadRequest)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	var result params.ErrorResult
+	err = json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `end time "tomorrow" is not a valid time in RFC3339 format`)
+}
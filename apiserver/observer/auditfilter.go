@@ -144,6 +144,7 @@ var readonlyMethods = set.NewStrings(
 	"Controller.GetControllerAccess",
 	"Controller.ModelConfig",
 	"Controller.ModelStatus",
+	"FirewallRules.EgressRules",
 	"MetricsDebug.GetMetrics",
 	"ModelConfig.ModelGet",
 	"ModelManager.ModelInfo",
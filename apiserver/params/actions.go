@@ -55,6 +55,10 @@ type ActionResult struct {
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
+
+	// OutputOverflow is true when the output was too large to be
+	// returned with the result, and must be fetched separately.
+	OutputOverflow bool `json:"output-overflow,omitempty"`
 }
 
 // ActionsByReceivers wrap a slice of Actions for API calls.
@@ -116,6 +120,93 @@ type ActionExecutionResult struct {
 	Message   string                 `json:"message,omitempty"`
 }
 
+// ApplicationActions is a slice of ApplicationAction for bulk requests.
+type ApplicationActions struct {
+	Actions []ApplicationAction `json:"actions,omitempty"`
+}
+
+// ApplicationAction describes an action to be run on all the units of
+// an application, at most MaxConcurrency units at a time. Once
+// StopOnFailures actions have failed, the remaining actions are
+// cancelled. Zero values mean no limit.
+type ApplicationAction struct {
+	ApplicationTag string                 `json:"application-tag"`
+	Name           string                 `json:"name"`
+	Parameters     map[string]interface{} `json:"parameters,omitempty"`
+	MaxConcurrency int                    `json:"max-concurrency,omitempty"`
+	StopOnFailures int                    `json:"stop-on-failures,omitempty"`
+}
+
+// ActionBatchIds holds the ids of action batches to query.
+type ActionBatchIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ActionBatchResults is a slice of ActionBatchResult for bulk requests.
+type ActionBatchResults struct {
+	Results []ActionBatchResult `json:"results,omitempty"`
+}
+
+// ActionBatchResult holds the aggregate result of an action run across
+// the units of an application.
+type ActionBatchResult struct {
+	Id             string         `json:"id,omitempty"`
+	ApplicationTag string         `json:"application-tag,omitempty"`
+	Name           string         `json:"name,omitempty"`
+	Status         string         `json:"status,omitempty"`
+	MaxConcurrency int            `json:"max-concurrency,omitempty"`
+	StopOnFailures int            `json:"stop-on-failures,omitempty"`
+	Enqueued       time.Time      `json:"enqueued,omitempty"`
+	Actions        []ActionResult `json:"actions,omitempty"`
+	Summary        map[string]int `json:"summary,omitempty"`
+	Error          *Error         `json:"error,omitempty"`
+}
+
+// ActionBatchAborted is the status of an action batch whose unreleased
+// actions were cancelled because too many actions failed.
+const ActionBatchAborted = "aborted"
+
+// ActionSchedules holds action schedules to add.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionSchedule describes an action that is enqueued on its receivers
+// whenever a cron expression fires.
+type ActionSchedule struct {
+	Name       string                 `json:"name"`
+	Action     string                 `json:"action"`
+	Receivers  []string               `json:"receivers"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Schedule   string                 `json:"schedule"`
+	Created    time.Time              `json:"created,omitempty"`
+	LastRun    time.Time              `json:"last-run,omitempty"`
+	NextRun    time.Time              `json:"next-run,omitempty"`
+}
+
+// ActionScheduleResults holds the action schedules in a model.
+type ActionScheduleResults struct {
+	Results []ActionSchedule `json:"results"`
+}
+
+// ActionScheduleNames holds the names of some action schedules.
+type ActionScheduleNames struct {
+	Names []string `json:"names"`
+}
+
+// ActionMessageParams holds the arguments for logging progress messages
+// for some actions.
+type ActionMessageParams struct {
+	Messages []EntityString `json:"messages"`
+}
+
+// ActionMessage represents a logged progress message for an action.
+// The values are sent JSON encoded by the action progress watcher.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
 // ApplicationsCharmActionsResults holds a slice of ApplicationCharmActionsResult for
 // a bulk result of charm Actions for Applications.
 type ApplicationsCharmActionsResults struct {
@@ -0,0 +1,31 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// AuditLogQuery holds the filters for a request to stream records
+// from the /audit-log endpoint. Empty fields match all records.
+type AuditLogQuery struct {
+	// Who selects conversations started by the named user.
+	Who string `schema:"who" url:"who,omitempty"`
+
+	// ModelUUID selects conversations with the model.
+	ModelUUID string `schema:"model-uuid" url:"model-uuid,omitempty"`
+
+	// ConversationID selects a single conversation.
+	ConversationID string `schema:"conversation-id" url:"conversation-id,omitempty"`
+
+	// Facade and Method select the API calls made.
+	Facade string `schema:"facade" url:"facade,omitempty"`
+	Method string `schema:"method" url:"method,omitempty"`
+
+	// After and Before bound the times of the API calls made, in
+	// RFC 3339 format.
+	After  string `schema:"after" url:"after,omitempty"`
+	Before string `schema:"before" url:"before,omitempty"`
+
+	// Machine is the id of the controller machine whose audit log is
+	// read, which must be the machine the client is connected to.
+	// It's required if the controller has more than one machine.
+	Machine string `schema:"machine" url:"machine,omitempty"`
+}
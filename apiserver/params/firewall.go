@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/errors"
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
 
 // FirewallRuleArgs holds the parameters for updating
 // one or more firewall rules.
@@ -27,6 +31,74 @@ type FirewallRule struct {
 	WhitelistCIDRS []string `json:"whitelist-cidrs,omitempty"`
 }
 
+// EgressRule is a rule for egress through a firewall.
+type EgressRule struct {
+	// Protocol is the protocol of the outgoing traffic.
+	Protocol string `json:"protocol"`
+
+	// FromPort is the start of the range of destination ports.
+	FromPort int `json:"from-port"`
+
+	// ToPort is the end of the range of destination ports.
+	ToPort int `json:"to-port"`
+
+	// DestinationCIDRs is the list of subnets to which traffic is
+	// allowed. If it is empty, traffic is allowed to any destination.
+	DestinationCIDRs []string `json:"destination-cidrs,omitempty"`
+}
+
+// FromNetworkEgressRule is a convenience helper to create a parameter
+// out of the network type, here for EgressRule.
+func FromNetworkEgressRule(r network.EgressRule) EgressRule {
+	return EgressRule{
+		Protocol:         r.Protocol,
+		FromPort:         r.FromPort,
+		ToPort:           r.ToPort,
+		DestinationCIDRs: r.DestinationCIDRs,
+	}
+}
+
+// NetworkEgressRule is a convenience helper to return the parameter
+// as network type, here for EgressRule.
+func (r EgressRule) NetworkEgressRule() network.EgressRule {
+	return network.EgressRule{
+		PortRange: network.PortRange{
+			Protocol: r.Protocol,
+			FromPort: r.FromPort,
+			ToPort:   r.ToPort,
+		},
+		DestinationCIDRs: r.DestinationCIDRs,
+	}
+}
+
+// ApplicationEgressRules holds the egress rules of an application.
+type ApplicationEgressRules struct {
+	// ApplicationTag is the tag of the application.
+	ApplicationTag string `json:"application-tag"`
+
+	// Rules holds the application's egress rules.
+	Rules []EgressRule `json:"rules"`
+}
+
+// ApplicationEgressRulesArgs holds the parameters for setting the egress
+// rules of one or more applications.
+type ApplicationEgressRulesArgs struct {
+	// Args holds the egress rules to set for each application.
+	Args []ApplicationEgressRules `json:"args"`
+}
+
+// EgressRulesResult holds the egress rules of an application, or an error.
+type EgressRulesResult struct {
+	Rules []EgressRule `json:"rules,omitempty"`
+	Error *Error       `json:"error,omitempty"`
+}
+
+// EgressRulesResults holds the results of getting the egress rules of
+// one or more applications.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
 // KnownServiceArgs holds the parameters for retrieving firewall rules.
 type KnownServiceArgs struct {
 	// KnownServices are the well known services for a firewall rule.
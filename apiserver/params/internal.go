@@ -366,6 +366,43 @@ type EntityWorkloadVersions struct {
 	Entities []EntityWorkloadVersion `json:"entities"`
 }
 
+// HookExecution describes a single run of a hook, action or commands
+// by a unit.
+type HookExecution struct {
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name,omitempty"`
+	Relation   string    `json:"relation,omitempty"`
+	RemoteUnit string    `json:"remote-unit,omitempty"`
+	Started    time.Time `json:"started"`
+	Finished   time.Time `json:"finished"`
+	ExitCode   int       `json:"exit-code"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// UnitHookExecutions holds the hook executions to record for a unit.
+type UnitHookExecutions struct {
+	Tag        string          `json:"tag"`
+	Executions []HookExecution `json:"executions"`
+}
+
+// UnitsHookExecutions holds the parameters for recording the hook
+// executions of a set of units.
+type UnitsHookExecutions struct {
+	Units []UnitHookExecutions `json:"units"`
+}
+
+// HookExecutionsResult holds the hook history of a unit, newest first,
+// or an error.
+type HookExecutionsResult struct {
+	Executions []HookExecution `json:"executions,omitempty"`
+	Error      *Error          `json:"error,omitempty"`
+}
+
+// HookExecutionsResults holds the hook histories of a set of units.
+type HookExecutionsResults struct {
+	Results []HookExecutionsResult `json:"results"`
+}
+
 // BytesResult holds the result of an API call that returns a slice
 // of bytes.
 type BytesResult struct {
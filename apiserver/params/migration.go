@@ -25,6 +25,10 @@ type InitiateMigrationArgs struct {
 type MigrationSpec struct {
 	ModelTag   string              `json:"model-tag"`
 	TargetInfo MigrationTargetInfo `json:"target-info"`
+
+	// DryRun, if true, checks whether the model could be migrated
+	// without starting a migration.
+	DryRun bool `json:"dry-run,omitempty"`
 }
 
 // MigrationTargetInfo holds the details required to connect to and
@@ -50,6 +54,41 @@ type InitiateMigrationResult struct {
 	ModelTag    string `json:"model-tag"`
 	Error       *Error `json:"error,omitempty"`
 	MigrationId string `json:"migration-id"`
+
+	// Problems holds the reasons the migration would fail, for a
+	// dry run.
+	Problems []string `json:"problems,omitempty"`
+}
+
+// CloneModelArgs holds the details required to clone one or more
+// models.
+type CloneModelArgs struct {
+	Specs []CloneModelSpec `json:"specs"`
+}
+
+// CloneModelSpec holds the details required to clone a single model
+// into a new model, on the same or another controller.
+type CloneModelSpec struct {
+	ModelTag   string              `json:"model-tag"`
+	Name       string              `json:"name"`
+	TargetInfo MigrationTargetInfo `json:"target-info"`
+
+	// TopologyOnly, if true, clones only the applications, relations
+	// and config of the model, without adding any machines or units.
+	TopologyOnly bool `json:"topology-only,omitempty"`
+}
+
+// CloneModelResults is used to return the results of one or more
+// model clone attempts.
+type CloneModelResults struct {
+	Results []CloneModelResult `json:"results"`
+}
+
+// CloneModelResult is used to return the result of one model clone
+// attempt. ModelTag is the tag of the new model.
+type CloneModelResult struct {
+	ModelTag string `json:"model-tag,omitempty"`
+	Error    *Error `json:"error,omitempty"`
 }
 
 // SetMigrationPhaseArgs provides a migration phase to the
@@ -164,6 +164,11 @@ type RelationSuspendedArg struct {
 type AddCharm struct {
 	URL     string `json:"url"`
 	Channel string `json:"channel"`
+
+	// Application optionally names the application the charm is being
+	// added to upgrade. Users granted the capability to add charms on
+	// the application may then do so without write access to the model.
+	Application string `json:"application,omitempty"`
 }
 
 // AddCharmWithAuthorization holds the arguments for making an AddCharmWithAuthorization API call.
@@ -171,6 +176,10 @@ type AddCharmWithAuthorization struct {
 	URL                string             `json:"url"`
 	Channel            string             `json:"channel"`
 	CharmStoreMacaroon *macaroon.Macaroon `json:"macaroon"`
+
+	// Application optionally names the application the charm is being
+	// added to upgrade, as for AddCharm.
+	Application string `json:"application,omitempty"`
 }
 
 // AddMachineParams encapsulates the parameters used to create a new machine.
@@ -406,6 +415,48 @@ type ApplicationUnset struct {
 	Options         []string `json:"options"`
 }
 
+// ModifyApplicationAccessRequest holds the parameters for granting and
+// revoking roles on applications.
+type ModifyApplicationAccessRequest struct {
+	Changes []ModifyApplicationAccess `json:"changes"`
+}
+
+// ModifyApplicationAccess contains parameters to grant and revoke a role
+// on an application.
+type ModifyApplicationAccess struct {
+	UserTag        string            `json:"user-tag"`
+	Action         ApplicationAction `json:"action"`
+	Role           string            `json:"role"`
+	ApplicationTag string            `json:"application-tag"`
+}
+
+// ApplicationAction is an action that can be performed on the access to
+// an application.
+type ApplicationAction string
+
+// Actions that can be performed on the access to an application.
+const (
+	GrantApplicationAccess  ApplicationAction = "grant"
+	RevokeApplicationAccess ApplicationAction = "revoke"
+)
+
+// ApplicationRole is a named set of capabilities, facade methods, that
+// may be granted to users on applications.
+type ApplicationRole struct {
+	Name         string   `json:"name"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// ApplicationRoles holds a number of application roles.
+type ApplicationRoles struct {
+	Roles []ApplicationRole `json:"roles"`
+}
+
+// ApplicationRoleNames holds the names of a number of application roles.
+type ApplicationRoleNames struct {
+	Names []string `json:"names"`
+}
+
 // ApplicationGet holds parameters for making the Get or
 // GetCharmURL calls.
 type ApplicationGet struct {
@@ -562,13 +613,14 @@ type UpdateApplicationUnits struct {
 
 // ApplicationUnitParams holds unit parameters used to update a unit.
 type ApplicationUnitParams struct {
-	ProviderId string                 `json:"provider-id"`
-	UnitTag    string                 `json:"unit-tag"`
-	Address    string                 `json:"address"`
-	Ports      []string               `json:"ports"`
-	Status     string                 `json:"status"`
-	Info       string                 `json:"info"`
-	Data       map[string]interface{} `json:"data"`
+	ProviderId       string                 `json:"provider-id"`
+	LegacyProviderId string                 `json:"legacy-provider-id,omitempty"`
+	UnitTag          string                 `json:"unit-tag"`
+	Address          string                 `json:"address"`
+	Ports            []string               `json:"ports"`
+	Status           string                 `json:"status"`
+	Info             string                 `json:"info"`
+	Data             map[string]interface{} `json:"data"`
 }
 
 // UpdateApplicationServiceArgs holds the parameters for
@@ -654,12 +706,15 @@ type Creds struct {
 // any one is valid, the authentication succeeds). If there are no
 // valid macaroons and macaroon authentication is configured,
 // the LoginResult will contain a macaroon that when
-// discharged, may allow access.
+// discharged, may allow access. If AuthTag is empty and IDToken
+// holds an OpenID Connect ID token, the user is authenticated by
+// the identity provider that issued it.
 type LoginRequest struct {
 	AuthTag     string           `json:"auth-tag"`
 	Credentials string           `json:"credentials"`
 	Nonce       string           `json:"nonce"`
 	Macaroons   []macaroon.Slice `json:"macaroons"`
+	IDToken     string           `json:"id-token,omitempty"`
 	CLIArgs     string           `json:"cli-args,omitempty"`
 	UserData    string           `json:"user-data"`
 }
@@ -898,6 +953,21 @@ type RedirectInfoResult struct {
 	CACert string `json:"ca-cert"`
 }
 
+// OIDCLoginConfig holds the details of the OpenID Connect identity
+// provider a controller accepts ID tokens from.
+type OIDCLoginConfig struct {
+	// IssuerURL holds the URL of the identity provider.
+	IssuerURL string `json:"issuer-url"`
+
+	// ClientID holds the OAuth 2.0 client ID that ID tokens
+	// must be issued to.
+	ClientID string `json:"client-id"`
+
+	// Scopes holds the scopes clients should request when
+	// obtaining an ID token.
+	Scopes []string `json:"scopes"`
+}
+
 // ReauthRequest holds a challenge/response token meaningful to the identity
 // provider.
 type ReauthRequest struct {
@@ -531,6 +531,11 @@ func (r *apiHandler) UserHasPermission(user names.UserTag, operation permission.
 	return common.HasPermission(r.state.UserPermission, user, operation, target)
 }
 
+// HasCapability returns true if the logged in user has been granted <capability> on <target>.
+func (r *apiHandler) HasCapability(capability permission.Capability, target names.Tag) (bool, error) {
+	return common.HasApplicationCapability(r.state.ApplicationUserCapabilities, r.entity.Tag(), capability, target)
+}
+
 // DescribeFacades returns the list of available Facades and their Versions
 func DescribeFacades(registry *facade.Registry) []params.FacadeVersions {
 	facades := registry.List()
@@ -147,7 +147,7 @@ func (a *Authenticator) checkCreds(
 		// When looking up model users, use a custom
 		// entity finder that looks up both the local user (if the user
 		// tag is in the local domain) and the model user.
-		entityFinder = modelUserEntityFinder{st}
+		entityFinder = modelUserEntityFinder{st: st, pool: a.statePool}
 	}
 	entity, err := authenticator.Authenticate(entityFinder, authTag, req)
 	if err != nil {
@@ -181,7 +181,8 @@ func (a *Authenticator) checkCreds(
 	return authInfo, nil
 }
 
-// LoginRequest extracts basic auth login details from an http.Request.
+// LoginRequest extracts basic auth, or bearer ID token, login details
+// from an http.Request.
 //
 // TODO(axw) we shouldn't be using params types here.
 func LoginRequest(req *http.Request) (params.LoginRequest, error) {
@@ -191,6 +192,12 @@ func LoginRequest(req *http.Request) (params.LoginRequest, error) {
 		return params.LoginRequest{Macaroons: macaroons}, nil
 	}
 	parts := strings.Fields(authHeader)
+	if len(parts) == 2 && parts[0] == "Bearer" {
+		return params.LoginRequest{
+			IDToken:   parts[1],
+			Macaroons: macaroons,
+		}, nil
+	}
 	if len(parts) != 2 || parts[0] != "Basic" {
 		// Invalid header format or no header provided.
 		return params.LoginRequest{}, errors.NotValidf("request format")
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/bakeryutil"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 )
 
@@ -53,6 +54,10 @@ type authContext struct {
 	macaroonAuthOnce   sync.Once
 	_macaroonAuth      *authentication.ExternalMacaroonAuthenticator
 	_macaroonAuthError error
+
+	// oidcAuthMutex guards the field below it.
+	oidcAuthMutex sync.Mutex
+	_oidcAuth     *authentication.OIDCAuthenticator
 }
 
 // newAuthContext creates a new authentication context for st.
@@ -134,13 +139,21 @@ type authenticator struct {
 
 // Authenticate implements authentication.EntityAuthenticator
 // by choosing the right kind of authentication for the given
-// tag.
+// tag, or for the ID token in the request if there is no tag.
 func (a authenticator) Authenticate(
 	entityFinder authentication.EntityFinder,
 	tag names.Tag,
 	req params.LoginRequest,
 ) (state.Entity, error) {
-	auth, err := a.authenticatorForTag(tag)
+	var (
+		auth authentication.EntityAuthenticator
+		err  error
+	)
+	if tag == nil && req.IDToken != "" {
+		auth, err = a.ctxt.oidcAuth()
+	} else {
+		auth, err = a.authenticatorForTag(tag)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -197,6 +210,67 @@ func (ctxt *authContext) externalMacaroonAuth() (authentication.EntityAuthentica
 	return ctxt._macaroonAuth, nil
 }
 
+// oidcAuth returns an authenticator that can authenticate logins with
+// OpenID Connect ID tokens. The OIDC settings are read from controller
+// config on every call, so that changes to them take effect without
+// restarting the API server; the authenticator, and the signing keys it
+// has fetched, are reused for as long as the settings stay the same.
+func (ctxt *authContext) oidcAuth() (authentication.EntityAuthenticator, error) {
+	controllerCfg, err := ctxt.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller config")
+	}
+	auth, err := newOIDCAuth(controllerCfg, ctxt.clock)
+	if errors.Cause(err) == errOIDCAuthNotConfigured {
+		return nil, errors.Trace(common.ErrNoCreds)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ctxt.oidcAuthMutex.Lock()
+	defer ctxt.oidcAuthMutex.Unlock()
+	if !sameOIDCAuth(ctxt._oidcAuth, auth) {
+		ctxt._oidcAuth = auth
+	}
+	return ctxt._oidcAuth, nil
+}
+
+var errOIDCAuthNotConfigured = errors.New("OIDC authentication is not configured")
+
+// newOIDCAuth returns an authenticator that can authenticate logins with
+// ID tokens issued by the OpenID Connect identity provider configured in
+// the given controller config. This is just a helper function for
+// authCtxt.oidcAuth.
+func newOIDCAuth(controllerCfg controller.Config, clock clock.Clock) (*authentication.OIDCAuthenticator, error) {
+	issuerURL := controllerCfg.OIDCIssuerURL()
+	if issuerURL == "" {
+		return nil, errOIDCAuthNotConfigured
+	}
+	return &authentication.OIDCAuthenticator{
+		IssuerURL:     issuerURL,
+		ClientID:      controllerCfg.OIDCClientID(),
+		GroupsClaim:   controllerCfg.OIDCGroupsClaim(),
+		UsernameClaim: controllerCfg.OIDCUsernameClaim(),
+		UserDomain:    controllerCfg.OIDCUserDomain(),
+		Clock:         clock,
+	}, nil
+}
+
+// sameOIDCAuth reports whether the two authenticators were created from
+// the same OIDC settings. A nil authenticator is never the same as any
+// other.
+func sameOIDCAuth(a, b *authentication.OIDCAuthenticator) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	return a.IssuerURL == b.IssuerURL &&
+		a.ClientID == b.ClientID &&
+		a.GroupsClaim == b.GroupsClaim &&
+		a.UsernameClaim == b.UsernameClaim &&
+		a.UserDomain == b.UserDomain
+}
+
 var errMacaroonAuthNotConfigured = errors.New("macaroon authentication is not configured")
 
 // newExternalMacaroonAuth returns an authenticator that can authenticate
@@ -24,7 +24,8 @@ type loginEntity interface {
 // an Entity value for model users, ensuring that the user exists in
 // the state's current model, while also supporting external users.
 type modelUserEntityFinder struct {
-	st *state.State
+	st   *state.State
+	pool *state.StatePool
 }
 
 // FindEntity implements state.EntityFinder.
@@ -0,0 +1,187 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stateauthenticator
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// GrantGroupAccess implements authentication.GroupAccessGranter by
+// applying the controller's OIDC group access rules for the groups the
+// user is a member of. The user's access to the controller, and to each
+// model named by a matching rule, is raised to the greatest access
+// granted by the matching rules. Access granted by groups the user is no
+// longer a member of is revoked, restoring the access they had before.
+// Access granted explicitly is never lowered.
+func (f modelUserEntityFinder) GrantGroupAccess(user names.UserTag, groups []string) error {
+	controllerCfg, err := f.st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	member := set.NewStrings(groups...)
+	controllerAccess := permission.NoAccess
+	modelAccess := make(map[string]permission.Access)
+	for _, rule := range controllerCfg.OIDCGroupAccess() {
+		if !member.Contains(rule.Group) {
+			continue
+		}
+		if rule.ModelUUID == "" {
+			if !controllerAccess.EqualOrGreaterControllerAccessThan(rule.Access) {
+				controllerAccess = rule.Access
+			}
+			continue
+		}
+		if current := modelAccess[rule.ModelUUID]; !current.EqualOrGreaterModelAccessThan(rule.Access) {
+			modelAccess[rule.ModelUUID] = rule.Access
+		}
+	}
+
+	grants, err := f.st.GroupAccessGrants(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	granted := make(map[string]state.GroupAccessGrant)
+	for _, grant := range grants {
+		if grant.Target.Kind() == names.ModelTagKind {
+			if _, ok := modelAccess[grant.Target.Id()]; !ok {
+				modelAccess[grant.Target.Id()] = permission.NoAccess
+			}
+		}
+		granted[grant.Target.String()] = grant
+	}
+
+	controllerGrant, hasGrant := granted[f.st.ControllerTag().String()]
+	if controllerAccess != permission.NoAccess || hasGrant {
+		err := reconcileGroupAccess(f.st, f.st, user, f.st.ControllerTag(), controllerAccess, controllerGrant, hasGrant,
+			permission.Access.GreaterControllerAccessThan,
+			func(spec state.UserAccessSpec) error {
+				_, err := f.st.AddControllerUser(spec)
+				return err
+			},
+		)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for modelUUID, access := range modelAccess {
+		grant, hasGrant := granted[names.NewModelTag(modelUUID).String()]
+		if err := f.reconcileModelGroupAccess(user, modelUUID, access, grant, hasGrant); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (f modelUserEntityFinder) reconcileModelGroupAccess(
+	user names.UserTag,
+	modelUUID string,
+	access permission.Access,
+	grant state.GroupAccessGrant,
+	hasGrant bool,
+) error {
+	st, err := f.pool.Get(modelUUID)
+	if errors.IsNotFound(err) {
+		logger.Warningf("OIDC group access refers to unknown model %q", modelUUID)
+		if hasGrant {
+			return errors.Trace(f.st.RemoveGroupAccessGrant(user, grant.Target))
+		}
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Release()
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return reconcileGroupAccess(f.st, st.State, user, model.ModelTag(), access, grant, hasGrant,
+		permission.Access.GreaterModelAccessThan,
+		func(spec state.UserAccessSpec) error {
+			_, err := model.AddUser(spec)
+			return err
+		},
+	)
+}
+
+// reconcileGroupAccess sets the user's access to the target to the access
+// granted by their groups, if that is greater than the access they would
+// have without it, and records the grant in grantSt so that it can be
+// revoked later. If their groups no longer grant them greater access, any
+// previously recorded grant is revoked, restoring the access they had
+// before it. A recorded grant whose access no longer matches the user's
+// access has been superseded by an explicit change, so is forgotten
+// without changing the user's access.
+func reconcileGroupAccess(
+	grantSt, st *state.State,
+	user names.UserTag,
+	target names.Tag,
+	access permission.Access,
+	grant state.GroupAccessGrant,
+	hasGrant bool,
+	greater func(permission.Access, permission.Access) bool,
+	add func(state.UserAccessSpec) error,
+) error {
+	current := permission.NoAccess
+	userAccess, err := st.UserAccess(user, target)
+	if err == nil {
+		current = userAccess.Access
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	recorded := hasGrant
+	if hasGrant && grant.Access != current {
+		logger.Infof("access on %s for %q changed since granted by group membership", names.ReadableString(target), user.Id())
+		hasGrant = false
+	}
+	base := current
+	if hasGrant {
+		base = grant.PreviousAccess
+	}
+
+	want := base
+	if greater(access, base) {
+		want = access
+		if !hasGrant || grant.Access != access {
+			err := grantSt.SetGroupAccessGrant(state.GroupAccessGrant{
+				User:           user,
+				Target:         target,
+				Access:         access,
+				PreviousAccess: base,
+			})
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	} else if recorded {
+		if err := grantSt.RemoveGroupAccessGrant(user, target); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if want == current {
+		return nil
+	}
+
+	switch {
+	case want == permission.NoAccess:
+		err = st.RemoveUserAccess(user, target)
+	case current == permission.NoAccess:
+		err = add(state.UserAccessSpec{
+			User:      user,
+			CreatedBy: user,
+			Access:    want,
+		})
+	default:
+		_, err = st.SetUserAccess(user, target, want)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("changed access on %s for %q from %q to %q by group membership", names.ReadableString(target), user.Id(), current, want)
+	return nil
+}
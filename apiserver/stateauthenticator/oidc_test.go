@@ -0,0 +1,252 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stateauthenticator_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication/oidctest"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/stateauthenticator"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type oidcAuthSuite struct {
+	statetesting.StateSuite
+	issuer        *oidctest.Issuer
+	authenticator *stateauthenticator.Authenticator
+}
+
+var _ = gc.Suite(&oidcAuthSuite{})
+
+func (s *oidcAuthSuite) SetUpTest(c *gc.C) {
+	s.issuer = oidctest.NewIssuer("juju")
+	s.ControllerConfig = map[string]interface{}{
+		controller.OIDCIssuerURL: s.issuer.URL,
+		controller.OIDCClientID:  "juju",
+	}
+	s.StateSuite.SetUpTest(c)
+	s.AddCleanup(func(*gc.C) { s.issuer.Close() })
+
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.OIDCGroupAccess: []interface{}{
+			"staff:login",
+			"admins:superuser",
+			"devs:write:" + s.State.ModelUUID(),
+			"ops:admin:" + s.State.ModelUUID(),
+		},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	authenticator, err := stateauthenticator.NewAuthenticator(s.StatePool, clock.WallClock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.authenticator = authenticator
+}
+
+var alice = names.NewUserTag("alice@oidc")
+
+func (s *oidcAuthSuite) login(c *gc.C, groups ...string) (names.Tag, error) {
+	token := s.issuer.IDToken("alice", groups, time.Hour)
+	authInfo, err := s.authenticator.AuthenticateLoginRequest(
+		"testing.invalid:1234",
+		s.State.ModelUUID(),
+		params.LoginRequest{IDToken: token},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return authInfo.Entity.Tag(), nil
+}
+
+func (s *oidcAuthSuite) access(c *gc.C, target names.Tag) permission.Access {
+	access, err := s.State.UserAccess(alice, target)
+	if errors.IsNotFound(err) {
+		return permission.NoAccess
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	return access.Access
+}
+
+func (s *oidcAuthSuite) TestLoginGrantsGroupAccess(c *gc.C) {
+	tag, err := s.login(c, "staff", "devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, alice)
+	c.Assert(s.access(c, s.State.ControllerTag()), gc.Equals, permission.LoginAccess)
+	c.Assert(s.access(c, s.State.ModelTag()), gc.Equals, permission.WriteAccess)
+}
+
+func (s *oidcAuthSuite) TestLoginGrantsGreatestAccess(c *gc.C) {
+	_, err := s.login(c, "staff", "admins", "devs", "ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.access(c, s.State.ControllerTag()), gc.Equals, permission.SuperuserAccess)
+	c.Assert(s.access(c, s.State.ModelTag()), gc.Equals, permission.AdminAccess)
+}
+
+func (s *oidcAuthSuite) TestLoginUpdatesGroupAccess(c *gc.C) {
+	_, err := s.login(c, "admins", "ops")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.login(c, "staff", "devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.access(c, s.State.ControllerTag()), gc.Equals, permission.LoginAccess)
+	c.Assert(s.access(c, s.State.ModelTag()), gc.Equals, permission.WriteAccess)
+}
+
+func (s *oidcAuthSuite) TestLoginRevokesGroupAccess(c *gc.C) {
+	_, err := s.login(c, "staff", "devs")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.login(c, "staff")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.access(c, s.State.ControllerTag()), gc.Equals, permission.LoginAccess)
+	c.Assert(s.access(c, s.State.ModelTag()), gc.Equals, permission.NoAccess)
+
+	// Once no group grants access to the controller, the user can no
+	// longer log in.
+	_, err = s.login(c)
+	c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+	c.Assert(s.access(c, s.State.ControllerTag()), gc.Equals, permission.NoAccess)
+
+	grants, err := s.State.GroupAccessGrants(alice)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, gc.HasLen, 0)
+}
+
+func (s *oidcAuthSuite) TestLoginDoesNotLowerExplicitAccess(c *gc.C) {
+	_, err := s.State.AddControllerUser(state.UserAccessSpec{
+		User:      alice,
+		CreatedBy: s.Owner,
+		Access:    permission.SuperuserAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Model.AddUser(state.UserAccessSpec{
+		User:      alice,
+		CreatedBy: s.Owner,
+		Access:    permission.AdminAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.login(c, "staff", "devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.access(c, s.State.ControllerTag()), gc.Equals, permission.SuperuserAccess)
+	c.Assert(s.access(c, s.State.ModelTag()), gc.Equals, permission.AdminAccess)
+	grants, err := s.State.GroupAccessGrants(alice)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, gc.HasLen, 0)
+}
+
+func (s *oidcAuthSuite) TestLoginRestoresExplicitAccess(c *gc.C) {
+	_, err := s.State.AddControllerUser(state.UserAccessSpec{
+		User:      alice,
+		CreatedBy: s.Owner,
+		Access:    permission.LoginAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Model.AddUser(state.UserAccessSpec{
+		User:      alice,
+		CreatedBy: s.Owner,
+		Access:    permission.ReadAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.login(c, "admins", "ops")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.access(c, s.State.ControllerTag()), gc.Equals, permission.SuperuserAccess)
+	c.Assert(s.access(c, s.State.ModelTag()), gc.Equals, permission.AdminAccess)
+	grants, err := s.State.GroupAccessGrants(alice)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, jc.DeepEquals, []state.GroupAccessGrant{{
+		User:           alice,
+		Target:         s.State.ControllerTag(),
+		Access:         permission.SuperuserAccess,
+		PreviousAccess: permission.LoginAccess,
+	}, {
+		User:           alice,
+		Target:         s.State.ModelTag(),
+		Access:         permission.AdminAccess,
+		PreviousAccess: permission.ReadAccess,
+	}})
+
+	_, err = s.login(c, "staff")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.access(c, s.State.ControllerTag()), gc.Equals, permission.LoginAccess)
+	c.Assert(s.access(c, s.State.ModelTag()), gc.Equals, permission.ReadAccess)
+}
+
+func (s *oidcAuthSuite) TestLoginKeepsExplicitChangeToGroupAccess(c *gc.C) {
+	_, err := s.login(c, "staff", "devs")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An explicit change supersedes the group access, so it is kept
+	// when the user leaves the group.
+	_, err = s.State.SetUserAccess(alice, s.State.ModelTag(), permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.login(c, "staff")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.access(c, s.State.ModelTag()), gc.Equals, permission.AdminAccess)
+}
+
+func (s *oidcAuthSuite) TestLoginWithoutGroupAccess(c *gc.C) {
+	_, err := s.login(c, "visitors")
+	c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+	c.Assert(errors.IsUnauthorized(err), jc.IsTrue)
+}
+
+func (s *oidcAuthSuite) TestLoginInvalidToken(c *gc.C) {
+	_, err := s.authenticator.AuthenticateLoginRequest(
+		"testing.invalid:1234",
+		s.State.ModelUUID(),
+		params.LoginRequest{IDToken: "not-a-token"},
+	)
+	c.Assert(err, gc.ErrorMatches, "invalid ID token: .*")
+	c.Assert(errors.IsUnauthorized(err), jc.IsTrue)
+}
+
+func (s *oidcAuthSuite) TestLoginFollowsConfigChanges(c *gc.C) {
+	tag, err := s.login(c, "staff")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, alice)
+
+	// Changing the OIDC settings takes effect without a new
+	// authenticator being created.
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.OIDCUserDomain: "sso",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	tag, err = s.login(c, "staff")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, names.NewUserTag("alice@sso"))
+
+	err = s.State.UpdateControllerConfig(nil, []string{
+		controller.OIDCIssuerURL,
+		controller.OIDCClientID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.login(c, "staff")
+	c.Assert(err, gc.ErrorMatches, "no credentials provided")
+}
+
+type oidcNotConfiguredSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&oidcNotConfiguredSuite{})
+
+func (s *oidcNotConfiguredSuite) TestLoginWithIDToken(c *gc.C) {
+	authenticator, err := stateauthenticator.NewAuthenticator(s.StatePool, clock.WallClock)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = authenticator.AuthenticateLoginRequest(
+		"testing.invalid:1234",
+		s.State.ModelUUID(),
+		params.LoginRequest{IDToken: "token"},
+	)
+	c.Assert(err, gc.ErrorMatches, "no credentials provided")
+}
@@ -118,6 +118,32 @@ func nameBasedHasPermission(name string, operation permission.Access, target nam
 	return operation == perm && targetTag.String() == target.String()
 }
 
+// HasCapability returns true if the logged in user's name is the
+// application access level whose capabilities include the given one,
+// followed by the target application tag.
+func (fa FakeAuthorizer) HasCapability(capability permission.Capability, target names.Tag) (bool, error) {
+	ut, ok := fa.Tag.(names.UserTag)
+	if !ok {
+		return false, nil
+	}
+	for _, access := range []permission.Access{
+		permission.ConfigAccess,
+		permission.RunActionAccess,
+		permission.UpgradeCharmAccess,
+	} {
+		prefix := string(access) + "-"
+		if !strings.HasPrefix(ut.Name(), prefix) || ut.Name()[len(prefix):] != target.String() {
+			continue
+		}
+		for _, c := range permission.ApplicationCapabilities(access) {
+			if c == capability {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 // ConnectedModel returns the UUID of the model the current client is
 // connected to.
 func (fa FakeAuthorizer) ConnectedModel() string {
@@ -103,6 +103,8 @@ type Broker interface {
 	DeleteOperator(appName string) error
 
 	// EnsureService creates or updates a service for pods with the given params.
+	// If the params include filesystems, each unit is given a stable identity
+	// and its own volumes, which are retained when its pod is rescheduled.
 	EnsureService(appName string, params *ServiceParams, numUnits int, config application.ConfigAttributes) error
 
 	// Service returns the service for the specified application.
@@ -148,6 +150,10 @@ type Unit struct {
 	Ports   []string
 	Dying   bool
 	Status  status.StatusInfo
+
+	// LegacyId, if set, is the id by which earlier versions of
+	// the provider identified the unit.
+	LegacyId string
 }
 
 // OperatorConfig is the config to use when creating an operator.
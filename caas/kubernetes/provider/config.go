@@ -27,6 +27,7 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+	ingressTLSSecretKey      = "kubernetes-ingress-tls-secret"
 )
 
 var configFields = environschema.Fields{
@@ -80,6 +81,11 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	ingressTLSSecretKey: {
+		Description: "the name of the secret holding the TLS certificate and key used by the ingress resource",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
@@ -414,6 +414,12 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteService(appName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deleteHeadlessService(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteIngress(appName); err != nil {
+		return errors.Trace(err)
+	}
 	if err := k.deleteStatefulSet(appName); err != nil {
 		return errors.Trace(err)
 	}
@@ -432,7 +438,7 @@ func (k *kubernetesClient) EnsureService(
 	if params == nil || params.PodSpec == nil {
 		return errors.Errorf("missing pod spec")
 	}
-	if params.PodSpec.OmitServiceFrontend && len(params.Filesystems) == 0 {
+	if params.PodSpec.OmitServiceFrontend && len(params.Filesystems) > 0 {
 		return errors.Errorf("kubernetes service is required when using storage")
 	}
 
@@ -452,12 +458,18 @@ func (k *kubernetesClient) EnsureService(
 	}
 
 	// Add a deployment controller configured to create the specified number of units/pods.
+	// Applications with storage use a StatefulSet so that each unit keeps
+	// its pod identity and volumes when the pod is rescheduled.
 	numPods := int32(numUnits)
 	if len(params.Filesystems) > 0 {
+		if err := k.configureHeadlessService(appName); err != nil {
+			return errors.Annotatef(err, "creating or updating headless service for %v", appName)
+		}
+		cleanups = append(cleanups, func() { k.deleteHeadlessService(appName) })
 		if err := k.configureStatefulSet(appName, unitSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
 			return errors.Annotate(err, "creating or updating StatefulSet")
 		}
-		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
+		cleanups = append(cleanups, func() { k.deleteStatefulSet(appName) })
 	} else {
 		if err := k.configureDeployment(appName, unitSpec, params.PodSpec.Containers, &numPods); err != nil {
 			return errors.Annotate(err, "creating or updating DeploymentController")
@@ -627,7 +639,8 @@ func (k *kubernetesClient) configureStatefulSet(
 			Name:   deploymentName(appName),
 			Labels: map[string]string{labelApplication: appName}},
 		Spec: apps.StatefulSetSpec{
-			Replicas: replicas,
+			Replicas:    replicas,
+			ServiceName: headlessServiceName(appName),
 			Selector: &v1.LabelSelector{
 				MatchLabels: map[string]string{labelApplication: appName},
 			},
@@ -652,10 +665,20 @@ func (k *kubernetesClient) configureStatefulSet(
 
 func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet) error {
 	statefulsets := k.AppsV1().StatefulSets(k.namespace)
-	_, err := statefulsets.Update(spec)
+	existing, err := statefulsets.Get(spec.Name, v1.GetOptions{})
 	if k8serrors.IsNotFound(err) {
 		_, err = statefulsets.Create(spec)
+		return errors.Trace(err)
 	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Only the replicas, pod template and update strategy of a
+	// StatefulSet may be changed; in particular the volume claim
+	// templates are immutable, so existing volumes are kept.
+	existing.Spec.Replicas = spec.Spec.Replicas
+	existing.Spec.Template = spec.Spec.Template
+	_, err = statefulsets.Update(existing)
 	return errors.Trace(err)
 }
 
@@ -670,6 +693,35 @@ func (k *kubernetesClient) deleteStatefulSet(appName string) error {
 	return errors.Trace(err)
 }
 
+// configureHeadlessService creates or updates the headless service which
+// governs the network identity of the pods in an application's StatefulSet.
+func (k *kubernetesClient) configureHeadlessService(appName string) error {
+	logger.Debugf("creating/updating headless service for %s", appName)
+	service := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   headlessServiceName(appName),
+			Labels: map[string]string{labelApplication: appName}},
+		Spec: core.ServiceSpec{
+			Selector:                 map[string]string{labelApplication: appName},
+			Type:                     core.ServiceTypeClusterIP,
+			ClusterIP:                "None",
+			PublishNotReadyAddresses: true,
+		},
+	}
+	return k.ensureService(service)
+}
+
+func (k *kubernetesClient) deleteHeadlessService(appName string) error {
+	services := k.CoreV1().Services(k.namespace)
+	err := services.Delete(headlessServiceName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func (k *kubernetesClient) configureService(appName string, containerPorts []core.ContainerPort, config application.ConfigAttributes) error {
 	logger.Debugf("creating/updating service for %s", appName)
 
@@ -746,6 +798,7 @@ func (k *kubernetesClient) ExposeService(appName string, config application.Conf
 	ingressSSLRedirect := config.GetBool(ingressSSLRedirectKey, defaultIngressSSLRedirect)
 	ingressSSLPassthrough := config.GetBool(ingressSSLPassthroughKey, defaultIngressSSLPassthrough)
 	ingressAllowHTTP := config.GetBool(ingressAllowHTTPKey, defaultIngressAllowHTTPKey)
+	tlsSecret := config.GetString(ingressTLSSecretKey, "")
 	httpPath := config.GetString(caas.JujuApplicationPath, caas.JujuDefaultApplicationPath)
 	if httpPath == "$appname" {
 		httpPath = appName
@@ -781,11 +834,17 @@ func (k *kubernetesClient) ExposeService(appName string, config application.Conf
 						Paths: []v1beta1.HTTPIngressPath{{
 							Path: httpPath,
 							Backend: v1beta1.IngressBackend{
-								ServiceName: svc.Name, ServicePort: svc.Spec.Ports[0].TargetPort},
+								ServiceName: svc.Name, ServicePort: intstr.FromInt(int(svc.Spec.Ports[0].Port))},
 						}}},
 				}}},
 		},
 	}
+	if tlsSecret != "" {
+		spec.Spec.TLS = []v1beta1.IngressTLS{{
+			Hosts:      []string{host},
+			SecretName: tlsSecret,
+		}}
+	}
 	return k.ensureIngress(spec)
 }
 
@@ -857,7 +916,7 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 		}
 		terminated := p.DeletionTimestamp != nil
 		unitInfo := caas.Unit{
-			Id:      string(p.UID),
+			Id:      providerId(&p),
 			Address: p.Status.PodIP,
 			Ports:   ports,
 			Dying:   terminated,
@@ -867,6 +926,11 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 				Since:   &now,
 			},
 		}
+		if unitInfo.Id != string(p.UID) {
+			// Earlier versions identified all pods by their UID, so
+			// existing units may still be recorded with it.
+			unitInfo.LegacyId = string(p.UID)
+		}
 		// If the pod is a Juju unit label, it was created directly
 		// by Juju an we can extract the unit tag to include on the result.
 		unitLabel := p.Labels[labelUnit]
@@ -881,6 +945,19 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 	return result, nil
 }
 
+// providerId returns the id used to associate the pod with a unit.
+// Pods managed by a StatefulSet are recreated with the same name when
+// they are rescheduled, so the name is used to keep the association;
+// other pods are identified by their UID.
+func providerId(pod *core.Pod) string {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "StatefulSet" {
+			return pod.Name
+		}
+	}
+	return string(pod.UID)
+}
+
 func (k *kubernetesClient) jujuStatus(podPhase core.PodPhase, terminated bool) status.Status {
 	if terminated {
 		return status.Terminated
@@ -1176,6 +1253,10 @@ func deploymentName(appName string) string {
 	return "juju-" + appName
 }
 
+func headlessServiceName(appName string) string {
+	return deploymentName(appName) + "-endpoints"
+}
+
 func resourceNamePrefix(appName string) string {
 	return "juju-" + names.NewApplicationTag(appName).String() + "-"
 }
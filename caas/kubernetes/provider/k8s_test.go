@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -161,6 +162,10 @@ func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
 	gomock.InOrder(
 		s.mockServices.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Delete("juju-test-endpoints", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
@@ -238,11 +243,18 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
-func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
-	ctrl := s.setupBroker(c)
-	defer ctrl.Finish()
+var storageServiceParams = &caas.ServiceParams{
+	PodSpec: basicPodspec,
+	Filesystems: []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("test/0/0"),
+		Size: 100,
+		Attachment: &storage.FilesystemAttachmentParams{
+			Path: "path/to/here",
+		},
+	}},
+}
 
-	numUnits := int32(2)
+func (s *K8sBrokerSuite) storageStatefulSet(c *gc.C, numUnits int32) *appsv1.StatefulSet {
 	unitSpec, err := provider.MakeUnitSpec(basicPodspec)
 	c.Assert(err, jc.ErrorIsNil)
 	podSpec := provider.PodSpec(unitSpec)
@@ -252,12 +264,13 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 	}}
 
 	scName := "sc"
-	statefulSetArg := &appsv1.StatefulSet{
+	return &appsv1.StatefulSet{
 		ObjectMeta: v1.ObjectMeta{
 			Name:   "juju-test",
 			Labels: map[string]string{"juju-application": "test"}},
 		Spec: appsv1.StatefulSetSpec{
-			Replicas: &numUnits,
+			Replicas:    &numUnits,
+			ServiceName: "juju-test-endpoints",
 			Selector: &v1.LabelSelector{
 				MatchLabels: map[string]string{"juju-application": "test"},
 			},
@@ -283,6 +296,25 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			}},
 		},
 	}
+}
+
+var headlessServiceArg = &core.Service{
+	ObjectMeta: v1.ObjectMeta{
+		Name:   "juju-test-endpoints",
+		Labels: map[string]string{"juju-application": "test"}},
+	Spec: core.ServiceSpec{
+		Selector:                 map[string]string{"juju-application": "test"},
+		Type:                     core.ServiceTypeClusterIP,
+		ClusterIP:                "None",
+		PublishNotReadyAddresses: true,
+	},
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	statefulSetArg := s.storageStatefulSet(c, 2)
 	serviceArg := &core.Service{
 		ObjectMeta: v1.ObjectMeta{
 			Name:   "juju-test",
@@ -300,11 +332,17 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 	}
 
 	gomock.InOrder(
+		s.mockServices.EXPECT().Get("juju-test-endpoints", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(headlessServiceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(headlessServiceArg).Times(1).
+			Return(nil, nil),
 		s.mockPersistentVolumeClaims.EXPECT().Get("fsvolume-0", v1.GetOptions{}).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStorageClass.EXPECT().List(v1.ListOptions{LabelSelector: "juju-storage in (test-unit-storage, test, default)"}).
 			Return(&storagev1.StorageClassList{Items: []storagev1.StorageClass{{ObjectMeta: v1.ObjectMeta{Name: "sc"}}}}, nil),
-		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
@@ -316,20 +354,168 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(nil, nil),
 	)
 
-	params := &caas.ServiceParams{
-		PodSpec: basicPodspec,
-		Filesystems: []storage.FilesystemParams{{
-			Tag:  names.NewFilesystemTag("test/0/0"),
-			Size: 100,
-			Attachment: &storage.FilesystemAttachmentParams{
-				Path: "path/to/here",
-			},
-		}},
-	}
-	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
+	err := s.broker.EnsureService("test", storageServiceParams, 2, application.ConfigAttributes{
 		"kubernetes-service-type":            "nodeIP",
 		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
 		"kubernetes-service-externalname":    "ext-name",
 	})
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithStorageScalesExisting(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	// The existing StatefulSet was created with a different volume claim;
+	// only the replicas and pod template are updated.
+	existing := s.storageStatefulSet(c, 2)
+	existing.ResourceVersion = "42"
+	existing.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[core.ResourceStorage] = resource.MustParse("50Mi")
+	expected := s.storageStatefulSet(c, 3)
+	expected.ResourceVersion = "42"
+	expected.Spec.VolumeClaimTemplates = existing.Spec.VolumeClaimTemplates
+
+	gomock.InOrder(
+		s.mockServices.EXPECT().Get("juju-test-endpoints", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(headlessServiceArg, nil),
+		s.mockServices.EXPECT().Update(headlessServiceArg).Times(1).
+			Return(nil, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get("fsvolume-0", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStorageClass.EXPECT().List(v1.ListOptions{LabelSelector: "juju-storage in (test-unit-storage, test, default)"}).
+			Return(&storagev1.StorageClassList{Items: []storagev1.StorageClass{{ObjectMeta: v1.ObjectMeta{Name: "sc"}}}}, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(existing, nil),
+		s.mockStatefulSets.EXPECT().Update(expected).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any()).Times(1).
+			Return(nil, nil),
+	)
+
+	err := s.broker.EnsureService("test", storageServiceParams, 3, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithStorageRequiresService(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	params := *storageServiceParams
+	podSpec := *basicPodspec
+	podSpec.OmitServiceFrontend = true
+	params.PodSpec = &podSpec
+	err := s.broker.EnsureService("test", &params, 2, nil)
+	c.Assert(err, gc.ErrorMatches, "kubernetes service is required when using storage")
+}
+
+func (s *K8sBrokerSuite) TestUnitsStatefulSetIdentity(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-application==test"}).Times(1).
+		Return(&core.PodList{Items: []core.Pod{{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "juju-test-0",
+				UID:  "uid-0",
+				OwnerReferences: []v1.OwnerReference{{
+					Kind: "StatefulSet",
+					Name: "juju-test",
+				}},
+			},
+			Status: core.PodStatus{Phase: core.PodRunning, PodIP: "10.0.0.1"},
+		}, {
+			ObjectMeta: v1.ObjectMeta{
+				Name: "juju-test-deadbeef",
+				UID:  "uid-1",
+			},
+			Status: core.PodStatus{Phase: core.PodPending},
+		}}}, nil)
+
+	units, err := s.broker.Units("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	c.Assert(units[0].Id, gc.Equals, "juju-test-0")
+	c.Assert(units[0].LegacyId, gc.Equals, "uid-0")
+	c.Assert(units[0].Address, gc.Equals, "10.0.0.1")
+	c.Assert(units[1].Id, gc.Equals, "uid-1")
+	c.Assert(units[1].LegacyId, gc.Equals, "")
+}
+
+func (s *K8sBrokerSuite) TestExposeService(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-test"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080), Protocol: "TCP"}},
+		},
+	}
+	ingressArg := &extensionsv1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"},
+			Annotations: map[string]string{
+				"ingress.kubernetes.io/rewrite-target":  "",
+				"ingress.kubernetes.io/ssl-redirect":    "true",
+				"kubernetes.io/ingress.class":           "nginx",
+				"kubernetes.io/ingress.allow-http":      "false",
+				"ingress.kubernetes.io/ssl-passthrough": "false",
+			},
+		},
+		Spec: extensionsv1beta1.IngressSpec{
+			TLS: []extensionsv1beta1.IngressTLS{{
+				Hosts:      []string{"example.com"},
+				SecretName: "example-tls",
+			}},
+			Rules: []extensionsv1beta1.IngressRule{{
+				Host: "example.com",
+				IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+					HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+						Paths: []extensionsv1beta1.HTTPIngressPath{{
+							Path: "/test",
+							Backend: extensionsv1beta1.IngressBackend{
+								ServiceName: "juju-test", ServicePort: intstr.FromInt(80)},
+						}}},
+				}}},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(svc, nil),
+		s.mockIngressInterface.EXPECT().Update(ingressArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Create(ingressArg).Times(1).
+			Return(nil, nil),
+	)
+
+	err := s.broker.ExposeService("test", application.ConfigAttributes{
+		"juju-external-hostname":          "example.com",
+		"juju-application-path":           "$appname",
+		"kubernetes-ingress-ssl-redirect": true,
+		"kubernetes-ingress-tls-secret":   "example-tls",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceNoHostname(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	err := s.broker.ExposeService("test", application.ConfigAttributes{})
+	c.Assert(err, gc.ErrorMatches, "external hostname required")
+}
+
+func (s *K8sBrokerSuite) TestUnexposeService(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockIngressInterface.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+		Return(nil)
+
+	err := s.broker.UnexposeService("test")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/watcher"
 )
 
 // type APIClient represents the action API functionality.
@@ -49,6 +50,10 @@ type APIClient interface {
 	// the ActionReceiver if necessary.
 	Actions(params.Entities) (params.ActionResults, error)
 
+	// ActionOutputs fetches actions by tag, including any output that
+	// was too large to be returned by Actions.
+	ActionOutputs(params.Entities) (params.ActionResults, error)
+
 	// FindActionTagsByPrefix takes a list of string prefixes and finds
 	// corresponding ActionTags that match that prefix.
 	FindActionTagsByPrefix(params.FindTags) (params.FindTagsResults, error)
@@ -56,6 +61,28 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// EnqueueApplicationActions takes a list of ApplicationActions and
+	// queues each up to be executed by all the units of its application,
+	// returning the aggregate result for each.
+	EnqueueApplicationActions(params.ApplicationActions) (params.ActionBatchResults, error)
+
+	// ActionBatches returns the aggregate results of the action batches
+	// with the given ids.
+	ActionBatches(params.ActionBatchIds) (params.ActionBatchResults, error)
+
+	// WatchActionProgress reports on logged action progress messages.
+	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
+
+	// AddActionSchedules adds schedules that periodically enqueue
+	// actions on their receivers.
+	AddActionSchedules(params.ActionSchedules) (params.ErrorResults, error)
+
+	// ActionSchedules returns all the action schedules in the model.
+	ActionSchedules() (params.ActionScheduleResults, error)
+
+	// RemoveActionSchedules removes the named action schedules.
+	RemoveActionSchedules(params.ActionScheduleNames) (params.ErrorResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
@@ -37,6 +37,18 @@ func (c *RunCommand) UnitTags() []names.UnitTag {
 	return c.unitTags
 }
 
+func (c *RunCommand) Application() string {
+	return c.application
+}
+
+func (c *RunCommand) MaxConcurrency() int {
+	return c.maxConcurrency
+}
+
+func (c *RunCommand) StopOnFailures() int {
+	return c.stopOnFailures
+}
+
 func (c *RunCommand) ActionName() string {
 	return c.actionName
 }
@@ -98,3 +110,21 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
+
+func NewAddScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &addScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/watcher/watchertest"
 )
 
 const (
@@ -126,11 +128,19 @@ type fakeAPIClient struct {
 	delay              *time.Timer
 	timeout            *time.Timer
 	actionResults      []params.ActionResult
+	actionOutputs      []params.ActionResult
 	enqueuedActions    params.Actions
 	actionsByReceivers []params.ActionsByReceiver
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	progressChanges    chan []string
+	batchResults       []params.ActionBatchResult
+	enqueuedAppActions params.ApplicationActions
+	addedSchedules     params.ActionSchedules
+	removedSchedules   params.ActionScheduleNames
+	schedules          []params.ActionSchedule
+	scheduleErrors     []params.ErrorResult
 	apiErr             error
 }
 
@@ -206,6 +216,24 @@ func (c *fakeAPIClient) Actions(args params.Entities) (params.ActionResults, err
 	}
 }
 
+func (c *fakeAPIClient) ActionOutputs(args params.Entities) (params.ActionResults, error) {
+	return params.ActionResults{Results: c.actionOutputs}, c.apiErr
+}
+
+func (c *fakeAPIClient) AddActionSchedules(args params.ActionSchedules) (params.ErrorResults, error) {
+	c.addedSchedules = args
+	return params.ErrorResults{Results: c.scheduleErrors}, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionSchedules() (params.ActionScheduleResults, error) {
+	return params.ActionScheduleResults{Results: c.schedules}, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveActionSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	c.removedSchedules = args
+	return params.ErrorResults{Results: c.scheduleErrors}, c.apiErr
+}
+
 func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsResults, error) {
 	return c.actionTagMatches, c.apiErr
 }
@@ -213,3 +241,19 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) WatchActionProgress(actionId string) (watcher.StringsWatcher, error) {
+	if c.apiErr != nil {
+		return nil, c.apiErr
+	}
+	return watchertest.NewMockStringsWatcher(c.progressChanges), nil
+}
+
+func (c *fakeAPIClient) EnqueueApplicationActions(args params.ApplicationActions) (params.ActionBatchResults, error) {
+	c.enqueuedAppActions = args
+	return params.ActionBatchResults{Results: c.batchResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ActionBatches(args params.ActionBatchIds) (params.ActionBatchResults, error) {
+	return params.ActionBatchResults{Results: c.batchResults}, c.apiErr
+}
@@ -23,6 +23,10 @@ import (
 // nameRule describes the name format of an action or keyName must match to be valid.
 var nameRule = charm.GetActionNameRule()
 
+// allUnitsSuffix is appended to an application name to run an action
+// on all of the application's units.
+const allUnitsSuffix = "/all"
+
 func NewRunCommand() cmd.Command {
 	return modelcmd.Wrap(&runCommand{})
 }
@@ -31,13 +35,16 @@ func NewRunCommand() cmd.Command {
 // params
 type runCommand struct {
 	ActionCommandBase
-	unitTags     []names.UnitTag
-	actionName   string
-	paramsYAML   cmd.FileVar
-	parseStrings bool
-	wait         waitFlag
-	out          cmd.Output
-	args         [][]string
+	unitTags       []names.UnitTag
+	application    string
+	maxConcurrency int
+	stopOnFailures int
+	actionName     string
+	paramsYAML     cmd.FileVar
+	parseStrings   bool
+	wait           waitFlag
+	out            cmd.Output
+	args           [][]string
 }
 
 const runDoc = `
@@ -57,6 +64,13 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+To run an action on all the units of an application, use <application>/all
+in place of the unit names.  The Juju controller then releases the action to
+at most --max-concurrency units at a time, and cancels the actions not yet
+released once --stop-on-failures actions have failed.  An aggregate result
+for the application is displayed; its ID can be used with --wait to follow
+the actions until they are all finished.
+
 Examples:
 
 $ juju run-action mysql/3 backup --wait
@@ -114,6 +128,11 @@ file:
 $ juju run-action sleeper/0 pause time=1000
 ...
 
+$ juju run-action mysql/all backup --max-concurrency 2 --stop-on-failures 1 --wait
+...
+The backup action is run on two units at a time, and no further units are
+started once one has failed.
+
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
@@ -126,44 +145,73 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.IntVar(&c.maxConcurrency, "max-concurrency", 0, "Maximum number of units running the action at once, for <application>/all (0 for no limit)")
+	f.IntVar(&c.stopOnFailures, "stop-on-failures", 0, "Stop releasing the action to units after this many failures, for <application>/all (0 to never stop)")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> [<unit> ...] <action name> [key.key.key...=value]",
+		Args:    "(<unit> [<unit> ...] | <application>/all) <action name> [key.key.key...=value]",
 		Purpose: "Queue an action for execution.",
 		Doc:     runDoc,
 	}
 }
 
-// Init gets the unit tag(s), action name and action arguments.
+// Init gets the unit tag(s) or application, action name and action arguments.
 func (c *runCommand) Init(args []string) error {
 	var unitNames []string
-	for idx, arg := range args {
-		if names.IsValidUnit(arg) {
-			unitNames = args[:idx+1]
-		} else if nameRule.MatchString(arg) {
-			c.actionName = arg
-			break
-		} else {
-			return errors.Errorf("invalid unit or action name %q", arg)
+	targets := 0
+	if len(args) > 0 && strings.HasSuffix(args[0], allUnitsSuffix) {
+		c.application = strings.TrimSuffix(args[0], allUnitsSuffix)
+		if !names.IsValidApplication(c.application) {
+			return errors.Errorf("invalid application name %q", c.application)
 		}
-	}
-	if len(unitNames) == 0 {
-		return errors.New("no unit specified")
+		targets = 1
+		if len(args) > 1 {
+			if !nameRule.MatchString(args[1]) {
+				return errors.Errorf("invalid action name %q", args[1])
+			}
+			c.actionName = args[1]
+		}
+	} else {
+		for idx, arg := range args {
+			if names.IsValidUnit(arg) {
+				unitNames = args[:idx+1]
+			} else if nameRule.MatchString(arg) {
+				c.actionName = arg
+				break
+			} else {
+				return errors.Errorf("invalid unit or action name %q", arg)
+			}
+		}
+		if len(unitNames) == 0 {
+			return errors.New("no unit specified")
+		}
+		targets = len(unitNames)
 	}
 	if c.actionName == "" {
 		return errors.New("no action specified")
 	}
-	c.unitTags = make([]names.UnitTag, len(unitNames))
-	for idx, unitName := range unitNames {
-		c.unitTags[idx] = names.NewUnitTag(unitName)
+	if c.application == "" && (c.maxConcurrency != 0 || c.stopOnFailures != 0) {
+		return errors.New("--max-concurrency and --stop-on-failures require an <application>/all target")
+	}
+	if c.maxConcurrency < 0 {
+		return errors.Errorf("--max-concurrency must not be negative")
+	}
+	if c.stopOnFailures < 0 {
+		return errors.Errorf("--stop-on-failures must not be negative")
+	}
+	if len(unitNames) > 0 {
+		c.unitTags = make([]names.UnitTag, len(unitNames))
+		for idx, unitName := range unitNames {
+			c.unitTags[idx] = names.NewUnitTag(unitName)
+		}
 	}
 
 	// Parse CLI key-value args if they exist.
 	c.args = make([][]string, 0)
-	for _, arg := range args[len(unitNames)+1:] {
+	for _, arg := range args[targets+1:] {
 		thisArg := strings.SplitN(arg, "=", 2)
 		if len(thisArg) != 2 {
 			return errors.Errorf("argument %q must be of the form key...=value", arg)
@@ -241,6 +289,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.application != "" {
+		return c.runApplicationAction(ctx, api, actionParams)
+	}
+
 	actions := make([]params.Action, len(c.unitTags))
 	for i, unitTag := range c.unitTags {
 		actions[i].Receiver = unitTag.String()
@@ -328,3 +380,125 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	return c.out.Write(ctx, output)
 }
+
+// runApplicationAction queues the action on all the units of the
+// application, and writes the aggregate result.
+func (c *runCommand) runApplicationAction(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	results, err := api.EnqueueApplicationActions(params.ApplicationActions{
+		Actions: []params.ApplicationAction{{
+			ApplicationTag: names.NewApplicationTag(c.application).String(),
+			Name:           c.actionName,
+			Parameters:     actionParams,
+			MaxConcurrency: c.maxConcurrency,
+			StopOnFailures: c.stopOnFailures,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// Immediate return, showing the queued action ids.
+	if !c.wait.forever && c.wait.d.Nanoseconds() <= 0 {
+		output, err := formatActionBatchResult(result, false)
+		if err != nil {
+			return err
+		}
+		return c.out.Write(ctx, output)
+	}
+
+	var wait *time.Timer
+	if c.wait.d.Nanoseconds() <= 0 {
+		// Indefinite wait. Discard the tick.
+		wait = time.NewTimer(0 * time.Second)
+		_ = <-wait.C
+	} else {
+		wait = time.NewTimer(c.wait.d)
+	}
+	result, err = getActionBatchResult(api, result.Id, wait)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output, err := formatActionBatchResult(result, true)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, output)
+}
+
+// getActionBatchResult repeatedly fetches the aggregate result of an
+// action batch until it is no longer running, or until "wait" times out.
+func getActionBatchResult(api APIClient, id string, wait *time.Timer) (params.ActionBatchResult, error) {
+	tick := time.NewTimer(2 * time.Second)
+	for {
+		results, err := api.ActionBatches(params.ActionBatchIds{Ids: []string{id}})
+		if err != nil {
+			return params.ActionBatchResult{}, err
+		}
+		if len(results.Results) != 1 {
+			return params.ActionBatchResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+		}
+		result := results.Results[0]
+		if result.Error != nil {
+			return params.ActionBatchResult{}, result.Error
+		}
+		if result.Status != params.ActionRunning {
+			return result, nil
+		}
+
+		// Block until a tick happens, or the timeout arrives.
+		select {
+		case _ = <-wait.C:
+			return result, nil
+		case _ = <-tick.C:
+			tick.Reset(2 * time.Second)
+		}
+	}
+}
+
+// formatActionBatchResult converts the aggregate result of an action
+// batch into a map for cmd.Output. Each unit's action id is included,
+// along with its full result when withResults is true.
+func formatActionBatchResult(result params.ActionBatchResult, withResults bool) (map[string]interface{}, error) {
+	appTag, err := names.ParseApplicationTag(result.ApplicationTag)
+	if err != nil {
+		return nil, err
+	}
+	units := make(map[string]interface{}, len(result.Actions))
+	for _, actionResult := range result.Actions {
+		if actionResult.Action == nil {
+			continue
+		}
+		actionTag, err := names.ParseActionTag(actionResult.Action.Tag)
+		if err != nil {
+			return nil, err
+		}
+		unitTag, err := names.ParseUnitTag(actionResult.Action.Receiver)
+		if err != nil {
+			return nil, err
+		}
+		if !withResults {
+			units[unitTag.Id()] = actionTag.Id()
+			continue
+		}
+		d := FormatActionResult(actionResult)
+		d["id"] = actionTag.Id()
+		units[unitTag.Id()] = d
+	}
+	output := map[string]interface{}{
+		"id":          result.Id,
+		"application": appTag.Id(),
+		"status":      result.Status,
+		"actions":     units,
+	}
+	if len(result.Summary) > 0 {
+		output["summary"] = result.Summary
+	}
+	return output, nil
+}
@@ -213,6 +213,121 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) TestInitApplication(c *gc.C) {
+	tests := []struct {
+		should               string
+		args                 []string
+		expectApplication    string
+		expectAction         string
+		expectMaxConcurrency int
+		expectStopOnFailures int
+		expectKVArgs         [][]string
+		expectError          string
+	}{{
+		should:            "work with an application/all target",
+		args:              []string{"mysql/all", "backup", "out=foo"},
+		expectApplication: "mysql",
+		expectAction:      "backup",
+		expectKVArgs:      [][]string{{"out", "foo"}},
+	}, {
+		should:               "handle --max-concurrency and --stop-on-failures",
+		args:                 []string{"mysql/all", "backup", "--max-concurrency", "2", "--stop-on-failures", "1"},
+		expectApplication:    "mysql",
+		expectAction:         "backup",
+		expectMaxConcurrency: 2,
+		expectStopOnFailures: 1,
+		expectKVArgs:         [][]string{},
+	}, {
+		should:      "fail with invalid application name",
+		args:        []string{"something-strange-/all", "backup"},
+		expectError: `invalid application name "something-strange-"`,
+	}, {
+		should:      "fail with no action specified",
+		args:        []string{"mysql/all"},
+		expectError: "no action specified",
+	}, {
+		should:      "fail with a unit after the application",
+		args:        []string{"mysql/all", validUnitId, "backup"},
+		expectError: `invalid action name "mysql/0"`,
+	}, {
+		should:      "fail with --max-concurrency on units",
+		args:        []string{validUnitId, "backup", "--max-concurrency", "2"},
+		expectError: "--max-concurrency and --stop-on-failures require an <application>/all target",
+	}, {
+		should:      "fail with negative --stop-on-failures",
+		args:        []string{"mysql/all", "backup", "--stop-on-failures", "-1"},
+		expectError: "--stop-on-failures must not be negative",
+	}}
+
+	for i, t := range tests {
+		wrappedCommand, command := action.NewRunCommandForTest(s.store)
+		c.Logf("test %d: should %s:\n$ juju run-action %s\n", i,
+			t.should, strings.Join(t.args, " "))
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := cmdtesting.InitCommand(wrappedCommand, args)
+		if t.expectError == "" {
+			c.Assert(err, jc.ErrorIsNil)
+			c.Check(command.UnitTags(), gc.HasLen, 0)
+			c.Check(command.Application(), gc.Equals, t.expectApplication)
+			c.Check(command.ActionName(), gc.Equals, t.expectAction)
+			c.Check(command.MaxConcurrency(), gc.Equals, t.expectMaxConcurrency)
+			c.Check(command.StopOnFailures(), gc.Equals, t.expectStopOnFailures)
+			c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.expectError)
+		}
+	}
+}
+
+func (s *RunSuite) TestRunApplication(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		batchResults: []params.ActionBatchResult{{
+			Id:             "batch-id",
+			ApplicationTag: "application-mysql",
+			Name:           "backup",
+			Status:         params.ActionCompleted,
+			Actions: []params.ActionResult{{
+				Action: &params.Action{
+					Tag:      validActionTagString,
+					Receiver: names.NewUnitTag(validUnitId).String(),
+				},
+				Status: params.ActionCompleted,
+				Output: map[string]interface{}{"size": 3},
+			}},
+			Summary: map[string]int{"completed": 1},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", "mysql/all", "backup", "--max-concurrency", "2", "--stop-on-failures", "1", "--wait")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.enqueuedAppActions, jc.DeepEquals, params.ApplicationActions{
+		Actions: []params.ApplicationAction{{
+			ApplicationTag: "application-mysql",
+			Name:           "backup",
+			Parameters:     map[string]interface{}{},
+			MaxConcurrency: 2,
+			StopOnFailures: 1,
+		}},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+actions:
+  mysql/0:
+    id: `+validActionId+`
+    results:
+      size: 3
+    status: completed
+application: mysql
+id: batch-id
+status: completed
+summary:
+  completed: 1
+`[1:])
+}
+
 func (s *RunSuite) TestRun(c *gc.C) {
 	tests := []struct {
 		should                 string
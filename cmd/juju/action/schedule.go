@@ -0,0 +1,356 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
+	"gopkg.in/juju/names.v2"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/cron"
+)
+
+func NewAddScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&addScheduleCommand{})
+}
+
+// addScheduleCommand adds a schedule that periodically queues an action
+// on some units.
+type addScheduleCommand struct {
+	ActionCommandBase
+	name       string
+	unitTags   []names.UnitTag
+	actionName string
+	schedule   string
+	args       [][]string
+}
+
+const addScheduleDoc = `
+Add a named schedule that queues an action on the given units whenever the
+cron expression given with --schedule fires. The expression has the usual
+five fields:
+
+    minute hour day-of-month month day-of-week
+
+and the shorthands @hourly, @daily, @weekly, @monthly and @yearly are also
+accepted. Times are in UTC. Runs that are missed while the controller is
+unavailable are skipped rather than queued late.
+
+Params are given as for 'juju run-action', and are validated against the
+charm each time the action is queued.
+
+Examples:
+
+    juju add-schedule nightly-backup mysql/0 backup --schedule "0 2 * * *"
+    juju add-schedule hourly-check mysql/0 mysql/1 check --schedule @hourly verbose=true
+
+See also:
+    list-schedules
+    remove-schedule
+    run-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *addScheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.StringVar(&c.schedule, "schedule", "", "Cron expression describing when the action runs")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *addScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-schedule",
+		Args:    "<schedule name> <unit> [<unit> ...] <action name> [key.key.key...=value]",
+		Purpose: "Run an action periodically.",
+		Doc:     addScheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *addScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule name specified")
+	}
+	c.name, args = args[0], args[1:]
+	if c.schedule == "" {
+		return errors.New("no --schedule specified")
+	}
+	if _, err := cron.Parse(c.schedule); err != nil {
+		return errors.Trace(err)
+	}
+	for len(args) > 0 && names.IsValidUnit(args[0]) {
+		c.unitTags = append(c.unitTags, names.NewUnitTag(args[0]))
+		args = args[1:]
+	}
+	if len(c.unitTags) == 0 {
+		return errors.New("no unit specified")
+	}
+	if len(args) == 0 {
+		return errors.New("no action specified")
+	}
+	if !nameRule.MatchString(args[0]) {
+		return errors.Errorf("invalid unit or action name %q", args[0])
+	}
+	c.actionName = args[0]
+	for _, arg := range args[1:] {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return errors.Errorf("argument %q must be of the form key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		for _, key := range keySlice {
+			if !nameRule.MatchString(key) {
+				return errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		c.args = append(c.args, append(keySlice, thisArg[1]))
+	}
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *addScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams := map[string]interface{}{}
+	for _, argSlice := range c.args {
+		valueIndex := len(argSlice) - 1
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(argSlice[valueIndex]), &value); err != nil {
+			return err
+		}
+		addValueToMap(argSlice[:valueIndex], value, actionParams)
+	}
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return err
+	}
+	typedParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("params must be a map, got %T", conformantParams)
+	}
+
+	schedule := params.ActionSchedule{
+		Name:       c.name,
+		Action:     c.actionName,
+		Parameters: typedParams,
+		Schedule:   c.schedule,
+	}
+	for _, tag := range c.unitTags {
+		schedule.Receivers = append(schedule.Receivers, tag.String())
+	}
+	results, err := api.AddActionSchedules(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{schedule},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists the action schedules in a model.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const listSchedulesDoc = `
+List the action schedules in the model, with the time each next runs.
+
+See also:
+    add-schedule
+    remove-schedule
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printSchedulesTabular,
+	})
+}
+
+// Info is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-schedules",
+		Purpose: "List action schedules.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"schedules"},
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// scheduleOutput is the output format of a single action schedule.
+type scheduleOutput struct {
+	Action     string                 `yaml:"action" json:"action"`
+	Units      []string               `yaml:"units" json:"units"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Schedule   string                 `yaml:"schedule" json:"schedule"`
+	LastRun    string                 `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+	NextRun    string                 `yaml:"next-run" json:"next-run"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules in this model.")
+		return nil
+	}
+	schedules := make(map[string]scheduleOutput, len(results.Results))
+	for _, result := range results.Results {
+		out := scheduleOutput{
+			Action:     result.Action,
+			Parameters: result.Parameters,
+			Schedule:   result.Schedule,
+			NextRun:    formatScheduleTime(result.NextRun),
+		}
+		if !result.LastRun.IsZero() {
+			out.LastRun = formatScheduleTime(result.LastRun)
+		}
+		for _, receiver := range result.Receivers {
+			tag, err := names.ParseTag(receiver)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			out.Units = append(out.Units, tag.Id())
+		}
+		schedules[result.Name] = out
+	}
+	return c.out.Write(ctx, schedules)
+}
+
+func formatScheduleTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// printSchedulesTabular prints the action schedules in tabular format.
+func printSchedulesTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.(map[string]scheduleOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	var scheduleNames []string
+	for name := range schedules {
+		scheduleNames = append(scheduleNames, name)
+	}
+	naturalsort.Sort(scheduleNames)
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", "Name", "Action", "Units", "Schedule", "Next run")
+	for _, name := range scheduleNames {
+		s := schedules[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, s.Action, strings.Join(s.Units, ","), s.Schedule, s.NextRun)
+	}
+	tw.Flush()
+	return nil
+}
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes action schedules.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	names []string
+}
+
+const removeScheduleDoc = `
+Remove the named action schedules. Actions already queued by a schedule are
+not affected; use 'juju cancel-action' to cancel them.
+
+See also:
+    add-schedule
+    list-schedules
+`
+
+// Info is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-schedule",
+		Args:    "<schedule name> [<schedule name> ...]",
+		Purpose: "Remove action schedules.",
+		Doc:     removeScheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule name specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RemoveActionSchedules(params.ActionScheduleNames{Names: c.names})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(c.names) {
+		return errors.Errorf("expected %d results, got %d", len(c.names), len(results.Results))
+	}
+	var failed bool
+	for i, result := range results.Results {
+		if result.Error != nil {
+			ctx.Infof("cannot remove schedule %q: %v", c.names[i], result.Error)
+			failed = true
+		}
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
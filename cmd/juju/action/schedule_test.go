@@ -0,0 +1,161 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.store.Models["ctrl"].CurrentModel = "admin/admin"
+	s.client = &fakeAPIClient{}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *ScheduleSuite) TestAddScheduleInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no schedule name specified",
+	}, {
+		args: []string{"backup", "mysql/0", "backup"},
+		err:  "no --schedule specified",
+	}, {
+		args: []string{"backup", "mysql/0", "backup", "--schedule", "61 * * * *"},
+		err:  "minute: value 61 outside 0-59 not valid",
+	}, {
+		args: []string{"backup", "backup", "--schedule", "@daily"},
+		err:  "no unit specified",
+	}, {
+		args: []string{"backup", "mysql/0", "--schedule", "@daily"},
+		err:  "no action specified",
+	}, {
+		args: []string{"backup", "mysql/0", "backup", "full", "--schedule", "@daily"},
+		err:  `argument "full" must be of the form key...=value`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ScheduleSuite) TestAddSchedule(c *gc.C) {
+	s.client.scheduleErrors = []params.ErrorResult{{}}
+	_, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store),
+		"nightly-backup", "mysql/0", "mysql/1", "backup", "--schedule", "0 2 * * *",
+		"full=true", "out.kind=xz",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.addedSchedules, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:      "nightly-backup",
+			Action:    "backup",
+			Receivers: []string{"unit-mysql-0", "unit-mysql-1"},
+			Parameters: map[string]interface{}{
+				"full": true,
+				"out":  map[string]interface{}{"kind": "xz"},
+			},
+			Schedule: "0 2 * * *",
+		}},
+	})
+}
+
+func (s *ScheduleSuite) TestAddScheduleError(c *gc.C) {
+	s.client.scheduleErrors = []params.ErrorResult{{
+		Error: &params.Error{Message: `action schedule "backup" already exists`},
+	}}
+	_, err := cmdtesting.RunCommand(c, action.NewAddScheduleCommandForTest(s.store),
+		"backup", "mysql/0", "backup", "--schedule", "@daily",
+	)
+	c.Assert(err, gc.ErrorMatches, `action schedule "backup" already exists`)
+}
+
+func (s *ScheduleSuite) TestListSchedules(c *gc.C) {
+	s.client.schedules = []params.ActionSchedule{{
+		Name:      "hourly-check",
+		Action:    "check",
+		Receivers: []string{"unit-mysql-0", "unit-mysql-1"},
+		Schedule:  "@hourly",
+		NextRun:   time.Date(2018, 6, 15, 11, 0, 0, 0, time.UTC),
+	}, {
+		Name:       "nightly-backup",
+		Action:     "backup",
+		Receivers:  []string{"unit-mysql-0"},
+		Parameters: map[string]interface{}{"full": true},
+		Schedule:   "0 2 * * *",
+		LastRun:    time.Date(2018, 6, 15, 2, 0, 0, 0, time.UTC),
+		NextRun:    time.Date(2018, 6, 16, 2, 0, 0, 0, time.UTC),
+	}}
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Name            Action  Units            Schedule   Next run\n"+
+		"hourly-check    check   mysql/0,mysql/1  @hourly    2018-06-15T11:00:00Z\n"+
+		"nightly-backup  backup  mysql/0          0 2 * * *  2018-06-16T02:00:00Z\n",
+	)
+
+	ctx, err = cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+hourly-check:
+  action: check
+  units:
+  - mysql/0
+  - mysql/1
+  schedule: '@hourly'
+  next-run: "2018-06-15T11:00:00Z"
+nightly-backup:
+  action: backup
+  units:
+  - mysql/0
+  parameters:
+    full: true
+  schedule: 0 2 * * *
+  last-run: "2018-06-15T02:00:00Z"
+  next-run: "2018-06-16T02:00:00Z"
+`[1:])
+}
+
+func (s *ScheduleSuite) TestListSchedulesEmpty(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No action schedules in this model.\n")
+}
+
+func (s *ScheduleSuite) TestRemoveSchedule(c *gc.C) {
+	s.client.scheduleErrors = []params.ErrorResult{{}, {
+		Error: &params.Error{Message: `action schedule "weekly" not found`},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "daily", "weekly")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.client.removedSchedules, jc.DeepEquals, params.ActionScheduleNames{
+		Names: []string{"daily", "weekly"},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `cannot remove schedule "weekly": action schedule "weekly" not found`+"\n")
+}
+
+func (s *ScheduleSuite) TestRemoveScheduleNoNames(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store))
+	c.Assert(err, gc.ErrorMatches, "no schedule name specified")
+}
@@ -4,12 +4,15 @@
 package action
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"time"
 
 	"github.com/juju/cmd"
 	errors "github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -27,6 +30,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
 const showOutputDoc = `
@@ -38,6 +42,15 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To follow the progress of a running action, use the --watch flag.  Progress
+messages logged by the action with action-log are printed as they arrive,
+and the results are displayed once the action has completed or failed.  A
+--wait duration may be combined with --watch to limit how long to follow.
+
+Results larger than the model's max-action-output-size are kept in blob
+storage rather than with the action; they are fetched and displayed in
+full automatically.
 `
 
 // Set up the output.
@@ -45,6 +58,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Print progress messages as they are logged, until the action completes")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -90,6 +104,14 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
+	if c.watch {
+		result, err := c.watchActionProgress(ctx, api, waitDur)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, FormatActionResult(result))
+	}
+
 	wait := time.NewTimer(0 * time.Second)
 
 	switch {
@@ -112,6 +134,65 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, FormatActionResult(result))
 }
 
+// watchActionProgress prints the progress messages logged by the requested
+// action until it is no longer running or pending, or until the wait
+// duration has elapsed. A negative wait duration waits indefinitely.
+func (c *showOutputCommand) watchActionProgress(ctx *cmd.Context, api APIClient, waitDur time.Duration) (params.ActionResult, error) {
+	actionTag, err := getActionTagByPrefix(api, c.requestedId)
+	if err != nil {
+		return params.ActionResult{}, errors.Trace(err)
+	}
+	w, err := api.WatchActionProgress(actionTag.Id())
+	if err != nil {
+		return params.ActionResult{}, errors.Trace(err)
+	}
+	defer worker.Stop(w)
+
+	var wait <-chan time.Time
+	if waitDur > 0 {
+		wait = time.After(waitDur)
+	}
+	// Check the action status straight away, and then every two seconds.
+	tick := time.NewTimer(0)
+	for {
+		select {
+		case changes, ok := <-w.Changes():
+			if !ok {
+				return params.ActionResult{}, errors.New("action progress watcher stopped")
+			}
+			for _, change := range changes {
+				if err := writeActionMessage(ctx, change); err != nil {
+					return params.ActionResult{}, errors.Trace(err)
+				}
+			}
+		case <-wait:
+			return fetchResult(api, c.requestedId)
+		case <-tick.C:
+			result, err := fetchResult(api, c.requestedId)
+			if err != nil {
+				return result, err
+			}
+			switch result.Status {
+			case params.ActionRunning, params.ActionPending:
+			default:
+				return result, nil
+			}
+			tick.Reset(2 * time.Second)
+		}
+	}
+}
+
+// writeActionMessage decodes a JSON encoded action progress message and
+// writes it to the context's stderr, prefixed with its timestamp.
+func writeActionMessage(ctx *cmd.Context, change string) error {
+	var message params.ActionMessage
+	if err := json.Unmarshal([]byte(change), &message); err != nil {
+		return errors.Annotate(err, "decoding action progress message")
+	}
+	_, err := fmt.Fprintf(ctx.Stderr, "%s %s\n", message.Timestamp.UTC().Format(time.RFC3339), message.Message)
+	return err
+}
+
 // GetActionResult tries to repeatedly fetch an action until it is
 // in a completed state and then it returns it.
 // It waits for a maximum of "wait" before returning with the latest action status.
@@ -170,12 +251,21 @@ func fetchResult(api APIClient, requestedId string) (params.ActionResult, error)
 		return none, err
 	}
 
-	actions, err := api.Actions(params.Entities{
+	entities := params.Entities{
 		Entities: []params.Entity{{actionTag.String()}},
-	})
+	}
+	actions, err := api.Actions(entities)
 	if err != nil {
 		return none, err
 	}
+	if len(actions.Results) == 1 && actions.Results[0].OutputOverflow {
+		// The output was too large to be returned with the
+		// action, so fetch it separately.
+		actions, err = api.ActionOutputs(entities)
+		if err != nil {
+			return none, err
+		}
+	}
 	actionResults := actions.Results
 	numActionResults := len(actionResults)
 	if numActionResults == 0 {
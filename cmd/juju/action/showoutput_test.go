@@ -290,6 +290,77 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestWatch(c *gc.C) {
+	client := makeFakeClient(
+		1*time.Second,
+		10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: "completed",
+			Output: map[string]interface{}{
+				"foo": "bar",
+			},
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	client.progressChanges = make(chan []string, 1)
+	client.progressChanges <- []string{
+		`{"timestamp":"2015-02-14T08:14:00Z","message":"starting backup"}`,
+		`{"timestamp":"2015-02-14T08:15:00Z","message":"backup 50% done"}`,
+	}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+2015-02-14T08:14:00Z starting backup
+2015-02-14T08:15:00Z backup 50% done
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+results:
+  foo: bar
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+`[1:])
+}
+
+func (s *ShowOutputSuite) TestRunFetchesOverflowOutput(c *gc.C) {
+	completed := params.ActionResult{
+		Status:    "completed",
+		Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+		Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+	}
+	overflow := completed
+	overflow.OutputOverflow = true
+	full := completed
+	full.Output = map[string]interface{}{"dump": "a very large listing"}
+
+	client := makeFakeClient(
+		0,
+		10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{overflow},
+		params.ActionsByNames{},
+		"",
+	)
+	client.actionOutputs = []params.ActionResult{full}
+	testRunHelper(c, s, client, "", `
+results:
+  dump: a very large listing
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+`[1:], "", validActionId, "-m")
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -367,6 +368,9 @@ func (h *bundleHandler) handleChanges() error {
 	// Deploy the bundle.
 	for i, change := range h.changes {
 		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+		if h.dryRun {
+			h.writeChangeDetails(change)
+		}
 		logger.Tracef("%d: change %s", i, pretty.Sprint(change))
 		switch change := change.(type) {
 		case *bundlechanges.AddCharmChange:
@@ -404,6 +408,51 @@ func (h *bundleHandler) handleChanges() error {
 	return nil
 }
 
+// writeChangeDetails writes the config, constraints and annotations
+// that a change would apply, so that a dry run shows exactly what
+// the bundle deploy would do. Each detail is written on its own line,
+// indented under the change's description.
+func (h *bundleHandler) writeChangeDetails(change bundlechanges.Change) {
+	switch change := change.(type) {
+	case *bundlechanges.AddApplicationChange:
+		p := change.Params
+		if p.Constraints != "" {
+			h.writeDetail("constraints", p.Constraints)
+		}
+		h.writeSettings(p.Options)
+	case *bundlechanges.SetOptionsChange:
+		h.writeSettings(change.Params.Options)
+	case *bundlechanges.SetAnnotationsChange:
+		annotations := make(map[string]interface{})
+		for key, value := range change.Params.Annotations {
+			annotations[key] = value
+		}
+		h.writeSettings(annotations)
+	}
+}
+
+// writeSettings writes the settings to stdout, sorted by key.
+func (h *bundleHandler) writeSettings(settings map[string]interface{}) {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := settings[key].(type) {
+		case string:
+			h.writeDetail(key, fmt.Sprintf("%q", value))
+		default:
+			h.writeDetail(key, fmt.Sprint(value))
+		}
+	}
+}
+
+// writeDetail writes a single detail line of a change to stdout.
+func (h *bundleHandler) writeDetail(key, value string) {
+	fmt.Fprintf(h.ctx.Stdout, "  %s: %s\n", key, value)
+}
+
 func (h *bundleHandler) isLocalCharm(name string) bool {
 	return strings.HasPrefix(name, ".") || filepath.IsAbs(name)
 }
@@ -481,7 +530,6 @@ func (h *bundleHandler) makeResourceMap(storeResources map[string]int, localReso
 
 // addApplication deploys an application with no units.
 func (h *bundleHandler) addApplication(change *bundlechanges.AddApplicationChange) error {
-	// TODO: add verbose output for details
 	if h.dryRun {
 		return nil
 	}
@@ -335,6 +335,69 @@ func (s *BundleDeployCharmStoreSuite) TestDryRunExistingModel(c *gc.C) {
 	c.Check(stdOut, gc.Equals, expected)
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDryRunShowsConfigChanges(c *gc.C) {
+	_, wpch := testcharms.UploadCharm(c, s.client, "xenial/wordpress-42", "wordpress")
+	err := s.DeployBundleYAML(c, `
+        applications:
+            wordpress:
+                charm: wordpress
+                num_units: 1
+                options:
+                    blog-title: these are the voyages
+    `)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stdOut, _, err := s.DeployBundleYAMLWithOutput(c, `
+        applications:
+            wordpress:
+                charm: wordpress
+                num_units: 1
+                options:
+                    blog-title: new title
+                annotations:
+                    gui-x: "10"
+                constraints: mem=8G
+    `, "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdOut, gc.Equals, ""+
+		"Changes to deploy bundle:\n"+
+		"- set application options for wordpress\n"+
+		`  blog-title: "new title"`+"\n"+
+		`- set constraints for wordpress to "mem=8G"`+"\n"+
+		"- set annotations for wordpress\n"+
+		`  gui-x: "10"`,
+	)
+
+	// Nothing was changed.
+	s.assertApplicationsDeployed(c, map[string]applicationInfo{
+		"wordpress": {
+			charm:  "cs:xenial/wordpress-42",
+			config: s.combinedSettings(wpch, charm.Settings{"blog-title": "these are the voyages"}),
+		},
+	})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDryRunShowsApplicationDetails(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-42", "wordpress")
+	stdOut, _, err := s.DeployBundleYAMLWithOutput(c, `
+        applications:
+            wordpress:
+                charm: wordpress
+                options:
+                    blog-title: these are the voyages
+                constraints: mem=8G
+    `, "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdOut, gc.Equals, ""+
+		"Changes to deploy bundle:\n"+
+		"- upload charm cs:xenial/wordpress-42 for series xenial\n"+
+		"- deploy application wordpress on xenial using cs:xenial/wordpress-42\n"+
+		"  constraints: mem=8G\n"+
+		`  blog-title: "these are the voyages"`,
+	)
+	s.assertApplicationsDeployed(c, map[string]applicationInfo{})
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleGatedCharm(c *gc.C) {
 	_, mysqlch := testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
 	url, _ := testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
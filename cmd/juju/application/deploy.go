@@ -476,6 +476,14 @@ for the bundle machine 3.
 Only top level machines can be mapped in this way, just as only top level
 machines can be defined in the machines section of the bundle.
 
+To review the changes a bundle deployment would make to the model without
+making them, use --dry-run. The bundle is compared with the current model
+and the applications, machines, units, relations, config, constraints and
+annotations that would be added or changed are printed; nothing is
+deployed.
+
+  juju deploy some-bundle --dry-run
+
 
 Examples:
     juju deploy mysql               (deploy to a new machine)
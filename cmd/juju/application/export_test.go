@@ -46,6 +46,13 @@ func NewResolvedCommandForTest(applicationResolveAPI applicationResolveAPI, clie
 	return modelcmd.Wrap(cmd)
 }
 
+// NewShowUnitCommandForTest returns a ShowUnitCommand with the api provided as specified.
+func NewShowUnitCommandForTest(api showUnitAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &showUnitCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewAddUnitCommandForTest returns an AddUnitCommand with the api provided as specified.
 func NewAddUnitCommandForTest(api applicationAddUnitAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &addUnitCommand{api: api}
@@ -19,6 +19,13 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+For applications in a Kubernetes model, an Ingress resource is created
+for the application's service. The application config settings
+juju-external-hostname (required), juju-application-path and
+kubernetes-ingress-tls-secret set the ingress host, path and TLS
+secret. The Ingress resource is removed when the application is
+unexposed.
+
 Examples:
     juju expose wordpress
 
@@ -0,0 +1,196 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showUnitDoc = `
+Show details about one or more units.
+
+With --hooks, the unit's recent hook history is shown, newest first: each
+hook, action and "juju run" command the unit has executed, with when it
+started and finished, its exit code, and for relation hooks the relation
+and remote unit involved. The controller keeps the most recent 100
+executions for each unit. Successful runs of the update-status hook are
+not recorded.
+
+Examples:
+
+    juju show-unit --hooks mysql/0
+    juju show-unit --hooks --format tabular mysql/0 wordpress/1
+
+See also:
+    show-status-log
+    debug-log
+`
+
+// NewShowUnitCommand returns a command which shows details of units.
+func NewShowUnitCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&showUnitCommand{})
+}
+
+// showUnitCommand shows details of units.
+type showUnitCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api showUnitAPI
+
+	unitNames []string
+	hooks     bool
+	isoTime   bool
+}
+
+type showUnitAPI interface {
+	Close() error
+	UnitsHookExecutions(units []string) ([]params.HookExecutionsResult, error)
+}
+
+// Info implements cmd.Command.
+func (c *showUnitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-unit",
+		Args:    "--hooks <unit name> [<unit name> ...]",
+		Purpose: "Displays information about units.",
+		Doc:     showUnitDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.hooks, "hooks", false, "Show the unit's recent hook history")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatUnitHooksTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *showUnitCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit specified")
+	}
+	for _, unit := range args {
+		if !names.IsValidUnit(unit) {
+			return errors.NotValidf("unit name %q", unit)
+		}
+	}
+	if !c.hooks {
+		// The hook history is all there is to show, for now.
+		return errors.New("nothing to show: specify --hooks")
+	}
+	c.unitNames = args
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *showUnitCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.UnitsHookExecutions(c.unitNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	units := make(map[string]UnitInfo)
+	for i, result := range results {
+		unitName := c.unitNames[i]
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "unit %q", unitName)
+		}
+		info := UnitInfo{Hooks: make([]HookExecutionInfo, len(result.Executions))}
+		for j, e := range result.Executions {
+			info.Hooks[j] = HookExecutionInfo{
+				Kind:       e.Kind,
+				Name:       e.Name,
+				Relation:   e.Relation,
+				RemoteUnit: e.RemoteUnit,
+				Started:    common.FormatTime(&e.Started, c.isoTime),
+				Duration:   (e.Finished.Sub(e.Started) / time.Millisecond * time.Millisecond).String(),
+				ExitCode:   e.ExitCode,
+				Error:      e.Error,
+			}
+		}
+		units[unitName] = info
+	}
+	return c.out.Write(ctx, units)
+}
+
+func (c *showUnitCommand) getAPI() (showUnitAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// UnitInfo holds the details of a unit shown by show-unit.
+type UnitInfo struct {
+	Hooks []HookExecutionInfo `yaml:"hooks" json:"hooks"`
+}
+
+// HookExecutionInfo holds the details of a hook, action or commands
+// execution in a unit's hook history.
+type HookExecutionInfo struct {
+	Kind       string `yaml:"kind" json:"kind"`
+	Name       string `yaml:"name,omitempty" json:"name,omitempty"`
+	Relation   string `yaml:"relation,omitempty" json:"relation,omitempty"`
+	RemoteUnit string `yaml:"remote-unit,omitempty" json:"remote-unit,omitempty"`
+	Started    string `yaml:"started" json:"started"`
+	Duration   string `yaml:"duration" json:"duration"`
+	ExitCode   int    `yaml:"exit-code" json:"exit-code"`
+	Error      string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// formatUnitHooksTabular writes the hook history of each unit as a
+// table.
+func formatUnitHooksTabular(writer io.Writer, value interface{}) error {
+	units, ok := value.(map[string]UnitInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", units, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Unit", "Started", "Duration", "Kind", "Name", "Relation", "Remote unit", "Exit", "Error")
+	for _, unitName := range sortedUnitNames(units) {
+		for _, hook := range units[unitName].Hooks {
+			w.Println(unitName, hook.Started, hook.Duration, hook.Kind, hook.Name,
+				hook.Relation, hook.RemoteUnit, fmt.Sprint(hook.ExitCode), hook.Error)
+		}
+	}
+	return tw.Flush()
+}
+
+func sortedUnitNames(units map[string]UnitInfo) []string {
+	unitNames := make([]string, 0, len(units))
+	for unitName := range units {
+		unitNames = append(unitNames, unitName)
+	}
+	naturalsort.Sort(unitNames)
+	return unitNames
+}
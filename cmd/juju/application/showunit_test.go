@@ -0,0 +1,131 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ShowUnitSuite struct {
+	testing.IsolationSuite
+	api *mockShowUnitAPI
+}
+
+var _ = gc.Suite(&ShowUnitSuite{})
+
+func (s *ShowUnitSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	started := time.Date(2018, 6, 15, 10, 30, 0, 0, time.UTC)
+	s.api = &mockShowUnitAPI{
+		results: []params.HookExecutionsResult{{
+			Executions: []params.HookExecution{{
+				Kind:       "hook",
+				Name:       "db-relation-changed",
+				Relation:   "db:2",
+				RemoteUnit: "mysql/0",
+				Started:    started.Add(time.Minute),
+				Finished:   started.Add(time.Minute + 1500*time.Millisecond),
+				ExitCode:   1,
+				Error:      "exit status 1",
+			}, {
+				Kind:     "hook",
+				Name:     "install",
+				Started:  started,
+				Finished: started.Add(20 * time.Second),
+			}},
+		}},
+	}
+}
+
+func (s *ShowUnitSuite) runShowUnit(c *gc.C, args ...string) (string, error) {
+	cmd := application.NewShowUnitCommandForTest(s.api, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *ShowUnitSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--hooks"},
+		err:  "no unit specified",
+	}, {
+		args: []string{"--hooks", "wordpress"},
+		err:  `unit name "wordpress" not valid`,
+	}, {
+		args: []string{"wordpress/0"},
+		err:  "nothing to show: specify --hooks",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runShowUnit(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ShowUnitSuite) TestShowHooks(c *gc.C) {
+	out, err := s.runShowUnit(c, "--hooks", "--utc", "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.units, jc.DeepEquals, []string{"wordpress/0"})
+	c.Assert(out, gc.Equals, `
+wordpress/0:
+  hooks:
+  - kind: hook
+    name: db-relation-changed
+    relation: db:2
+    remote-unit: mysql/0
+    started: 2018-06-15 10:31:00Z
+    duration: 1.5s
+    exit-code: 1
+    error: exit status 1
+  - kind: hook
+    name: install
+    started: 2018-06-15 10:30:00Z
+    duration: 20s
+    exit-code: 0
+`[1:])
+}
+
+func (s *ShowUnitSuite) TestShowHooksTabular(c *gc.C) {
+	out, err := s.runShowUnit(c, "--hooks", "--utc", "--format", "tabular", "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+Unit         Started               Duration  Kind  Name                 Relation  Remote unit  Exit  Error
+wordpress/0  2018-06-15 10:31:00Z  1.5s      hook  db-relation-changed  db:2      mysql/0      1     exit status 1
+`[1:]+"wordpress/0  2018-06-15 10:30:00Z  20s       hook  install                                     0     \n")
+}
+
+func (s *ShowUnitSuite) TestShowHooksUnitError(c *gc.C) {
+	s.api.results = []params.HookExecutionsResult{{
+		Error: &params.Error{Message: `unit "wordpress/0" not found`, Code: params.CodeNotFound},
+	}}
+	_, err := s.runShowUnit(c, "--hooks", "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `unit "wordpress/0": unit "wordpress/0" not found`)
+}
+
+type mockShowUnitAPI struct {
+	units   []string
+	results []params.HookExecutionsResult
+}
+
+func (m *mockShowUnitAPI) Close() error {
+	return nil
+}
+
+func (m *mockShowUnitAPI) UnitsHookExecutions(units []string) ([]params.HookExecutionsResult, error) {
+	m.units = units
+	return m.results, nil
+}
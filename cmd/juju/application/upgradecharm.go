@@ -81,11 +81,12 @@ type ResourceLister interface {
 }
 
 // NewCharmAdderFunc is the type of a function used to construct
-// a new CharmAdder.
+// a new CharmAdder, which adds charms to upgrade the named application.
 type NewCharmAdderFunc func(
 	api.Connection,
 	*httpbakery.Client,
 	csclientparams.Channel,
+	string,
 ) CharmAdder
 
 // UpgradeCharm is responsible for upgrading an application's charm.
@@ -297,7 +298,7 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	charmAdder := c.NewCharmAdder(apiRoot, bakeryClient, c.Channel)
+	charmAdder := c.NewCharmAdder(apiRoot, bakeryClient, c.Channel, c.ApplicationName)
 	charmRepo := c.getCharmStore(bakeryClient, modelConfig)
 
 	applicationInfo, err := charmUpgradeClient.Get(c.ApplicationName)
@@ -466,6 +467,7 @@ func newCharmAdder(
 	api api.Connection,
 	bakeryClient *httpbakery.Client,
 	channel csclientparams.Channel,
+	applicationName string,
 ) CharmAdder {
 	csClient := newCharmStoreClient(bakeryClient).WithChannel(channel)
 
@@ -476,7 +478,9 @@ func newCharmAdder(
 		*apiClient
 	}{
 		charmstoreClient: &charmstoreClient{Client: csClient},
-		apiClient:        &apiClient{Client: api.Client()},
+		// Naming the application allows users granted upgrade-charm
+		// access to it to add the charm without model write access.
+		apiClient: &apiClient{Client: api.Client().ForApplication(applicationName)},
 	}
 	return charmstoreAdapter
 }
@@ -138,8 +138,8 @@ func (s *UpgradeCharmSuite) SetUpTest(c *gc.C) {
 		apiOpen,
 		s.deployResources,
 		s.resolveCharm,
-		func(conn api.Connection, bakeryClient *httpbakery.Client, channel csclientparams.Channel) CharmAdder {
-			s.AddCall("NewCharmAdder", conn, bakeryClient, channel)
+		func(conn api.Connection, bakeryClient *httpbakery.Client, channel csclientparams.Channel, applicationName string) CharmAdder {
+			s.AddCall("NewCharmAdder", conn, bakeryClient, channel, applicationName)
 			s.PopNoErr()
 			return &s.charmAdder
 		},
@@ -184,6 +184,18 @@ func (s *UpgradeCharmSuite) TestStorageConstraints(c *gc.C) {
 	})
 }
 
+func (s *UpgradeCharmSuite) TestCharmAdderForApplication(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	for _, call := range s.Calls() {
+		if call.FuncName == "NewCharmAdder" {
+			c.Assert(call.Args[3], gc.Equals, "foo")
+			return
+		}
+	}
+	c.Fatalf("NewCharmAdder not called")
+}
+
 func (s *UpgradeCharmSuite) TestStorageConstraintsMinFacadeVersion(c *gc.C) {
 	s.apiConnection.bestFacadeVersion = 1
 	_, err := s.runUpgradeCharm(c, "foo", "--storage", "bar=baz")
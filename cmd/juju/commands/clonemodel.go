@@ -0,0 +1,153 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+func newCloneModelCommand() modelcmd.ModelCommand {
+	var cmd cloneModelCommand
+	cmd.newAPIRoot = cmd.CommandBase.NewAPIRoot
+	return modelcmd.Wrap(&cmd, modelcmd.WrapSkipModelFlags)
+}
+
+// cloneModelCommand copies a model into a new model.
+type cloneModelCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
+	api              cloneModelAPI
+	newModelName     string
+	targetController string
+	topologyOnly     bool
+}
+
+type cloneModelAPI interface {
+	CloneModel(spec controller.MigrationSpec, name string, topologyOnly bool) (string, error)
+}
+
+const cloneModelDoc = `
+clone-model creates a new model with a copy of the applications,
+relations, configuration and storage pools of an existing model. The
+new model may be on the same controller as the original or, if a target
+controller is given, on another controller, which must be in the juju
+client's local configuration cache (see "juju login").
+
+By default the new model also gets a machine for each machine in the
+original, with the same series and constraints and with containers on
+the same hosts, and a unit for each unit, placed as in the original.
+The machines are provisioned afresh; nothing is copied from the
+original's machines, and the charms' install hooks run as usual. Unit
+numbers and machine ids may differ from the original's.
+
+With --topology-only, no machines or units are added, leaving an empty
+copy of the model's topology. This is useful for spinning up a staging
+copy of a production model, for example to test charm upgrades.
+
+The original model is not affected. The new model has the same owner and
+cloud credential as the original. Only controller administrators can
+clone models, and the controller model can't be cloned.
+
+Examples:
+
+    juju clone-model production staging
+    juju clone-model --topology-only production staging
+    juju clone-model production staging other-controller
+
+See also:
+    migrate
+    add-model
+`
+
+// Info implements cmd.Command.
+func (c *cloneModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "clone-model",
+		Args:    "[--topology-only] <model-name> <new-model-name> [<target-controller-name>]",
+		Purpose: "Copy a model into a new model.",
+		Doc:     cloneModelDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *cloneModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.topologyOnly, "topology-only", false, "Copy the applications and relations, without any machines or units")
+}
+
+// Init implements cmd.Command.
+func (c *cloneModelCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("model not specified")
+	}
+	if len(args) < 2 {
+		return errors.New("new model name not specified")
+	}
+	if len(args) > 3 {
+		return errors.New("too many arguments specified")
+	}
+	if !names.IsValidModelName(args[1]) {
+		return errors.NotValidf("model name %q", args[1])
+	}
+
+	c.SetModelName(args[0], false)
+	c.newModelName = args[1]
+	if len(args) == 3 {
+		c.targetController = args[2]
+	}
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *cloneModelCommand) Run(ctx *cmd.Context) error {
+	targetController := c.targetController
+	if targetController == "" {
+		var err error
+		if targetController, err = c.ControllerName(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	spec, err := getTargetControllerSpec(&c.ModelCommandBase, c.newAPIRoot, targetController)
+	if err != nil {
+		return err
+	}
+	modelName, err := c.ModelName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uuids, err := c.ModelUUIDs([]string{modelName})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	spec.ModelUUID = uuids[0]
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	uuid, err := api.CloneModel(*spec, c.newModelName, c.topologyOnly)
+	if err != nil {
+		return err
+	}
+	ctx.Infof("Cloned model %q as %q (%s) on controller %q", modelName, c.newModelName, uuid, targetController)
+	return nil
+}
+
+func (c *cloneModelCommand) getAPI() (cloneModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	apiRoot, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controller.NewClient(apiRoot), nil
+}
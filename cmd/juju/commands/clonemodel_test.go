@@ -0,0 +1,168 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type CloneModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeCloneModelAPI
+	modelAPI *fakeModelAPI
+	store    *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&CloneModelSuite{})
+
+const sourceControllerUUID = "eeeeeeee-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *CloneModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	err := s.store.AddController("source", jujuclient.ControllerDetails{
+		ControllerUUID: sourceControllerUUID,
+		APIEndpoints:   []string{"5.6.7.8:9"},
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("source")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("source", jujuclient.AccountDetails{
+		User:     "sourceuser",
+		Password: "sourcesecret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.store.AddController("target", jujuclient.ControllerDetails{
+		ControllerUUID: targetControllerUUID,
+		APIEndpoints:   []string{"1.2.3.4:5"},
+		CACert:         "cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("target", jujuclient.AccountDetails{
+		User:     "targetuser",
+		Password: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeCloneModelAPI{}
+	s.modelAPI = &fakeModelAPI{
+		models: []base.UserModel{{
+			Name:  "model",
+			UUID:  modelUUID,
+			Type:  model.IAAS,
+			Owner: "sourceuser",
+		}},
+	}
+}
+
+func (s *CloneModelSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "model not specified",
+	}, {
+		args: []string{"model"},
+		err:  "new model name not specified",
+	}, {
+		args: []string{"model", "Bad_Name"},
+		err:  `model name "Bad_Name" not valid`,
+	}, {
+		args: []string{"model", "staging", "target", "extra"},
+		err:  "too many arguments specified",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.makeAndRun(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *CloneModelSuite) TestCloneSameController(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "staging")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals,
+		"Cloned model \"model\" as \"staging\" (new-uuid) on controller \"source\"\n")
+	c.Check(s.api.name, gc.Equals, "staging")
+	c.Check(s.api.topologyOnly, jc.IsFalse)
+	c.Check(s.api.spec, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: sourceControllerUUID,
+		TargetAddrs:          []string{"5.6.7.8:9"},
+		TargetCACert:         "somecert",
+		TargetUser:           "sourceuser",
+		TargetPassword:       "sourcesecret",
+	})
+}
+
+func (s *CloneModelSuite) TestCloneOtherController(c *gc.C) {
+	_, err := s.makeAndRun(c, "--topology-only", "model", "staging", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.api.topologyOnly, jc.IsTrue)
+	c.Check(s.api.spec, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *CloneModelSuite) TestCloneError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.makeAndRun(c, "model", "staging")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *CloneModelSuite) TestControllerDoesntExist(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "staging", "wat")
+	c.Check(err, gc.ErrorMatches, "controller wat not found")
+	c.Check(s.api.spec, gc.IsNil)
+}
+
+func (s *CloneModelSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := newCloneModelCommand()
+	cmd.SetClientStore(s.store)
+	cmd.SetModelAPI(s.modelAPI)
+	inner := modelcmd.InnerCommand(cmd).(*cloneModelCommand)
+	inner.api = s.api
+	inner.newAPIRoot = func(jujuclient.ClientStore, string, string) (api.Connection, error) {
+		return nil, errors.New("unexpected connection")
+	}
+	return cmdtesting.RunCommand(c, cmd, args...)
+}
+
+type fakeCloneModelAPI struct {
+	spec         *controller.MigrationSpec
+	name         string
+	topologyOnly bool
+	err          error
+}
+
+func (a *fakeCloneModelAPI) CloneModel(spec controller.MigrationSpec, name string, topologyOnly bool) (string, error) {
+	a.spec = &spec
+	a.name = name
+	a.topologyOnly = topologyOnly
+	if a.err != nil {
+		return "", a.err
+	}
+	return "new-uuid", nil
+}
@@ -4,7 +4,11 @@
 package commands
 
 import (
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"strings"
 	"time"
@@ -49,13 +53,38 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-message' and '--exclude-message' options filter by regular
+expressions matched against the log message.
+
+The '--agent-type' option only shows messages logged by agents of the given
+type: "machine", "unit", "application" or "controller". Messages from the
+controller are those logged by the agents of the controller machines.
+
+The '--since' and '--until' options limit the messages shown to those logged
+in a time range. Each takes either a time in RFC3339 format, or a duration
+such as "2h30m", meaning that long ago. Once the '--until' time has passed
+no new messages are shown.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --include-message options are logically ORed together.
+* All --exclude-message options are logically ORed together.
+* All --agent-type options are logically ORed together.
+* The combined selections are logically ANDed to form the complete filter.
+
+All filtering is done by the controller, so the number of lines shown by
+'--lines' and '--limit' counts only messages that pass the filter.
+
+With '--format json' each message is written as a JSON object on a line of
+its own, for processing by other tools.
+
+The '--export' option saves all the (possibly filtered) messages logged so
+far to a gzipped file of JSON lines, for example to attach to an incident
+report. It is normally used with '--since' and '--until'; '--lines',
+'--replay' and '--tail' have no effect on an export.
 
 Examples:
 
@@ -94,6 +123,21 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Save all messages logged between 09:00 and 11:00 UTC on 1 June 2018:
+
+    juju debug-log --export incident.jsonl.gz \
+        --since 2018-06-01T09:00:00Z \
+        --until 2018-06-01T11:00:00Z
+
+Show the messages logged by the controller in the last hour that mention
+"lease", but not "lease claimed", as JSON lines:
+
+    juju debug-log --no-tail --replay --since 1h \
+        --agent-type controller \
+        --include-message lease \
+        --exclude-message "lease claimed" \
+        --format json
+
 See also: 
     status
     ssh`
@@ -131,6 +175,11 @@ type debugLogCommand struct {
 	notail bool
 	color  bool
 
+	since        string
+	until        string
+	outputFormat string
+	export       string
+
 	format string
 	tz     *time.Location
 }
@@ -143,6 +192,11 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeMessage), "include-message", "Only show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeMessage), "exclude-message", "Do not show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.AgentType), "agent-type", "Only show log messages from these types of agent: machine, unit, application or controller")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged after this time (RFC3339) or duration ago")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged before this time (RFC3339) or duration ago")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -160,6 +214,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.location, "location", false, "Show filename and line numbers")
 	f.BoolVar(&c.date, "date", false, "Show dates as well as times")
 	f.BoolVar(&c.ms, "ms", false, "Show times to millisecond precision")
+	f.StringVar(&c.outputFormat, "format", "text", `Output format, one of "text" or "json"`)
+	f.StringVar(&c.export, "export", "", "Save the existing (possibly filtered) log messages to this file as gzipped JSON lines")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -174,6 +230,40 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	if c.export != "" && c.tail {
+		return errors.NotValidf("setting --export and --tail")
+	}
+	for _, agentType := range c.params.AgentType {
+		switch agentType {
+		case "machine", "unit", "application", "controller":
+		default:
+			return errors.Errorf("agent type %q is not one of %q, %q, %q, %q",
+				agentType, "machine", "unit", "application", "controller")
+		}
+	}
+	now := time.Now()
+	if c.since != "" {
+		t, err := parseDebugLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.params.StartTime = t
+	}
+	if c.until != "" {
+		t, err := parseDebugLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		c.params.EndTime = t
+	}
+	if !c.params.StartTime.IsZero() && !c.params.EndTime.IsZero() && c.params.EndTime.Before(c.params.StartTime) {
+		return errors.New("--until time is before --since time")
+	}
+	switch c.outputFormat {
+	case "text", "json":
+	default:
+		return errors.Errorf("format %q is not one of %q, %q", c.outputFormat, "text", "json")
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
@@ -190,6 +280,19 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// parseDebugLogTime parses the value of the --since and --until options,
+// which is either a time in RFC3339 format or a duration before now.
+func parseDebugLogTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("%q is neither a time in RFC3339 format nor a duration", value)
+	}
+	return now.Add(-d), nil
+}
+
 func (c *debugLogCommand) processEntities(entities []string) []string {
 	if entities == nil {
 		return nil
@@ -224,6 +327,7 @@ func (c *debugLogCommand) processEntities(entities []string) []string {
 
 type DebugLogAPI interface {
 	WatchDebugLog(params common.DebugLogParams) (<-chan common.LogMessage, error)
+	ExportDebugLog(params common.DebugLogParams) (io.ReadCloser, error)
 	Close() error
 }
 
@@ -256,6 +360,9 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 		return err
 	}
 	defer client.Close()
+	if c.export != "" {
+		return c.exportLog(ctx, client)
+	}
 	messages, err := client.WatchDebugLog(c.params)
 	if err != nil {
 		return err
@@ -269,12 +376,54 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 		if !ok {
 			break
 		}
+		if c.outputFormat == "json" {
+			if err := c.writeJSONLogRecord(writer, msg); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
 		c.writeLogRecord(writer, msg)
 	}
 
 	return nil
 }
 
+// exportLog saves the log messages matching the filter to the export
+// file. The file is removed if the export fails.
+func (c *debugLogCommand) exportLog(ctx *cmd.Context, client DebugLogAPI) (err error) {
+	archive, err := client.ExportDebugLog(c.params)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	filename := ctx.AbsPath(c.export)
+	f, err := os.Create(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = errors.Trace(closeErr)
+		}
+		if err != nil {
+			os.Remove(filename)
+		}
+	}()
+	// The controller can only report a failure part way through the
+	// export by cutting the archive short, so check that it's complete
+	// as it's written.
+	zr, err := gzip.NewReader(io.TeeReader(archive, f))
+	if err != nil {
+		return errors.Annotate(err, "exporting log")
+	}
+	if _, err := io.Copy(ioutil.Discard, zr); err != nil {
+		return errors.Annotate(err, "exporting log")
+	}
+	ctx.Infof("Log exported to %s", c.export)
+	return nil
+}
+
 var SeverityColor = map[string]*ansiterm.Context{
 	"TRACE":   ansiterm.Foreground(ansiterm.Default),
 	"DEBUG":   ansiterm.Foreground(ansiterm.Green),
@@ -297,3 +446,29 @@ func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage
 	}
 	fmt.Fprintln(w, r.Message)
 }
+
+// jsonLogRecord is the form of a log message written with --format json.
+type jsonLogRecord struct {
+	Entity    string    `json:"entity"`
+	Timestamp time.Time `json:"timestamp"`
+	Severity  string    `json:"severity"`
+	Module    string    `json:"module"`
+	Location  string    `json:"location,omitempty"`
+	Message   string    `json:"message"`
+}
+
+func (c *debugLogCommand) writeJSONLogRecord(w *ansiterm.Writer, r common.LogMessage) error {
+	data, err := json.Marshal(jsonLogRecord{
+		Entity:    r.Entity,
+		Timestamp: r.Timestamp.In(c.tz),
+		Severity:  r.Severity,
+		Module:    r.Module,
+		Location:  r.Location,
+		Message:   r.Message,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return errors.Trace(err)
+}
@@ -4,6 +4,12 @@
 package commands
 
 import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/cmd/cmdtesting"
@@ -104,6 +110,49 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--include-message", "^foo", "--exclude-message", "bar$", "--exclude-message", "baz"},
+			expected: common.DebugLogParams{
+				IncludeMessage: []string{"^foo"},
+				ExcludeMessage: []string{"bar$", "baz"},
+				Backlog:        10,
+			},
+		}, {
+			args: []string{"--agent-type", "unit", "--agent-type", "controller"},
+			expected: common.DebugLogParams{
+				AgentType: []string{"unit", "controller"},
+				Backlog:   10,
+			},
+		}, {
+			args:     []string{"--agent-type", "model"},
+			errMatch: `agent type "model" is not one of "machine", "unit", "application", "controller"`,
+		}, {
+			args: []string{"--since", "2016-11-30T10:51:00Z", "--until", "2016-11-30T11:51:00Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				StartTime: time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC),
+				EndTime:   time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since value: "yesterday" is neither a time in RFC3339 format nor a duration`,
+		}, {
+			args:     []string{"--until", "-1h"},
+			errMatch: `invalid --until value: "-1h" is neither a time in RFC3339 format nor a duration`,
+		}, {
+			args:     []string{"--since", "2016-11-30T11:51:00Z", "--until", "2016-11-30T10:51:00Z"},
+			errMatch: `--until time is before --since time`,
+		}, {
+			args: []string{"--format", "json"},
+			expected: common.DebugLogParams{
+				Backlog: 10,
+			},
+		}, {
+			args:     []string{"--export", "logs.jsonl.gz", "--tail"},
+			errMatch: `setting --export and --tail not valid`,
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format "yaml" is not one of "text", "json"`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -142,6 +191,19 @@ func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	})
 }
 
+func (s *DebugLogSuite) TestRelativeTimes(c *gc.C) {
+	command := &debugLogCommand{}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	before := time.Now()
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--since", "2h", "--until", "30m"})
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Now()
+
+	c.Check(command.params.StartTime.Before(before.Add(-2*time.Hour)), jc.IsFalse)
+	c.Check(command.params.StartTime.After(after.Add(-2*time.Hour)), jc.IsFalse)
+	c.Check(command.params.EndTime.Sub(command.params.StartTime), gc.Equals, 90*time.Minute)
+}
+
 func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	// test timezone is 6 hours east of UTC
 	tz := time.FixedZone("test", 6*60*60)
@@ -182,10 +244,77 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	checkOutput(
 		"--location",
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
+	checkOutput(
+		"--format", "json",
+		`{"entity":"machine-0","timestamp":"2016-10-09T14:15:23.345+06:00","severity":"INFO","module":"test.module","location":"somefile.go:123","message":"this is the log output"}`+"\n")
+	checkOutput(
+		"--format", "json", "--utc",
+		`{"entity":"machine-0","timestamp":"2016-10-09T08:15:23.345Z","severity":"INFO","module":"test.module","location":"somefile.go:123","message":"this is the log output"}`+"\n")
+}
+
+func gzipped(c *gc.C, data string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zw.Close(), jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *DebugLogSuite) TestExport(c *gc.C) {
+	archive := gzipped(c, `{"tag":"machine-0","msg":"hello"}`+"\n")
+	fake := &fakeDebugLogAPI{export: archive}
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return fake, nil
+	})
+	dir := c.MkDir()
+	ctx := cmdtesting.Context(c)
+	ctx.Dir = dir
+	command := newDebugLogCommand(jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(command, []string{
+		"--export", "logs.jsonl.gz",
+		"--since", "2018-06-01T09:00:00Z",
+		"--until", "2018-06-01T11:00:00Z",
+		"--include", "machine-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(fake.params, jc.DeepEquals, common.DebugLogParams{
+		IncludeEntity: []string{"machine-0"},
+		Backlog:       10,
+		StartTime:     time.Date(2018, 6, 1, 9, 0, 0, 0, time.UTC),
+		EndTime:       time.Date(2018, 6, 1, 11, 0, 0, 0, time.UTC),
+	})
+	data, err := ioutil.ReadFile(filepath.Join(dir, "logs.jsonl.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, archive)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Log exported to logs.jsonl.gz\n")
+}
+
+func (s *DebugLogSuite) TestExportTruncated(c *gc.C) {
+	archive := gzipped(c, `{"tag":"machine-0","msg":"hello"}`+"\n")
+	fake := &fakeDebugLogAPI{export: archive[:len(archive)-4]}
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return fake, nil
+	})
+	dir := c.MkDir()
+	ctx := cmdtesting.Context(c)
+	ctx.Dir = dir
+	command := newDebugLogCommand(jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(command, []string{"--export", "logs.jsonl.gz"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Run(ctx)
+	c.Assert(err, gc.ErrorMatches, "exporting log: unexpected EOF")
+
+	_, err = os.Stat(filepath.Join(dir, "logs.jsonl.gz"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
 }
 
 type fakeDebugLogAPI struct {
 	log    []common.LogMessage
+	export []byte
 	params common.DebugLogParams
 	err    error
 }
@@ -205,6 +334,14 @@ func (fake *fakeDebugLogAPI) WatchDebugLog(params common.DebugLogParams) (<-chan
 	return response, nil
 }
 
+func (fake *fakeDebugLogAPI) ExportDebugLog(params common.DebugLogParams) (io.ReadCloser, error) {
+	if fake.err != nil {
+		return nil, fake.err
+	}
+	fake.params = params
+	return ioutil.NopCloser(bytes.NewReader(fake.export)), nil
+}
+
 func (fake *fakeDebugLogAPI) Close() error {
 	return nil
 }
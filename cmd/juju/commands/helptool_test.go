@@ -73,6 +73,7 @@ Aliases: help-tool, hook-tools
 var expectedCommands = []string{
 	"action-fail",
 	"action-get",
+	"action-log",
 	"action-set",
 	"add-metric",
 	"application-version-set",
@@ -287,6 +287,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Firewall rule commands.
 	r.Register(firewall.NewSetFirewallRuleCommand())
 	r.Register(firewall.NewListFirewallRulesCommand())
+	r.Register(firewall.NewSetEgressRulesCommand())
+	r.Register(firewall.NewListEgressRulesCommand())
 
 	// Destruction commands.
 	r.Register(application.NewRemoveRelationCommand())
@@ -304,6 +306,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil, nil))
 	r.Register(application.NewResolvedCommand())
+	r.Register(application.NewShowUnitCommand())
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
 
@@ -370,9 +373,15 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewDestroyCommand())
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
+	r.Register(model.NewAddRoleCommand())
+	r.Register(model.NewRemoveRoleCommand())
+	r.Register(model.NewRolesCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewWaitCommand())
 
 	r.Register(newMigrateCommand())
+	r.Register(newCloneModelCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
 		r.Register(model.NewDumpDBCommand())
@@ -384,6 +393,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewAddScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -442,6 +454,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -396,6 +396,8 @@ var commandNames = []string{
 	"add-machine",
 	"add-model",
 	"add-relation",
+	"add-role",
+	"add-schedule",
 	"add-space",
 	"add-ssh-key",
 	"add-storage",
@@ -407,6 +409,7 @@ var commandNames = []string{
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -416,6 +419,7 @@ var commandNames = []string{
 	"change-user-password",
 	"charm",
 	"charm-resources",
+	"clone-model",
 	"clouds",
 	"collect-metrics",
 	"config",
@@ -436,10 +440,12 @@ var commandNames = []string{
 	"disable-user",
 	"disabled-commands",
 	"download-backup",
+	"egress-rules",
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -463,6 +469,7 @@ var commandNames = []string{
 	"list-controllers",
 	"list-credentials",
 	"list-disabled-commands",
+	"list-egress-rules",
 	"list-firewall-rules",
 	"list-machines",
 	"list-models",
@@ -471,6 +478,8 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-roles",
+	"list-schedules",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -505,7 +514,9 @@ var commandNames = []string{
 	"remove-machine",
 	"remove-offer",
 	"remove-relation",
+	"remove-role",
 	"remove-saas",
+	"remove-schedule",
 	"remove-ssh-key",
 	"remove-storage",
 	"remove-unit",
@@ -517,12 +528,15 @@ var commandNames = []string{
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
+	"roles",
 	"run",
 	"run-action",
+	"schedules",
 	"scp",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
+	"set-egress-rules",
 	"set-firewall-rule",
 	"set-meter-status",
 	"set-model-constraints",
@@ -542,6 +556,7 @@ var commandNames = []string{
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-unit",
 	"show-user",
 	"show-wallet",
 	"sla",
@@ -569,6 +584,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait",
 	"wallets",
 	"whoami",
 }
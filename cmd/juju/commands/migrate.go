@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
 
@@ -28,10 +31,12 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationDryRun(spec controller.MigrationSpec) ([]string, error)
 }
 
 const migrateDoc = `
@@ -57,6 +62,13 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, the model is not migrated. Instead the checks run at the
+start of a migration are made on both controllers, and the model is
+exported and trial imported into the target controller, which then
+discards it. Every problem that would cause the migration to fail is
+reported, such as a cloud or credential missing from the target
+controller, an unavailable charm or incompatible Juju versions.
+
 See also:
     login
     controllers
@@ -67,12 +79,18 @@ See also:
 func (c *migrateCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "migrate",
-		Args:    "<model-name> <target-controller-name>",
+		Args:    "[--dry-run] <model-name> <target-controller-name>",
 		Purpose: "Migrate a hosted model to another controller.",
 		Doc:     migrateDoc,
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model can be migrated, without migrating it")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -91,14 +109,24 @@ func (c *migrateCommand) Init(args []string) error {
 }
 
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
+	return getTargetControllerSpec(&c.ModelCommandBase, c.newAPIRoot, c.targetController)
+}
+
+// getTargetControllerSpec returns a migration spec holding the details
+// needed to connect to the target controller on the user's behalf.
+func getTargetControllerSpec(
+	c *modelcmd.ModelCommandBase,
+	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error),
+	targetController string,
+) (*controller.MigrationSpec, error) {
 	store := c.ClientStore()
 
-	controllerInfo, err := store.ControllerByName(c.targetController)
+	controllerInfo, err := store.ControllerByName(targetController)
 	if err != nil {
 		return nil, err
 	}
 
-	accountInfo, err := store.AccountDetails(c.targetController)
+	accountInfo, err := store.AccountDetails(targetController)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +134,7 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	var macs []macaroon.Slice
 	if accountInfo.Password == "" {
 		var err error
-		macs, err = c.getTargetControllerMacaroons()
+		macs, err = getTargetControllerMacaroons(c, newAPIRoot, targetController)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -141,6 +169,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.runDryRun(ctx, api, *spec, modelName)
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -149,6 +180,22 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, api migrateAPI, spec controller.MigrationSpec, modelName string) error {
+	problems, err := api.MigrationDryRun(spec)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		ctx.Infof("Model %q can be migrated to controller %q", modelName, c.targetController)
+		return nil
+	}
+	ctx.Infof("Model %q can't be migrated to controller %q:", modelName, c.targetController)
+	for _, problem := range problems {
+		fmt.Fprintf(ctx.Stdout, "  - %s\n", problem)
+	}
+	return cmd.ErrSilent
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
@@ -160,8 +207,12 @@ func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	return controller.NewClient(apiRoot), nil
 }
 
-func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error) {
-	jar, err := c.CommandBase.CookieJar(c.ClientStore(), c.targetController)
+func getTargetControllerMacaroons(
+	c *modelcmd.ModelCommandBase,
+	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error),
+	targetController string,
+) ([]macaroon.Slice, error) {
+	jar, err := c.CommandBase.CookieJar(c.ClientStore(), targetController)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -171,7 +222,7 @@ func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error
 	//
 	// TODO(axw,mjs) add a controller API that returns a macaroon that
 	// may be used for the sole purpose of migration.
-	api, err := c.newAPIRoot(c.ClientStore(), c.targetController, "")
+	api, err := newAPIRoot(c.ClientStore(), targetController, "")
 	if err != nil {
 		return nil, errors.Annotate(err, "connecting to target controller")
 	}
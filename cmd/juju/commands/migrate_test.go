@@ -156,6 +156,31 @@ func (s *MigrateSuite) TestSuccess(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.api.dryRunSeen, jc.IsTrue)
+	c.Check(s.api.specSeen.ModelUUID, gc.Equals, modelUUID)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to controller \"target\"\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *MigrateSuite) TestDryRunProblems(c *gc.C) {
+	s.api.dryRunProblems = []string{
+		"target prechecks failed: model has higher version than target controller (2.5.0 > 2.4.0)",
+		`import validation failed: cloud "aws" not found`,
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(s.api.dryRunSeen, jc.IsTrue)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"model\" can't be migrated to controller \"target\":\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"  - target prechecks failed: model has higher version than target controller (2.5.0 > 2.4.0)\n"+
+		"  - import validation failed: cloud \"aws\" not found\n")
+}
+
 func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	err := s.store.UpdateAccount("target", jujuclient.AccountDetails{
 		User:     "targetuser",
@@ -236,7 +261,9 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen       *controller.MigrationSpec
+	dryRunSeen     bool
+	dryRunProblems []string
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -244,6 +271,12 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) MigrationDryRun(spec controller.MigrationSpec) ([]string, error) {
+	a.specSeen = &spec
+	a.dryRunSeen = true
+	return a.dryRunProblems, nil
+}
+
 type fakeModelAPI struct {
 	models []base.UserModel
 }
@@ -0,0 +1,232 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewAuditLogCommand returns a command that allows a controller admin
+// to query the controller's audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{clock: clock.WallClock})
+}
+
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	newAPI func(addr string) (auditLogAPI, error)
+	clock  clock.Clock
+
+	who            string
+	modelUUID      string
+	conversationID string
+	facade         string
+	method         string
+	after          string
+	before         string
+	machine        string
+}
+
+type auditLogAPI interface {
+	Close() error
+	OpenAuditLog(params.AuditLogQuery) (apicontroller.AuditLogStream, error)
+}
+
+var auditLogDoc = `
+Shows the audit records held by the controller, one JSON record per line.
+
+Records are grouped into conversations: a conversation record describes
+who connected and to which model, followed by the requests made over that
+connection and the responses to them. When filtering by facade, method or
+time, a conversation is only shown if at least one of its requests matches.
+
+The --after and --before options accept either an RFC3339 timestamp or a
+duration, which is taken as that long ago.
+
+Each controller machine keeps its own audit log, so a highly available
+controller refuses to show records unless the machine whose audit log is
+to be shown is specified with --machine.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --after 24h
+    juju audit-log --facade Application --method Deploy
+    juju audit-log --model-uuid deadbeef-0bad-400d-8000-4b1d0d06f00d
+    juju audit-log --machine 1
+
+See also:
+    controller-config
+`
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Shows the audit records held by the controller.",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.who, "user", "", "Only show conversations started by this user")
+	f.StringVar(&c.modelUUID, "model-uuid", "", "Only show conversations with this model")
+	f.StringVar(&c.conversationID, "conversation", "", "Only show the conversation with this ID")
+	f.StringVar(&c.facade, "facade", "", "Only show requests to this facade")
+	f.StringVar(&c.method, "method", "", "Only show requests to this method")
+	f.StringVar(&c.after, "after", "", "Only show requests made at or after this time")
+	f.StringVar(&c.before, "before", "", "Only show requests made before this time")
+	f.StringVar(&c.machine, "machine", "", "Show the audit log of this controller machine")
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// getAPI returns an API client connected to the given controller
+// address, or to any of the controller's addresses if it's empty.
+func (c *auditLogCommand) getAPI(addr string) (auditLogAPI, error) {
+	if c.newAPI != nil {
+		return c.newAPI(addr)
+	}
+	if addr == "" {
+		return c.NewControllerAPIClient()
+	}
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	accountDetails, err := c.CurrentAccountDetails()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !names.NewUserTag(accountDetails.User).IsLocal() {
+		// External users log in with macaroons, as for NewAPIRoot.
+		accountDetails = &jujuclient.AccountDetails{}
+	}
+	args, err := c.NewAPIConnectionParams(c.ClientStore(), controllerName, "", accountDetails)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	openAPI := args.OpenAPI
+	args.OpenAPI = func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+		info.Addrs = []string{addr}
+		return openAPI(info, opts)
+	}
+	conn, err := juju.NewAPIConnection(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apicontroller.NewClient(conn), nil
+}
+
+// openAuditLog opens the audit log stream for the query. If the query
+// names a controller machine, each of the controller's addresses is
+// tried in turn until the machine is found.
+func (c *auditLogCommand) openAuditLog(query params.AuditLogQuery) (auditLogAPI, apicontroller.AuditLogStream, error) {
+	addrs := []string{""}
+	if query.Machine != "" {
+		controllerName, err := c.ControllerName()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		details, err := c.ClientStore().ControllerByName(controllerName)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		addrs = details.APIEndpoints
+	}
+	for _, addr := range addrs {
+		client, err := c.getAPI(addr)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		stream, err := client.OpenAuditLog(query)
+		if err == nil {
+			return client, stream, nil
+		}
+		client.Close()
+		if query.Machine == "" || !params.IsCodeNotFound(errors.Cause(err)) {
+			return nil, nil, errors.Trace(err)
+		}
+		logger.Debugf("%v", err)
+	}
+	return nil, nil, errors.NotFoundf("controller machine %q", query.Machine)
+}
+
+// parseTime accepts either an RFC3339 timestamp or a duration, which
+// is taken relative to now, and returns the time in RFC3339 format.
+func (c *auditLogCommand) parseTime(name, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return "", errors.NotValidf("%s value %q", name, value)
+	}
+	return c.clock.Now().Add(-d).UTC().Format(time.RFC3339), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	after, err := c.parseTime("--after", c.after)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	before, err := c.parseTime("--before", c.before)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, stream, err := c.openAuditLog(params.AuditLogQuery{
+		Who:            c.who,
+		ModelUUID:      c.modelUUID,
+		ConversationID: c.conversationID,
+		Facade:         c.facade,
+		Method:         c.method,
+		After:          after,
+		Before:         before,
+		Machine:        c.machine,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	defer stream.Close()
+
+	enc := json.NewEncoder(ctx.Stdout)
+	for {
+		record, err := stream.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := enc.Encode(record); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
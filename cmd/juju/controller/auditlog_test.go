@@ -0,0 +1,182 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *jujutesting.Clock
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeAuditLogAPI{stream: &fakeAuditLogStream{}}
+	s.clock = jujutesting.NewClock(time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *auditLogSuite) newCommand() cmd.Command {
+	return controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+}
+
+func (s *auditLogSuite) TestOutput(c *gc.C) {
+	s.api.stream.records = []auditlog.Record{{
+		Conversation: &auditlog.Conversation{Who: "bob", ConversationID: "c1"},
+	}, {
+		Request: &auditlog.Request{ConversationID: "c1", RequestID: 1, Facade: "Client", Method: "FullStatus"},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		`{"conversation":{"who":"bob","what":"","when":"","model-name":"","model-uuid":"","conversation-id":"c1","connection-id":""}}`+"\n"+
+		`{"request":{"conversation-id":"c1","connection-id":"","request-id":1,"when":"","facade":"Client","method":"FullStatus","version":0}}`+"\n",
+	)
+	c.Assert(s.api.closed, jc.IsTrue)
+	c.Assert(s.api.stream.closed, jc.IsTrue)
+}
+
+func (s *auditLogSuite) TestMachine(c *gc.C) {
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{
+		APIEndpoints: []string{"10.0.0.1:17070", "10.0.0.2:17070", "10.0.0.3:17070"},
+	}
+	// The first address is for another machine.
+	s.api.errs = []error{&params.Error{Code: params.CodeNotFound, Message: "wrong machine"}}
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--machine", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.opens, gc.Equals, 2)
+	c.Assert(s.api.query.Machine, gc.Equals, "1")
+	c.Assert(s.api.stream.closed, jc.IsTrue)
+}
+
+func (s *auditLogSuite) TestMachineNotFound(c *gc.C) {
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{
+		APIEndpoints: []string{"10.0.0.1:17070"},
+	}
+	s.api.errs = []error{&params.Error{Code: params.CodeNotFound, Message: "wrong machine"}}
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--machine", "1")
+	c.Assert(err, gc.ErrorMatches, `controller machine "1" not found`)
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(),
+		"--user", "bob",
+		"--model-uuid", coretesting.ModelTag.Id(),
+		"--conversation", "c1",
+		"--facade", "Client",
+		"--method", "FullStatus",
+		"--after", "24h",
+		"--before", "2018-06-01T11:00:00Z",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.query, jc.DeepEquals, params.AuditLogQuery{
+		Who:            "bob",
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ConversationID: "c1",
+		Facade:         "Client",
+		Method:         "FullStatus",
+		After:          "2018-05-31T12:00:00Z",
+		Before:         "2018-06-01T11:00:00Z",
+	})
+}
+
+func (s *auditLogSuite) TestInvalidTime(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--after", "yesterday")
+	c.Assert(err, gc.ErrorMatches, `--after value "yesterday" not valid`)
+	c.Assert(s.api.opened, jc.IsFalse)
+}
+
+func (s *auditLogSuite) TestUnrecognizedArg(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "whoops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
+}
+
+func (s *auditLogSuite) TestOpenError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *auditLogSuite) TestStreamError(c *gc.C) {
+	s.api.stream.err = errors.New("kaboom")
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+type fakeAuditLogAPI struct {
+	stream *fakeAuditLogStream
+	query  params.AuditLogQuery
+	err    error
+	errs   []error
+	opened bool
+	opens  int
+	closed bool
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeAuditLogAPI) OpenAuditLog(q params.AuditLogQuery) (apicontroller.AuditLogStream, error) {
+	f.opened = true
+	f.opens++
+	f.query = q
+	if f.err != nil {
+		return nil, f.err
+	}
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	return f.stream, nil
+}
+
+type fakeAuditLogStream struct {
+	records []auditlog.Record
+	err     error
+	closed  bool
+}
+
+func (f *fakeAuditLogStream) Next() (auditlog.Record, error) {
+	if f.err != nil {
+		return auditlog.Record{}, f.err
+	}
+	if len(f.records) == 0 {
+		return auditlog.Record{}, io.EOF
+	}
+	record := f.records[0]
+	f.records = f.records[1:]
+	return record, nil
+}
+
+func (f *fakeAuditLogStream) Close() error {
+	f.closed = true
+	return nil
+}
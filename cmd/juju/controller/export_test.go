@@ -171,6 +171,17 @@ func NewConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore) cm
 	return modelcmd.WrapController(c)
 }
 
+// NewAuditLogCommandForTest returns an auditLogCommand with the api
+// and clock provided as specified.
+func NewAuditLogCommandForTest(api auditLogAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{
+		newAPI: func(string) (auditLogAPI, error) { return api, nil },
+		clock:  clock,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
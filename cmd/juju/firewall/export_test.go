@@ -33,3 +33,27 @@ func NewSetRulesCommandForTest(
 	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
 	return modelcmd.Wrap(aCmd)
 }
+
+func NewSetEgressRulesCommandForTest(
+	api SetEgressRulesAPI,
+) cmd.Command {
+	aCmd := &setEgressRulesCommand{
+		newAPIFunc: func() (SetEgressRulesAPI, error) {
+			return api, nil
+		},
+	}
+	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(aCmd)
+}
+
+func NewListEgressRulesCommandForTest(
+	api ListEgressRulesAPI,
+) cmd.Command {
+	aCmd := &listEgressRulesCommand{
+		newAPIFunc: func() (ListEgressRulesAPI, error) {
+			return api, nil
+		},
+	}
+	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(aCmd)
+}
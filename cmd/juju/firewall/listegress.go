@@ -0,0 +1,113 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+var listEgressRulesHelpSummary = `
+Prints the egress rules of an application.`[1:]
+
+var listEgressRulesHelpDetails = `
+Lists the egress rules which control the outgoing traffic allowed
+from the machines hosting an application's units.
+
+Examples:
+    juju list-egress-rules mysql
+    juju egress-rules mysql
+
+See also: 
+    set-egress-rules`
+
+// NewListEgressRulesCommand returns a command to list the egress rules
+// of an application.
+func NewListEgressRulesCommand() cmd.Command {
+	cmd := &listEgressRulesCommand{}
+	cmd.newAPIFunc = func() (ListEgressRulesAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return firewallrules.NewClient(root), nil
+
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type listEgressRulesCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	out         cmd.Output
+	application string
+
+	newAPIFunc func() (ListEgressRulesAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *listEgressRulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-egress-rules",
+		Args:    "<application>",
+		Purpose: listEgressRulesHelpSummary,
+		Doc:     listEgressRulesHelpDetails,
+		Aliases: []string{"egress-rules"},
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *listEgressRulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatEgressListTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listEgressRulesCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.application = args[0]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ListEgressRulesAPI defines the API methods that the list egress rules command uses.
+type ListEgressRulesAPI interface {
+	Close() error
+	EgressRules(application string) ([]network.EgressRule, error)
+}
+
+// Run implements cmd.Command.
+func (c *listEgressRulesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	rulesResult, err := client.EgressRules(c.application)
+	if err != nil {
+		return err
+	}
+
+	rules := make([]egressRule, len(rulesResult))
+	for i, r := range rulesResult {
+		rules[i] = egressRule{
+			Ports:            r.PortRange.String(),
+			DestinationCIDRs: r.DestinationCIDRs,
+		}
+	}
+	return c.out.Write(ctx, rules)
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type ListEgressRulesSuite struct {
+	testing.BaseSuite
+
+	mockAPI *mockListEgressRulesAPI
+}
+
+var _ = gc.Suite(&ListEgressRulesSuite{})
+
+func (s *ListEgressRulesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mockAPI = &mockListEgressRulesAPI{
+		rules: []network.EgressRule{
+			network.MustNewEgressRule("tcp", 443, 443),
+			network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32", "10.0.0.3/32"),
+		},
+	}
+}
+
+func (s *ListEgressRulesSuite) TestInitMissingApplication(c *gc.C) {
+	_, err := s.runList(c)
+	c.Assert(err, gc.ErrorMatches, "no application specified")
+}
+
+func (s *ListEgressRulesSuite) TestListError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runList(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, ".*fail.*")
+}
+
+func (s *ListEgressRulesSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.runList(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.application, gc.Equals, "mysql")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Ports    Destination subnets
+443/tcp  0.0.0.0/0
+53/udp   10.0.0.2/32,10.0.0.3/32
+
+`[1:])
+}
+
+func (s *ListEgressRulesSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.runList(c, "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- ports: 443/tcp
+- ports: 53/udp
+  destination-subnets:
+  - 10.0.0.2/32
+  - 10.0.0.3/32
+`[1:])
+}
+
+func (s *ListEgressRulesSuite) runList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, firewall.NewListEgressRulesCommandForTest(s.mockAPI), args...)
+}
+
+type mockListEgressRulesAPI struct {
+	application string
+	rules       []network.EgressRule
+	err         error
+}
+
+func (s *mockListEgressRulesAPI) Close() error {
+	return nil
+}
+
+func (s *mockListEgressRulesAPI) EgressRules(application string) ([]network.EgressRule, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.application = application
+	return s.rules, nil
+}
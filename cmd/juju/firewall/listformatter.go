@@ -48,3 +48,33 @@ func formatFirewallRulesTabular(writer io.Writer, rules firewallRules) {
 	}
 	tw.Flush()
 }
+
+type egressRule struct {
+	Ports            string   `yaml:"ports" json:"ports"`
+	DestinationCIDRs []string `yaml:"destination-subnets,omitempty" json:"destination-subnets,omitempty"`
+}
+
+func formatEgressListTabular(writer io.Writer, value interface{}) error {
+	rules, ok := value.([]egressRule)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", rules, value)
+	}
+	formatEgressRulesTabular(writer, rules)
+	return nil
+}
+
+// formatEgressRulesTabular returns a tabular summary of egress rules.
+func formatEgressRulesTabular(writer io.Writer, rules []egressRule) {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Ports", "Destination subnets")
+	for _, rule := range rules {
+		destinations := strings.Join(rule.DestinationCIDRs, ",")
+		if destinations == "" {
+			destinations = "0.0.0.0/0"
+		}
+		w.Println(rule.Ports, destinations)
+	}
+	tw.Flush()
+}
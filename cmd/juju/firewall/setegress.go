@@ -0,0 +1,158 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"net"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+var setEgressRulesHelpSummary = `
+Sets the egress rules of an application.`[1:]
+
+var setEgressRulesHelpDetails = `
+Egress rules control the outgoing traffic allowed from the machines
+hosting an application's units. Each rule consists of a port range
+and protocol, and optionally a comma separated list of destination
+subnets; if no subnets are given, traffic to the ports is allowed
+to any destination.
+
+Once any application deployed to a machine has egress rules, the
+machine may only send traffic allowed by the egress rules of the
+applications deployed to it. Setting egress rules replaces any the
+application already has; use --clear to remove them, which lifts the
+restriction.
+
+Traffic to the controller's API servers is always allowed, so that
+the machine agents keep working. Nothing else is: unless the rules
+allow it, the machines cannot resolve names (53/udp and 53/tcp to the
+model's DNS servers), install packages or charm dependencies (80/tcp
+and 443/tcp to the archive or proxy mirrors), or reach NTP servers
+(123/udp). Include rules for whichever of these the application needs.
+
+Egress rules are only enforced in models using the "instance"
+firewall mode, by providers that support them (currently OpenStack
+with Neutron); elsewhere, setting them fails.
+
+Examples:
+    juju set-egress-rules mysql 443/tcp
+    juju set-egress-rules mysql 443/tcp=10.0.0.0/8 53/udp=10.0.0.2/32,10.0.0.3/32
+    juju set-egress-rules mysql 53/udp=10.0.0.2/32 80/tcp=10.0.0.10/32 443/tcp=10.0.0.10/32
+    juju set-egress-rules mysql --clear
+
+See also: 
+    list-egress-rules`
+
+// NewSetEgressRulesCommand returns a command to set the egress rules
+// of an application.
+func NewSetEgressRulesCommand() cmd.Command {
+	cmd := &setEgressRulesCommand{}
+	cmd.newAPIFunc = func() (SetEgressRulesAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return firewallrules.NewClient(root), nil
+
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type setEgressRulesCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	application string
+	clear       bool
+
+	rules      []network.EgressRule
+	newAPIFunc func() (SetEgressRulesAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *setEgressRulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-egress-rules",
+		Args:    "<application> <port>[-<port>]/<protocol>[=<cidr>[,<cidr>...]] ...",
+		Purpose: setEgressRulesHelpSummary,
+		Doc:     setEgressRulesHelpDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *setEgressRulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.clear, "clear", false, "remove the application's egress rules")
+}
+
+// Init implements cmd.Command.
+func (c *setEgressRulesCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.application, args = args[0], args[1:]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	if c.clear {
+		return cmd.CheckEmpty(args)
+	}
+	if len(args) == 0 {
+		return errors.New("no egress rules specified, use --clear to remove them")
+	}
+	for _, arg := range args {
+		rule, err := parseEgressRule(arg)
+		if err != nil {
+			return errors.Annotatef(err, "invalid egress rule %q", arg)
+		}
+		c.rules = append(c.rules, rule)
+	}
+	return nil
+}
+
+// parseEgressRule parses an egress rule of the form
+// <port>[-<port>]/<protocol>[=<cidr>[,<cidr>...]].
+func parseEgressRule(value string) (network.EgressRule, error) {
+	parts := strings.SplitN(value, "=", 2)
+	portRange, err := network.ParsePortRange(parts[0])
+	if err != nil {
+		return network.EgressRule{}, errors.Trace(err)
+	}
+	var cidrs []string
+	if len(parts) == 2 {
+		for _, cidr := range strings.Split(parts[1], ",") {
+			cidr = strings.TrimSpace(cidr)
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return network.EgressRule{}, errors.Trace(err)
+			}
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return network.EgressRule{PortRange: portRange, DestinationCIDRs: cidrs}, nil
+}
+
+// SetEgressRulesAPI defines the API methods that the set egress rules command uses.
+type SetEgressRulesAPI interface {
+	Close() error
+	SetEgressRules(application string, rules []network.EgressRule) error
+}
+
+// Run implements cmd.Command.
+func (c *setEgressRulesCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetEgressRules(c.application, c.rules)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type SetEgressRulesSuite struct {
+	testing.BaseSuite
+
+	mockAPI *mockSetEgressRulesAPI
+}
+
+var _ = gc.Suite(&SetEgressRulesSuite{})
+
+func (s *SetEgressRulesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mockAPI = &mockSetEgressRulesAPI{}
+}
+
+func (s *SetEgressRulesSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application specified",
+	}, {
+		args: []string{"no_such"},
+		err:  `application name "no_such" not valid`,
+	}, {
+		args: []string{"mysql"},
+		err:  "no egress rules specified, use --clear to remove them",
+	}, {
+		args: []string{"mysql", "443/sctp"},
+		err:  `invalid egress rule "443/sctp": invalid protocol "sctp", expected "tcp", "udp", or "icmp"`,
+	}, {
+		args: []string{"mysql", "443/tcp=10.0/8"},
+		err:  `invalid egress rule "443/tcp=10.0/8": invalid CIDR address: 10.0/8`,
+	}, {
+		args: []string{"mysql", "--clear", "443/tcp"},
+		err:  `unrecognized args: \["443/tcp"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		_, err := s.runSetEgressRules(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SetEgressRulesSuite) TestSetEgressRules(c *gc.C) {
+	_, err := s.runSetEgressRules(c, "mysql", "443/tcp", "53/udp=10.0.0.2/32,10.0.0.3/32", "8000-8080/tcp=2001:db8::/32")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.application, gc.Equals, "mysql")
+	c.Assert(s.mockAPI.rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32", "10.0.0.3/32"),
+		network.MustNewEgressRule("tcp", 8000, 8080, "2001:db8::/32"),
+	})
+}
+
+func (s *SetEgressRulesSuite) TestClearEgressRules(c *gc.C) {
+	s.mockAPI.rules = []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)}
+	_, err := s.runSetEgressRules(c, "mysql", "--clear")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.application, gc.Equals, "mysql")
+	c.Assert(s.mockAPI.rules, gc.HasLen, 0)
+}
+
+func (s *SetEgressRulesSuite) TestSetError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runSetEgressRules(c, "mysql", "443/tcp")
+	c.Assert(err, gc.ErrorMatches, ".*fail.*")
+}
+
+func (s *SetEgressRulesSuite) runSetEgressRules(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, firewall.NewSetEgressRulesCommandForTest(s.mockAPI), args...)
+}
+
+type mockSetEgressRulesAPI struct {
+	application string
+	rules       []network.EgressRule
+	err         error
+}
+
+func (s *mockSetEgressRulesAPI) Close() error {
+	return nil
+}
+
+func (s *mockSetEgressRulesAPI) SetEgressRules(application string, rules []network.EgressRule) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.application = application
+	s.rules = rules
+	return nil
+}
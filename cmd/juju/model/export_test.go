@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -45,6 +46,17 @@ func NewRetryProvisioningCommandForTest(api RetryProvisioningAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewWaitCommandForTest returns a WaitCommand with the api and clock
+// provided as specified.
+func NewWaitCommandForTest(api WaitAPI, clock clock.Clock) cmd.Command {
+	cmd := &waitCommand{
+		api:   api,
+		clock: clock,
+	}
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(cmd)
+}
+
 // NewShowCommandForTest returns a ShowCommand with the api provided as specified.
 func NewShowCommandForTest(api ShowModelAPI, refreshFunc func(jujuclient.ClientStore, string) error, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showModelCommand{api: api}
@@ -60,6 +72,13 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.Wrap(cmd)
 }
 
+// NewExportBundleCommandForTest returns an ExportBundleCommand with the api provided as specified.
+func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportBundleCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewDumpDBCommandForTest returns a DumpDBCommand with the api provided as specified.
 func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpDBCommand{api: api}
@@ -118,6 +137,50 @@ func NewRevokeCommandForTest(modelsApi RevokeModelAPI, offersAPI RevokeOfferAPI,
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
 
+// NewGrantApplicationCommandForTest returns a GrantCommand with the
+// application api provided as specified.
+func NewGrantApplicationCommandForTest(applicationsAPI GrantApplicationAPI, store jujuclient.ClientStore) (cmd.Command, *GrantCommand) {
+	cmd := &grantCommand{
+		applicationsApi: applicationsAPI,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd), &GrantCommand{cmd}
+}
+
+// NewRevokeApplicationCommandForTest returns a RevokeCommand with the
+// application api provided as specified.
+func NewRevokeApplicationCommandForTest(applicationsAPI RevokeApplicationAPI, store jujuclient.ClientStore) (cmd.Command, *RevokeCommand) {
+	cmd := &revokeCommand{
+		applicationsApi: applicationsAPI,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
+}
+
+// NewAddRoleCommandForTest returns an add-role command with the api
+// provided as specified.
+func NewAddRoleCommandForTest(api RoleAPI) cmd.Command {
+	cmd := &addRoleCommand{roleCommandBase: roleCommandBase{api: api}}
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRemoveRoleCommandForTest returns a remove-role command with the api
+// provided as specified.
+func NewRemoveRoleCommandForTest(api RoleAPI) cmd.Command {
+	cmd := &removeRoleCommand{roleCommandBase: roleCommandBase{api: api}}
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRolesCommandForTest returns a roles command with the api provided
+// as specified.
+func NewRolesCommandForTest(api RoleAPI) cmd.Command {
+	cmd := &rolesCommand{roleCommandBase: roleCommandBase{api: api}}
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(cmd)
+}
+
 func NewModelSetConstraintsCommandForTest() cmd.Command {
 	cmd := &modelSetConstraintsCommand{}
 	cmd.SetClientStore(jujuclienttesting.MinimalStore())
@@ -6,8 +6,10 @@ package model
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/api/applicationoffers"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -17,7 +19,7 @@ import (
 )
 
 var usageGrantSummary = `
-Grants access level to a Juju user for a model, controller, or application offer.`[1:]
+Grants access level to a Juju user for a model, controller, application offer or application.`[1:]
 
 var usageGrantDetails = `
 By default, the controller is the current controller.
@@ -40,6 +42,15 @@ Valid access levels for application offers are:
     consume
     admin
 
+Users may also be granted a role on a single application in a model with
+the --application option, allowing them to call only the API methods
+making up the role on that application without write access to the
+model. The role may be one added to the model with add-role, or one of
+the application access levels:
+    config
+    run-action
+    upgrade-charm
+
 Examples:
 Grant user 'joe' 'read' access to model 'mymodel':
 
@@ -69,12 +80,21 @@ Grant user 'sam' 'read' access to application offers 'fred/prod.hosted-mysql' an
 
     juju grant sam read fred/prod.hosted-mysql mary/test.hosted-mysql
 
+Grant user 'joe' 'config' access to application 'mysql' in model 'mymodel':
+
+    juju grant joe config mymodel --application mysql
+
+Grant user 'jim' the 'operator' role on application 'mysql' in model 'mymodel':
+
+    juju grant jim operator mymodel --application mysql
+
 See also: 
     revoke
-    add-user`[1:]
+    add-user
+    add-role`[1:]
 
 var usageRevokeSummary = `
-Revokes access from a Juju user for a model, controller, or application offer.`[1:]
+Revokes access from a Juju user for a model, controller, application offer or application.`[1:]
 
 var usageRevokeDetails = `
 By default, the controller is the current controller.
@@ -83,6 +103,9 @@ Revoking write access, from a user who has that permission, will leave
 that user with read access. Revoking read access, however, also revokes
 write access.
 
+Revoking a role on an application with the --application option revokes
+whichever role the user was granted on it.
+
 Examples:
 Revoke 'read' (and 'write') access from user 'joe' for model 'mymodel':
 
@@ -104,16 +127,27 @@ Revoke 'consume' access from user 'sam' for models 'fred/prod.hosted-mysql' and
 
     juju revoke sam consume fred/prod.hosted-mysql mary/test.hosted-mysql
 
+Revoke the 'operator' role from user 'jim' for application 'mysql' in model 'mymodel':
+
+    juju revoke jim operator mymodel --application mysql
+
 See also: 
     grant`[1:]
 
 type accessCommand struct {
 	modelcmd.ControllerCommandBase
 
-	User       string
-	ModelNames []string
-	OfferURLs  []*crossmodel.OfferURL
-	Access     string
+	User        string
+	ModelNames  []string
+	OfferURLs   []*crossmodel.OfferURL
+	Access      string
+	Application string
+}
+
+// SetFlags implements cmd.Command.
+func (c *accessCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.Application, "application", "", "The application in the model to change the user's role on")
 }
 
 // Init implements cmd.Command.
@@ -151,6 +185,9 @@ func (c *accessCommand) Init(args []string) error {
 	if len(c.ModelNames) > 0 && len(c.OfferURLs) > 0 {
 		return errors.New("either specify model names or offer URLs but not both")
 	}
+	if c.Application != "" {
+		return c.initApplication()
+	}
 
 	// Special case for backwards compatibility.
 	if c.Access == "addmodel" {
@@ -177,6 +214,19 @@ func (c *accessCommand) Init(args []string) error {
 	return nil
 }
 
+func (c *accessCommand) initApplication() error {
+	if !names.IsValidApplication(c.Application) {
+		return errors.NotValidf("application name %q", c.Application)
+	}
+	if len(c.ModelNames) != 1 || len(c.OfferURLs) > 0 {
+		return errors.New("--application requires exactly one model name")
+	}
+	if err := permission.ValidateApplicationAccess(permission.Access(c.Access)); err == nil {
+		return nil
+	}
+	return permission.ValidateRoleName(c.Access)
+}
+
 // NewGrantCommand returns a new grant command.
 func NewGrantCommand() cmd.Command {
 	return modelcmd.WrapController(&grantCommand{})
@@ -185,8 +235,9 @@ func NewGrantCommand() cmd.Command {
 // grantCommand represents the command to grant a user access to one or more models.
 type grantCommand struct {
 	accessCommand
-	modelsApi GrantModelAPI
-	offersApi GrantOfferAPI
+	modelsApi       GrantModelAPI
+	offersApi       GrantOfferAPI
+	applicationsApi GrantApplicationAPI
 }
 
 // Info implements Command.Info.
@@ -221,6 +272,17 @@ func (c *grantCommand) getOfferAPI() (GrantOfferAPI, error) {
 	return applicationoffers.NewClient(root), nil
 }
 
+func (c *grantCommand) getApplicationAPI() (GrantApplicationAPI, error) {
+	if c.applicationsApi != nil {
+		return c.applicationsApi, nil
+	}
+	root, err := c.NewModelAPIRoot(c.ModelNames[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
 // GrantModelAPI defines the API functions used by the grant command.
 type GrantModelAPI interface {
 	Close() error
@@ -239,8 +301,17 @@ type GrantOfferAPI interface {
 	GrantOffer(user, access string, offerURLs ...string) error
 }
 
+// GrantApplicationAPI defines the API functions used by the grant command.
+type GrantApplicationAPI interface {
+	Close() error
+	GrantApplication(user, role string, applications ...string) error
+}
+
 // Run implements cmd.Command.
 func (c *grantCommand) Run(ctx *cmd.Context) error {
+	if c.Application != "" {
+		return c.runForApplication()
+	}
 	if len(c.ModelNames) > 0 {
 		return c.runForModel()
 	}
@@ -292,6 +363,17 @@ func (c *grantCommand) runForOffers() error {
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
 
+func (c *grantCommand) runForApplication() error {
+	client, err := c.getApplicationAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.GrantApplication(c.User, c.Access, c.Application)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
 // NewRevokeCommand returns a new revoke command.
 func NewRevokeCommand() cmd.Command {
 	return modelcmd.WrapController(&revokeCommand{})
@@ -300,8 +382,9 @@ func NewRevokeCommand() cmd.Command {
 // revokeCommand revokes a user's access to models.
 type revokeCommand struct {
 	accessCommand
-	modelsApi RevokeModelAPI
-	offersApi RevokeOfferAPI
+	modelsApi       RevokeModelAPI
+	offersApi       RevokeOfferAPI
+	applicationsApi RevokeApplicationAPI
 }
 
 // Info implements cmd.Command.
@@ -336,6 +419,17 @@ func (c *revokeCommand) getOfferAPI() (RevokeOfferAPI, error) {
 	return applicationoffers.NewClient(root), nil
 }
 
+func (c *revokeCommand) getApplicationAPI() (RevokeApplicationAPI, error) {
+	if c.applicationsApi != nil {
+		return c.applicationsApi, nil
+	}
+	root, err := c.NewModelAPIRoot(c.ModelNames[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
 // RevokeModelAPI defines the API functions used by the revoke command.
 type RevokeModelAPI interface {
 	Close() error
@@ -354,8 +448,17 @@ type RevokeOfferAPI interface {
 	RevokeOffer(user, access string, offerURLs ...string) error
 }
 
+// RevokeApplicationAPI defines the API functions used by the revoke command.
+type RevokeApplicationAPI interface {
+	Close() error
+	RevokeApplication(user string, applications ...string) error
+}
+
 // Run implements cmd.Command.
 func (c *revokeCommand) Run(ctx *cmd.Context) error {
+	if c.Application != "" {
+		return c.runForApplication()
+	}
 	if len(c.ModelNames) > 0 {
 		return c.runForModel()
 	}
@@ -392,6 +495,17 @@ func (c *revokeCommand) runForModel() error {
 	return block.ProcessBlockedError(client.RevokeModel(c.User, c.Access, models...), block.BlockChange)
 }
 
+func (c *revokeCommand) runForApplication() error {
+	client, err := c.getApplicationAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.RevokeApplication(c.User, c.Application)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
 type accountDetailsGetter interface {
 	CurrentAccountDetails() (*jujuclient.AccountDetails, error)
 }
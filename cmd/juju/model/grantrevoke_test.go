@@ -235,6 +235,63 @@ func (s *grantSuite) TestControllerAccessForOffer(c *gc.C) {
 	c.Check(msg, gc.Matches, `You have specified a controller access permission "superuser".*`)
 }
 
+func (s *grantSuite) TestInitApplication(c *gc.C) {
+	wrappedCmd, grantCmd := model.NewGrantApplicationCommandForTest(nil, s.store)
+	err := cmdtesting.InitCommand(wrappedCmd, []string{"bob", "operator", "model1", "--application", "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grantCmd.User, gc.Equals, "bob")
+	c.Assert(grantCmd.Access, gc.Equals, "operator")
+	c.Assert(grantCmd.ModelNames, jc.DeepEquals, []string{"model1"})
+	c.Assert(grantCmd.Application, gc.Equals, "mysql")
+}
+
+func (s *grantSuite) TestInitApplicationErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"bob", "config", "--application", "mysql"},
+		err:  "--application requires exactly one model name",
+	}, {
+		args: []string{"bob", "config", "model1", "model2", "--application", "mysql"},
+		err:  "--application requires exactly one model name",
+	}, {
+		args: []string{"bob", "config", "fred/model.offer1", "--application", "mysql"},
+		err:  "--application requires exactly one model name",
+	}, {
+		args: []string{"bob", "config", "model1", "--application", "my_sql"},
+		err:  `application name "my_sql" not valid`,
+	}, {
+		args: []string{"bob", "Operator", "model1", "--application", "mysql"},
+		err:  `role name "Operator" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		wrappedCmd, _ := model.NewGrantApplicationCommandForTest(nil, s.store)
+		err := cmdtesting.InitCommand(wrappedCmd, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *grantSuite) TestGrantApplication(c *gc.C) {
+	fakeAPI := &fakeApplicationGrantRevokeAPI{}
+	wrappedCmd, _ := model.NewGrantApplicationCommandForTest(fakeAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCmd, "sam", "operator", "foo", "--application", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.user, gc.Equals, "sam")
+	c.Assert(fakeAPI.role, gc.Equals, "operator")
+	c.Assert(fakeAPI.applications, jc.DeepEquals, []string{"mysql"})
+}
+
+func (s *revokeSuite) TestRevokeApplication(c *gc.C) {
+	fakeAPI := &fakeApplicationGrantRevokeAPI{}
+	wrappedCmd, _ := model.NewRevokeApplicationCommandForTest(fakeAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCmd, "sam", "operator", "foo", "--application", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.user, gc.Equals, "sam")
+	c.Assert(fakeAPI.role, gc.Equals, "")
+	c.Assert(fakeAPI.applications, jc.DeepEquals, []string{"mysql"})
+}
+
 type fakeModelGrantRevokeAPI struct {
 	err        error
 	user       string
@@ -282,3 +339,25 @@ func (f *fakeOffersGrantRevokeAPI) fake(user, access string, offerURLs ...string
 	f.offerURLs = append(f.offerURLs, offerURLs...)
 	return f.err
 }
+
+type fakeApplicationGrantRevokeAPI struct {
+	err          error
+	user         string
+	role         string
+	applications []string
+}
+
+func (f *fakeApplicationGrantRevokeAPI) Close() error { return nil }
+
+func (f *fakeApplicationGrantRevokeAPI) GrantApplication(user, role string, applications ...string) error {
+	f.user = user
+	f.role = role
+	f.applications = applications
+	return f.err
+}
+
+func (f *fakeApplicationGrantRevokeAPI) RevokeApplication(user string, applications ...string) error {
+	f.user = user
+	f.applications = applications
+	return f.err
+}
@@ -0,0 +1,290 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/permission"
+)
+
+var usageAddRoleSummary = `
+Adds a role that may be granted to users on applications in a model.`[1:]
+
+var usageAddRoleDetails = `
+A role is a named set of capabilities, each of which is an API facade
+method. Granting a user a role on an application, with "juju grant
+--application", lets them call each of the role's methods on that
+application without write access to the model.
+
+Valid capabilities are:
+    Action.Enqueue
+    Action.EnqueueApplicationActions
+    Application.Set
+    Application.SetApplicationsConfig
+    Application.SetCharm
+    Application.Unset
+    Application.UnsetApplicationsConfig
+    Client.AddCharm
+    Client.AddCharmWithAuthorization
+
+An application access level may be given in place of the capabilities
+making it up:
+    config          the Application.Set*, Application.Unset* methods,
+                    changing charm configuration only
+    run-action      the Action.Enqueue* methods
+    upgrade-charm   Application.SetCharm and the Client.AddCharm* methods
+
+Each application access level may also be granted on its own, without
+adding a role for it.
+
+Examples:
+Add a role 'operator' allowing users to change the configuration of an
+application and run actions on its units:
+
+    juju add-role operator config run-action
+
+Add a role 'runner' allowing users to run actions on individual units of
+an application, but not on all of its units at once:
+
+    juju add-role runner Action.Enqueue
+
+See also:
+    grant
+    remove-role
+    roles`[1:]
+
+var usageRemoveRoleDetails = `
+A role that is still granted to any user on an application cannot be
+removed; revoke it first.
+
+Examples:
+    juju remove-role operator
+
+See also:
+    add-role
+    revoke
+    roles`[1:]
+
+var usageRolesDetails = `
+Lists the roles added to the model, along with the capabilities making
+up each of them.
+
+Examples:
+    juju roles
+    juju roles --format yaml
+
+See also:
+    add-role
+    remove-role
+    grant`[1:]
+
+// RoleAPI defines the API functions used by the role commands.
+type RoleAPI interface {
+	Close() error
+	AddRole(permission.Role) error
+	RemoveRole(name string) error
+	Roles() ([]permission.Role, error)
+}
+
+type roleCommandBase struct {
+	modelcmd.ModelCommandBase
+	api RoleAPI
+}
+
+func (c *roleCommandBase) getAPI() (RoleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// NewAddRoleCommand returns a command to add a role to a model.
+func NewAddRoleCommand() cmd.Command {
+	return modelcmd.Wrap(&addRoleCommand{})
+}
+
+// addRoleCommand adds a role to a model.
+type addRoleCommand struct {
+	roleCommandBase
+	role permission.Role
+}
+
+// Info implements cmd.Command.
+func (c *addRoleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-role",
+		Args:    "<role name> <capability>|<application access> [...]",
+		Purpose: usageAddRoleSummary,
+		Doc:     usageAddRoleDetails,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *addRoleCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no role name specified")
+	}
+	if len(args) < 2 {
+		return errors.New("no capabilities specified")
+	}
+	capabilities, err := permission.ParseCapabilities(args[1:]...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.role = permission.Role{Name: args[0], Capabilities: capabilities}
+	return c.role.Validate()
+}
+
+// Run implements cmd.Command.
+func (c *addRoleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return block.ProcessBlockedError(client.AddRole(c.role), block.BlockChange)
+}
+
+// NewRemoveRoleCommand returns a command to remove a role from a model.
+func NewRemoveRoleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeRoleCommand{})
+}
+
+// removeRoleCommand removes a role from a model.
+type removeRoleCommand struct {
+	roleCommandBase
+	name string
+}
+
+// Info implements cmd.Command.
+func (c *removeRoleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-role",
+		Args:    "<role name>",
+		Purpose: "Removes a role from a model.",
+		Doc:     usageRemoveRoleDetails,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *removeRoleCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no role name specified")
+	}
+	c.name = args[0]
+	if err := permission.ValidateRoleName(c.name); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *removeRoleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return block.ProcessBlockedError(client.RemoveRole(c.name), block.BlockRemove)
+}
+
+// NewRolesCommand returns a command to list the roles in a model.
+func NewRolesCommand() cmd.Command {
+	return modelcmd.Wrap(&rolesCommand{})
+}
+
+// rolesCommand lists the roles in a model.
+type rolesCommand struct {
+	roleCommandBase
+	out cmd.Output
+}
+
+// Info implements cmd.Command.
+func (c *rolesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "roles",
+		Purpose: "Lists the roles in a model.",
+		Doc:     usageRolesDetails,
+		Aliases: []string{"list-roles"},
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *rolesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatRolesTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *rolesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *rolesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	roles, err := client.Roles()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(roles) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No roles to display.")
+		return nil
+	}
+	result := make(map[string][]string)
+	for _, role := range roles {
+		capabilities := make([]string, len(role.Capabilities))
+		for i, capability := range role.Capabilities {
+			capabilities[i] = string(capability)
+		}
+		result[role.Name] = capabilities
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatRolesTabular(writer io.Writer, value interface{}) error {
+	roles, ok := value.(map[string][]string)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", roles, value)
+	}
+	names := make([]string, 0, len(roles))
+	for name := range roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Role\tCapabilities")
+	for _, name := range names {
+		fmt.Fprintf(tw, "%s\t%s\n", name, strings.Join(roles[name], ", "))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,141 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/testing"
+)
+
+type rolesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeRoleAPI
+}
+
+var _ = gc.Suite(&rolesSuite{})
+
+func (s *rolesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeRoleAPI{}
+}
+
+func (s *rolesSuite) TestAddRoleInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no role name specified",
+	}, {
+		args: []string{"operator"},
+		err:  "no capabilities specified",
+	}, {
+		args: []string{"Operator", "config"},
+		err:  `role name "Operator" not valid`,
+	}, {
+		args: []string{"config", "config"},
+		err:  `role name "config" clashes with an application access level`,
+	}, {
+		args: []string{"operator", "write"},
+		err:  `"write" application capability not valid`,
+	}, {
+		args: []string{"operator", "Application.Deploy"},
+		err:  `"Application.Deploy" application capability not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(model.NewAddRoleCommandForTest(s.api), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *rolesSuite) TestAddRole(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewAddRoleCommandForTest(s.api), "operator", "Application.SetCharm", "run-action")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "AddRole", permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Application.SetCharm", "Action.Enqueue", "Action.EnqueueApplicationActions"},
+	})
+}
+
+func (s *rolesSuite) TestAddRoleBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestAddRoleBlocked"))
+	_, err := cmdtesting.RunCommand(c, model.NewAddRoleCommandForTest(s.api), "operator", "config")
+	testing.AssertOperationWasBlocked(c, err, ".*TestAddRoleBlocked.*")
+}
+
+func (s *rolesSuite) TestRemoveRole(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewRemoveRoleCommandForTest(s.api), "operator")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "RemoveRole", "operator")
+}
+
+func (s *rolesSuite) TestRemoveRoleInit(c *gc.C) {
+	err := cmdtesting.InitCommand(model.NewRemoveRoleCommandForTest(s.api), nil)
+	c.Assert(err, gc.ErrorMatches, "no role name specified")
+	err = cmdtesting.InitCommand(model.NewRemoveRoleCommandForTest(s.api), []string{"operator", "extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *rolesSuite) TestRoles(c *gc.C) {
+	s.api.roles = []permission.Role{{
+		Name:         "app-team",
+		Capabilities: []permission.Capability{"Application.SetCharm"},
+	}, {
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, model.NewRolesCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Role      Capabilities\n"+
+		"app-team  Application.SetCharm\n"+
+		"operator  Application.SetApplicationsConfig, Action.Enqueue\n")
+
+	ctx, err = cmdtesting.RunCommand(c, model.NewRolesCommandForTest(s.api), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"app-team:\n"+
+		"- Application.SetCharm\n"+
+		"operator:\n"+
+		"- Application.SetApplicationsConfig\n"+
+		"- Action.Enqueue\n")
+}
+
+func (s *rolesSuite) TestRolesNone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewRolesCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No roles to display.\n")
+}
+
+type fakeRoleAPI struct {
+	jtesting.Stub
+	roles []permission.Role
+}
+
+func (f *fakeRoleAPI) Close() error {
+	return nil
+}
+
+func (f *fakeRoleAPI) AddRole(role permission.Role) error {
+	f.MethodCall(f, "AddRole", role)
+	return f.NextErr()
+}
+
+func (f *fakeRoleAPI) RemoveRole(name string) error {
+	f.MethodCall(f, "RemoveRole", name)
+	return f.NextErr()
+}
+
+func (f *fakeRoleAPI) Roles() ([]permission.Role, error) {
+	f.MethodCall(f, "Roles")
+	return f.roles, f.NextErr()
+}
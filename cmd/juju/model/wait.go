@@ -0,0 +1,371 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+const waitCommandDoc = `
+Blocks until the units of the model, or of the named applications, reach
+one of the given workload statuses and one of the given agent statuses.
+
+By default the command waits for every unit to report an "active" workload
+status with an "idle" agent. Applications named on the command line that do
+not yet exist are waited for; applications without units are considered
+settled.
+
+If any of the units being waited for goes into an error state the command
+fails immediately, unless --fail-fast=false is given, in which case error
+statuses are only considered settled if they have been asked for explicitly.
+
+When the command finishes, whether it succeeded or not, the status of each
+unit being waited for is written out in the requested format.
+
+Examples:
+
+    juju wait
+    juju wait mysql wordpress --timeout 30m
+    juju wait --workload-status active,blocked --format json
+    juju wait -m mymodel --agent-status idle,executing
+
+See also:
+    status
+`
+
+// NewWaitCommand returns a command that blocks until the model reaches
+// the requested state.
+func NewWaitCommand() cmd.Command {
+	return modelcmd.Wrap(&waitCommand{clock: clock.WallClock})
+}
+
+// waitCommand blocks until the units being waited for settle.
+type waitCommand struct {
+	modelcmd.ModelCommandBase
+	out   cmd.Output
+	api   WaitAPI
+	clock clock.Clock
+
+	applications   []string
+	workloadStatus string
+	agentStatus    string
+	timeout        time.Duration
+	failFast       bool
+
+	workloadStatuses map[status.Status]bool
+	agentStatuses    map[status.Status]bool
+}
+
+// AllWatcher defines the methods of the model's all watcher that the
+// wait command uses.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// WaitAPI defines the methods on the client API that the wait
+// command calls.
+type WaitAPI interface {
+	Close() error
+	WatchAll() (AllWatcher, error)
+}
+
+// waitAPI adapts an api.Client to the WaitAPI interface.
+type waitAPI struct {
+	*api.Client
+}
+
+// WatchAll is part of the WaitAPI interface.
+func (a waitAPI) WatchAll() (AllWatcher, error) {
+	watcher, err := a.Client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+// Info implements Command.Info.
+func (c *waitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait",
+		Args:    "[<application name> ...]",
+		Purpose: "Waits for the units of a model to settle.",
+		Doc:     waitCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *waitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.StringVar(&c.workloadStatus, "workload-status", string(status.Active), "Comma separated workload statuses to wait for")
+	f.StringVar(&c.agentStatus, "agent-status", string(status.Idle), "Comma separated agent statuses to wait for")
+	f.DurationVar(&c.timeout, "timeout", 0, "Maximum time to wait, 0 waits forever")
+	f.BoolVar(&c.failFast, "fail-fast", true, "Fail as soon as a unit being waited for is in error")
+}
+
+// Init implements Command.Init.
+func (c *waitCommand) Init(args []string) error {
+	for _, arg := range args {
+		if !names.IsValidApplication(arg) {
+			return errors.NotValidf("application name %q", arg)
+		}
+	}
+	c.applications = args
+	if c.timeout < 0 {
+		return errors.NotValidf("negative timeout")
+	}
+	var err error
+	c.workloadStatuses, err = parseStatuses("workload", c.workloadStatus, status.Status.KnownWorkloadStatus)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.agentStatuses, err = parseStatuses("agent", c.agentStatus, status.Status.KnownAgentStatus)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func parseStatuses(kind, value string, known func(status.Status) bool) (map[status.Status]bool, error) {
+	statuses := make(map[status.Status]bool)
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !known(status.Status(s)) {
+			return nil, errors.NotValidf("%s status %q", kind, s)
+		}
+		statuses[status.Status(s)] = true
+	}
+	if len(statuses) == 0 {
+		return nil, errors.Errorf("no %s status specified", kind)
+	}
+	return statuses, nil
+}
+
+func (c *waitCommand) getAPI() (WaitAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return waitAPI{client}, nil
+}
+
+// Run implements Command.Run.
+func (c *waitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	defer watcher.Stop()
+
+	done := make(chan struct{})
+	defer close(done)
+	deltasCh := make(chan []multiwatcher.Delta)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case deltasCh <- deltas:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var timeout <-chan time.Time
+	if c.timeout > 0 {
+		timeout = c.clock.After(c.timeout)
+	}
+
+	state := newWaitState(c.applications)
+	for {
+		select {
+		case deltas := <-deltasCh:
+			state.update(deltas)
+		case err := <-errCh:
+			return errors.Annotate(err, "cannot watch model")
+		case <-timeout:
+			if err := c.out.Write(ctx, state.result(false)); err != nil {
+				return errors.Trace(err)
+			}
+			return errors.Errorf("timed out after %v waiting for %s", c.timeout, c.describe())
+		}
+
+		if c.failFast {
+			if failed := state.failed(); len(failed) > 0 {
+				if err := c.out.Write(ctx, state.result(false)); err != nil {
+					return errors.Trace(err)
+				}
+				return errors.Errorf("units in error: %s", strings.Join(failed, ", "))
+			}
+		}
+		if state.settled(c.workloadStatuses, c.agentStatuses) {
+			return c.out.Write(ctx, state.result(true))
+		}
+	}
+}
+
+// describe returns a description of what is being waited for, for
+// use in error messages.
+func (c *waitCommand) describe() string {
+	if len(c.applications) == 0 {
+		return "model to settle"
+	}
+	return fmt.Sprintf("%s to settle", strings.Join(c.applications, ", "))
+}
+
+// waitState holds the applications and units of the model as reported
+// by the all watcher.
+type waitState struct {
+	// selected holds the names of the applications being waited for;
+	// if it is empty, all applications are waited for.
+	selected     []string
+	applications map[string]bool
+	units        map[string]*multiwatcher.UnitInfo
+}
+
+func newWaitState(selected []string) *waitState {
+	return &waitState{
+		selected:     selected,
+		applications: make(map[string]bool),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+	}
+}
+
+func (s *waitState) update(deltas []multiwatcher.Delta) {
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(s.applications, info.Name)
+			} else {
+				s.applications[info.Name] = true
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(s.units, info.Name)
+			} else {
+				s.units[info.Name] = info
+			}
+		}
+	}
+}
+
+// waitingFor reports whether the given application is being waited for.
+func (s *waitState) waitingFor(application string) bool {
+	if len(s.selected) == 0 {
+		return true
+	}
+	for _, name := range s.selected {
+		if name == application {
+			return true
+		}
+	}
+	return false
+}
+
+// selectedUnits returns the units being waited for, sorted by name.
+func (s *waitState) selectedUnits() []*multiwatcher.UnitInfo {
+	var units []*multiwatcher.UnitInfo
+	for _, unit := range s.units {
+		if s.waitingFor(unit.Application) {
+			units = append(units, unit)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].Name < units[j].Name
+	})
+	return units
+}
+
+// failed returns the names of the units being waited for that are in
+// an error state.
+func (s *waitState) failed() []string {
+	var failed []string
+	for _, unit := range s.selectedUnits() {
+		if unit.WorkloadStatus.Current == status.Error || unit.AgentStatus.Current == status.Error {
+			failed = append(failed, unit.Name)
+		}
+	}
+	return failed
+}
+
+// settled reports whether all the applications being waited for exist
+// and all of their units are in one of the given statuses.
+func (s *waitState) settled(workload, agent map[status.Status]bool) bool {
+	for _, name := range s.selected {
+		if !s.applications[name] {
+			return false
+		}
+	}
+	for _, unit := range s.selectedUnits() {
+		if !workload[unit.WorkloadStatus.Current] || !agent[unit.AgentStatus.Current] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *waitState) result(settled bool) waitResult {
+	result := waitResult{
+		Settled: settled,
+		Units:   make(map[string]waitUnitStatus),
+	}
+	for _, unit := range s.selectedUnits() {
+		result.Units[unit.Name] = waitUnitStatus{
+			Application:     unit.Application,
+			WorkloadStatus:  string(unit.WorkloadStatus.Current),
+			WorkloadMessage: unit.WorkloadStatus.Message,
+			AgentStatus:     string(unit.AgentStatus.Current),
+			AgentMessage:    unit.AgentStatus.Message,
+		}
+	}
+	return result
+}
+
+// waitResult is the output of the wait command.
+type waitResult struct {
+	Settled bool                      `yaml:"settled" json:"settled"`
+	Units   map[string]waitUnitStatus `yaml:"units" json:"units"`
+}
+
+type waitUnitStatus struct {
+	Application     string `yaml:"application" json:"application"`
+	WorkloadStatus  string `yaml:"workload-status" json:"workload-status"`
+	WorkloadMessage string `yaml:"workload-message,omitempty" json:"workload-message,omitempty"`
+	AgentStatus     string `yaml:"agent-status" json:"agent-status"`
+	AgentMessage    string `yaml:"agent-message,omitempty" json:"agent-message,omitempty"`
+}
@@ -0,0 +1,261 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type waitSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeWaitAPI
+	clock *jujutesting.Clock
+}
+
+var _ = gc.Suite(&waitSuite{})
+
+func (s *waitSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeWaitAPI{
+		watcher: &fakeAllWatcher{
+			deltas:  make(chan []multiwatcher.Delta, 10),
+			stopped: make(chan struct{}),
+		},
+	}
+	s.clock = jujutesting.NewClock(time.Now())
+}
+
+func (s *waitSuite) runWait(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewWaitCommandForTest(s.api, s.clock), args...)
+}
+
+func (s *waitSuite) send(deltas ...multiwatcher.Delta) {
+	s.api.watcher.deltas <- deltas
+}
+
+func applicationDelta(name string) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{Name: name}}
+}
+
+func unitDelta(name, application string, workload, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    application,
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload},
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+	}}
+}
+
+func (s *waitSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"no/good"},
+		err:  `application name "no/good" not valid`,
+	}, {
+		args: []string{"--workload-status", "happy"},
+		err:  `workload status "happy" not valid`,
+	}, {
+		args: []string{"--agent-status", "active"},
+		err:  `agent status "active" not valid`,
+	}, {
+		args: []string{"--agent-status", ""},
+		err:  `no agent status specified`,
+	}, {
+		args: []string{"--timeout", "-1s"},
+		err:  `negative timeout not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(model.NewWaitCommandForTest(s.api, s.clock), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *waitSuite) TestSettled(c *gc.C) {
+	s.send(
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Maintenance, status.Executing),
+	)
+	s.send(
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+	)
+	ctx, err := s.runWait(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		`{"settled":true,"units":{"mysql/0":{"application":"mysql","workload-status":"active","agent-status":"idle"}}}`+"\n")
+	c.Assert(s.api.closed, jc.IsTrue)
+	c.Assert(s.api.watcher.isStopped(), jc.IsTrue)
+}
+
+func (s *waitSuite) TestSelectedApplications(c *gc.C) {
+	s.send(
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+		applicationDelta("wordpress"),
+		unitDelta("wordpress/0", "wordpress", status.Blocked, status.Idle),
+	)
+	ctx, err := s.runWait(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+settled: true
+units:
+  mysql/0:
+    application: mysql
+    workload-status: active
+    agent-status: idle
+`[1:])
+}
+
+func (s *waitSuite) TestWaitsForMissingApplication(c *gc.C) {
+	s.send(
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+	)
+	s.send(
+		applicationDelta("wordpress"),
+		unitDelta("wordpress/0", "wordpress", status.Waiting, status.Executing),
+	)
+	s.send(
+		unitDelta("wordpress/0", "wordpress", status.Blocked, status.Idle),
+	)
+	_, err := s.runWait(c, "mysql", "wordpress", "--workload-status", "active,blocked")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.watcher.pending(), gc.Equals, 0)
+}
+
+func (s *waitSuite) TestRemovedUnit(c *gc.C) {
+	s.send(
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+		unitDelta("mysql/1", "mysql", status.Maintenance, status.Executing),
+	)
+	removed := unitDelta("mysql/1", "mysql", status.Maintenance, status.Executing)
+	removed.Removed = true
+	s.send(removed)
+	_, err := s.runWait(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.watcher.pending(), gc.Equals, 0)
+}
+
+func (s *waitSuite) TestFailFast(c *gc.C) {
+	s.send(
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+		unitDelta("mysql/1", "mysql", status.Error, status.Idle),
+	)
+	ctx, err := s.runWait(c)
+	c.Assert(err, gc.ErrorMatches, `units in error: mysql/1`)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "settled: false\n")
+}
+
+func (s *waitSuite) TestNoFailFast(c *gc.C) {
+	s.send(
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Error, status.Idle),
+	)
+	s.send(
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+	)
+	_, err := s.runWait(c, "--fail-fast=false")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.watcher.pending(), gc.Equals, 0)
+}
+
+func (s *waitSuite) TestTimeout(c *gc.C) {
+	s.send(
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Maintenance, status.Executing),
+	)
+	errc := make(chan error, 1)
+	go func() {
+		_, err := s.runWait(c, "mysql", "--timeout", "10m")
+		errc <- err
+	}()
+	c.Assert(s.clock.WaitAdvance(10*time.Minute, testing.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case err := <-errc:
+		c.Assert(err, gc.ErrorMatches, `timed out after 10m0s waiting for mysql to settle`)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+}
+
+func (s *waitSuite) TestWatcherError(c *gc.C) {
+	s.api.watcher.err = errors.New("boom")
+	_, err := s.runWait(c)
+	c.Assert(err, gc.ErrorMatches, `cannot watch model: boom`)
+}
+
+func (s *waitSuite) TestWatchAllError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.runWait(c)
+	c.Assert(err, gc.ErrorMatches, `cannot watch model: boom`)
+}
+
+type fakeWaitAPI struct {
+	watcher *fakeAllWatcher
+	err     error
+	closed  bool
+}
+
+func (f *fakeWaitAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeWaitAPI) WatchAll() (model.AllWatcher, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.watcher, nil
+}
+
+type fakeAllWatcher struct {
+	deltas  chan []multiwatcher.Delta
+	stopped chan struct{}
+	err     error
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	select {
+	case deltas := <-w.deltas:
+		return deltas, nil
+	case <-w.stopped:
+		return nil, errors.New("watcher was stopped")
+	}
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	close(w.stopped)
+	return nil
+}
+
+func (w *fakeAllWatcher) isStopped() bool {
+	select {
+	case <-w.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *fakeAllWatcher) pending() int {
+	return len(w.deltas)
+}
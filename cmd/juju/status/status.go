@@ -43,6 +43,13 @@ type statusCommand struct {
 
 	color bool
 
+	// watch indicates that status should be redisplayed as it changes.
+	watch bool
+
+	// formatters holds the output formatters by name, so that watch
+	// mode can format each refresh itself.
+	formatters map[string]cmd.Formatter
+
 	// relations indicates if 'relations' section is displayed
 	relations bool
 
@@ -85,15 +92,24 @@ The available output formats are:
 - json: Displays information about the model, machines, applications, and units
       in structured JSON format.
       
+- jsonl: Streams status changes of machines, applications and units as they
+      happen, one JSON object per line. Filter patterns are matched against
+      the names of the changed entities. This format implies --watch.
+      
 In tabular format, 'Relations' section is not displayed by default. 
 Use --relations option to see this section. This option is ignored in all other 
 formats.
 
+With --watch, status is redisplayed whenever the model changes, highlighting
+the lines that changed, until interrupted.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
     juju show-status --relations
+    juju show-status --watch
+    juju show-status --format jsonl
 
 See also:
     machines
@@ -116,6 +132,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	f.BoolVar(&c.watch, "watch", false, "Redisplay status as it changes")
 
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section")
 
@@ -131,19 +148,24 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	defaultFormat := "tabular"
 
-	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
+	c.formatters = map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
+		"jsonl":   cmd.FormatJson,
 		"short":   FormatOneline,
 		"oneline": FormatOneline,
 		"line":    FormatOneline,
 		"tabular": c.FormatTabular,
 		"summary": FormatSummary,
-	})
+	}
+	c.out.AddFlags(f, defaultFormat, c.formatters)
 }
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.out.Name() == "jsonl" {
+		c.watch = true
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -169,23 +191,6 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
-	status, err := apiclient.Status(c.patterns)
-	if err != nil {
-		if status == nil {
-			// Status call completely failed, there is nothing to report
-			return errors.Trace(err)
-		}
-		// Display any error, but continue to print status if some was returned
-		fmt.Fprintf(ctx.Stderr, "%v\n", err)
-	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
-	}
-
-	controllerName, err := c.ControllerName()
-	if err != nil {
-		return errors.Trace(err)
-	}
-
 	showRelations := true
 	if c.out.Name() != "tabular" {
 		if c.relationsFlagProvidedF() {
@@ -195,8 +200,12 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	} else {
 		showRelations = c.relations
 	}
-	formatter := newStatusFormatter(status, controllerName, c.isoTime, showRelations)
-	formatted, err := formatter.format()
+
+	if c.watch {
+		return c.runWatch(ctx, apiclient, showRelations)
+	}
+
+	formatted, status, err := c.formatStatus(ctx, apiclient, showRelations)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -226,6 +235,34 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// formatStatus fetches the status of the model and returns it ready
+// for output, along with the status it was formatted from.
+func (c *statusCommand) formatStatus(ctx *cmd.Context, apiclient statusAPI, showRelations bool) (interface{}, *params.FullStatus, error) {
+	status, err := apiclient.Status(c.patterns)
+	if err != nil {
+		if status == nil {
+			// Status call completely failed, there is nothing to report
+			return nil, nil, errors.Trace(err)
+		}
+		// Display any error, but continue to print status if some was returned
+		fmt.Fprintf(ctx.Stderr, "%v\n", err)
+	} else if status == nil {
+		return nil, nil, errors.Errorf("unable to obtain the current status")
+	}
+
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	formatter := newStatusFormatter(status, controllerName, c.isoTime, showRelations)
+	formatted, err := formatter.format()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return formatted, status, nil
+}
+
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
 	return FormatTabular(writer, c.color, value)
 }
@@ -0,0 +1,332 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/juju/ansiterm"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/mattn/go-isatty"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// clearScreen moves the cursor to the top left and clears the screen.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// changedHighlight is used to highlight the lines of output that have
+// changed since the previous refresh.
+var changedHighlight = ansiterm.Styles(ansiterm.Bold)
+
+// watchRefreshInterval is the minimum time between refreshes of the
+// displayed status. Changes seen in the meantime are gathered into a
+// single refresh, so that a busy model doesn't cause a status call
+// for each batch of changes.
+const watchRefreshInterval = time.Second
+
+// watchClock is the clock used to rate limit refreshes.
+var watchClock clock.Clock = clock.WallClock
+
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// watchAllForStatus returns a watcher of all changes to the model
+// using the given status client.
+var watchAllForStatus = func(apiclient statusAPI) (allWatcher, error) {
+	client, ok := apiclient.(*api.Client)
+	if !ok {
+		return nil, errors.NotSupportedf("watching status")
+	}
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+func isTerminal(f interface{}) bool {
+	f_, ok := f.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f_.Fd())
+}
+
+// runWatch displays the status of the model each time it changes, no
+// more often than watchRefreshInterval, until interrupted or the
+// watcher fails.
+func (c *statusCommand) runWatch(ctx *cmd.Context, apiclient statusAPI, showRelations bool) error {
+	watcher, err := watchAllForStatus(apiclient)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	defer watcher.Stop()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	done := make(chan struct{})
+	defer close(done)
+	deltasCh := make(chan []multiwatcher.Delta)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case deltasCh <- deltas:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var (
+		handle func([]multiwatcher.Delta) error
+
+		// refreshDue fires when the changes gathered since the
+		// last refresh are to be displayed; it's nil if there are
+		// none.
+		refreshDue  <-chan time.Time
+		refresh     func() error
+		lastRefresh time.Time
+	)
+	if c.out.Name() == "jsonl" {
+		events := newStatusEvents(c.patterns)
+		handle = func(deltas []multiwatcher.Delta) error {
+			for _, event := range events.update(deltas) {
+				if err := c.formatters["jsonl"](ctx.Stdout, event); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
+		}
+	} else {
+		display := &statusDisplay{
+			terminal: c.color || isTerminal(ctx.Stdout),
+		}
+		refresh = func() error {
+			lastRefresh = watchClock.Now()
+			formatted, _, err := c.formatStatus(ctx, apiclient, showRelations)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			var buf bytes.Buffer
+			if err := c.formatters[c.out.Name()](&buf, formatted); err != nil {
+				return errors.Trace(err)
+			}
+			display.show(ctx.Stdout, buf.String())
+			return nil
+		}
+		if err := refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		handle = func([]multiwatcher.Delta) error {
+			if refreshDue == nil {
+				wait := watchRefreshInterval - watchClock.Now().Sub(lastRefresh)
+				if wait < 0 {
+					wait = 0
+				}
+				refreshDue = watchClock.After(wait)
+			}
+			return nil
+		}
+	}
+
+	for {
+		select {
+		case deltas := <-deltasCh:
+			if err := handle(deltas); err != nil {
+				return errors.Trace(err)
+			}
+		case <-refreshDue:
+			refreshDue = nil
+			if err := refresh(); err != nil {
+				return errors.Trace(err)
+			}
+		case err := <-errCh:
+			return errors.Annotate(err, "cannot watch model")
+		case <-interrupted:
+			return nil
+		}
+	}
+}
+
+// statusDisplay redraws formatted status, highlighting the lines that
+// differ from the previous output.
+type statusDisplay struct {
+	// terminal indicates that the output supports ANSI escape codes.
+	// If it does not, each refresh is written after the last one and
+	// nothing is highlighted.
+	terminal bool
+	previous map[string]bool
+}
+
+func (d *statusDisplay) show(out io.Writer, text string) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	current := make(map[string]bool)
+	for _, line := range lines {
+		current[line] = true
+	}
+
+	w := ansiterm.NewWriter(out)
+	if !d.terminal {
+		if d.previous != nil {
+			w.Write([]byte("\n"))
+		}
+		w.Write([]byte(text))
+		d.previous = current
+		return
+	}
+	w.SetColorCapable(true)
+	w.Write([]byte(clearScreen))
+	for _, line := range lines {
+		if d.previous != nil && !d.previous[line] && strings.TrimSpace(line) != "" {
+			changedHighlight.Fprintf(w, "%s\n", line)
+		} else {
+			w.Write([]byte(line + "\n"))
+		}
+	}
+	d.previous = current
+}
+
+// statusEvent describes a change to the status of a machine,
+// application or unit.
+type statusEvent struct {
+	Kind           string           `json:"kind"`
+	Id             string           `json:"id"`
+	Removed        bool             `json:"removed,omitempty"`
+	Status         *statusEventInfo `json:"status,omitempty"`
+	AgentStatus    *statusEventInfo `json:"agent-status,omitempty"`
+	InstanceStatus *statusEventInfo `json:"instance-status,omitempty"`
+}
+
+type statusEventInfo struct {
+	Current string `json:"current"`
+	Message string `json:"message,omitempty"`
+	Since   string `json:"since,omitempty"`
+}
+
+func newStatusEventInfo(info multiwatcher.StatusInfo) *statusEventInfo {
+	result := &statusEventInfo{
+		Current: string(info.Current),
+		Message: info.Message,
+	}
+	if info.Since != nil {
+		result.Since = info.Since.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+// statusEvents turns all watcher deltas into status events, dropping
+// deltas that do not change the status of an entity.
+type statusEvents struct {
+	patterns []string
+	last     map[multiwatcher.EntityId]statusEvent
+}
+
+func newStatusEvents(patterns []string) *statusEvents {
+	return &statusEvents{
+		patterns: patterns,
+		last:     make(map[multiwatcher.EntityId]statusEvent),
+	}
+}
+
+// matches reports whether any of the filter patterns match one of
+// the given names. If there are no patterns, everything matches.
+func (e *statusEvents) matches(names ...string) bool {
+	if len(e.patterns) == 0 {
+		return true
+	}
+	for _, pattern := range e.patterns {
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *statusEvents) update(deltas []multiwatcher.Delta) []statusEvent {
+	var events []statusEvent
+	for _, delta := range deltas {
+		var event statusEvent
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.MachineInfo:
+			if !e.matches(info.Id) {
+				continue
+			}
+			event = statusEvent{
+				Kind:           "machine",
+				Id:             info.Id,
+				AgentStatus:    newStatusEventInfo(info.AgentStatus),
+				InstanceStatus: newStatusEventInfo(info.InstanceStatus),
+			}
+		case *multiwatcher.ApplicationInfo:
+			if !e.matches(info.Name) {
+				continue
+			}
+			event = statusEvent{
+				Kind:   "application",
+				Id:     info.Name,
+				Status: newStatusEventInfo(info.Status),
+			}
+		case *multiwatcher.RemoteApplicationInfo:
+			if !e.matches(info.Name) {
+				continue
+			}
+			event = statusEvent{
+				Kind:   "remote-application",
+				Id:     info.Name,
+				Status: newStatusEventInfo(info.Status),
+			}
+		case *multiwatcher.UnitInfo:
+			if !e.matches(info.Name, info.Application) {
+				continue
+			}
+			event = statusEvent{
+				Kind:        "unit",
+				Id:          info.Name,
+				Status:      newStatusEventInfo(info.WorkloadStatus),
+				AgentStatus: newStatusEventInfo(info.AgentStatus),
+			}
+		default:
+			continue
+		}
+
+		id := delta.Entity.EntityId()
+		if delta.Removed {
+			delete(e.last, id)
+			events = append(events, statusEvent{
+				Kind:    event.Kind,
+				Id:      event.Id,
+				Removed: true,
+			})
+			continue
+		}
+		if last, ok := e.last[id]; ok && reflect.DeepEqual(last, event) {
+			continue
+		}
+		e.last[id] = event
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,262 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WatchSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WatchSuite{})
+
+func unitDelta(name, application string, workload, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    application,
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload},
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+	}}
+}
+
+func (s *WatchSuite) TestStatusEvents(c *gc.C) {
+	since := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	events := newStatusEvents(nil)
+	c.Assert(events.update([]multiwatcher.Delta{
+		{Entity: &multiwatcher.MachineInfo{
+			Id:             "0",
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Started},
+			InstanceStatus: multiwatcher.StatusInfo{Current: status.Running, Since: &since},
+		}},
+		{Entity: &multiwatcher.ApplicationInfo{
+			Name:   "mysql",
+			Status: multiwatcher.StatusInfo{Current: status.Waiting, Message: "waiting for machine"},
+		}},
+		unitDelta("mysql/0", "mysql", status.Waiting, status.Allocating),
+		{Entity: &multiwatcher.RelationInfo{Key: "mysql:cluster"}},
+	}), jc.DeepEquals, []statusEvent{{
+		Kind:           "machine",
+		Id:             "0",
+		AgentStatus:    &statusEventInfo{Current: "started"},
+		InstanceStatus: &statusEventInfo{Current: "running", Since: "2018-06-01T12:00:00Z"},
+	}, {
+		Kind:   "application",
+		Id:     "mysql",
+		Status: &statusEventInfo{Current: "waiting", Message: "waiting for machine"},
+	}, {
+		Kind:        "unit",
+		Id:          "mysql/0",
+		Status:      &statusEventInfo{Current: "waiting"},
+		AgentStatus: &statusEventInfo{Current: "allocating"},
+	}})
+
+	// Deltas that don't change the status are dropped.
+	c.Assert(events.update([]multiwatcher.Delta{
+		unitDelta("mysql/0", "mysql", status.Waiting, status.Allocating),
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+	}), jc.DeepEquals, []statusEvent{{
+		Kind:        "unit",
+		Id:          "mysql/0",
+		Status:      &statusEventInfo{Current: "active"},
+		AgentStatus: &statusEventInfo{Current: "idle"},
+	}})
+
+	removed := unitDelta("mysql/0", "mysql", status.Active, status.Idle)
+	removed.Removed = true
+	c.Assert(events.update([]multiwatcher.Delta{removed}), jc.DeepEquals, []statusEvent{{
+		Kind:    "unit",
+		Id:      "mysql/0",
+		Removed: true,
+	}})
+}
+
+func (s *WatchSuite) TestStatusEventsPatterns(c *gc.C) {
+	events := newStatusEvents([]string{"mysql", "word*/1"})
+	result := events.update([]multiwatcher.Delta{
+		{Entity: &multiwatcher.MachineInfo{Id: "0"}},
+		{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}},
+		{Entity: &multiwatcher.ApplicationInfo{Name: "wordpress"}},
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+		unitDelta("wordpress/0", "wordpress", status.Active, status.Idle),
+		unitDelta("wordpress/1", "wordpress", status.Active, status.Idle),
+	})
+	var ids []string
+	for _, event := range result {
+		ids = append(ids, event.Id)
+	}
+	c.Assert(ids, jc.DeepEquals, []string{"mysql", "mysql/0", "wordpress/1"})
+}
+
+func (s *WatchSuite) TestDisplayNotTerminal(c *gc.C) {
+	var buf bytes.Buffer
+	display := &statusDisplay{}
+	display.show(&buf, "a\nb\n")
+	display.show(&buf, "a\nc\n")
+	c.Assert(buf.String(), gc.Equals, "a\nb\n\na\nc\n")
+}
+
+func (s *WatchSuite) TestDisplayHighlightsChanges(c *gc.C) {
+	var buf bytes.Buffer
+	display := &statusDisplay{terminal: true}
+	display.show(&buf, "a\nb\n")
+	c.Assert(buf.String(), gc.Equals, clearScreen+"a\nb\n")
+
+	buf.Reset()
+	display.show(&buf, "a\nc\n")
+	c.Assert(buf.String(), gc.Matches, `(?s)\x1b\[H\x1b\[2Ja\n\x1b\[1m.*c\n.*`)
+}
+
+func (s *WatchSuite) TestRunJSONL(c *gc.C) {
+	watcher := &fakeStatusWatcher{
+		deltas: [][]multiwatcher.Delta{{
+			unitDelta("mysql/0", "mysql", status.Waiting, status.Allocating),
+		}, {
+			unitDelta("mysql/0", "mysql", status.Waiting, status.Allocating),
+		}, {
+			unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+		}},
+	}
+	client := &fakeAPIClient{}
+	s.PatchValue(&newAPIClientForStatus, func(*statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	s.PatchValue(&watchAllForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	command := &statusCommand{
+		relationsFlagProvidedF: func() bool { return false },
+	}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, modelcmd.Wrap(command), "--format", "jsonl")
+	c.Assert(err, gc.ErrorMatches, "cannot watch model: watcher finished")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		`{"kind":"unit","id":"mysql/0","status":{"current":"waiting"},"agent-status":{"current":"allocating"}}`+"\n"+
+		`{"kind":"unit","id":"mysql/0","status":{"current":"active"},"agent-status":{"current":"idle"}}`+"\n")
+	c.Assert(watcher.stopped, jc.IsTrue)
+	c.Assert(client.closeCalled, jc.IsTrue)
+	c.Assert(client.patternsUsed, gc.IsNil)
+}
+
+func (s *WatchSuite) TestRunRateLimitsRefreshes(c *gc.C) {
+	drained := make(chan struct{})
+	finish := make(chan struct{})
+	watcher := &fakeStatusWatcher{
+		deltas: [][]multiwatcher.Delta{{
+			unitDelta("mysql/0", "mysql", status.Waiting, status.Allocating),
+		}, {
+			unitDelta("mysql/0", "mysql", status.Waiting, status.Executing),
+		}, {
+			unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+		}},
+		drained: drained,
+		finish:  finish,
+	}
+	client := &countingAPIClient{
+		fakeAPIClient: fakeAPIClient{statusReturn: &params.FullStatus{}},
+		calls:         make(chan struct{}, 10),
+	}
+	s.PatchValue(&newAPIClientForStatus, func(*statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	s.PatchValue(&watchAllForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+	clock := testing.NewClock(time.Now())
+	s.PatchValue(&watchClock, clock)
+
+	command := &statusCommand{
+		relationsFlagProvidedF: func() bool { return false },
+	}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	done := make(chan error, 1)
+	go func() {
+		_, err := cmdtesting.RunCommand(c, modelcmd.Wrap(command), "--format", "yaml")
+		done <- err
+	}()
+
+	// The initial status is shown straight away, and the three
+	// batches of changes that follow are shown together.
+	s.waitCall(c, client.calls)
+	select {
+	case <-drained:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("watcher not drained")
+	}
+	err := clock.WaitAdvance(watchRefreshInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c, client.calls)
+
+	close(finish)
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, "cannot watch model: watcher finished")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("command did not finish")
+	}
+	c.Assert(client.calls, gc.HasLen, 0)
+}
+
+func (s *WatchSuite) waitCall(c *gc.C, calls <-chan struct{}) {
+	select {
+	case <-calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("status not called")
+	}
+}
+
+type countingAPIClient struct {
+	fakeAPIClient
+	calls chan struct{}
+}
+
+func (a *countingAPIClient) Status(patterns []string) (*params.FullStatus, error) {
+	a.calls <- struct{}{}
+	return a.fakeAPIClient.Status(patterns)
+}
+
+type fakeStatusWatcher struct {
+	deltas  [][]multiwatcher.Delta
+	stopped bool
+
+	// drained, if not nil, is closed once all the deltas have been
+	// read, after which Next waits for finish to be closed.
+	drained chan struct{}
+	finish  chan struct{}
+}
+
+func (w *fakeStatusWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.deltas) == 0 {
+		if w.drained != nil {
+			close(w.drained)
+			w.drained = nil
+			<-w.finish
+		}
+		return nil, errors.New("watcher finished")
+	}
+	deltas := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return deltas, nil
+}
+
+func (w *fakeStatusWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
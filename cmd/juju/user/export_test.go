@@ -18,6 +18,7 @@ var (
 	ListModels       = &listModels
 	NewAPIConnection = &newAPIConnection
 	LoginClientStore = &loginClientStore
+	OIDCIDToken      = &oidcIDToken
 )
 
 const NoModelsMessage = noModelsMessage
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/authentication"
 	apibase "github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/apiserver/params"
@@ -44,6 +45,17 @@ except verify that fact.
 If the -u flag is provided, the juju login command will attempt to log
 into the controller as that user.
 
+If the --oidc flag is provided, the juju login command will log into
+the controller with the OpenID Connect identity provider configured for
+it (see the oidc-issuer-url controller setting). You will be asked to
+visit a web page, possibly on another device, and enter a code there to
+approve the login. The ID token the identity provider then issues is
+used for subsequent commands until it expires, when you will need to
+log in again. You are logged in as the user named by the token's
+subject (see the oidc-username-claim setting) in the controller's OIDC
+user domain (see oidc-user-domain), for example "alice@oidc", and your
+email address must have been verified by the identity provider.
+
 After login, a token ("macaroon") will become active. It has an expiration
 time of 24 hours. Upon expiration, no further Juju commands can be issued
 and the user will be prompted to log in again.
@@ -61,6 +73,7 @@ Examples:
     juju login somepubliccontroller
     juju login jimm.jujucharms.com
     juju login -u bob
+    juju login --oidc
 
 See also:
     disable-user
@@ -80,6 +93,7 @@ var (
 	// loginClientStore is used as the client store. When it is nil,
 	// the default client store will be used.
 	loginClientStore jujuclient.ClientStore
+	oidcIDToken      = (*authentication.DeviceLogin).IDToken
 )
 
 // NewLoginCommand returns a new cmd.Command to handle "juju login".
@@ -94,6 +108,7 @@ type loginCommand struct {
 	modelcmd.ControllerCommandBase
 	domain   string
 	username string
+	oidc     bool
 
 	// controllerName holds the name of the current controller.
 	// We define this and the --controller flag here because
@@ -122,6 +137,7 @@ func (c *loginCommand) SetFlags(fset *gnuflag.FlagSet) {
 	fset.StringVar(&c.controllerName, "controller", "", "")
 	fset.StringVar(&c.username, "u", "", "log in as this local user")
 	fset.StringVar(&c.username, "user", "", "")
+	fset.BoolVar(&c.oidc, "oidc", false, "log in with the controller's OpenID Connect identity provider")
 }
 
 // Init implements Command.Init.
@@ -130,6 +146,9 @@ func (c *loginCommand) Init(args []string) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.oidc && c.username != "" {
+		return errors.New("cannot specify both --oidc and a user name")
+	}
 	c.domain = domain
 	return nil
 }
@@ -303,13 +322,20 @@ func (c *loginCommand) publicControllerLogin(
 	dialOpts.BakeryClient = bclient
 
 	dial := func(d *jujuclient.AccountDetails) (api.Connection, error) {
+		if d == nil {
+			return apiOpen(&c.CommandBase, &api.Info{
+				Addrs:     []string{host},
+				SkipLogin: true,
+			}, dialOpts)
+		}
 		var tag names.Tag
-		if d.User != "" {
+		if d.User != "" && d.OIDCToken == "" {
 			tag = names.NewUserTag(d.User)
 		}
 		return apiOpen(&c.CommandBase, &api.Info{
 			Tag:      tag,
 			Password: d.Password,
+			IDToken:  d.OIDCToken,
 			Addrs:    []string{host},
 		}, dialOpts)
 	}
@@ -338,7 +364,8 @@ func (c *loginCommand) publicControllerLogin(
 // on whether we have some existing local controller information or not.
 //
 // The dial function should make API connection using the account
-// details that it is passed.
+// details that it is passed, or an API connection that has not logged
+// in if it is passed nil.
 func (c *loginCommand) login(
 	ctx *cmd.Context,
 	accountDetails *jujuclient.AccountDetails,
@@ -354,9 +381,16 @@ Run "juju logout" first before attempting to log in as a different user.`,
 			accountDetails.User)
 	}
 
-	if accountDetails != nil && accountDetails.Password != "" {
+	if c.oidc && accountDetails != nil && accountDetails.User != "" && accountDetails.OIDCToken == "" {
+		return nil, nil, errors.Errorf(`already logged in as %s.
+
+Run "juju logout" first before attempting to log in with OIDC.`,
+			accountDetails.User)
+	}
+
+	if accountDetails != nil && (accountDetails.Password != "" || accountDetails.OIDCToken != "") {
 		// We've been provided some account details that
-		// contain a password, so try that first.
+		// contain a password or ID token, so try that first.
 		conn, err := dial(accountDetails)
 		if err == nil {
 			return conn, accountDetails, nil
@@ -365,6 +399,9 @@ Run "juju logout" first before attempting to log in as a different user.`,
 			return nil, nil, errors.Trace(err)
 		}
 	}
+	if c.oidc {
+		return c.oidcLogin(ctx, dial)
+	}
 	if c.username == "" {
 		// No username specified, so try external-user login first.
 		conn, err := dial(&jujuclient.AccountDetails{})
@@ -405,6 +442,62 @@ Run "juju logout" first before attempting to log in as a different user.`,
 	return conn, accountDetails, errors.Trace(err)
 }
 
+// oidcLogin logs into a controller with an ID token obtained from the
+// controller's OpenID Connect identity provider.
+func (c *loginCommand) oidcLogin(
+	ctx *cmd.Context,
+	dial func(*jujuclient.AccountDetails) (api.Connection, error),
+) (api.Connection, *jujuclient.AccountDetails, error) {
+	conn, err := dial(nil)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	oidcConfig, err := api.OIDCLoginConfig(conn)
+	conn.Close()
+	if params.IsCodeNotFound(err) || params.IsCodeNotImplemented(err) {
+		return nil, nil, errors.New("controller does not support OIDC login")
+	}
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	token, err := oidcIDToken(&authentication.DeviceLogin{
+		IssuerURL: oidcConfig.IssuerURL,
+		ClientID:  oidcConfig.ClientID,
+		Scopes:    oidcConfig.Scopes,
+		Prompt: func(verificationURI, userCode string) error {
+			fmt.Fprintf(ctx.Stderr, oidcPromptMessage, verificationURI, userCode)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	accountDetails := &jujuclient.AccountDetails{
+		OIDCToken: token,
+	}
+	conn, err = dial(accountDetails)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	user, ok := conn.AuthTag().(names.UserTag)
+	if !ok {
+		conn.Close()
+		return nil, nil, errors.Errorf("logged in as %v, not a user", conn.AuthTag())
+	}
+	accountDetails.User = user.Id()
+	return conn, accountDetails, nil
+}
+
+const oidcPromptMessage = `
+To log in, visit:
+
+    %s
+
+and enter the code %s
+
+Waiting for the login to be approved...
+`[1:]
+
 const noModelsMessage = `
 There are no models available. You can add models with
 "juju add-model", or you can ask an administrator or owner
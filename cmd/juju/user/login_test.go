@@ -14,6 +14,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/authentication"
 	apibase "github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/user"
@@ -67,6 +68,9 @@ func (s *LoginCommandSuite) TestInitError(c *gc.C) {
 	}, {
 		args:   []string{"foobar", "extra"},
 		stderr: `ERROR unrecognized args: \["extra"\]\n`,
+	}, {
+		args:   []string{"--oidc", "-u", "bob"},
+		stderr: `ERROR cannot specify both --oidc and a user name\n`,
 	}} {
 		c.Logf("test %d", i)
 		stdout, stderr, code := runLogin(c, "", test.args...)
@@ -258,6 +262,104 @@ There are no models available(.|\n)*`[1:])
 	c.Assert(code, gc.Equals, 0)
 }
 
+// oidcMockAPI is a loginMockAPI that also answers the
+// OIDCLoginConfig call made before logging in with OIDC.
+type oidcMockAPI struct {
+	*loginMockAPI
+	config params.OIDCLoginConfig
+	err    error
+}
+
+func (m *oidcMockAPI) APICall(objType string, version int, id, request string, args, response interface{}) error {
+	if objType != "Admin" || request != "OIDCLoginConfig" {
+		return errors.Errorf("unexpected call %s.%s", objType, request)
+	}
+	if m.err != nil {
+		return m.err
+	}
+	*(response.(*params.OIDCLoginConfig)) = m.config
+	return nil
+}
+
+func (s *LoginCommandSuite) patchOIDC(c *gc.C, conn *oidcMockAPI) *[]*jujuclient.AccountDetails {
+	var dialed []*jujuclient.AccountDetails
+	s.PatchValue(user.NewAPIConnection, func(p juju.NewAPIConnectionParams) (api.Connection, error) {
+		if p.AccountDetails == nil {
+			dialed = append(dialed, nil)
+			return conn, nil
+		}
+		accountDetails := *p.AccountDetails
+		dialed = append(dialed, &accountDetails)
+		return s.apiConnection, nil
+	})
+	s.PatchValue(user.OIDCIDToken, func(login *authentication.DeviceLogin) (string, error) {
+		c.Check(login.IssuerURL, gc.Equals, conn.config.IssuerURL)
+		c.Check(login.ClientID, gc.Equals, conn.config.ClientID)
+		c.Check(login.Scopes, jc.DeepEquals, conn.config.Scopes)
+		if err := login.Prompt("https://issuer.example.com/device", "ABCD-EFGH"); err != nil {
+			return "", err
+		}
+		return "id-token", nil
+	})
+	return &dialed
+}
+
+func (s *LoginCommandSuite) TestLoginWithOIDC(c *gc.C) {
+	err := s.store.RemoveAccount("testing")
+	c.Assert(err, jc.ErrorIsNil)
+	dialed := s.patchOIDC(c, &oidcMockAPI{
+		loginMockAPI: s.apiConnection,
+		config: params.OIDCLoginConfig{
+			IssuerURL: "https://issuer.example.com",
+			ClientID:  "juju",
+			Scopes:    []string{"openid", "email"},
+		},
+	})
+	s.apiConnection.authTag = names.NewUserTag("alice@oidc")
+	stdout, stderr, code := runLogin(c, "", "--oidc")
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Matches, `
+To log in, visit:
+
+    https://issuer.example.com/device
+
+and enter the code ABCD-EFGH
+
+Waiting for the login to be approved...
+Welcome, alice@oidc. You are now logged into "testing".
+(.|\n)*`[1:])
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(*dialed, jc.DeepEquals, []*jujuclient.AccountDetails{
+		nil,
+		{OIDCToken: "id-token"},
+	})
+	account, err := s.store.AccountDetails("testing")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(account, jc.DeepEquals, &jujuclient.AccountDetails{
+		User:            "alice@oidc",
+		OIDCToken:       "id-token",
+		LastKnownAccess: "superuser",
+	})
+}
+
+func (s *LoginCommandSuite) TestLoginWithOIDCNotSupported(c *gc.C) {
+	err := s.store.RemoveAccount("testing")
+	c.Assert(err, jc.ErrorIsNil)
+	s.patchOIDC(c, &oidcMockAPI{
+		loginMockAPI: s.apiConnection,
+		err:          &params.Error{Code: params.CodeNotFound, Message: "OIDC login not configured"},
+	})
+	_, stderr, code := runLogin(c, "", "--oidc")
+	c.Check(stderr, gc.Equals, "ERROR cannot log into controller \"testing\": controller does not support OIDC login\n")
+	c.Assert(code, gc.Equals, 1)
+}
+
+func (s *LoginCommandSuite) TestLoginWithOIDCAlreadyLoggedIn(c *gc.C) {
+	_, stderr, code := runLogin(c, "", "--oidc")
+	c.Check(stderr, gc.Matches, `ERROR cannot log into controller "testing": already logged in as current-user.(.|\n)*`)
+	c.Assert(code, gc.Equals, 1)
+}
+
 func runLogin(c *gc.C, stdin string, args ...string) (stdout, stderr string, errCode int) {
 	c.Logf("in LoginControllerSuite.run")
 	var stdoutBuf, stderrBuf bytes.Buffer
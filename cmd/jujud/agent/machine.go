@@ -50,7 +50,9 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
+	corelease "github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	jujunames "github.com/juju/juju/juju/names"
@@ -106,6 +108,10 @@ var (
 // Variable to override in tests, default is true
 var ProductionMongoWriteConcern = true
 
+// raftLeaseRequestTimeout is how long a lease client waits for the
+// raft leader to apply a lease change.
+const raftLeaseRequestTimeout = 5 * time.Second
+
 func init() {
 	stateWorkerDialOpts = mongo.DefaultDialOpts()
 	stateWorkerDialOpts.PostDial = func(session *mgo.Session) error {
@@ -360,6 +366,11 @@ type MachineAgent struct {
 	// Only API servers have hubs. This is temporary until the apiserver and
 	// peergrouper have manifolds.
 	centralHub *pubsub.StructuredHub
+
+	// leaseFSM holds the raft lease state, and leaseForwarder sends
+	// lease changes to the raft leader to be applied.
+	leaseFSM       *raftlease.FSM
+	leaseForwarder raftlease.Forwarder
 }
 
 // Wait waits for the machine agent to finish.
@@ -448,6 +459,11 @@ func (a *MachineAgent) Run(*cmd.Context) (err error) {
 	// have dependencies on a central hub worker.
 	a.centralHub = centralhub.New(a.Tag().(names.MachineTag))
 
+	a.leaseFSM = raftlease.NewFSM()
+	a.leaseForwarder = raftlease.NewPubsubForwarder(
+		a.centralHub, clock.WallClock, a.Tag().String(), raftLeaseRequestTimeout,
+	)
+
 	// Before doing anything else, we need to make sure the certificate generated for
 	// use by mongo to validate controller connections is correct. This needs to be done
 	// before any possible restart of the mongo service.
@@ -564,6 +580,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
 			NewModelWorker:                    a.startModelWorkers,
 			ControllerSupportsSpaces:          controllerSupportsSpaces,
+			LeaseFSM:                          a.leaseFSM,
 		})
 		if err := dependency.Install(engine, manifolds); err != nil {
 			if err := worker.Stop(engine); err != nil {
@@ -954,6 +971,7 @@ func (a *MachineAgent) initState(agentConfig agent.Config) (*state.State, error)
 		agentConfig,
 		dialOpts,
 		a.mongoTxnCollector.AfterRunTransaction,
+		a.newRaftLeaseClient,
 	)
 	if err != nil {
 		return nil, err
@@ -993,6 +1011,7 @@ func (a *MachineAgent) startModelWorkers(modelUUID string, modelType state.Model
 		InstPollerAggregationDelay:  3 * time.Second,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
 		ActionPrunerInterval:        24 * time.Hour,
+		ActionSchedulerInterval:     time.Minute,
 		NewEnvironFunc:              newEnvirons,
 		NewContainerBrokerFunc:      newCAASBroker,
 		NewMigrationMaster:          migrationmaster.NewWorker,
@@ -1056,10 +1075,27 @@ func (a *MachineAgent) ensureMongoServer(agentConfig agent.Config) (err error) {
 	return nil
 }
 
+// newRaftLeaseClient returns a lease client backed by the raft lease
+// FSM, which forwards lease changes to the raft leader over the
+// central hub.
+func (a *MachineAgent) newRaftLeaseClient(namespace, modelUUID string) (corelease.Client, error) {
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:       a.leaseFSM,
+		Forwarder: a.leaseForwarder,
+		Namespace: namespace,
+		ModelUUID: modelUUID,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return store, nil
+}
+
 func openState(
 	agentConfig agent.Config,
 	dialOpts mongo.DialOpts,
 	runTransactionObserver state.RunTransactionObserverFunc,
+	newRaftLeaseClient state.NewLeaseClientFunc,
 ) (_ *state.State, _ *state.Machine, err error) {
 	info, ok := agentConfig.MongoInfo()
 	if !ok {
@@ -1078,6 +1114,7 @@ func openState(
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: runTransactionObserver,
+		NewRaftLeaseClient:     newRaftLeaseClient,
 	})
 	if err != nil {
 		return nil, nil, err
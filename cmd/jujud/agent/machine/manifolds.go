@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/container/lxd"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/state"
 	proxyconfig "github.com/juju/juju/utils/proxy"
@@ -71,6 +72,7 @@ import (
 	"github.com/juju/juju/worker/raft/raftbackstop"
 	"github.com/juju/juju/worker/raft/raftclusterer"
 	"github.com/juju/juju/worker/raft/raftflag"
+	"github.com/juju/juju/worker/raft/raftforwarder"
 	"github.com/juju/juju/worker/raft/rafttransport"
 	"github.com/juju/juju/worker/reboot"
 	"github.com/juju/juju/worker/restorewatcher"
@@ -94,6 +96,10 @@ const (
 	// globalClockUpdaterBackoffDelay is the amount of time to
 	// delay when a concurrent global clock update is detected.
 	globalClockUpdaterBackoffDelay = 10 * time.Second
+
+	// raftLeaseApplyTimeout is how long the raft forwarder waits
+	// for a lease command to be applied to the raft log.
+	raftLeaseApplyTimeout = 5 * time.Second
 )
 
 // ManifoldsConfig allows specialisation of the result of Manifolds.
@@ -219,6 +225,12 @@ type ManifoldsConfig struct {
 	// not the controller model, represented by the given *state.State,
 	// supports network spaces.
 	ControllerSupportsSpaces func(*state.State) (bool, error)
+
+	// LeaseFSM is the raft FSM holding lease state. It is shared with
+	// the lease stores used by state when the raft-leases feature
+	// flag is set. It's used whether or not the flag is set, and
+	// can restore the snapshots written by the SimpleFSM it replaces.
+	LeaseFSM *raftlease.FSM
 }
 
 // Manifolds returns a set of co-configured manifolds covering the
@@ -757,7 +769,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			ClockName:     clockName,
 			AgentName:     agentName,
 			TransportName: raftTransportName,
-			FSM:           &raft.SimpleFSM{},
+			FSM:           config.LeaseFSM,
 			Logger:        loggo.GetLogger("juju.worker.raft"),
 			NewWorker:     raft.NewWorker,
 		}),
@@ -776,6 +788,17 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:      raftclusterer.NewWorker,
 		})),
 
+		// The raft forwarder applies lease commands published on the
+		// central hub, and so can only run on the raft leader.
+		raftForwarderName: ifRaftLeader(raftforwarder.Manifold(raftforwarder.ManifoldConfig{
+			ClockName:      clockName,
+			RaftName:       raftName,
+			CentralHubName: centralHubName,
+			ApplyTimeout:   raftLeaseApplyTimeout,
+			Logger:         loggo.GetLogger("juju.worker.raft.raftforwarder"),
+			NewWorker:      raftforwarder.NewWorker,
+		})),
+
 		raftBackstopName: raftbackstop.Manifold(raftbackstop.ManifoldConfig{
 			RaftName:       raftName,
 			CentralHubName: centralHubName,
@@ -909,6 +932,7 @@ const (
 	raftFlagName      = "raft-leader-flag"
 	raftEnabledName   = "raft-enabled-flag"
 	raftBackstopName  = "raft-backstop"
+	raftForwarderName = "raft-forwarder"
 
 	validCredentialFlagName = "valid-credential-flag"
 )
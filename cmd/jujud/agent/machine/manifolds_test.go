@@ -80,6 +80,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"raft-backstop",
 		"raft-clusterer",
 		"raft-enabled-flag",
+		"raft-forwarder",
 		"raft-leader-flag",
 		"raft-transport",
 		"reboot-executor",
@@ -154,6 +155,7 @@ func (*ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"raft-backstop",
 		"raft-clusterer",
 		"raft-enabled-flag",
+		"raft-forwarder",
 		"raft-leader-flag",
 		"raft-transport",
 		"valid-credential-flag",
@@ -584,6 +586,25 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state",
 		"state-config-watcher"},
 
+	"raft-forwarder": {
+		"agent",
+		"central-hub",
+		"certificate-watcher",
+		"clock",
+		"http-server",
+		"is-controller-flag",
+		"raft",
+		"raft-enabled-flag",
+		"raft-leader-flag",
+		"raft-transport",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"raft-leader-flag": {
 		"agent",
 		"central-hub",
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/collections/set"
@@ -19,6 +20,8 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/permission"
 )
 
 const (
@@ -55,6 +58,51 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogSinks is a list of the destinations audit log records
+	// are written to. Valid sinks are "file", "syslog" and
+	// "webhook".
+	AuditLogSinks = "audit-log-sinks"
+
+	// AuditLogSyslogHost is the host-port of the syslog server
+	// audit log records are forwarded to by the syslog sink.
+	AuditLogSyslogHost = "audit-log-syslog-host"
+
+	// AuditLogSyslogCACert is the CA certificate (x.509, PEM-encoded)
+	// used to validate the syslog server's certificate.
+	AuditLogSyslogCACert = "audit-log-syslog-ca-cert"
+
+	// AuditLogSyslogClientCert is the client certificate (x.509,
+	// PEM-encoded) used when connecting to the syslog server.
+	AuditLogSyslogClientCert = "audit-log-syslog-client-cert"
+
+	// AuditLogSyslogClientKey is the client private key (PEM-encoded)
+	// used when connecting to the syslog server.
+	AuditLogSyslogClientKey = "audit-log-syslog-client-key"
+
+	// AuditLogWebhookURL is the http(s) URL that batches of audit log
+	// records are POSTed to by the webhook sink.
+	AuditLogWebhookURL = "audit-log-webhook-url"
+
+	// AuditLogWebhookBatchSize is the maximum number of records the
+	// webhook sink sends in one request.
+	AuditLogWebhookBatchSize = "audit-log-webhook-batch-size"
+
+	// AuditLogWebhookFlushInterval is how long the webhook sink waits
+	// before sending a batch that isn't full, eg "5s".
+	AuditLogWebhookFlushInterval = "audit-log-webhook-flush-interval"
+
+	// AuditLogSinkFile is the audit log sink which writes to a
+	// rotated audit.log file on each controller machine.
+	AuditLogSinkFile = "file"
+
+	// AuditLogSinkSyslog is the audit log sink which forwards
+	// records to a remote syslog server.
+	AuditLogSinkSyslog = "syslog"
+
+	// AuditLogSinkWebhook is the audit log sink which POSTs batches
+	// of records as JSON to a remote HTTP endpoint.
+	AuditLogSinkWebhook = "webhook"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -78,6 +126,42 @@ const (
 	// IdentityPublicKey sets the public key of the identity manager.
 	IdentityPublicKey = "identity-public-key"
 
+	// OIDCIssuerURL sets the URL of an OpenID Connect identity
+	// provider whose ID tokens users may log in with.
+	OIDCIssuerURL = "oidc-issuer-url"
+
+	// OIDCClientID is the OAuth 2.0 client ID, registered with the
+	// OpenID Connect identity provider, that ID tokens must be
+	// issued to.
+	OIDCClientID = "oidc-client-id"
+
+	// OIDCScopes is the list of scopes clients request when
+	// obtaining an ID token.
+	OIDCScopes = "oidc-scopes"
+
+	// OIDCGroupsClaim is the name of the ID token claim listing
+	// the groups the user is a member of.
+	OIDCGroupsClaim = "oidc-groups-claim"
+
+	// OIDCUsernameClaim is the name of the ID token claim holding
+	// the name of the user. It must be a valid local user name.
+	OIDCUsernameClaim = "oidc-username-claim"
+
+	// OIDCUserDomain is the domain of the users who log in with ID
+	// tokens, who are named "<username claim>@<domain>". It must not
+	// be shared with any other identity provider.
+	OIDCUserDomain = "oidc-user-domain"
+
+	// OIDCGroupAccess is a list of rules granting access to the
+	// members of OpenID Connect groups, each of the form
+	// "<group>:<controller access>" or
+	// "<group>:<model access>:<model uuid>". When a user logs in
+	// with an ID token, their access to the controller and to each
+	// model named by a rule is set to the greatest access granted by
+	// the rules for the groups they are a member of. Access not
+	// covered by any of their groups' rules is left as it is.
+	OIDCGroupAccess = "oidc-group-access"
+
 	// SetNUMAControlPolicyKey stores the value for this setting
 	SetNUMAControlPolicyKey = "set-numa-control-policy"
 
@@ -131,6 +215,26 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultAuditLogWebhookBatchSize is the default maximum number
+	// of records sent to the audit log webhook in one request.
+	DefaultAuditLogWebhookBatchSize = 100
+
+	// DefaultAuditLogWebhookFlushInterval is the default time the
+	// audit log webhook sink waits before sending a partial batch.
+	DefaultAuditLogWebhookFlushInterval = 5 * time.Second
+
+	// DefaultOIDCGroupsClaim is the default name of the ID token
+	// claim listing the groups a user is a member of.
+	DefaultOIDCGroupsClaim = "groups"
+
+	// DefaultOIDCUsernameClaim is the default name of the ID token
+	// claim holding the name of the user.
+	DefaultOIDCUsernameClaim = "sub"
+
+	// DefaultOIDCUserDomain is the default domain of the users who
+	// log in with ID tokens.
+	DefaultOIDCUserDomain = "oidc"
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -182,6 +286,13 @@ var (
 		ControllerUUIDKey,
 		IdentityPublicKey,
 		IdentityURL,
+		OIDCIssuerURL,
+		OIDCClientID,
+		OIDCScopes,
+		OIDCGroupsClaim,
+		OIDCUsernameClaim,
+		OIDCUserDomain,
+		OIDCGroupAccess,
 		SetNUMAControlPolicyKey,
 		StatePort,
 		MongoMemoryProfile,
@@ -195,6 +306,14 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogSinks,
+		AuditLogSyslogHost,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogWebhookURL,
+		AuditLogWebhookBatchSize,
+		AuditLogWebhookFlushInterval,
 		CAASOperatorImagePath,
 		Features,
 	}
@@ -206,10 +325,25 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		AuditLogSinks,
+		AuditLogSyslogHost,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogWebhookURL,
+		AuditLogWebhookBatchSize,
+		AuditLogWebhookFlushInterval,
 		JujuHASpace,
 		JujuManagementSpace,
 		CAASOperatorImagePath,
 		Features,
+		OIDCIssuerURL,
+		OIDCClientID,
+		OIDCScopes,
+		OIDCGroupsClaim,
+		OIDCUsernameClaim,
+		OIDCUserDomain,
+		OIDCGroupAccess,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -220,6 +354,19 @@ var (
 		ReadOnlyMethodsWildcard,
 	}
 
+	// DefaultOIDCScopes is the default list of scopes clients
+	// request when obtaining an ID token.
+	DefaultOIDCScopes = []string{"openid", "email", "groups"}
+
+	// DefaultAuditLogSinks is the default list of audit log sinks.
+	DefaultAuditLogSinks = []string{AuditLogSinkFile}
+
+	auditLogSinks = set.NewStrings(
+		AuditLogSinkFile,
+		AuditLogSinkSyslog,
+		AuditLogSinkWebhook,
+	)
+
 	methodNameRE = regexp.MustCompile(`[[:alpha:]][[:alnum:]]*\.[[:alpha:]][[:alnum:]]*`)
 )
 
@@ -357,6 +504,62 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogSinks returns the names of the sinks audit log records
+// should be written to.
+func (c Config) AuditLogSinks() set.Strings {
+	if value, ok := c[AuditLogSinks]; ok {
+		value := value.([]interface{})
+		items := set.NewStrings()
+		for _, item := range value {
+			items.Add(item.(string))
+		}
+		return items
+	}
+	return set.NewStrings(DefaultAuditLogSinks...)
+}
+
+// AuditLogSyslogConfig returns the connection details for the syslog
+// audit log sink.
+func (c Config) AuditLogSyslogConfig() syslog.RawConfig {
+	return syslog.RawConfig{
+		Enabled:    c.AuditLogSinks().Contains(AuditLogSinkSyslog),
+		Host:       c.asString(AuditLogSyslogHost),
+		CACert:     c.asString(AuditLogSyslogCACert),
+		ClientCert: c.asString(AuditLogSyslogClientCert),
+		ClientKey:  c.asString(AuditLogSyslogClientKey),
+	}
+}
+
+// AuditLogWebhookURL returns the URL the webhook audit log sink sends
+// records to.
+func (c Config) AuditLogWebhookURL() string {
+	return c.asString(AuditLogWebhookURL)
+}
+
+// AuditLogWebhookBatchSize returns the maximum number of records the
+// webhook audit log sink sends in one request.
+func (c Config) AuditLogWebhookBatchSize() int {
+	if value, ok := c[AuditLogWebhookBatchSize]; ok {
+		// Values obtained over the API are encoded as float64.
+		if floatValue, ok := value.(float64); ok {
+			return int(floatValue)
+		}
+		return value.(int)
+	}
+	return DefaultAuditLogWebhookBatchSize
+}
+
+// AuditLogWebhookFlushInterval returns how long the webhook audit log
+// sink waits before sending a partial batch of records.
+func (c Config) AuditLogWebhookFlushInterval() time.Duration {
+	if value, ok := c[AuditLogWebhookFlushInterval].(string); ok {
+		// Value has already been validated.
+		val, _ := time.ParseDuration(value)
+		return val
+	}
+	return DefaultAuditLogWebhookFlushInterval
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -391,6 +594,125 @@ func (c Config) IdentityURL() string {
 	return c.asString(IdentityURL)
 }
 
+// OIDCIssuerURL returns the URL of the OpenID Connect identity provider
+// users may log in with, or "" if OpenID Connect login is not enabled.
+func (c Config) OIDCIssuerURL() string {
+	return c.asString(OIDCIssuerURL)
+}
+
+// OIDCClientID returns the OAuth 2.0 client ID that ID tokens must be
+// issued to.
+func (c Config) OIDCClientID() string {
+	return c.asString(OIDCClientID)
+}
+
+// OIDCScopes returns the scopes clients request when obtaining an ID
+// token.
+func (c Config) OIDCScopes() []string {
+	if value, ok := c[OIDCScopes]; ok {
+		value := value.([]interface{})
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = item.(string)
+		}
+		return items
+	}
+	return DefaultOIDCScopes
+}
+
+// OIDCGroupsClaim returns the name of the ID token claim listing the
+// groups a user is a member of.
+func (c Config) OIDCGroupsClaim() string {
+	if claim := c.asString(OIDCGroupsClaim); claim != "" {
+		return claim
+	}
+	return DefaultOIDCGroupsClaim
+}
+
+// OIDCUsernameClaim returns the name of the ID token claim holding the
+// name of the user.
+func (c Config) OIDCUsernameClaim() string {
+	if claim := c.asString(OIDCUsernameClaim); claim != "" {
+		return claim
+	}
+	return DefaultOIDCUsernameClaim
+}
+
+// OIDCUserDomain returns the domain of the users who log in with ID
+// tokens.
+func (c Config) OIDCUserDomain() string {
+	if domain := c.asString(OIDCUserDomain); domain != "" {
+		return domain
+	}
+	return DefaultOIDCUserDomain
+}
+
+// OIDCGroupAccess returns the rules granting access to the members of
+// OpenID Connect groups. See OIDCGroupAccess for more details.
+func (c Config) OIDCGroupAccess() []GroupAccess {
+	value, ok := c[OIDCGroupAccess].([]interface{})
+	if !ok {
+		return nil
+	}
+	rules := make([]GroupAccess, 0, len(value))
+	for _, item := range value {
+		rule, err := ParseGroupAccess(item.(string))
+		if err != nil {
+			// We check the rules can be parsed in the Validate
+			// function, so we really do not expect this to fail.
+			panic(err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// GroupAccess is a rule granting access to the members of a group.
+type GroupAccess struct {
+	// Group is the name of the group.
+	Group string
+
+	// Access is the access granted to the group's members.
+	Access permission.Access
+
+	// ModelUUID holds the UUID of the model the access is granted
+	// on. If it is empty, the access is granted on the controller.
+	ModelUUID string
+}
+
+// ParseGroupAccess parses a group access rule of the form
+// "<group>:<controller access>" or "<group>:<model access>:<model uuid>".
+// The group name may itself contain colons.
+func ParseGroupAccess(s string) (GroupAccess, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 {
+		return GroupAccess{}, errors.NotValidf("group access %q", s)
+	}
+	var rule GroupAccess
+	if last := parts[len(parts)-1]; utils.IsValidUUIDString(last) {
+		rule.ModelUUID = last
+		parts = parts[:len(parts)-1]
+		if len(parts) < 2 {
+			return GroupAccess{}, errors.NotValidf("group access %q", s)
+		}
+	}
+	rule.Access = permission.Access(parts[len(parts)-1])
+	rule.Group = strings.Join(parts[:len(parts)-1], ":")
+	if rule.Group == "" {
+		return GroupAccess{}, errors.NotValidf("group access %q with no group", s)
+	}
+	var err error
+	if rule.ModelUUID == "" {
+		err = permission.ValidateControllerAccess(rule.Access)
+	} else {
+		err = permission.ValidateModelAccess(rule.Access)
+	}
+	if err != nil {
+		return GroupAccess{}, errors.Annotatef(err, "group access %q", s)
+	}
+	return rule, nil
+}
+
 // AutocertURL returns the URL used to obtain official TLS certificates
 // when a client connects to the API. See AutocertURLKey
 // for more details.
@@ -507,6 +829,10 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.validateOIDCConfig(); err != nil {
+		return errors.Trace(err)
+	}
+
 	caCert, caCertOK := c.CACert()
 	if !caCertOK {
 		return errors.Errorf("missing CA certificate")
@@ -591,6 +917,84 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.validateAuditLogSinks(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (c Config) validateAuditLogSinks() error {
+	sinks, ok := c[AuditLogSinks].([]interface{})
+	if !ok {
+		return nil
+	}
+	for i, name := range sinks {
+		name := name.(string)
+		if !auditLogSinks.Contains(name) {
+			return errors.Errorf(
+				"invalid audit log sinks: expected one of %q, got %q at position %d",
+				auditLogSinks.SortedValues(),
+				name,
+				i+1,
+			)
+		}
+	}
+	configured := c.AuditLogSinks()
+	if configured.Contains(AuditLogSinkSyslog) {
+		if err := c.AuditLogSyslogConfig().Validate(); err != nil {
+			return errors.Annotate(err, "invalid audit log syslog config")
+		}
+	}
+	if configured.Contains(AuditLogSinkWebhook) {
+		u, err := url.Parse(c.AuditLogWebhookURL())
+		if err != nil {
+			return errors.Annotate(err, "invalid audit log webhook URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid audit log webhook URL: expected http or https URL, got %q", c.AuditLogWebhookURL())
+		}
+		if size := c.AuditLogWebhookBatchSize(); size <= 0 {
+			return errors.Errorf("invalid audit log webhook batch size: should be greater than 0, got %d", size)
+		}
+	}
+	if v, ok := c[AuditLogWebhookFlushInterval].(string); ok {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid audit log webhook flush interval")
+		}
+		if interval <= 0 {
+			return errors.Errorf("invalid audit log webhook flush interval: should be greater than 0, got %v", interval)
+		}
+	}
+	return nil
+}
+
+func (c Config) validateOIDCConfig() error {
+	if v, ok := c[OIDCIssuerURL].(string); ok {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid OIDC issuer URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid OIDC issuer URL: expected http or https URL, got %q", v)
+		}
+		if c.OIDCClientID() == "" {
+			return errors.Errorf("%s must be set when %s is set", OIDCClientID, OIDCIssuerURL)
+		}
+	}
+	if v, ok := c[OIDCUserDomain].(string); ok {
+		if v == "local" || !names.IsValidUser("user@"+v) {
+			return errors.NotValidf("OIDC user domain %q", v)
+		}
+	}
+	if v, ok := c[OIDCGroupAccess].([]interface{}); ok {
+		for i, rule := range v {
+			if _, err := ParseGroupAccess(rule.(string)); err != nil {
+				return errors.Annotatef(err, "invalid OIDC group access at position %d", i+1)
+			}
+		}
+	}
 	return nil
 }
 
@@ -646,47 +1050,77 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	AuditLogCaptureArgs:     schema.Bool(),
-	AuditLogMaxSize:         schema.String(),
-	AuditLogMaxBackups:      schema.ForceInt(),
-	AuditLogExcludeMethods:  schema.List(schema.String()),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
-	MaxLogsAge:              schema.String(),
-	MaxLogsSize:             schema.String(),
-	MaxTxnLogSize:           schema.String(),
-	JujuHASpace:             schema.String(),
-	JujuManagementSpace:     schema.String(),
-	CAASOperatorImagePath:   schema.String(),
-	Features:                schema.List(schema.String()),
+	AuditingEnabled:              schema.Bool(),
+	AuditLogCaptureArgs:          schema.Bool(),
+	AuditLogMaxSize:              schema.String(),
+	AuditLogMaxBackups:           schema.ForceInt(),
+	AuditLogExcludeMethods:       schema.List(schema.String()),
+	AuditLogSinks:                schema.List(schema.String()),
+	AuditLogSyslogHost:           schema.String(),
+	AuditLogSyslogCACert:         schema.String(),
+	AuditLogSyslogClientCert:     schema.String(),
+	AuditLogSyslogClientKey:      schema.String(),
+	AuditLogWebhookURL:           schema.String(),
+	AuditLogWebhookBatchSize:     schema.ForceInt(),
+	AuditLogWebhookFlushInterval: schema.String(),
+	APIPort:                      schema.ForceInt(),
+	StatePort:                    schema.ForceInt(),
+	IdentityURL:                  schema.String(),
+	IdentityPublicKey:            schema.String(),
+	OIDCIssuerURL:                schema.String(),
+	OIDCClientID:                 schema.String(),
+	OIDCScopes:                   schema.List(schema.String()),
+	OIDCGroupsClaim:              schema.String(),
+	OIDCUsernameClaim:            schema.String(),
+	OIDCUserDomain:               schema.String(),
+	OIDCGroupAccess:              schema.List(schema.String()),
+	SetNUMAControlPolicyKey:      schema.Bool(),
+	AutocertURLKey:               schema.String(),
+	AutocertDNSNameKey:           schema.String(),
+	AllowModelAccessKey:          schema.Bool(),
+	MongoMemoryProfile:           schema.String(),
+	MaxLogsAge:                   schema.String(),
+	MaxLogsSize:                  schema.String(),
+	MaxTxnLogSize:                schema.String(),
+	JujuHASpace:                  schema.String(),
+	JujuManagementSpace:          schema.String(),
+	CAASOperatorImagePath:        schema.String(),
+	Features:                     schema.List(schema.String()),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	AuditLogCaptureArgs:     DefaultAuditLogCaptureArgs,
-	AuditLogMaxSize:         fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
-	AuditLogMaxBackups:      DefaultAuditLogMaxBackups,
-	AuditLogExcludeMethods:  DefaultAuditLogExcludeMethods,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      schema.Omit,
-	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
-	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
-	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
-	JujuHASpace:             schema.Omit,
-	JujuManagementSpace:     schema.Omit,
-	CAASOperatorImagePath:   schema.Omit,
-	Features:                schema.Omit,
+	APIPort:                      DefaultAPIPort,
+	AuditingEnabled:              DefaultAuditingEnabled,
+	AuditLogCaptureArgs:          DefaultAuditLogCaptureArgs,
+	AuditLogMaxSize:              fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
+	AuditLogMaxBackups:           DefaultAuditLogMaxBackups,
+	AuditLogExcludeMethods:       DefaultAuditLogExcludeMethods,
+	AuditLogSinks:                DefaultAuditLogSinks,
+	AuditLogSyslogHost:           schema.Omit,
+	AuditLogSyslogCACert:         schema.Omit,
+	AuditLogSyslogClientCert:     schema.Omit,
+	AuditLogSyslogClientKey:      schema.Omit,
+	AuditLogWebhookURL:           schema.Omit,
+	AuditLogWebhookBatchSize:     DefaultAuditLogWebhookBatchSize,
+	AuditLogWebhookFlushInterval: DefaultAuditLogWebhookFlushInterval.String(),
+	StatePort:                    DefaultStatePort,
+	IdentityURL:                  schema.Omit,
+	IdentityPublicKey:            schema.Omit,
+	OIDCIssuerURL:                schema.Omit,
+	OIDCClientID:                 schema.Omit,
+	OIDCScopes:                   schema.Omit,
+	OIDCGroupsClaim:              schema.Omit,
+	OIDCUsernameClaim:            schema.Omit,
+	OIDCUserDomain:               schema.Omit,
+	OIDCGroupAccess:              schema.Omit,
+	SetNUMAControlPolicyKey:      DefaultNUMAControlPolicy,
+	AutocertURLKey:               schema.Omit,
+	AutocertDNSNameKey:           schema.Omit,
+	AllowModelAccessKey:          schema.Omit,
+	MongoMemoryProfile:           schema.Omit,
+	MaxLogsAge:                   fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
+	MaxLogsSize:                  fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
+	MaxTxnLogSize:                fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	JujuHASpace:                  schema.Omit,
+	JujuManagementSpace:          schema.Omit,
+	CAASOperatorImagePath:        schema.Omit,
+	Features:                     schema.Omit,
 })
@@ -16,6 +16,8 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/testing"
 )
 
@@ -186,6 +188,86 @@ var validateTests = []struct {
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "ReadOnlyMethods", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "Sharon Jones" at position 3`,
+}, {
+	about: "invalid audit log sink",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.AuditLogSinks: []interface{}{"file", "carrier-pigeon"},
+	},
+	expectError: `invalid audit log sinks: expected one of \["file" "syslog" "webhook"\], got "carrier-pigeon" at position 2`,
+}, {
+	about: "syslog audit log sink without host",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.AuditLogSinks: []interface{}{"syslog"},
+	},
+	expectError: `invalid audit log syslog config: Host "" not valid`,
+}, {
+	about: "webhook audit log sink without URL",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.AuditLogSinks: []interface{}{"webhook"},
+	},
+	expectError: `invalid audit log webhook URL: expected http or https URL, got ""`,
+}, {
+	about: "invalid audit log webhook batch size",
+	config: controller.Config{
+		controller.CACertKey:                testing.CACert,
+		controller.AuditLogSinks:            []interface{}{"webhook"},
+		controller.AuditLogWebhookURL:       "https://siem.example.com/audit",
+		controller.AuditLogWebhookBatchSize: 0,
+	},
+	expectError: `invalid audit log webhook batch size: should be greater than 0, got 0`,
+}, {
+	about: "invalid audit log webhook flush interval",
+	config: controller.Config{
+		controller.CACertKey:                    testing.CACert,
+		controller.AuditLogWebhookFlushInterval: "soon",
+	},
+	expectError: `invalid audit log webhook flush interval: time: invalid duration "?soon"?`,
+}, {
+	about: "invalid OIDC issuer URL",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.OIDCIssuerURL: "sso.example.com",
+		controller.OIDCClientID:  "juju",
+	},
+	expectError: `invalid OIDC issuer URL: expected http or https URL, got "sso.example.com"`,
+}, {
+	about: "OIDC issuer URL requires client ID",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.OIDCIssuerURL: "https://sso.example.com",
+	},
+	expectError: `oidc-client-id must be set when oidc-issuer-url is set`,
+}, {
+	about: "invalid OIDC group access",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.OIDCGroupAccess: []interface{}{"admins:superuser", "devs"},
+	},
+	expectError: `invalid OIDC group access at position 2: group access "devs" not valid`,
+}, {
+	about: "invalid OIDC group controller access",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.OIDCGroupAccess: []interface{}{"devs:write"},
+	},
+	expectError: `invalid OIDC group access at position 1: group access "devs:write": "write" controller access not valid`,
+}, {
+	about: "local OIDC user domain",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.OIDCUserDomain: "local",
+	},
+	expectError: `OIDC user domain "local" not valid`,
+}, {
+	about: "invalid OIDC user domain",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.OIDCUserDomain: "sso@example",
+	},
+	expectError: `OIDC user domain "sso@example" not valid`,
 }, {
 	about: "invalid CAAS operator docker image path",
 	config: controller.Config{
@@ -344,6 +426,90 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 	))
 }
 
+func (s *ConfigSuite) TestAuditLogSinkDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSinks(), gc.DeepEquals, set.NewStrings("file"))
+	c.Assert(cfg.AuditLogSyslogConfig(), gc.Equals, syslog.RawConfig{})
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "")
+	c.Assert(cfg.AuditLogWebhookBatchSize(), gc.Equals, 100)
+	c.Assert(cfg.AuditLogWebhookFlushInterval(), gc.Equals, 5*time.Second)
+}
+
+func (s *ConfigSuite) TestAuditLogSinkValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"audit-log-sinks":                  []string{"file", "syslog", "webhook"},
+			"audit-log-syslog-host":            "syslog.example.com:6514",
+			"audit-log-syslog-ca-cert":         testing.CACert,
+			"audit-log-syslog-client-cert":     testing.ServerCert,
+			"audit-log-syslog-client-key":      testing.ServerKey,
+			"audit-log-webhook-url":            "https://siem.example.com/audit",
+			"audit-log-webhook-batch-size":     50.0,
+			"audit-log-webhook-flush-interval": "30s",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSinks(), gc.DeepEquals, set.NewStrings("file", "syslog", "webhook"))
+	c.Assert(cfg.AuditLogSyslogConfig(), gc.Equals, syslog.RawConfig{
+		Enabled:    true,
+		Host:       "syslog.example.com:6514",
+		CACert:     testing.CACert,
+		ClientCert: testing.ServerCert,
+		ClientKey:  testing.ServerKey,
+	})
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "https://siem.example.com/audit")
+	c.Assert(cfg.AuditLogWebhookBatchSize(), gc.Equals, 50)
+	c.Assert(cfg.AuditLogWebhookFlushInterval(), gc.Equals, 30*time.Second)
+}
+
+func (s *ConfigSuite) TestOIDCDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OIDCIssuerURL(), gc.Equals, "")
+	c.Assert(cfg.OIDCScopes(), jc.DeepEquals, []string{"openid", "email", "groups"})
+	c.Assert(cfg.OIDCGroupsClaim(), gc.Equals, "groups")
+	c.Assert(cfg.OIDCUsernameClaim(), gc.Equals, "sub")
+	c.Assert(cfg.OIDCUserDomain(), gc.Equals, "oidc")
+	c.Assert(cfg.OIDCGroupAccess(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestOIDCValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"oidc-issuer-url":     "https://sso.example.com",
+			"oidc-client-id":      "juju",
+			"oidc-scopes":         []string{"openid", "email"},
+			"oidc-groups-claim":   "roles",
+			"oidc-username-claim": "preferred_username",
+			"oidc-user-domain":    "sso",
+			"oidc-group-access": []string{
+				"admins:superuser",
+				"cn=devs:ou=groups:write:" + testing.ModelTag.Id(),
+			},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.OIDCIssuerURL(), gc.Equals, "https://sso.example.com")
+	c.Assert(cfg.OIDCClientID(), gc.Equals, "juju")
+	c.Assert(cfg.OIDCScopes(), jc.DeepEquals, []string{"openid", "email"})
+	c.Assert(cfg.OIDCGroupsClaim(), gc.Equals, "roles")
+	c.Assert(cfg.OIDCUsernameClaim(), gc.Equals, "preferred_username")
+	c.Assert(cfg.OIDCUserDomain(), gc.Equals, "sso")
+	c.Assert(cfg.OIDCGroupAccess(), jc.DeepEquals, []controller.GroupAccess{{
+		Group:  "admins",
+		Access: permission.SuperuserAccess,
+	}, {
+		Group:     "cn=devs:ou=groups",
+		Access:    permission.WriteAccess,
+		ModelUUID: testing.ModelTag.Id(),
+	}})
+}
+
 func (s *ConfigSuite) TestAuditLogExcludeMethodsType(c *gc.C) {
 	_, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -195,38 +195,18 @@ func NewLogFile(logDir string, maxSize, maxBackups int) AuditLog {
 		logger.Errorf("Unable to prime %s (proceeding anyway): %v", logPath, err)
 	}
 
-	return &auditLogFile{
+	return NewSinkLog(&auditLogFile{
 		fileLogger: &lumberjack.Logger{
 			Filename:   logPath,
 			MaxSize:    maxSize,
 			MaxBackups: maxBackups,
 			Compress:   true,
 		},
-	}
-}
-
-// AddConversation implements AuditLog.
-func (a *auditLogFile) AddConversation(c Conversation) error {
-	return errors.Trace(a.addRecord(Record{Conversation: &c}))
-}
-
-// AddRequest implements AuditLog.
-func (a *auditLogFile) AddRequest(m Request) error {
-	return errors.Trace(a.addRecord(Record{Request: &m}))
-
-}
-
-// AddResponse implements AuditLog.
-func (a *auditLogFile) AddResponse(m ResponseErrors) error {
-	return errors.Trace(a.addRecord(Record{Errors: &m}))
-}
-
-// Close implements AuditLog.
-func (a *auditLogFile) Close() error {
-	return errors.Trace(a.fileLogger.Close())
+	})
 }
 
-func (a *auditLogFile) addRecord(r Record) error {
+// Send implements Sink.
+func (a *auditLogFile) Send(r Record) error {
 	bytes, err := json.Marshal(r)
 	if err != nil {
 		return errors.Trace(err)
@@ -238,6 +218,11 @@ func (a *auditLogFile) addRecord(r Record) error {
 	return errors.Trace(err)
 }
 
+// Close implements Sink.
+func (a *auditLogFile) Close() error {
+	return errors.Trace(a.fileLogger.Close())
+}
+
 func idString(id uint64) string {
 	return fmt.Sprintf("%X", id)
 }
@@ -6,6 +6,8 @@ package auditlog
 import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 // Config holds parameters to control audit logging.
@@ -29,6 +31,16 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
+	// Sinks names the destinations audit records are written to, as
+	// listed in the controller config.
+	Sinks set.Strings
+
+	// Syslog holds the connection details used by the syslog sink.
+	Syslog syslog.RawConfig
+
+	// Webhook holds the settings used by the webhook sink.
+	Webhook WebhookConfig
+
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
 }
@@ -0,0 +1,10 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+// SetMaxTracked sets the number of conversations and requests the
+// filter remembers.
+func (f *RecordFilter) SetMaxTracked(n int) {
+	f.maxTracked = n
+}
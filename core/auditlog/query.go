@@ -0,0 +1,256 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+)
+
+// maxRecordSize is the longest line ReadLogFile will accept. Requests
+// with captured API args can be large.
+const maxRecordSize = 16 * 1024 * 1024
+
+// LogFiles returns the paths of the audit log files in logDir, oldest
+// first: any rotated (and possibly compressed) backups, followed by
+// the current audit.log.
+func LogFiles(logDir string) ([]string, error) {
+	// Backups are named by lumberjack with a sortable timestamp, eg
+	// audit-2018-06-15T01-02-03.456.log.gz.
+	backups, err := filepath.Glob(filepath.Join(logDir, "audit-*.log*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(backups)
+	current := filepath.Join(logDir, "audit.log")
+	if _, err := os.Stat(current); err == nil {
+		backups = append(backups, current)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Trace(err)
+	}
+	return backups, nil
+}
+
+// ReadLogFile calls f with each record in the audit log file at path,
+// which is gzip compressed if its name ends in ".gz". Lines that
+// can't be parsed are skipped. Reading stops at the first error
+// returned by f.
+func ReadLogFile(path string, f func(Record) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gzReader, err := gzip.NewReader(file)
+		if err != nil {
+			return errors.Annotatef(err, "reading %s", path)
+		}
+		defer gzReader.Close()
+		reader = gzReader
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			logger.Warningf("skipping invalid audit record at %s:%d: %v", path, line, err)
+			continue
+		}
+		if err := f(record); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Annotatef(scanner.Err(), "reading %s", path)
+}
+
+// Filter describes the audit records to select. Empty fields match
+// all records.
+type Filter struct {
+	// Who matches the user who started the conversation.
+	Who string
+
+	// ModelUUID matches the model the conversation was with.
+	ModelUUID string
+
+	// ConversationID matches a single conversation.
+	ConversationID string
+
+	// Facade and Method match the API calls made.
+	Facade string
+	Method string
+
+	// After and Before bound the times of the API calls made.
+	After  time.Time
+	Before time.Time
+}
+
+// selectsRequests reports whether the filter restricts requests,
+// rather than just conversations.
+func (f Filter) selectsRequests() bool {
+	return f.Facade != "" || f.Method != "" || !f.After.IsZero() || !f.Before.IsZero()
+}
+
+func (f Filter) matchConversation(c *Conversation) bool {
+	return (f.Who == "" || c.Who == f.Who) &&
+		(f.ModelUUID == "" || c.ModelUUID == f.ModelUUID) &&
+		(f.ConversationID == "" || c.ConversationID == f.ConversationID)
+}
+
+func (f Filter) matchRequest(r *Request) bool {
+	if f.Facade != "" && r.Facade != f.Facade {
+		return false
+	}
+	if f.Method != "" && r.Method != f.Method {
+		return false
+	}
+	if f.After.IsZero() && f.Before.IsZero() {
+		return true
+	}
+	when, err := time.Parse(time.RFC3339, r.When)
+	if err != nil {
+		return false
+	}
+	return !when.Before(f.After) && (f.Before.IsZero() || when.Before(f.Before))
+}
+
+// maxTracked is the number of conversations, and of requests awaiting
+// responses, a RecordFilter remembers by default. Once there are more,
+// the oldest are forgotten, so that filtering a large log doesn't use
+// an unbounded amount of memory. Any later records of a forgotten
+// conversation or request are not selected.
+const maxTracked = 50000
+
+// RecordFilter selects records read in order from an audit log.
+// Requests and responses don't say who made them, so the filter
+// tracks the conversations they belong to.
+type RecordFilter struct {
+	filter Filter
+
+	// maxTracked is the number of conversations, and of requests,
+	// the filter remembers.
+	maxTracked int
+
+	// pending holds matching conversations which haven't been
+	// output yet because none of their requests have matched.
+	pending map[string]Conversation
+
+	// conversations and requests hold the IDs of the conversations
+	// and requests that have been output. Requests are forgotten once
+	// their responses have been output.
+	conversations set.Strings
+	requests      set.Strings
+
+	// conversationOrder and requestOrder hold the IDs of the
+	// conversations and requests tracked, oldest first, so that the
+	// oldest can be forgotten. They may also hold IDs that have
+	// already been forgotten.
+	conversationOrder []string
+	requestOrder      []string
+}
+
+// NewRecordFilter returns a RecordFilter that selects records
+// matching the filter.
+func NewRecordFilter(filter Filter) *RecordFilter {
+	return &RecordFilter{
+		filter:        filter,
+		maxTracked:    maxTracked,
+		pending:       make(map[string]Conversation),
+		conversations: set.NewStrings(),
+		requests:      set.NewStrings(),
+	}
+}
+
+// Match returns the records that should be output in response to
+// the next record read from the log. If the filter selects requests
+// by facade, method or time, a conversation is only output (just
+// before its first matching request) if one of its requests matches.
+// A response is output if its request was.
+func (f *RecordFilter) Match(r Record) []Record {
+	switch {
+	case r.Conversation != nil:
+		c := r.Conversation
+		if !f.filter.matchConversation(c) {
+			return nil
+		}
+		if f.filter.selectsRequests() {
+			f.pending[c.ConversationID] = *c
+			f.trackConversation(c.ConversationID)
+			return nil
+		}
+		f.conversations.Add(c.ConversationID)
+		f.trackConversation(c.ConversationID)
+		return []Record{r}
+	case r.Request != nil:
+		req := r.Request
+		pending, isPending := f.pending[req.ConversationID]
+		if !isPending && !f.conversations.Contains(req.ConversationID) {
+			return nil
+		}
+		if !f.filter.matchRequest(req) {
+			return nil
+		}
+		var result []Record
+		if isPending {
+			delete(f.pending, req.ConversationID)
+			f.conversations.Add(req.ConversationID)
+			result = append(result, Record{Conversation: &pending})
+		}
+		key := requestKey(req.ConversationID, req.RequestID)
+		f.requests.Add(key)
+		f.requestOrder = f.track(f.requestOrder, key, func(key string) {
+			f.requests.Remove(key)
+		})
+		return append(result, r)
+	case r.Errors != nil:
+		key := requestKey(r.Errors.ConversationID, r.Errors.RequestID)
+		if f.requests.Contains(key) {
+			// There is only one response to each request.
+			f.requests.Remove(key)
+			return []Record{r}
+		}
+	}
+	return nil
+}
+
+// trackConversation records that the conversation is being tracked,
+// forgetting the oldest conversation if there are too many.
+func (f *RecordFilter) trackConversation(id string) {
+	f.conversationOrder = f.track(f.conversationOrder, id, func(id string) {
+		delete(f.pending, id)
+		f.conversations.Remove(id)
+	})
+}
+
+// track appends the id to order, calling forget with, and dropping, the
+// oldest ids while order holds more than maxTracked of them.
+func (f *RecordFilter) track(order []string, id string, forget func(string)) []string {
+	order = append(order, id)
+	for len(order) > f.maxTracked {
+		forget(order[0])
+		order = order[1:]
+	}
+	return order
+}
+
+func requestKey(conversationID string, requestID uint64) string {
+	return fmt.Sprintf("%s/%d", conversationID, requestID)
+}
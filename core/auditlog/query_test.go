@@ -0,0 +1,194 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type QuerySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&QuerySuite{})
+
+var queryRecords = []auditlog.Record{{
+	Conversation: &auditlog.Conversation{Who: "bob", ModelUUID: "uuid1", ConversationID: "c1", When: "2018-06-01T12:00:00Z"},
+}, {
+	Request: &auditlog.Request{ConversationID: "c1", RequestID: 1, Facade: "Client", Method: "FullStatus", When: "2018-06-01T12:00:01Z"},
+}, {
+	Conversation: &auditlog.Conversation{Who: "mary", ModelUUID: "uuid2", ConversationID: "c2", When: "2018-06-01T12:01:00Z"},
+}, {
+	Request: &auditlog.Request{ConversationID: "c1", RequestID: 2, Facade: "Application", Method: "Deploy", When: "2018-06-01T12:01:30Z"},
+}, {
+	Request: &auditlog.Request{ConversationID: "c2", RequestID: 1, Facade: "Application", Method: "Deploy", When: "2018-06-01T12:02:00Z"},
+}, {
+	Errors: &auditlog.ResponseErrors{ConversationID: "c2", RequestID: 1, When: "2018-06-01T12:02:01Z"},
+}, {
+	Errors: &auditlog.ResponseErrors{ConversationID: "c1", RequestID: 2, When: "2018-06-01T12:02:02Z"},
+}}
+
+func filterRecords(filter auditlog.Filter) []auditlog.Record {
+	recordFilter := auditlog.NewRecordFilter(filter)
+	var result []auditlog.Record
+	for _, r := range queryRecords {
+		result = append(result, recordFilter.Match(r)...)
+	}
+	return result
+}
+
+func (s *QuerySuite) TestEmptyFilterMatchesAll(c *gc.C) {
+	c.Assert(filterRecords(auditlog.Filter{}), jc.DeepEquals, queryRecords)
+}
+
+func (s *QuerySuite) TestFilterByUser(c *gc.C) {
+	c.Assert(filterRecords(auditlog.Filter{Who: "mary"}), jc.DeepEquals, []auditlog.Record{
+		queryRecords[2], queryRecords[4], queryRecords[5],
+	})
+}
+
+func (s *QuerySuite) TestFilterByModel(c *gc.C) {
+	c.Assert(filterRecords(auditlog.Filter{ModelUUID: "uuid1"}), jc.DeepEquals, []auditlog.Record{
+		queryRecords[0], queryRecords[1], queryRecords[3], queryRecords[6],
+	})
+}
+
+func (s *QuerySuite) TestFilterByConversation(c *gc.C) {
+	c.Assert(filterRecords(auditlog.Filter{ConversationID: "c2"}), jc.DeepEquals, []auditlog.Record{
+		queryRecords[2], queryRecords[4], queryRecords[5],
+	})
+}
+
+func (s *QuerySuite) TestFilterByMethod(c *gc.C) {
+	// Conversations are only included if one of their requests
+	// matches, and come just before the first one.
+	c.Assert(filterRecords(auditlog.Filter{Facade: "Client"}), jc.DeepEquals, []auditlog.Record{
+		queryRecords[0], queryRecords[1],
+	})
+	c.Assert(filterRecords(auditlog.Filter{Facade: "Application", Method: "Deploy", Who: "mary"}), jc.DeepEquals, []auditlog.Record{
+		queryRecords[2], queryRecords[4], queryRecords[5],
+	})
+}
+
+func (s *QuerySuite) TestFilterByTime(c *gc.C) {
+	filter := auditlog.Filter{
+		After:  time.Date(2018, 6, 1, 12, 1, 0, 0, time.UTC),
+		Before: time.Date(2018, 6, 1, 12, 2, 0, 0, time.UTC),
+	}
+	c.Assert(filterRecords(filter), jc.DeepEquals, []auditlog.Record{
+		queryRecords[0], queryRecords[3], queryRecords[6],
+	})
+}
+
+func (s *QuerySuite) TestFilterForgetsOldestConversations(c *gc.C) {
+	recordFilter := auditlog.NewRecordFilter(auditlog.Filter{Method: "Deploy"})
+	recordFilter.SetMaxTracked(1)
+	var result []auditlog.Record
+	for _, r := range queryRecords {
+		result = append(result, recordFilter.Match(r)...)
+	}
+	// By the time c1's Deploy request is read, c1 has been forgotten
+	// in favour of c2.
+	c.Assert(result, jc.DeepEquals, []auditlog.Record{
+		queryRecords[2], queryRecords[4], queryRecords[5],
+	})
+}
+
+func (s *QuerySuite) TestFilterForgetsAnsweredRequests(c *gc.C) {
+	recordFilter := auditlog.NewRecordFilter(auditlog.Filter{})
+	for _, r := range queryRecords {
+		recordFilter.Match(r)
+	}
+	// A repeated response isn't selected once its request has been
+	// answered.
+	c.Assert(recordFilter.Match(queryRecords[6]), gc.HasLen, 0)
+}
+
+func (s *QuerySuite) TestLogFiles(c *gc.C) {
+	dir := c.MkDir()
+	paths, err := auditlog.LogFiles(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paths, gc.HasLen, 0)
+
+	for _, name := range []string{
+		"audit.log",
+		"audit-2018-06-02T00-00-00.000.log.gz",
+		"audit-2018-06-01T00-00-00.000.log.gz",
+		"logsink.log",
+	} {
+		f, err := os.Create(filepath.Join(dir, name))
+		c.Assert(err, jc.ErrorIsNil)
+		f.Close()
+	}
+	paths, err = auditlog.LogFiles(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paths, jc.DeepEquals, []string{
+		filepath.Join(dir, "audit-2018-06-01T00-00-00.000.log.gz"),
+		filepath.Join(dir, "audit-2018-06-02T00-00-00.000.log.gz"),
+		filepath.Join(dir, "audit.log"),
+	})
+}
+
+func (s *QuerySuite) TestReadLogFile(c *gc.C) {
+	dir := c.MkDir()
+	logFile := auditlog.NewLogFile(dir, 300, 10)
+	c.Assert(logFile.AddConversation(*queryRecords[0].Conversation), jc.ErrorIsNil)
+	c.Assert(logFile.AddRequest(*queryRecords[1].Request), jc.ErrorIsNil)
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+
+	var records []auditlog.Record
+	err := auditlog.ReadLogFile(filepath.Join(dir, "audit.log"), func(r auditlog.Record) error {
+		records = append(records, r)
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, queryRecords[:2])
+}
+
+func (s *QuerySuite) TestReadCompressedLogFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "audit-2018-06-01T00-00-00.000.log.gz")
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	w := gzip.NewWriter(f)
+	_, err = w.Write([]byte(`{"conversation":{"who":"bob","conversation-id":"c1"}}` + "\n" + "garbage\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	var records []auditlog.Record
+	err = auditlog.ReadLogFile(path, func(r auditlog.Record) error {
+		records = append(records, r)
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []auditlog.Record{{
+		Conversation: &auditlog.Conversation{Who: "bob", ConversationID: "c1"},
+	}})
+}
+
+func (s *QuerySuite) TestReadLogFileStopsOnError(c *gc.C) {
+	dir := c.MkDir()
+	logFile := auditlog.NewLogFile(dir, 300, 10)
+	c.Assert(logFile.AddConversation(*queryRecords[0].Conversation), jc.ErrorIsNil)
+	c.Assert(logFile.AddRequest(*queryRecords[1].Request), jc.ErrorIsNil)
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+
+	calls := 0
+	err := auditlog.ReadLogFile(filepath.Join(dir, "audit.log"), func(r auditlog.Record) error {
+		calls++
+		return errors.New("enough")
+	})
+	c.Assert(err, gc.ErrorMatches, "enough")
+	c.Assert(calls, gc.Equals, 1)
+}
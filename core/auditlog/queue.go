@@ -0,0 +1,212 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+const (
+	// maxPendingRecords bounds the number of records a remote sink
+	// holds while its destination is unavailable. Once it's reached
+	// the oldest records are dropped.
+	maxPendingRecords = 10000
+
+	// initialRetryDelay and maxRetryDelay bound the time a remote
+	// sink waits before retrying after a failed send.
+	initialRetryDelay = time.Second
+	maxRetryDelay     = time.Minute
+)
+
+// queueConfig holds the settings for a queue.
+type queueConfig struct {
+	// Kind names the sort of sink the queue is for, as in
+	// "audit log webhook closed".
+	Kind string
+
+	// Destination describes where records are sent, for log and
+	// error messages.
+	Destination string
+
+	// BatchSize is the maximum number of records passed to Send at
+	// once. A batch is sent as soon as it's full.
+	BatchSize int
+
+	// FlushInterval is how long to wait before sending a batch that
+	// isn't full.
+	FlushInterval time.Duration
+
+	// Send sends a batch of records to the destination.
+	Send func([]Record) error
+
+	Clock clock.Clock
+}
+
+// newQueue returns a queue which hands records to config.Send in
+// batches from a background goroutine, so that sending a record
+// never blocks on the network. Failed batches are retried with
+// increasing delays until the queue is closed.
+func newQueue(config queueConfig) *queue {
+	q := &queue{
+		config: config,
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go q.loop()
+	return q
+}
+
+type queue struct {
+	config queueConfig
+
+	mu      sync.Mutex
+	pending []Record
+	dropped int
+	closed  bool
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Send queues the record to be sent.
+func (q *queue) Send(r Record) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return errors.Errorf("audit log %s closed", q.config.Kind)
+	}
+	q.pending = append(q.pending, r)
+	q.trim()
+	if len(q.pending) >= q.config.BatchSize {
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Close stops the queue after making one last attempt to send any
+// pending records. Once it returns, Send won't be called again.
+func (q *queue) Close() error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.mu.Unlock()
+
+	close(q.stop)
+	<-q.done
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if unsent := len(q.pending); unsent > 0 {
+		return errors.Errorf("%d audit records not sent to %s", unsent, q.config.Destination)
+	}
+	return nil
+}
+
+func (q *queue) loop() {
+	defer close(q.done)
+	var retryDelay time.Duration
+	for {
+		wake := q.wake
+		wait := q.config.FlushInterval
+		if retryDelay > 0 {
+			// Don't hammer a failing destination just because
+			// another batch has filled up.
+			wake = nil
+			wait = retryDelay
+		}
+		select {
+		case <-q.stop:
+			if err := q.flush(); err != nil {
+				logger.Errorf("sending audit records to %s: %v", q.config.Destination, err)
+			}
+			return
+		case <-wake:
+		case <-q.config.Clock.After(wait):
+		}
+		if err := q.flush(); err != nil {
+			logger.Warningf("sending audit records to %s: %v", q.config.Destination, err)
+			retryDelay = nextRetryDelay(retryDelay)
+			continue
+		}
+		retryDelay = 0
+	}
+}
+
+// flush sends all pending records, a batch at a time. A batch that
+// fails is put back at the front of the queue.
+func (q *queue) flush() error {
+	for {
+		batch := q.nextBatch()
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := q.config.Send(batch); err != nil {
+			q.requeue(batch)
+			return errors.Trace(err)
+		}
+		q.reportDropped()
+	}
+}
+
+func (q *queue) nextBatch() []Record {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := len(q.pending)
+	if n > q.config.BatchSize {
+		n = q.config.BatchSize
+	}
+	batch := make([]Record, n)
+	copy(batch, q.pending)
+	q.pending = q.pending[n:]
+	return batch
+}
+
+func (q *queue) requeue(batch []Record) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(batch, q.pending...)
+	q.trim()
+}
+
+// trim drops the oldest pending records if there are too many. It
+// must be called with q.mu held.
+func (q *queue) trim() {
+	if excess := len(q.pending) - maxPendingRecords; excess > 0 {
+		q.pending = q.pending[excess:]
+		q.dropped += excess
+	}
+}
+
+func (q *queue) reportDropped() {
+	q.mu.Lock()
+	dropped := q.dropped
+	q.dropped = 0
+	q.mu.Unlock()
+	if dropped > 0 {
+		logger.Warningf("dropped %d audit records while %s was unavailable", dropped, q.config.Destination)
+	}
+}
+
+func nextRetryDelay(current time.Duration) time.Duration {
+	if current == 0 {
+		return initialRetryDelay
+	}
+	next := current * 2
+	if next > maxRetryDelay {
+		next = maxRetryDelay
+	}
+	return next
+}
@@ -0,0 +1,156 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// Sink is a destination for audit log records. The local log file,
+// syslog and webhook destinations are all sinks; NewSinkLog adapts
+// one to the AuditLog interface.
+type Sink interface {
+	// Send writes the record to the sink.
+	Send(Record) error
+
+	// Close releases any resources held by the sink, flushing any
+	// buffered records first if possible.
+	Close() error
+}
+
+// NewSinkLog returns an AuditLog which writes each conversation,
+// request and response to the given sink as a Record.
+func NewSinkLog(sink Sink) AuditLog {
+	return &sinkLog{sink: sink}
+}
+
+type sinkLog struct {
+	sink Sink
+}
+
+// AddConversation implements AuditLog.
+func (l *sinkLog) AddConversation(c Conversation) error {
+	return errors.Trace(l.sink.Send(Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (l *sinkLog) AddRequest(m Request) error {
+	return errors.Trace(l.sink.Send(Record{Request: &m}))
+}
+
+// AddResponse implements AuditLog.
+func (l *sinkLog) AddResponse(m ResponseErrors) error {
+	return errors.Trace(l.sink.Send(Record{Errors: &m}))
+}
+
+// Close implements AuditLog.
+func (l *sinkLog) Close() error {
+	return errors.Trace(l.sink.Close())
+}
+
+// NewTeeLog returns an AuditLog which writes to all of the logs
+// passed in. A failure writing to one log doesn't stop the entry
+// being written to the others; the first error is returned.
+func NewTeeLog(logs ...AuditLog) AuditLog {
+	return teeLog(logs)
+}
+
+type teeLog []AuditLog
+
+// AddConversation implements AuditLog.
+func (t teeLog) AddConversation(c Conversation) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddConversation(c)
+	})
+}
+
+// AddRequest implements AuditLog.
+func (t teeLog) AddRequest(m Request) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddRequest(m)
+	})
+}
+
+// AddResponse implements AuditLog.
+func (t teeLog) AddResponse(m ResponseErrors) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddResponse(m)
+	})
+}
+
+// Close implements AuditLog.
+func (t teeLog) Close() error {
+	return t.each(func(log AuditLog) error {
+		return log.Close()
+	})
+}
+
+func (t teeLog) each(f func(AuditLog) error) error {
+	var result error
+	for _, log := range t {
+		if err := f(log); err != nil {
+			if result == nil {
+				result = errors.Trace(err)
+			} else {
+				logger.Errorf("writing audit log: %v", err)
+			}
+		}
+	}
+	return result
+}
+
+// NewSwitchLog returns a SwitchLog which writes to the given log.
+func NewSwitchLog(log AuditLog) *SwitchLog {
+	return &SwitchLog{log: log}
+}
+
+// SwitchLog is an AuditLog which writes to another log that can be
+// replaced while it's in use. Connections hold on to the audit log
+// they started with, so this lets them carry on recording when the
+// audit log sinks are reconfigured.
+type SwitchLog struct {
+	mu  sync.RWMutex
+	log AuditLog
+}
+
+// Switch makes the SwitchLog write to the given log from now on, and
+// closes the log it wrote to before.
+func (l *SwitchLog) Switch(log AuditLog) error {
+	l.mu.Lock()
+	old := l.log
+	l.log = log
+	l.mu.Unlock()
+	// No-one is writing to the old log now.
+	return errors.Trace(old.Close())
+}
+
+// AddConversation implements AuditLog.
+func (l *SwitchLog) AddConversation(c Conversation) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return errors.Trace(l.log.AddConversation(c))
+}
+
+// AddRequest implements AuditLog.
+func (l *SwitchLog) AddRequest(m Request) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return errors.Trace(l.log.AddRequest(m))
+}
+
+// AddResponse implements AuditLog.
+func (l *SwitchLog) AddResponse(m ResponseErrors) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return errors.Trace(l.log.AddResponse(m))
+}
+
+// Close implements AuditLog.
+func (l *SwitchLog) Close() error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return errors.Trace(l.log.Close())
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type SinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinkSuite{})
+
+func (s *SinkSuite) TestSinkLog(c *gc.C) {
+	var sink fakeSink
+	log := auditlog.NewSinkLog(&sink)
+
+	conversation := auditlog.Conversation{Who: "bjork", ConversationID: "abc"}
+	request := auditlog.Request{ConversationID: "abc", Facade: "Homogenic"}
+	response := auditlog.ResponseErrors{ConversationID: "abc"}
+	c.Assert(log.AddConversation(conversation), jc.ErrorIsNil)
+	c.Assert(log.AddRequest(request), jc.ErrorIsNil)
+	c.Assert(log.AddResponse(response), jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	sink.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{auditlog.Record{Conversation: &conversation}}},
+		{"Send", []interface{}{auditlog.Record{Request: &request}}},
+		{"Send", []interface{}{auditlog.Record{Errors: &response}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SinkSuite) TestTeeLogWritesToAll(c *gc.C) {
+	var log1, log2 fakeLog
+	log := auditlog.NewTeeLog(&log1, &log2)
+
+	request := auditlog.Request{ConversationID: "abc", Facade: "Vespertine"}
+	c.Assert(log.AddRequest(request), jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	log1.stub.CheckCalls(c, []testing.StubCall{
+		{"AddRequest", []interface{}{request}},
+		{"Close", nil},
+	})
+	log2.stub.CheckCalls(c, []testing.StubCall{
+		{"AddRequest", []interface{}{request}},
+		{"Close", nil},
+	})
+}
+
+func (s *SinkSuite) TestTeeLogContinuesAfterError(c *gc.C) {
+	var log1, log2 fakeLog
+	log1.stub.SetErrors(errors.New("disk full"))
+	log := auditlog.NewTeeLog(&log1, &log2)
+
+	err := log.AddConversation(auditlog.Conversation{Who: "bjork"})
+	c.Assert(err, gc.ErrorMatches, "disk full")
+	log2.stub.CheckCallNames(c, "AddConversation")
+}
+
+func (s *SinkSuite) TestSwitchLog(c *gc.C) {
+	var log1, log2 fakeLog
+	log := auditlog.NewSwitchLog(&log1)
+
+	request1 := auditlog.Request{ConversationID: "abc", Facade: "Debut"}
+	c.Assert(log.AddRequest(request1), jc.ErrorIsNil)
+	c.Assert(log.Switch(&log2), jc.ErrorIsNil)
+	request2 := auditlog.Request{ConversationID: "abc", Facade: "Post"}
+	c.Assert(log.AddRequest(request2), jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	log1.stub.CheckCalls(c, []testing.StubCall{
+		{"AddRequest", []interface{}{request1}},
+		{"Close", nil},
+	})
+	log2.stub.CheckCalls(c, []testing.StubCall{
+		{"AddRequest", []interface{}{request2}},
+		{"Close", nil},
+	})
+}
+
+type fakeSink struct {
+	stub testing.Stub
+}
+
+func (s *fakeSink) Send(r auditlog.Record) error {
+	s.stub.AddCall("Send", r)
+	return s.stub.NextErr()
+}
+
+func (s *fakeSink) Close() error {
+	s.stub.AddCall("Close")
+	return s.stub.NextErr()
+}
@@ -0,0 +1,155 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// syslogModule is the module name attached to audit records sent to
+// syslog, so they can be told apart from forwarded log messages.
+const syslogModule = "juju.audit"
+
+// SyslogSender sends log records to a syslog server. It's
+// implemented by logfwd/syslog.Client.
+type SyslogSender interface {
+	Send([]logfwd.Record) error
+	Close() error
+}
+
+// syslogFlushInterval is how often the syslog sink checks for
+// records it hasn't sent. Records are normally sent as soon as
+// they're queued.
+const syslogFlushInterval = time.Second
+
+// NewSyslogSink returns a Sink which forwards each audit record, as
+// a JSON message, to a syslog server. Records are queued and sent by
+// a background goroutine, so Send doesn't block on the network. The
+// connection is opened with the open function when the first record
+// is sent, and re-opened after a send fails; failed sends are retried
+// with increasing delays until the sink is closed.
+func NewSyslogSink(open func() (SyslogSender, error), origin logfwd.Origin, clock clock.Clock) Sink {
+	s := &syslogSink{
+		open:   open,
+		origin: origin,
+		clock:  clock,
+	}
+	s.queue = newQueue(queueConfig{
+		Kind:          "syslog",
+		Destination:   "syslog",
+		BatchSize:     1,
+		FlushInterval: syslogFlushInterval,
+		Send:          s.send,
+		Clock:         clock,
+	})
+	return s
+}
+
+type syslogSink struct {
+	*queue
+	open   func() (SyslogSender, error)
+	origin logfwd.Origin
+	clock  clock.Clock
+
+	mu     sync.Mutex
+	sender SyslogSender
+}
+
+// send sends the records to the syslog server, connecting first if
+// need be. It's only called by the queue.
+func (s *syslogSink) send(records []Record) error {
+	logRecords := make([]logfwd.Record, 0, len(records))
+	for _, r := range records {
+		rec, err := s.logRecord(r)
+		if err != nil {
+			// It can't be sent, so don't retry it.
+			logger.Errorf("not sending audit record to syslog: %v", err)
+			continue
+		}
+		logRecords = append(logRecords, rec)
+	}
+	if len(logRecords) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sender == nil {
+		sender, err := s.open()
+		if err != nil {
+			return errors.Annotate(err, "connecting to syslog")
+		}
+		s.sender = sender
+	}
+	if err := s.sender.Send(logRecords); err != nil {
+		// Drop the connection; the retry will reconnect.
+		s.closeSender()
+		return errors.Annotate(err, "sending audit records to syslog")
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *syslogSink) Close() error {
+	err := s.queue.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if closeErr := s.closeSender(); err == nil {
+		err = closeErr
+	}
+	return errors.Trace(err)
+}
+
+func (s *syslogSink) closeSender() error {
+	if s.sender == nil {
+		return nil
+	}
+	err := s.sender.Close()
+	s.sender = nil
+	return err
+}
+
+func (s *syslogSink) logRecord(r Record) (logfwd.Record, error) {
+	message, err := json.Marshal(r)
+	if err != nil {
+		return logfwd.Record{}, errors.Trace(err)
+	}
+	level := loggo.INFO
+	if r.Errors != nil {
+		level = loggo.WARNING
+	}
+	return logfwd.Record{
+		Origin:    s.origin,
+		Timestamp: s.timestamp(r),
+		Level:     level,
+		Location:  logfwd.SourceLocation{Module: syslogModule},
+		Message:   string(message),
+	}, nil
+}
+
+// timestamp returns the time the record was made, falling back to
+// the current time if it can't be determined.
+func (s *syslogSink) timestamp(r Record) time.Time {
+	var when string
+	switch {
+	case r.Conversation != nil:
+		when = r.Conversation.When
+	case r.Request != nil:
+		when = r.Request.When
+	case r.Errors != nil:
+		when = r.Errors.When
+	}
+	if t, err := time.Parse(time.RFC3339, when); err == nil {
+		return t
+	}
+	return s.clock.Now()
+}
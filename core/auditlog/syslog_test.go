@@ -0,0 +1,190 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SyslogSinkSuite struct {
+	testing.IsolationSuite
+
+	stub    testing.Stub
+	events  chan string
+	senders []*fakeSyslogSender
+	origin  logfwd.Origin
+	clock   *testing.Clock
+}
+
+var _ = gc.Suite(&SyslogSinkSuite{})
+
+func (s *SyslogSinkSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub.ResetCalls()
+	s.events = make(chan string, 10)
+	s.senders = nil
+	s.origin = logfwd.OriginForMachineAgent(
+		names.NewMachineTag("0"),
+		coretesting.ControllerTag.Id(),
+		coretesting.ModelTag.Id(),
+		version.MustParse("2.4.0"),
+	)
+	s.clock = testing.NewClock(time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (s *SyslogSinkSuite) open() (auditlog.SyslogSender, error) {
+	s.stub.AddCall("open")
+	s.events <- "open"
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	sender := &fakeSyslogSender{events: s.events}
+	s.senders = append(s.senders, sender)
+	return sender, nil
+}
+
+// expectEvents waits for the sink to open connections and send
+// records in the expected order.
+func (s *SyslogSinkSuite) expectEvents(c *gc.C, expected ...string) {
+	for _, expect := range expected {
+		select {
+		case event := <-s.events:
+			c.Assert(event, gc.Equals, expect)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %s", expect)
+		}
+	}
+}
+
+func (s *SyslogSinkSuite) assertNoEvent(c *gc.C) {
+	select {
+	case event := <-s.events:
+		c.Fatalf("unexpected %s", event)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *SyslogSinkSuite) TestSend(c *gc.C) {
+	sink := auditlog.NewSyslogSink(s.open, s.origin, s.clock)
+	err := sink.Send(auditlog.Record{Request: &auditlog.Request{
+		ConversationID: "abc",
+		When:           "2018-05-03T12:34:56Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        6,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectEvents(c, "open", "Send")
+	err = sink.Send(auditlog.Record{Errors: &auditlog.ResponseErrors{
+		ConversationID: "abc",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectEvents(c, "Send")
+
+	s.stub.CheckCallNames(c, "open")
+	c.Assert(s.senders, gc.HasLen, 1)
+	sender := s.senders[0]
+	sender.stub.CheckCallNames(c, "Send", "Send")
+
+	records := sender.stub.Calls()[0].Args[0].([]logfwd.Record)
+	c.Assert(records, jc.DeepEquals, []logfwd.Record{{
+		Origin:    s.origin,
+		Timestamp: time.Date(2018, 5, 3, 12, 34, 56, 0, time.UTC),
+		Level:     loggo.INFO,
+		Location:  logfwd.SourceLocation{Module: "juju.audit"},
+		Message:   `{"request":{"conversation-id":"abc","connection-id":"","request-id":0,"when":"2018-05-03T12:34:56Z","facade":"Application","method":"Deploy","version":6}}`,
+	}})
+
+	// Errors are sent as warnings, and records without a valid time
+	// are stamped with the current time.
+	records = sender.stub.Calls()[1].Args[0].([]logfwd.Record)
+	c.Assert(records, gc.HasLen, 1)
+	c.Assert(records[0].Level, gc.Equals, loggo.WARNING)
+	c.Assert(records[0].Timestamp, gc.Equals, s.clock.Now())
+
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+	sender.stub.CheckCallNames(c, "Send", "Send", "Close")
+}
+
+func (s *SyslogSinkSuite) TestSendDoesNotWaitForServer(c *gc.C) {
+	s.stub.SetErrors(errors.New("connection refused"))
+	sink := auditlog.NewSyslogSink(s.open, s.origin, s.clock)
+	defer sink.Close()
+
+	// The server being unreachable isn't reported to the caller.
+	err := sink.Send(auditlog.Record{Conversation: &auditlog.Conversation{}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectEvents(c, "open")
+	err = sink.Send(auditlog.Record{Conversation: &auditlog.Conversation{}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoEvent(c)
+
+	// Both records are sent once the retry succeeds. The flush
+	// interval timer is still outstanding alongside the retry timer.
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectEvents(c, "open", "Send", "Send")
+	s.stub.CheckCallNames(c, "open", "open")
+}
+
+func (s *SyslogSinkSuite) TestReconnectsAfterSendError(c *gc.C) {
+	sink := auditlog.NewSyslogSink(s.open, s.origin, s.clock)
+	defer sink.Close()
+	c.Assert(sink.Send(auditlog.Record{Conversation: &auditlog.Conversation{}}), jc.ErrorIsNil)
+	s.expectEvents(c, "open", "Send")
+
+	s.senders[0].stub.SetErrors(errors.New("broken pipe"))
+	c.Assert(sink.Send(auditlog.Record{Conversation: &auditlog.Conversation{}}), jc.ErrorIsNil)
+	s.expectEvents(c, "Send", "Close")
+
+	// The flush interval timers from both sends are still
+	// outstanding alongside the retry timer.
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 3)
+	c.Assert(err, jc.ErrorIsNil)
+	s.expectEvents(c, "open", "Send")
+	s.stub.CheckCallNames(c, "open", "open")
+	c.Assert(s.senders, gc.HasLen, 2)
+	s.senders[1].stub.CheckCallNames(c, "Send")
+}
+
+func (s *SyslogSinkSuite) TestCloseReportsUnsent(c *gc.C) {
+	s.stub.SetErrors(errors.New("connection refused"), errors.New("connection refused"))
+	sink := auditlog.NewSyslogSink(s.open, s.origin, s.clock)
+	c.Assert(sink.Send(auditlog.Record{Conversation: &auditlog.Conversation{}}), jc.ErrorIsNil)
+	s.expectEvents(c, "open")
+
+	err := sink.Close()
+	c.Assert(err, gc.ErrorMatches, "1 audit records not sent to syslog")
+	err = sink.Send(auditlog.Record{Conversation: &auditlog.Conversation{}})
+	c.Assert(err, gc.ErrorMatches, "audit log syslog closed")
+}
+
+type fakeSyslogSender struct {
+	stub   testing.Stub
+	events chan<- string
+}
+
+func (s *fakeSyslogSender) Send(records []logfwd.Record) error {
+	s.stub.AddCall("Send", records)
+	s.events <- "Send"
+	return s.stub.NextErr()
+}
+
+func (s *fakeSyslogSender) Close() error {
+	s.stub.AddCall("Close")
+	s.events <- "Close"
+	return s.stub.NextErr()
+}
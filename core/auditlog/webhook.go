@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+// WebhookConfig holds the settings for a webhook sink.
+type WebhookConfig struct {
+	// URL is the http(s) endpoint batches of records are POSTed to,
+	// as a JSON list.
+	URL string
+
+	// BatchSize is the maximum number of records sent in one
+	// request. A batch is sent as soon as it's full.
+	BatchSize int
+
+	// FlushInterval is how long to wait before sending a batch that
+	// isn't full.
+	FlushInterval time.Duration
+}
+
+// Validate checks the webhook configuration.
+func (cfg WebhookConfig) Validate() error {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.Annotate(err, "invalid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	if cfg.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if cfg.FlushInterval <= 0 {
+		return errors.NotValidf("non-positive FlushInterval")
+	}
+	return nil
+}
+
+// HTTPClient sends HTTP requests. It's implemented by *http.Client.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// NewWebhookSink returns a Sink which POSTs batches of records to a
+// remote HTTP endpoint. Records are queued and sent by a background
+// goroutine, so Send doesn't block on the network; failed requests
+// are retried with increasing delays until the sink is closed.
+func NewWebhookSink(config WebhookConfig, client HTTPClient, clock clock.Clock) (Sink, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &webhookSink{
+		config: config,
+		client: client,
+	}
+	s.queue = newQueue(queueConfig{
+		Kind:          "webhook",
+		Destination:   config.URL,
+		BatchSize:     config.BatchSize,
+		FlushInterval: config.FlushInterval,
+		Send:          s.post,
+		Clock:         clock,
+	})
+	return s, nil
+}
+
+// webhookSink implements Sink; Send and Close are provided by the
+// queue.
+type webhookSink struct {
+	*queue
+	config WebhookConfig
+	client HTTPClient
+}
+
+func (s *webhookSink) post(records []Record) error {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequest("POST", s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected response %q", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WebhookSinkSuite struct {
+	testing.IsolationSuite
+
+	server   *httptest.Server
+	batches  chan []auditlog.Record
+	statuses chan int
+	clock    *testing.Clock
+	config   auditlog.WebhookConfig
+}
+
+var _ = gc.Suite(&WebhookSinkSuite{})
+
+func (s *WebhookSinkSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.batches = make(chan []auditlog.Record, 10)
+	s.statuses = make(chan int, 10)
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.clock = testing.NewClock(time.Time{})
+	s.config = auditlog.WebhookConfig{
+		URL:           s.server.URL,
+		BatchSize:     2,
+		FlushInterval: 5 * time.Second,
+	}
+}
+
+func (s *WebhookSinkSuite) handle(w http.ResponseWriter, req *http.Request) {
+	status := http.StatusOK
+	select {
+	case status = <-s.statuses:
+	default:
+	}
+	var records []auditlog.Record
+	if err := json.NewDecoder(req.Body).Decode(&records); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.batches <- records
+	w.WriteHeader(status)
+}
+
+func (s *WebhookSinkSuite) newSink(c *gc.C) auditlog.Sink {
+	sink, err := auditlog.NewWebhookSink(s.config, http.DefaultClient, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return sink
+}
+
+func (s *WebhookSinkSuite) nextBatch(c *gc.C) []auditlog.Record {
+	select {
+	case batch := <-s.batches:
+		return batch
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for batch")
+	}
+	return nil
+}
+
+func (s *WebhookSinkSuite) assertNoBatch(c *gc.C) {
+	select {
+	case batch := <-s.batches:
+		c.Fatalf("unexpected batch %v", batch)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func request(id uint64) auditlog.Record {
+	return auditlog.Record{Request: &auditlog.Request{
+		ConversationID: "abc",
+		RequestID:      id,
+		Facade:         "Application",
+		Method:         "Deploy",
+	}}
+}
+
+func (s *WebhookSinkSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		config auditlog.WebhookConfig
+		expect string
+	}{{
+		auditlog.WebhookConfig{URL: "ftp://example.com", BatchSize: 1, FlushInterval: time.Second},
+		`URL "ftp://example.com" not valid`,
+	}, {
+		auditlog.WebhookConfig{URL: "https://example.com", FlushInterval: time.Second},
+		"non-positive BatchSize not valid",
+	}, {
+		auditlog.WebhookConfig{URL: "https://example.com", BatchSize: 1},
+		"non-positive FlushInterval not valid",
+	}} {
+		c.Logf("test %d: %s", i, test.expect)
+		_, err := auditlog.NewWebhookSink(test.config, http.DefaultClient, s.clock)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *WebhookSinkSuite) TestSendsFullBatch(c *gc.C) {
+	sink := s.newSink(c)
+	defer sink.Close()
+
+	c.Assert(sink.Send(request(1)), jc.ErrorIsNil)
+	s.assertNoBatch(c)
+	c.Assert(sink.Send(request(2)), jc.ErrorIsNil)
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []auditlog.Record{request(1), request(2)})
+}
+
+func (s *WebhookSinkSuite) TestSendsPartialBatchAfterFlushInterval(c *gc.C) {
+	sink := s.newSink(c)
+	defer sink.Close()
+
+	c.Assert(sink.Send(request(1)), jc.ErrorIsNil)
+	err := s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []auditlog.Record{request(1)})
+}
+
+func (s *WebhookSinkSuite) TestRetriesFailedBatch(c *gc.C) {
+	sink := s.newSink(c)
+	defer sink.Close()
+
+	s.statuses <- http.StatusServiceUnavailable
+	c.Assert(sink.Send(request(1)), jc.ErrorIsNil)
+	c.Assert(sink.Send(request(2)), jc.ErrorIsNil)
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []auditlog.Record{request(1), request(2)})
+
+	// The flush interval timer is still outstanding alongside the
+	// retry timer.
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []auditlog.Record{request(1), request(2)})
+}
+
+func (s *WebhookSinkSuite) TestCloseFlushes(c *gc.C) {
+	sink := s.newSink(c)
+	c.Assert(sink.Send(request(1)), jc.ErrorIsNil)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []auditlog.Record{request(1)})
+
+	err := sink.Send(request(2))
+	c.Assert(err, gc.ErrorMatches, "audit log webhook closed")
+}
+
+func (s *WebhookSinkSuite) TestCloseReportsUnsent(c *gc.C) {
+	sink := s.newSink(c)
+	s.statuses <- http.StatusInternalServerError
+	c.Assert(sink.Send(request(1)), jc.ErrorIsNil)
+	err := sink.Close()
+	c.Assert(err, gc.ErrorMatches, `1 audit records not sent to .*`)
+}
@@ -0,0 +1,212 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-style schedule expressions and calculates
+// when they next fire.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expr string
+
+	minutes     bits
+	hours       bits
+	daysOfMonth bits
+	months      bits
+	daysOfWeek  bits
+
+	// anyDayOfMonth and anyDayOfWeek record whether the day fields
+	// were unrestricted, which determines how they are combined.
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// descriptors maps the supported shorthand expressions to their
+// five field equivalents.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// Parse parses a standard five field cron expression:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field may be "*", a value, a range "a-b" or a comma separated
+// list of these, and values and ranges may have a "/step" suffix.
+// Months and days of the week may also be given by their three letter
+// English names. The shorthands @yearly, @annually, @monthly, @weekly,
+// @daily, @midnight and @hourly are also accepted.
+func Parse(expr string) (*Schedule, error) {
+	fieldsExpr := strings.TrimSpace(expr)
+	if desc, ok := descriptors[strings.ToLower(fieldsExpr)]; ok {
+		fieldsExpr = desc
+	}
+	fields := strings.Fields(fieldsExpr)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("cron expression %q with %d fields, expected 5", expr, len(fields))
+	}
+	s := &Schedule{expr: expr}
+	var err error
+	if s.minutes, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, errors.Annotate(err, "minute")
+	}
+	if s.hours, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, errors.Annotate(err, "hour")
+	}
+	if s.daysOfMonth, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, errors.Annotate(err, "day of month")
+	}
+	if s.months, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, errors.Annotate(err, "month")
+	}
+	// Sunday may be given as either 0 or 7.
+	if s.daysOfWeek, err = parseField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, errors.Annotate(err, "day of week")
+	}
+	if s.daysOfWeek.has(7) {
+		s.daysOfWeek |= 1
+	}
+	s.anyDayOfMonth = strings.HasPrefix(fields[2], "*")
+	s.anyDayOfWeek = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// maxSearch bounds the search for the next matching time, so that
+// expressions that can never match (eg "0 0 30 2 *") terminate.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first time after t that matches the schedule, at a
+// resolution of one minute. If the schedule never matches, the zero
+// time is returned.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.Add(maxSearch)
+	for t.Before(limit) {
+		switch {
+		case !s.months.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !s.hours.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !s.minutes.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of t matches the schedule. As with
+// cron, if both day fields are restricted a day matching either is
+// accepted.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.daysOfMonth.has(t.Day())
+	dow := s.daysOfWeek.has(int(t.Weekday()))
+	switch {
+	case s.anyDayOfMonth && s.anyDayOfWeek:
+		return true
+	case s.anyDayOfMonth:
+		return dow
+	case s.anyDayOfWeek:
+		return dom
+	}
+	return dom || dow
+}
+
+// bits is a set of field values.
+type bits uint64
+
+func (b bits) has(v int) bool {
+	return b&(1<<uint(v)) != 0
+}
+
+func parseField(field string, min, max int, names map[string]int) (bits, error) {
+	var result bits
+	for _, item := range strings.Split(field, ",") {
+		rangeExpr, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			rangeExpr = item[:i]
+			var err error
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.NotValidf("step in %q", item)
+			}
+		}
+		var lo, hi int
+		switch {
+		case rangeExpr == "*":
+			lo, hi = min, max
+		case strings.Contains(rangeExpr, "-"):
+			parts := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if lo, err = parseValue(parts[0], min, max, names); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi, err = parseValue(parts[1], min, max, names); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if lo > hi {
+				return 0, errors.NotValidf("range %q", rangeExpr)
+			}
+		default:
+			var err error
+			if lo, err = parseValue(rangeExpr, min, max, names); err != nil {
+				return 0, errors.Trace(err)
+			}
+			hi = lo
+			if step > 1 {
+				// "a/n" means every n starting at a.
+				hi = max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			result |= 1 << uint(v)
+		}
+	}
+	return result, nil
+}
+
+func parseValue(value string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.NotValidf("value %q", value)
+	}
+	if v < min || v > max {
+		return 0, errors.NotValidf("value %d outside %d-%d", v, min, max)
+	}
+	return v, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type CronSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CronSuite{})
+
+// Friday 15th June 2018.
+var base = time.Date(2018, 6, 15, 10, 30, 20, 0, time.UTC)
+
+func (*CronSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		expr     string
+		expected time.Time
+	}{{
+		expr:     "* * * * *",
+		expected: time.Date(2018, 6, 15, 10, 31, 0, 0, time.UTC),
+	}, {
+		expr:     "*/15 * * * *",
+		expected: time.Date(2018, 6, 15, 10, 45, 0, 0, time.UTC),
+	}, {
+		expr:     "5/20 * * * *",
+		expected: time.Date(2018, 6, 15, 10, 45, 0, 0, time.UTC),
+	}, {
+		expr:     "0 2 * * *",
+		expected: time.Date(2018, 6, 16, 2, 0, 0, 0, time.UTC),
+	}, {
+		expr:     "30 10 * * 5",
+		expected: time.Date(2018, 6, 22, 10, 30, 0, 0, time.UTC),
+	}, {
+		expr:     "0 0 1,15 * *",
+		expected: time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		expr:     "0 0 * * mon-wed",
+		expected: time.Date(2018, 6, 18, 0, 0, 0, 0, time.UTC),
+	}, {
+		expr:     "0 0 * * 7",
+		expected: time.Date(2018, 6, 17, 0, 0, 0, 0, time.UTC),
+	}, {
+		// Either day field may match when both are restricted.
+		expr:     "0 0 13 * fri",
+		expected: time.Date(2018, 6, 22, 0, 0, 0, 0, time.UTC),
+	}, {
+		expr:     "0 12 1 jan *",
+		expected: time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, {
+		expr:     "@weekly",
+		expected: time.Date(2018, 6, 17, 0, 0, 0, 0, time.UTC),
+	}, {
+		expr:     "@hourly",
+		expected: time.Date(2018, 6, 15, 11, 0, 0, 0, time.UTC),
+	}, {
+		expr: "0 0 30 2 *",
+	}} {
+		c.Logf("test %d: %s", i, test.expr)
+		s, err := cron.Parse(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.String(), gc.Equals, test.expr)
+		c.Check(s.Next(base), gc.Equals, test.expected)
+	}
+}
+
+func (*CronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		expr string
+		err  string
+	}{{
+		expr: "* * *",
+		err:  `cron expression "\* \* \*" with 3 fields, expected 5 not valid`,
+	}, {
+		expr: "60 * * * *",
+		err:  `minute: value 60 outside 0-59 not valid`,
+	}, {
+		expr: "5-1 * * * *",
+		err:  `minute: range "5-1" not valid`,
+	}, {
+		expr: "*/0 * * * *",
+		err:  `minute: step in "\*/0" not valid`,
+	}, {
+		expr: "0 0 * foo *",
+		err:  `month: value "foo" not valid`,
+	}, {
+		expr: "0 0 0 * *",
+		err:  `day of month: value 0 outside 1-31 not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.expr)
+		_, err := cron.Parse(test.expr)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/lease"
+)
+
+const (
+	// CommandVersion is the current version of the command format.
+	// It must be incremented whenever the format changes in a way
+	// that older FSMs cannot understand.
+	CommandVersion = 1
+
+	// OperationClaim denotes claiming a new lease.
+	OperationClaim = "claim"
+
+	// OperationExtend denotes extending an already-held lease.
+	OperationExtend = "extend"
+
+	// OperationExpire denotes expiring a lease.
+	OperationExpire = "expire"
+
+	// OperationPin denotes pinning a lease, preventing it from
+	// being expired.
+	OperationPin = "pin"
+
+	// OperationUnpin denotes removing a pin from a lease.
+	OperationUnpin = "unpin"
+)
+
+// Command captures the details of an operation to be run on the FSM.
+type Command struct {
+	// Version of the command format, in case it changes and we need
+	// to handle multiple formats.
+	Version int `yaml:"version"`
+
+	// Operation is one of claim, extend, expire, pin or unpin.
+	Operation string `yaml:"operation"`
+
+	// Namespace is the kind of lease.
+	Namespace string `yaml:"namespace"`
+
+	// ModelUUID identifies the model the lease belongs to.
+	ModelUUID string `yaml:"model-uuid"`
+
+	// Lease is the name of the lease the command affects.
+	Lease string `yaml:"lease"`
+
+	// Holder is the one claiming or extending the lease. For pin
+	// and unpin operations it identifies the entity holding the pin.
+	Holder string `yaml:"holder,omitempty"`
+
+	// Duration is how long the lease should last.
+	Duration time.Duration `yaml:"duration,omitempty"`
+
+	// Time is the time at which the command was submitted to raft,
+	// according to the raft leader's clock. All lease expiry
+	// calculations are made relative to this time, so that every
+	// FSM in the cluster reaches the same state.
+	Time time.Time `yaml:"time"`
+}
+
+// Validate checks that the command describes a valid state change.
+func (c *Command) Validate() error {
+	if c.Version != CommandVersion {
+		return errors.NotValidf("version %d", c.Version)
+	}
+	if err := lease.ValidateString(c.Namespace); err != nil {
+		return errors.Annotate(err, "invalid namespace")
+	}
+	if err := lease.ValidateString(c.ModelUUID); err != nil {
+		return errors.Annotate(err, "invalid model UUID")
+	}
+	if err := lease.ValidateString(c.Lease); err != nil {
+		return errors.Annotate(err, "invalid lease")
+	}
+	switch c.Operation {
+	case OperationClaim, OperationExtend:
+		if err := lease.ValidateString(c.Holder); err != nil {
+			return errors.Annotate(err, "invalid holder")
+		}
+		if c.Duration <= 0 {
+			return errors.NotValidf("duration %v", c.Duration)
+		}
+	case OperationPin, OperationUnpin:
+		if err := lease.ValidateString(c.Holder); err != nil {
+			return errors.Annotate(err, "invalid holder")
+		}
+	case OperationExpire:
+	default:
+		return errors.NotValidf("operation %q", c.Operation)
+	}
+	return nil
+}
+
+// Marshal converts this command to a byte slice.
+func (c *Command) Marshal() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// UnmarshalCommand converts a marshalled command []byte into a
+// command.
+func UnmarshalCommand(data []byte) (*Command, error) {
+	var result Command
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,375 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// SnapshotVersion is the current version of the snapshot format.
+const SnapshotVersion = 1
+
+// Key identifies a lease across all namespaces and models.
+type Key struct {
+	Namespace string
+	ModelUUID string
+	Lease     string
+}
+
+// entry holds the details of a lease.
+type entry struct {
+	holder   string
+	start    time.Time
+	duration time.Duration
+}
+
+func (e *entry) expiry() time.Time {
+	return e.start.Add(e.duration)
+}
+
+// FSMResponse is the value returned from applying a command to the
+// FSM. Callers applying commands through raft should check the
+// response for an error.
+type FSMResponse interface {
+	// Error returns the error that occurred applying the command,
+	// or nil if the command was applied successfully.
+	Error() error
+}
+
+type response struct {
+	err error
+}
+
+// Error is part of the FSMResponse interface.
+func (r *response) Error() error {
+	return r.err
+}
+
+func invalidResponse() *response {
+	return &response{err: lease.ErrInvalid}
+}
+
+// NewFSM returns a new, empty FSM.
+func NewFSM() *FSM {
+	return &FSM{
+		entries: make(map[Key]*entry),
+		pinned:  make(map[Key]set.Strings),
+	}
+}
+
+// FSM is an implementation of raft.FSM which records lease claims,
+// extensions, expiries and pins. It is safe for concurrent use.
+type FSM struct {
+	mu      sync.Mutex
+	entries map[Key]*entry
+	pinned  map[Key]set.Strings
+}
+
+// Apply is part of the raft.FSM interface.
+func (f *FSM) Apply(log *raft.Log) interface{} {
+	command, err := UnmarshalCommand(log.Data)
+	if err != nil {
+		return &response{err: errors.Trace(err)}
+	}
+	if err := command.Validate(); err != nil {
+		return &response{err: errors.Trace(err)}
+	}
+	key := Key{
+		Namespace: command.Namespace,
+		ModelUUID: command.ModelUUID,
+		Lease:     command.Lease,
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch command.Operation {
+	case OperationClaim:
+		return f.claim(key, command.Holder, command.Time, command.Duration)
+	case OperationExtend:
+		return f.extend(key, command.Holder, command.Time, command.Duration)
+	case OperationExpire:
+		return f.expire(key, command.Time)
+	case OperationPin:
+		return f.pin(key, command.Holder)
+	case OperationUnpin:
+		return f.unpin(key, command.Holder)
+	}
+	// Validate ensures we never get here.
+	return &response{err: errors.NotValidf("operation %q", command.Operation)}
+}
+
+func (f *FSM) claim(key Key, holder string, now time.Time, duration time.Duration) *response {
+	if existing, found := f.entries[key]; found && now.Before(existing.expiry()) {
+		return invalidResponse()
+	}
+	f.entries[key] = &entry{
+		holder:   holder,
+		start:    now,
+		duration: duration,
+	}
+	return &response{}
+}
+
+func (f *FSM) extend(key Key, holder string, now time.Time, duration time.Duration) *response {
+	existing, found := f.entries[key]
+	if !found || existing.holder != holder {
+		return invalidResponse()
+	}
+	// Extending never shortens a lease.
+	if expiry := now.Add(duration); expiry.After(existing.expiry()) {
+		existing.start = now
+		existing.duration = duration
+	}
+	return &response{}
+}
+
+func (f *FSM) expire(key Key, now time.Time) *response {
+	existing, found := f.entries[key]
+	if !found {
+		return invalidResponse()
+	}
+	if !f.pinned[key].IsEmpty() {
+		return invalidResponse()
+	}
+	if now.Before(existing.expiry()) {
+		return invalidResponse()
+	}
+	delete(f.entries, key)
+	return &response{}
+}
+
+func (f *FSM) pin(key Key, entity string) *response {
+	if f.pinned[key] == nil {
+		f.pinned[key] = set.NewStrings()
+	}
+	f.pinned[key].Add(entity)
+	return &response{}
+}
+
+func (f *FSM) unpin(key Key, entity string) *response {
+	if pins := f.pinned[key]; pins != nil {
+		pins.Remove(entity)
+		if pins.IsEmpty() {
+			delete(f.pinned, key)
+		}
+	}
+	return &response{}
+}
+
+// Leases returns the leases held in the given namespace and model,
+// keyed by lease name.
+func (f *FSM) Leases(namespace, modelUUID string) map[string]lease.Info {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make(map[string]lease.Info)
+	for key, entry := range f.entries {
+		if key.Namespace != namespace || key.ModelUUID != modelUUID {
+			continue
+		}
+		results[key.Lease] = lease.Info{
+			Holder: entry.holder,
+			Expiry: entry.expiry(),
+		}
+	}
+	return results
+}
+
+// Pinned returns the entities pinning each lease in the given
+// namespace and model, keyed by lease name.
+func (f *FSM) Pinned(namespace, modelUUID string) map[string][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make(map[string][]string)
+	for key, entities := range f.pinned {
+		if key.Namespace != namespace || key.ModelUUID != modelUUID {
+			continue
+		}
+		results[key.Lease] = entities.SortedValues()
+	}
+	return results
+}
+
+// Snapshot is part of the raft.FSM interface.
+func (f *FSM) Snapshot() (raft.FSMSnapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+	}
+	for key, entry := range f.entries {
+		snapshot.Entries = append(snapshot.Entries, SnapshotEntry{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+			Holder:    entry.holder,
+			Start:     entry.start,
+			Duration:  entry.duration,
+		})
+	}
+	for key, entities := range f.pinned {
+		snapshot.Pins = append(snapshot.Pins, SnapshotPin{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+			Entities:  entities.SortedValues(),
+		})
+	}
+	sort.Sort(snapshotEntries(snapshot.Entries))
+	sort.Sort(snapshotPins(snapshot.Pins))
+	return snapshot, nil
+}
+
+// Restore is part of the raft.FSM interface.
+func (f *FSM) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var snapshot Snapshot
+	if err := yaml.Unmarshal(data, &snapshot); err != nil || snapshot.Version == 0 {
+		if logs, legacyErr := decodeLegacySnapshot(data); legacyErr == nil {
+			return f.restoreLegacy(logs)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if snapshot.Version != SnapshotVersion {
+		return errors.NotValidf("snapshot version %d", snapshot.Version)
+	}
+	entries := make(map[Key]*entry, len(snapshot.Entries))
+	for _, e := range snapshot.Entries {
+		key := Key{Namespace: e.Namespace, ModelUUID: e.ModelUUID, Lease: e.Lease}
+		entries[key] = &entry{
+			holder:   e.Holder,
+			start:    e.Start,
+			duration: e.Duration,
+		}
+	}
+	pinned := make(map[Key]set.Strings, len(snapshot.Pins))
+	for _, p := range snapshot.Pins {
+		key := Key{Namespace: p.Namespace, ModelUUID: p.ModelUUID, Lease: p.Lease}
+		pinned[key] = set.NewStrings(p.Entities...)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries = entries
+	f.pinned = pinned
+	return nil
+}
+
+// decodeLegacySnapshot decodes a snapshot written by the
+// worker/raft.SimpleFSM, which controllers used before leases were
+// kept in raft. It holds the gob-encoded data of every log applied.
+func decodeLegacySnapshot(data []byte) ([][]byte, error) {
+	var logs [][]byte
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&logs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return logs, nil
+}
+
+// restoreLegacy replaces the FSM's state with that made by applying
+// the logs from a legacy snapshot. Logs that aren't lease commands
+// are skipped, just as they are when applied after the snapshot.
+func (f *FSM) restoreLegacy(logs [][]byte) error {
+	f.mu.Lock()
+	f.entries = make(map[Key]*entry)
+	f.pinned = make(map[Key]set.Strings)
+	f.mu.Unlock()
+	for _, data := range logs {
+		f.Apply(&raft.Log{Data: data})
+	}
+	return nil
+}
+
+// Snapshot is an implementation of raft.FSMSnapshot, returned by
+// FSM.Snapshot in this package.
+type Snapshot struct {
+	Version int             `yaml:"version"`
+	Entries []SnapshotEntry `yaml:"entries"`
+	Pins    []SnapshotPin   `yaml:"pins,omitempty"`
+}
+
+// SnapshotEntry holds the details of a lease in a snapshot.
+type SnapshotEntry struct {
+	Namespace string        `yaml:"namespace"`
+	ModelUUID string        `yaml:"model-uuid"`
+	Lease     string        `yaml:"lease"`
+	Holder    string        `yaml:"holder"`
+	Start     time.Time     `yaml:"start"`
+	Duration  time.Duration `yaml:"duration"`
+}
+
+// SnapshotPin holds the entities pinning a lease in a snapshot.
+type SnapshotPin struct {
+	Namespace string   `yaml:"namespace"`
+	ModelUUID string   `yaml:"model-uuid"`
+	Lease     string   `yaml:"lease"`
+	Entities  []string `yaml:"entities"`
+}
+
+// Persist is part of the raft.FSMSnapshot interface.
+func (s *Snapshot) Persist(sink raft.SnapshotSink) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		sink.Cancel()
+		return errors.Trace(err)
+	}
+	if _, err := sink.Write(data); err != nil {
+		sink.Cancel()
+		return errors.Trace(err)
+	}
+	return sink.Close()
+}
+
+// Release is part of the raft.FSMSnapshot interface.
+func (*Snapshot) Release() {}
+
+type snapshotEntries []SnapshotEntry
+
+func (s snapshotEntries) Len() int      { return len(s) }
+func (s snapshotEntries) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s snapshotEntries) Less(i, j int) bool {
+	return keyLess(
+		Key{s[i].Namespace, s[i].ModelUUID, s[i].Lease},
+		Key{s[j].Namespace, s[j].ModelUUID, s[j].Lease},
+	)
+}
+
+type snapshotPins []SnapshotPin
+
+func (s snapshotPins) Len() int      { return len(s) }
+func (s snapshotPins) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s snapshotPins) Less(i, j int) bool {
+	return keyLess(
+		Key{s[i].Namespace, s[i].ModelUUID, s[i].Lease},
+		Key{s[j].Namespace, s[j].ModelUUID, s[j].Lease},
+	)
+}
+
+func keyLess(a, b Key) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	if a.ModelUUID != b.ModelUUID {
+		return a.ModelUUID < b.ModelUUID
+	}
+	return a.Lease < b.Lease
+}
@@ -0,0 +1,282 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io/ioutil"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+type fsmSuite struct {
+	testing.IsolationSuite
+
+	fsm  *raftlease.FSM
+	now  time.Time
+	uuid string
+}
+
+var _ = gc.Suite(&fsmSuite{})
+
+func (s *fsmSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.fsm = raftlease.NewFSM()
+	s.now = time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)
+	s.uuid = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+}
+
+func (s *fsmSuite) apply(c *gc.C, command raftlease.Command) error {
+	command.Version = raftlease.CommandVersion
+	command.Namespace = "leadership"
+	command.ModelUUID = s.uuid
+	if command.Time.IsZero() {
+		command.Time = s.now
+	}
+	data, err := command.Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	result := s.fsm.Apply(&raft.Log{Data: data})
+	response, ok := result.(raftlease.FSMResponse)
+	c.Assert(ok, jc.IsTrue)
+	return response.Error()
+}
+
+func (s *fsmSuite) claim(c *gc.C, name, holder string, duration time.Duration) error {
+	return s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationClaim,
+		Lease:     name,
+		Holder:    holder,
+		Duration:  duration,
+	})
+}
+
+func (s *fsmSuite) TestClaim(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases("leadership", s.uuid), jc.DeepEquals, map[string]lease.Info{
+		"mysql": {Holder: "mysql/0", Expiry: s.now.Add(time.Minute)},
+	})
+	c.Assert(s.fsm.Leases("singular-controller", s.uuid), gc.HasLen, 0)
+}
+
+func (s *fsmSuite) TestClaimHeld(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.claim(c, "mysql", "mysql/1", time.Minute)
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
+
+func (s *fsmSuite) TestClaimAfterExpiry(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationClaim,
+		Lease:     "mysql",
+		Holder:    "mysql/1",
+		Duration:  time.Minute,
+		Time:      s.now.Add(2 * time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases("leadership", s.uuid)["mysql"].Holder, gc.Equals, "mysql/1")
+}
+
+func (s *fsmSuite) TestExtend(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationExtend,
+		Lease:     "mysql",
+		Holder:    "mysql/0",
+		Duration:  time.Minute,
+		Time:      s.now.Add(30 * time.Second),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases("leadership", s.uuid)["mysql"].Expiry, gc.Equals, s.now.Add(90*time.Second))
+}
+
+func (s *fsmSuite) TestExtendNeverShortens(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationExtend,
+		Lease:     "mysql",
+		Holder:    "mysql/0",
+		Duration:  time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases("leadership", s.uuid)["mysql"].Expiry, gc.Equals, s.now.Add(time.Hour))
+}
+
+func (s *fsmSuite) TestExtendWrongHolder(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationExtend,
+		Lease:     "mysql",
+		Holder:    "mysql/1",
+		Duration:  time.Minute,
+	})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
+
+func (s *fsmSuite) TestExpire(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Too early.
+	err = s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationExpire,
+		Lease:     "mysql",
+		Time:      s.now.Add(59 * time.Second),
+	})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+
+	err = s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationExpire,
+		Lease:     "mysql",
+		Time:      s.now.Add(time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases("leadership", s.uuid), gc.HasLen, 0)
+}
+
+func (s *fsmSuite) TestExpirePinned(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationPin,
+		Lease:     "mysql",
+		Holder:    "machine-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned("leadership", s.uuid), jc.DeepEquals, map[string][]string{
+		"mysql": {"machine-0"},
+	})
+
+	expire := raftlease.Command{
+		Operation: raftlease.OperationExpire,
+		Lease:     "mysql",
+		Time:      s.now.Add(time.Hour),
+	}
+	err = s.apply(c, expire)
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+
+	err = s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationUnpin,
+		Lease:     "mysql",
+		Holder:    "machine-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned("leadership", s.uuid), gc.HasLen, 0)
+
+	err = s.apply(c, expire)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *fsmSuite) TestInvalidCommand(c *gc.C) {
+	err := s.apply(c, raftlease.Command{
+		Operation: "frobnicate",
+		Lease:     "mysql",
+	})
+	c.Assert(err, gc.ErrorMatches, `operation "frobnicate" not valid`)
+
+	result := s.fsm.Apply(&raft.Log{Data: []byte("not: [valid")})
+	c.Assert(result.(raftlease.FSMResponse).Error(), gc.NotNil)
+}
+
+func (s *fsmSuite) TestSnapshotRestore(c *gc.C) {
+	err := s.claim(c, "mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.claim(c, "wordpress", "wordpress/1", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apply(c, raftlease.Command{
+		Operation: raftlease.OperationPin,
+		Lease:     "mysql",
+		Holder:    "machine-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot, err := s.fsm.Snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	sink := &fakeSnapshotSink{}
+	err = snapshot.Persist(sink)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.closed, jc.IsTrue)
+
+	restored := raftlease.NewFSM()
+	err = restored.Restore(ioutil.NopCloser(bytes.NewReader(sink.Bytes())))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored.Leases("leadership", s.uuid), jc.DeepEquals, s.fsm.Leases("leadership", s.uuid))
+	c.Assert(restored.Pinned("leadership", s.uuid), jc.DeepEquals, map[string][]string{
+		"mysql": {"machine-0"},
+	})
+}
+
+func (s *fsmSuite) TestRestoreBadVersion(c *gc.C) {
+	err := s.fsm.Restore(ioutil.NopCloser(bytes.NewReader([]byte("version: 42\n"))))
+	c.Assert(err, gc.ErrorMatches, "snapshot version 42 not valid")
+}
+
+func (s *fsmSuite) TestRestoreLegacySnapshot(c *gc.C) {
+	// Controllers that ran raft before leases were kept in it have
+	// snapshots written by the SimpleFSM: the gob-encoded data of
+	// every log applied.
+	claim := raftlease.Command{
+		Version:   raftlease.CommandVersion,
+		Operation: raftlease.OperationClaim,
+		Namespace: "leadership",
+		ModelUUID: s.uuid,
+		Lease:     "mysql",
+		Holder:    "mysql/0",
+		Duration:  time.Minute,
+		Time:      s.now,
+	}
+	claimData, err := claim.Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode([][]byte{[]byte("command1"), claimData})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.claim(c, "wordpress", "wordpress/1", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.fsm.Restore(ioutil.NopCloser(&buf))
+	c.Assert(err, jc.ErrorIsNil)
+
+	leases := s.fsm.Leases("leadership", s.uuid)
+	c.Assert(leases, gc.HasLen, 1)
+	c.Assert(leases["mysql"].Holder, gc.Equals, "mysql/0")
+}
+
+func (s *fsmSuite) TestRestoreGarbage(c *gc.C) {
+	err := s.fsm.Restore(ioutil.NopCloser(bytes.NewReader([]byte("not: [valid"))))
+	c.Assert(err, gc.NotNil)
+}
+
+type fakeSnapshotSink struct {
+	bytes.Buffer
+	closed    bool
+	cancelled bool
+}
+
+func (s *fakeSnapshotSink) ID() string {
+	return "snapshot"
+}
+
+func (s *fakeSnapshotSink) Cancel() error {
+	s.cancelled = true
+	return nil
+}
+
+func (s *fakeSnapshotSink) Close() error {
+	s.closed = true
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// RequestTopic is the topic on which lease commands are published
+// for the raft leader to apply.
+const RequestTopic = "lease.request"
+
+// ForwardRequest is the message published on RequestTopic.
+type ForwardRequest struct {
+	// Command is the marshalled command to apply.
+	Command string `yaml:"command"`
+
+	// ResponseTopic is the topic on which the result of applying
+	// the command should be published.
+	ResponseTopic string `yaml:"response-topic"`
+}
+
+// ForwardResponse is the message published on a request's
+// ResponseTopic once the command has been applied.
+type ForwardResponse struct {
+	Error *ResponseError `yaml:"error,omitempty"`
+}
+
+// ResponseError is used to transmit errors from applying a command
+// back to the requester.
+type ResponseError struct {
+	Message string `yaml:"message"`
+	Code    string `yaml:"code,omitempty"`
+}
+
+// InvalidCode is the ResponseError code reported when applying a
+// command fails with lease.ErrInvalid.
+const InvalidCode = "invalid"
+
+// AsResponseError converts an error into a ResponseError suitable
+// for publishing in a ForwardResponse.
+func AsResponseError(err error) *ResponseError {
+	if err == nil {
+		return nil
+	}
+	result := &ResponseError{Message: err.Error()}
+	if errors.Cause(err) == lease.ErrInvalid {
+		result.Code = InvalidCode
+	}
+	return result
+}
+
+// AsError converts a ResponseError back into an error.
+func (e *ResponseError) AsError() error {
+	if e == nil {
+		return nil
+	}
+	if e.Code == InvalidCode {
+		return lease.ErrInvalid
+	}
+	return errors.New(e.Message)
+}
+
+// PubsubForwarder is a Forwarder which publishes commands on the
+// central hub, where they are picked up by the raft forwarder worker
+// running on the raft leader.
+type PubsubForwarder struct {
+	// requestID is accessed atomically, so must be kept 64-bit
+	// aligned by being the first field.
+	requestID uint64
+
+	hub            *pubsub.StructuredHub
+	clock          clock.Clock
+	clientID       string
+	requestTimeout time.Duration
+}
+
+// NewPubsubForwarder returns a forwarder which sends commands over
+// the given hub. The clientID must be unique amongst the controllers
+// sharing the hub; it is used to construct response topics.
+func NewPubsubForwarder(hub *pubsub.StructuredHub, clock clock.Clock, clientID string, requestTimeout time.Duration) *PubsubForwarder {
+	return &PubsubForwarder{
+		hub:            hub,
+		clock:          clock,
+		clientID:       clientID,
+		requestTimeout: requestTimeout,
+	}
+}
+
+// ForwardRequest is part of the Forwarder interface.
+func (f *PubsubForwarder) ForwardRequest(command *Command) error {
+	data, err := command.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	responseTopic := fmt.Sprintf("%s.%s.%d",
+		RequestTopic, f.clientID, atomic.AddUint64(&f.requestID, 1),
+	)
+	// The handler must never block the hub, so only the first
+	// response is kept.
+	results := make(chan error, 1)
+	unsubscribe, err := f.hub.Subscribe(
+		responseTopic,
+		func(_ string, resp ForwardResponse, err error) {
+			if err == nil {
+				err = resp.Error.AsError()
+			}
+			select {
+			case results <- err:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		return errors.Annotatef(err, "subscribing to %q", responseTopic)
+	}
+	defer unsubscribe()
+
+	if _, err := f.hub.Publish(RequestTopic, ForwardRequest{
+		Command:       string(data),
+		ResponseTopic: responseTopic,
+	}); err != nil {
+		return errors.Annotatef(err, "publishing %s", command.Operation)
+	}
+
+	select {
+	case err := <-results:
+		if err == lease.ErrInvalid {
+			return err
+		}
+		return errors.Trace(err)
+	case <-f.clock.After(f.requestTimeout):
+		return errors.Timeoutf("lease %s request", command.Operation)
+	}
+}
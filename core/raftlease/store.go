@@ -0,0 +1,179 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// Forwarder submits commands to the raft leader, and reports the
+// result of applying them to the FSM.
+type Forwarder interface {
+	// ForwardRequest sends the command to the raft leader, returning
+	// the error from applying the command (for example
+	// lease.ErrInvalid), or an error if the command couldn't be
+	// delivered.
+	ForwardRequest(*Command) error
+}
+
+// StoreConfig holds the resources and settings needed to create a
+// Store.
+type StoreConfig struct {
+	// FSM is the local copy of the lease state, kept up to date by
+	// raft.
+	FSM *FSM
+
+	// Forwarder is used to send commands to the raft leader.
+	Forwarder Forwarder
+
+	// Namespace is the kind of lease managed by the store.
+	Namespace string
+
+	// ModelUUID identifies the model whose leases the store manages.
+	ModelUUID string
+}
+
+// Validate checks that the configuration is valid.
+func (config StoreConfig) Validate() error {
+	if config.FSM == nil {
+		return errors.NotValidf("nil FSM")
+	}
+	if config.Forwarder == nil {
+		return errors.NotValidf("nil Forwarder")
+	}
+	if err := lease.ValidateString(config.Namespace); err != nil {
+		return errors.Annotate(err, "invalid Namespace")
+	}
+	if err := lease.ValidateString(config.ModelUUID); err != nil {
+		return errors.Annotate(err, "invalid ModelUUID")
+	}
+	return nil
+}
+
+// NewStore returns a lease.Client backed by the raft lease FSM.
+func NewStore(config StoreConfig) (*Store, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Store{config: config}, nil
+}
+
+// Store is an implementation of lease.Client which reads lease state
+// from the local FSM, and writes it by forwarding commands to the
+// raft leader.
+type Store struct {
+	config StoreConfig
+}
+
+// ClaimLease is part of the lease.Client interface.
+func (s *Store) ClaimLease(name string, request lease.Request) error {
+	return s.leaseRequest(OperationClaim, name, request)
+}
+
+// ExtendLease is part of the lease.Client interface.
+func (s *Store) ExtendLease(name string, request lease.Request) error {
+	return s.leaseRequest(OperationExtend, name, request)
+}
+
+func (s *Store) leaseRequest(operation, name string, request lease.Request) error {
+	if err := lease.ValidateString(name); err != nil {
+		return errors.Annotatef(err, "invalid name")
+	}
+	if err := request.Validate(); err != nil {
+		return errors.Annotatef(err, "invalid request")
+	}
+	return s.forward(&Command{
+		Operation: operation,
+		Lease:     name,
+		Holder:    request.Holder,
+		Duration:  request.Duration,
+	})
+}
+
+// ExpireLease is part of the lease.Client interface.
+func (s *Store) ExpireLease(name string) error {
+	if err := lease.ValidateString(name); err != nil {
+		return errors.Annotatef(err, "invalid name")
+	}
+	return s.forward(&Command{
+		Operation: OperationExpire,
+		Lease:     name,
+	})
+}
+
+// PinLease prevents the named lease from being expired until every
+// entity pinning it has unpinned it.
+func (s *Store) PinLease(name, entity string) error {
+	return s.pinRequest(OperationPin, name, entity)
+}
+
+// UnpinLease removes the given entity's pin from the named lease.
+func (s *Store) UnpinLease(name, entity string) error {
+	return s.pinRequest(OperationUnpin, name, entity)
+}
+
+func (s *Store) pinRequest(operation, name, entity string) error {
+	if err := lease.ValidateString(name); err != nil {
+		return errors.Annotatef(err, "invalid name")
+	}
+	if err := lease.ValidateString(entity); err != nil {
+		return errors.Annotatef(err, "invalid entity")
+	}
+	return s.forward(&Command{
+		Operation: operation,
+		Lease:     name,
+		Holder:    entity,
+	})
+}
+
+func (s *Store) forward(command *Command) error {
+	command.Version = CommandVersion
+	command.Namespace = s.config.Namespace
+	command.ModelUUID = s.config.ModelUUID
+	err := s.config.Forwarder.ForwardRequest(command)
+	if errors.Cause(err) == lease.ErrInvalid {
+		return lease.ErrInvalid
+	}
+	return errors.Trace(err)
+}
+
+// Leases is part of the lease.Client interface.
+func (s *Store) Leases() map[string]lease.Info {
+	leases := s.config.FSM.Leases(s.config.Namespace, s.config.ModelUUID)
+	for name, info := range leases {
+		info.Trapdoor = assertOpTrapdoor
+		leases[name] = info
+	}
+	return leases
+}
+
+// Pinned returns the entities pinning each of the store's leases,
+// keyed by lease name.
+func (s *Store) Pinned() map[string][]string {
+	return s.config.FSM.Pinned(s.config.Namespace, s.config.ModelUUID)
+}
+
+// Refresh is part of the lease.Client interface. The FSM is kept up
+// to date by raft, so there is nothing to do.
+func (s *Store) Refresh() error {
+	return nil
+}
+
+// assertOpTrapdoor is the lease.Trapdoor used for raft leases. Lease
+// state is not stored in MongoDB, so there is nothing a transaction
+// could assert on to check that the lease is still held when it's
+// applied. Rather than let such transactions run unguarded, callers
+// passing a *[]txn.Op get an error.
+func assertOpTrapdoor(key interface{}) error {
+	if key == nil {
+		return nil
+	}
+	if _, ok := key.(*[]txn.Op); !ok {
+		return errors.NotValidf("expected *[]txn.Op; %T", key)
+	}
+	return errors.NotSupportedf("guarding transactions with raft leases")
+}
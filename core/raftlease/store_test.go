@@ -0,0 +1,158 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease_test
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+type storeSuite struct {
+	testing.IsolationSuite
+
+	fsm       *raftlease.FSM
+	forwarder *fakeForwarder
+	store     *raftlease.Store
+	now       time.Time
+}
+
+var _ = gc.Suite(&storeSuite{})
+
+func (s *storeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.now = time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)
+	s.fsm = raftlease.NewFSM()
+	s.forwarder = &fakeForwarder{fsm: s.fsm, now: s.now}
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:       s.fsm,
+		Forwarder: s.forwarder,
+		Namespace: "leadership",
+		ModelUUID: "model-uuid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store = store
+}
+
+func (s *storeSuite) TestValidate(c *gc.C) {
+	_, err := raftlease.NewStore(raftlease.StoreConfig{
+		Forwarder: s.forwarder,
+		Namespace: "leadership",
+		ModelUUID: "model-uuid",
+	})
+	c.Assert(err, gc.ErrorMatches, "nil FSM not valid")
+
+	_, err = raftlease.NewStore(raftlease.StoreConfig{
+		FSM:       s.fsm,
+		Namespace: "leadership",
+		ModelUUID: "model-uuid",
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Forwarder not valid")
+
+	_, err = raftlease.NewStore(raftlease.StoreConfig{
+		FSM:       s.fsm,
+		Forwarder: s.forwarder,
+		ModelUUID: "model-uuid",
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid Namespace: string is empty")
+}
+
+func (s *storeSuite) TestClaimLease(c *gc.C) {
+	err := s.store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.forwarder.commands, gc.HasLen, 1)
+	c.Assert(s.forwarder.commands[0], jc.DeepEquals, raftlease.Command{
+		Version:   raftlease.CommandVersion,
+		Operation: raftlease.OperationClaim,
+		Namespace: "leadership",
+		ModelUUID: "model-uuid",
+		Lease:     "mysql",
+		Holder:    "mysql/0",
+		Duration:  time.Minute,
+	})
+
+	leases := s.store.Leases()
+	c.Assert(leases, gc.HasLen, 1)
+	c.Assert(leases["mysql"].Holder, gc.Equals, "mysql/0")
+	c.Assert(leases["mysql"].Expiry, gc.Equals, s.now.Add(time.Minute))
+}
+
+func (s *storeSuite) TestClaimLeaseInvalid(c *gc.C) {
+	err := s.store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.ClaimLease("mysql", lease.Request{Holder: "mysql/1", Duration: time.Minute})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
+
+func (s *storeSuite) TestClaimLeaseValidation(c *gc.C) {
+	err := s.store.ClaimLease("my sql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, gc.ErrorMatches, "invalid name: string contains forbidden characters")
+	err = s.store.ClaimLease("mysql", lease.Request{Holder: "mysql/0"})
+	c.Assert(err, gc.ErrorMatches, "invalid request: invalid duration")
+	c.Assert(s.forwarder.commands, gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestForwardError(c *gc.C) {
+	s.forwarder.err = errors.New("no leader")
+	err := s.store.ExpireLease("mysql")
+	c.Assert(err, gc.ErrorMatches, "no leader")
+}
+
+func (s *storeSuite) TestPinUnpin(c *gc.C) {
+	err := s.store.PinLease("mysql", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.Pinned(), jc.DeepEquals, map[string][]string{
+		"mysql": {"machine-0"},
+	})
+	err = s.store.UnpinLease("mysql", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.Pinned(), gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestTrapdoor(c *gc.C) {
+	err := s.store.ClaimLease("mysql", lease.Request{Holder: "mysql/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	trapdoor := s.store.Leases()["mysql"].Trapdoor
+
+	c.Assert(trapdoor(nil), jc.ErrorIsNil)
+	// Lease holders aren't in MongoDB, so transactions can't be
+	// guarded by them.
+	ops := []txn.Op{{C: "something"}}
+	err = trapdoor(&ops)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "guarding transactions with raft leases not supported")
+	c.Assert(ops, gc.HasLen, 1)
+	c.Assert(trapdoor("what"), gc.ErrorMatches, `expected \*\[\]txn.Op; string not valid`)
+}
+
+// fakeForwarder applies commands directly to an FSM, as the raft
+// forwarder running on the leader would.
+type fakeForwarder struct {
+	fsm      *raftlease.FSM
+	now      time.Time
+	err      error
+	commands []raftlease.Command
+}
+
+func (f *fakeForwarder) ForwardRequest(command *raftlease.Command) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.commands = append(f.commands, *command)
+	stamped := *command
+	stamped.Time = f.now
+	data, err := stamped.Marshal()
+	if err != nil {
+		return err
+	}
+	return f.fsm.Apply(&raft.Log{Data: data}).(raftlease.FSMResponse).Error()
+}
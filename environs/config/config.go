@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
+	"github.com/juju/juju/logfwd/elasticsearch"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 )
@@ -48,6 +50,15 @@ const (
 	FwNone = "none"
 )
 
+const (
+	// LogForwardSinkSyslog forwards logs to a syslog server.
+	LogForwardSinkSyslog = "syslog"
+
+	// LogForwardSinkElasticsearch forwards logs to an Elasticsearch
+	// server.
+	LogForwardSinkElasticsearch = "elasticsearch"
+)
+
 // TODO(katco-): Please grow this over time.
 // Centralized place to store values of config keys. This transitions
 // mistakes in referencing key-values to a compile-time error.
@@ -178,6 +189,29 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogForwardSink selects the type of log sink that logs are
+	// forwarded to when log forwarding is enabled.
+	LogForwardSink = "logforward-sink"
+
+	// LogFwdElasticsearchURL sets the URL of the Elasticsearch server.
+	LogFwdElasticsearchURL = "elasticsearch-url"
+
+	// LogFwdElasticsearchIndex sets the name of the Elasticsearch index
+	// that logs are written to.
+	LogFwdElasticsearchIndex = "elasticsearch-index"
+
+	// LogFwdElasticsearchCACert sets the certificate of the CA that
+	// signed the Elasticsearch server certificate.
+	LogFwdElasticsearchCACert = "elasticsearch-ca-cert"
+
+	// LogFwdElasticsearchClientCert sets the client certificate for
+	// Elasticsearch forwarding.
+	LogFwdElasticsearchClientCert = "elasticsearch-client-cert"
+
+	// LogFwdElasticsearchClientKey sets the client key for
+	// Elasticsearch forwarding.
+	LogFwdElasticsearchClientKey = "elasticsearch-client-key"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -206,6 +240,17 @@ const (
 	// grow to before it is pruned, eg "5M"
 	MaxActionResultsSize = "max-action-results-size"
 
+	// MaxActionOutputSize is the maximum size of the results of a single
+	// action that are stored with the action. Larger results are moved
+	// to blob storage, eg "64K"
+	MaxActionOutputSize = "max-action-output-size"
+
+	// ActionRetention overrides the maximum age of completed actions with
+	// the given names, as a comma separated list of name=age pairs, eg
+	// "backup=720h,status=24h". The overrides do not exempt actions from
+	// pruning by size once max-action-results-size is exceeded.
+	ActionRetention = "action-retention"
+
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
@@ -392,6 +437,9 @@ const (
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
+
+	// DefaultActionOutputSize is the default value for MaxActionOutputSize.
+	DefaultActionOutputSize = "64K"
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -580,6 +628,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if lfCfg, ok := cfg.LogFwdElasticsearch(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid elasticsearch forwarding config")
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -613,6 +667,18 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MaxActionOutputSize].(string); ok {
+		if _, err := parseByteSize(v); err != nil {
+			return errors.Annotate(err, "invalid max action output size in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[ActionRetention].(string); ok {
+		if _, err := parseActionRetention(v); err != nil {
+			return errors.Annotate(err, "invalid action retention in model configuration")
+		}
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		if f, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid update status hook interval in model configuration")
@@ -989,6 +1055,15 @@ func (c *Config) AptMirror() string {
 	return c.asString("apt-mirror")
 }
 
+// LogFwdSink returns the type of log sink that logs are forwarded to
+// when log forwarding is enabled.
+func (c *Config) LogFwdSink() string {
+	if s, ok := c.defined[LogForwardSink]; ok && s != "" {
+		return s.(string)
+	}
+	return LogForwardSinkSyslog
+}
+
 // LogFwdSyslog returns the syslog forwarding config.
 func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	partial := false
@@ -996,7 +1071,7 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 
 	if s, ok := c.defined[LogForwardEnabled]; ok {
 		partial = true
-		lfCfg.Enabled = s.(bool)
+		lfCfg.Enabled = s.(bool) && c.LogFwdSink() == LogForwardSinkSyslog
 	}
 
 	if s, ok := c.defined[LogFwdSyslogHost]; ok && s != "" {
@@ -1025,6 +1100,35 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdElasticsearch returns the Elasticsearch forwarding config.
+func (c *Config) LogFwdElasticsearch() (*elasticsearch.RawConfig, bool) {
+	partial := false
+	var lfCfg elasticsearch.RawConfig
+
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		partial = true
+		lfCfg.Enabled = s.(bool) && c.LogFwdSink() == LogForwardSinkElasticsearch
+	}
+
+	for key, field := range map[string]*string{
+		LogFwdElasticsearchURL:        &lfCfg.URL,
+		LogFwdElasticsearchIndex:      &lfCfg.Index,
+		LogFwdElasticsearchCACert:     &lfCfg.CACert,
+		LogFwdElasticsearchClientCert: &lfCfg.ClientCert,
+		LogFwdElasticsearchClientKey:  &lfCfg.ClientKey,
+	} {
+		if s, ok := c.defined[key]; ok && s != "" {
+			partial = true
+			*field = s.(string)
+		}
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1273,6 +1377,73 @@ func (c *Config) MaxActionResultsSizeMB() uint {
 	return uint(val)
 }
 
+// MaxActionOutputSize is the maximum size in bytes of the results of a
+// single action that are stored with the action itself.
+func (c *Config) MaxActionOutputSize() uint64 {
+	raw := c.asString(MaxActionOutputSize)
+	if raw == "" {
+		raw = DefaultActionOutputSize
+	}
+	// Value has already been validated.
+	val, _ := parseByteSize(raw)
+	return val
+}
+
+// ActionRetention returns the maximum age of completed actions, keyed
+// on action name, that override MaxActionResultsAge.
+func (c *Config) ActionRetention() map[string]time.Duration {
+	// Value has already been validated.
+	val, _ := parseActionRetention(c.asString(ActionRetention))
+	return val
+}
+
+// parseByteSize parses a size in bytes, with an optional K, M or G
+// suffix to specify KiB, MiB or GiB.
+func parseByteSize(str string) (uint64, error) {
+	s := strings.TrimSpace(str)
+	mult := uint64(1)
+	if s != "" {
+		switch strings.ToUpper(s[len(s)-1:]) {
+		case "K":
+			mult = 1 << 10
+		case "M":
+			mult = 1 << 20
+		case "G":
+			mult = 1 << 30
+		}
+		if mult != 1 {
+			s = s[:len(s)-1]
+		}
+	}
+	val, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, errors.NotValidf("size %q", str)
+	}
+	return val * mult, nil
+}
+
+// parseActionRetention parses a comma separated list of
+// action-name=duration pairs.
+func parseActionRetention(str string) (map[string]time.Duration, error) {
+	result := make(map[string]time.Duration)
+	for _, item := range strings.Split(str, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("expected name=duration, got %q", item)
+		}
+		age, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, errors.Annotatef(err, "retention for action %q", parts[0])
+		}
+		result[parts[0]] = age
+	}
+	return result, nil
+}
+
 // UpdateStatusHookInterval is how often to run the charm
 // update-status hook.
 func (c *Config) UpdateStatusHookInterval() time.Duration {
@@ -1400,6 +1571,13 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
 
+	LogForwardSink:                schema.Omit,
+	LogFwdElasticsearchURL:        schema.Omit,
+	LogFwdElasticsearchIndex:      schema.Omit,
+	LogFwdElasticsearchCACert:     schema.Omit,
+	LogFwdElasticsearchClientCert: schema.Omit,
+	LogFwdElasticsearchClientKey:  schema.Omit,
+
 	// Storage related config.
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey:      schema.Omit,
@@ -1446,6 +1624,8 @@ var alwaysOptional = schema.Defaults{
 	MaxStatusHistorySize:         schema.Omit,
 	MaxActionResultsAge:          schema.Omit,
 	MaxActionResultsSize:         schema.Omit,
+	MaxActionOutputSize:          schema.Omit,
+	ActionRetention:              schema.Omit,
 	UpdateStatusHookInterval:     schema.Omit,
 	EgressSubnets:                schema.Omit,
 	FanConfig:                    schema.Omit,
@@ -1832,6 +2012,37 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogForwardSink: {
+		Description: `The type of log sink that logs are forwarded to when log forwarding is enabled (default syslog).`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{LogForwardSinkSyslog, LogForwardSinkElasticsearch},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchURL: {
+		Description: `The URL of the Elasticsearch server, which may include credentials.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchIndex: {
+		Description: `The Elasticsearch index that logs are written to; {date} and {model-uuid} are replaced with the date and model of each log record (default juju-{date}).`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchCACert: {
+		Description: `The certificate of the CA that signed the Elasticsearch server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchClientCert: {
+		Description: `The Elasticsearch client certificate in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdElasticsearchClientKey: {
+		Description: `The Elasticsearch client key in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1907,6 +2118,16 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxActionOutputSize: {
+		Description: "The maximum size of the results stored with a single action, larger results are kept in blob storage (default 64K)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ActionRetention: {
+		Description: "Comma separated name=age pairs overriding the maximum age of completed actions with the given names, eg \"backup=720h\"; once the actions collection exceeds max-action-results-size, the oldest actions are still pruned regardless of their name",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	UpdateStatusHookInterval: {
 		Description: "How often to run the charm update-status hook, in human-readable time format (default 5m, range 1-60m)",
 		Type:        environschema.Tstring,
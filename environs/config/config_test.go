@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
+	"github.com/juju/juju/logfwd/elasticsearch"
 	"github.com/juju/juju/testing"
 )
 
@@ -522,6 +523,30 @@ var configTests = []configTest{
 			"syslog-client-key":  serverKey2,
 		}),
 		err: `invalid syslog forwarding config: validating TLS config: parsing client key pair: (crypto/)?tls: private key does not match public key`,
+	}, {
+		about:       "Invalid logforward-sink",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-sink": "splunk",
+		}),
+		err: `logforward-sink: expected one of \[syslog elasticsearch\], got "splunk"`,
+	}, {
+		about:       "Invalid elasticsearch URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-sink":    "elasticsearch",
+			"elasticsearch-url":  "elastic.example.com",
+		}),
+		err: `invalid elasticsearch forwarding config: URL "elastic.example.com" not valid`,
+	}, {
+		about:       "Invalid elasticsearch index",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"elasticsearch-url":   "https://elastic.example.com",
+			"elasticsearch-index": "Juju",
+		}),
+		err: `invalid elasticsearch forwarding config: index "Juju" with upper case characters not valid`,
 	}, {
 		about:       "net-bond-reconfigure-delay value",
 		useDefaults: config.UseDefaults,
@@ -552,6 +577,18 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid elasticsearch config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":        true,
+			"logforward-sink":           "elasticsearch",
+			"elasticsearch-url":         "https://elastic.example.com:9200",
+			"elasticsearch-index":       "juju-{model-uuid}-{date}",
+			"elasticsearch-ca-cert":     testing.CACert,
+			"elasticsearch-client-cert": testing.ServerCert,
+			"elasticsearch-client-key":  testing.ServerKey,
+		}),
 	}, {
 		about:       "Valid container-inherit-properties",
 		useDefaults: config.UseDefaults,
@@ -649,7 +686,7 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	lfCfg, hasLogCfg := cfg.LogFwdSyslog()
 	if v, ok := test.attrs["logforward-enabled"].(bool); ok {
 		c.Assert(hasLogCfg, jc.IsTrue)
-		c.Assert(lfCfg.Enabled, gc.Equals, v)
+		c.Assert(lfCfg.Enabled, gc.Equals, v && cfg.LogFwdSink() == config.LogForwardSinkSyslog)
 	}
 	if v, ok := test.attrs["syslog-ca-cert"].(string); v != "" {
 		c.Assert(hasLogCfg, jc.IsTrue)
@@ -1097,6 +1134,45 @@ func (s *ConfigSuite) TestBackupDir(c *gc.C) {
 	c.Assert(config.BackupDir(), gc.Equals, testDir)
 }
 
+func (s *ConfigSuite) TestLogFwdSinkDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled": true,
+		"syslog-host":        "localhost:1234",
+		"syslog-ca-cert":     testing.CACert,
+		"syslog-client-cert": testing.ServerCert,
+		"syslog-client-key":  testing.ServerKey,
+	})
+	c.Assert(cfg.LogFwdSink(), gc.Equals, "syslog")
+	syslogCfg, ok := cfg.LogFwdSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(syslogCfg.Enabled, jc.IsTrue)
+	esCfg, ok := cfg.LogFwdElasticsearch()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(esCfg.Enabled, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestLogFwdElasticsearch(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled":    true,
+		"logforward-sink":       "elasticsearch",
+		"elasticsearch-url":     "https://elastic.example.com:9200",
+		"elasticsearch-index":   "juju-{date}",
+		"elasticsearch-ca-cert": testing.CACert,
+	})
+	c.Assert(cfg.LogFwdSink(), gc.Equals, "elasticsearch")
+	syslogCfg, ok := cfg.LogFwdSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(syslogCfg.Enabled, jc.IsFalse)
+	esCfg, ok := cfg.LogFwdElasticsearch()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(*esCfg, jc.DeepEquals, elasticsearch.RawConfig{
+		Enabled: true,
+		URL:     "https://elastic.example.com:9200",
+		Index:   "juju-{date}",
+		CACert:  testing.CACert,
+	})
+}
+
 func (s *ConfigSuite) TestAutoHookRetryDefault(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{})
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
@@ -1282,6 +1358,36 @@ func (s *ConfigSuite) TestStatusHistoryConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(8192))
 }
 
+func (s *ConfigSuite) TestActionOutputConfigDefaults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaxActionOutputSize(), gc.Equals, uint64(64*1024))
+	c.Assert(cfg.ActionRetention(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestActionOutputConfigValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"max-action-output-size": "2M",
+		"action-retention":       "backup=720h, status=24h",
+	})
+	c.Assert(cfg.MaxActionOutputSize(), gc.Equals, uint64(2*1024*1024))
+	c.Assert(cfg.ActionRetention(), jc.DeepEquals, map[string]time.Duration{
+		"backup": 720 * time.Hour,
+		"status": 24 * time.Hour,
+	})
+}
+
+func (s *ConfigSuite) TestActionOutputConfigInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"max-action-output-size": "lots",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid max action output size in model configuration: size "lots" not valid`)
+
+	_, err = config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"action-retention": "backup",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid action retention in model configuration: expected name=duration, got "backup"`)
+}
+
 func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigDefault(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{})
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 5*time.Minute)
@@ -424,6 +424,16 @@ type Firewaller interface {
 	IngressRules(ctx context.ProviderCallContext) ([]network.IngressRule, error)
 }
 
+// EgressFirewaller is implemented by environs whose instances may be
+// able to restrict their egress traffic; see
+// instance.InstanceEgressFirewaller.
+type EgressFirewaller interface {
+	// SupportsEgressRules reports whether the environ's instances
+	// can restrict their egress to the traffic allowed by egress
+	// rules.
+	SupportsEgressRules(ctx context.ProviderCallContext) (bool, error)
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
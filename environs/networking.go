@@ -130,6 +130,23 @@ func SupportsSpaces(ctx context.ProviderCallContext, env Environ) bool {
 	return ok
 }
 
+// SupportsEgressRules reports whether the environ's instances can
+// enforce egress rules.
+func SupportsEgressRules(ctx context.ProviderCallContext, env Environ) bool {
+	fw, ok := env.(EgressFirewaller)
+	if !ok {
+		return false
+	}
+	ok, err := fw.SupportsEgressRules(ctx)
+	if err != nil {
+		if !errors.IsNotSupported(err) {
+			logger.Errorf("checking model egress rules support failed with: %v", err)
+		}
+		return false
+	}
+	return ok
+}
+
 // SupportsContainerAddresses checks if the environment will let us allocate
 // addresses for containers from the host ranges.
 func SupportsContainerAddresses(ctx context.ProviderCallContext, env Environ) bool {
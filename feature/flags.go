@@ -49,5 +49,12 @@ const NewPresence = "new-presence"
 // (or just unwanted noise).
 const DisableRaft = "disable-raft"
 
+// RaftLeases indicates that singular controller leases should be
+// stored in the raft lease FSM rather than in MongoDB. Application
+// leadership stays in MongoDB, since leadership-gated transactions
+// need to assert on the lease holder. This value is only checked
+// using the controller config "features" attribute.
+const RaftLeases = "raft-leases"
+
 // UpgradeSeries is a development feature flag.
 const UpgradeSeries = "upgrade-series"
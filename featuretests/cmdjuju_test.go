@@ -110,6 +110,10 @@ func (s *cmdJujuSuite) TestApplicationUnset(c *gc.C) {
 func (s *cmdJujuSuite) TestApplicationGetIAASModel(c *gc.C) {
 	expected := `application: dummy-application
 application-config:
+  hook-timeout:
+    description: Maximum time a hook may run before it is killed, e.g. 30m
+    source: unset
+    type: string
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -153,6 +157,10 @@ settings:
 func (s *cmdJujuSuite) TestApplicationGetCAASModel(c *gc.C) {
 	expected := `application: dummy-application
 application-config:
+  hook-timeout:
+    description: Maximum time a hook may run before it is killed, e.g. 30m
+    source: unset
+    type: string
   juju-application-path:
     default: /
     description: the relative http path used to access an application
@@ -188,6 +196,11 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-tls-secret:
+    description: the name of the secret holding the TLS certificate and key used by
+      the ingress resource
+    source: unset
+    type: string
   kubernetes-service-external-ips:
     description: list of IP addresses for which nodes in the cluster will also accept
       traffic
@@ -61,6 +61,28 @@ type InstanceFirewaller interface {
 	IngressRules(ctx context.ProviderCallContext, machineId string) ([]network.IngressRule, error)
 }
 
+// InstanceEgressFirewaller provides instance-level egress firewall
+// functionality. Instances without egress rules may send traffic to any
+// destination; once an instance has egress rules, it may only send the
+// traffic they allow.
+type InstanceEgressFirewaller interface {
+	// AllowEgress allows the given egress on the instance, which
+	// should have been started with the given machine id.
+	AllowEgress(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error
+
+	// DisallowEgress removes the given egress rules from the instance,
+	// which should have been started with the given machine id.
+	DisallowEgress(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error
+
+	// EgressRules returns the set of egress rules for the instance,
+	// which should have been applied to the given machine id. The
+	// rules are returned as sorted by network.SortEgressRules().
+	// It is expected that there be only one egress rule result for a
+	// given port range - the rule's DestinationCIDRs will contain all
+	// applicable destination address rules for that port range.
+	EgressRules(ctx context.ProviderCallContext, machineId string) ([]network.EgressRule, error)
+}
+
 // HardwareCharacteristics represents the characteristics of the instance (if known).
 // Attributes that are nil are unknown or not supported.
 type HardwareCharacteristics struct {
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/network"
 )
@@ -37,7 +38,8 @@ type NewAPIConnectionParams struct {
 	// AccountDetails contains the account details to use for logging
 	// in to the Juju API. If this is nil, then no login will take
 	// place. If AccountDetails.Password and AccountDetails.Macaroon
-	// are zero, the login will be as an external user.
+	// are zero, the login will be as an external user, using
+	// AccountDetails.OIDCToken if it is set.
 	AccountDetails *jujuclient.AccountDetails
 
 	// ModelUUID is an optional model UUID. If specified, the API connection
@@ -69,6 +71,9 @@ func NewAPIConnection(args NewAPIConnectionParams) (_ api.Connection, err error)
 	if err != nil {
 		redirErr, ok := errors.Cause(err).(*api.RedirectError)
 		if !ok {
+			if apiInfo.IDToken != "" && params.IsCodeUnauthorized(err) {
+				return nil, errors.Annotate(err, `cannot log in with OIDC token, run "juju login --oidc" to log in again`)
+			}
 			return nil, errors.Trace(err)
 		}
 		// We've been told to connect to a different API server,
@@ -140,6 +145,7 @@ func NewAPIConnection(args NewAPIConnectionParams) (_ api.Connection, err error)
 			// that we've logged in as.
 			accountDetails = &jujuclient.AccountDetails{
 				User:            user.Id(),
+				OIDCToken:       apiInfo.IDToken,
 				LastKnownAccess: st.ControllerAccess(),
 			}
 		} else if apiInfo.Tag == nil {
@@ -190,6 +196,10 @@ func connectionInfo(args NewAPIConnectionParams) (*api.Info, *jujuclient.Control
 		// If no password is recorded, we'll attempt to
 		// authenticate using macaroons.
 		apiInfo.Password = account.Password
+	} else if apiInfo.Tag == nil && account.OIDCToken != "" {
+		// External users who logged in with an OpenID Connect
+		// ID token use that rather than macaroons.
+		apiInfo.IDToken = account.OIDCToken
 	}
 	return apiInfo, controller, nil
 }
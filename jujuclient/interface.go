@@ -82,6 +82,10 @@ type AccountDetails struct {
 	// Password is the password for the account.
 	Password string `yaml:"password,omitempty"`
 
+	// OIDCToken is the OpenID Connect ID token the account logs
+	// in with, if it logged in with "juju login --oidc".
+	OIDCToken string `yaml:"oidc-token,omitempty"`
+
 	// LastKnownAccess is the last known access level for the account.
 	LastKnownAccess string `yaml:"last-known-access,omitempty"`
 }
@@ -0,0 +1,315 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/logfwd"
+)
+
+var logger = loggo.GetLogger("juju.logfwd.elasticsearch")
+
+const (
+	// maxBatchSize is the maximum number of records sent in a single
+	// bulk request.
+	maxBatchSize = 500
+
+	// maxAttempts is the number of times a bulk request is attempted
+	// before giving up. When the client gives up, the forwarder is
+	// restarted and resumes from the last record that was sent.
+	maxAttempts = 5
+
+	// initialRetryDelay and maxRetryDelay bound the time waited
+	// between attempts; the delay doubles after each attempt.
+	initialRetryDelay = time.Second
+	maxRetryDelay     = 30 * time.Second
+
+	// requestTimeout is the time allowed for a bulk request.
+	requestTimeout = 30 * time.Second
+)
+
+// HTTPClient exposes the underlying functionality needed by Client.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client sends log records to an Elasticsearch bulk API endpoint.
+type Client struct {
+	config     RawConfig
+	httpClient HTTPClient
+	clock      clock.Clock
+}
+
+// Open returns a new client that sends records to the endpoint
+// described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	httpClient := &http.Client{
+		Timeout: requestTimeout,
+	}
+	if tlsConfig != nil {
+		httpClient.Transport = &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			TLSClientConfig:     tlsConfig,
+			TLSHandshakeTimeout: 10 * time.Second,
+		}
+	}
+	client, err := OpenForClient(cfg, httpClient, clock.WallClock)
+	return client, errors.Trace(err)
+}
+
+// OpenForClient returns a new client that sends records to the
+// endpoint described by the config using the given HTTP client.
+func OpenForClient(cfg RawConfig, httpClient HTTPClient, clock clock.Clock) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		config:     cfg,
+		httpClient: httpClient,
+		clock:      clock,
+	}, nil
+}
+
+// Close closes the client.
+func (client *Client) Close() error {
+	return nil
+}
+
+// Send sends the records to the bulk API endpoint, returning once
+// they have all been accepted. Records that are rejected as invalid
+// are logged and dropped; an error is returned if the endpoint could
+// not accept the records after a number of attempts.
+func (client *Client) Send(records []logfwd.Record) error {
+	for len(records) > 0 {
+		n := len(records)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		if err := client.sendBatch(records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (client *Client) sendBatch(records []logfwd.Record) error {
+	delay := initialRetryDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := client.post(records)
+		if err != nil && retry == nil {
+			return errors.Annotatef(err, "sending records to %s", client.redactedEndpoint())
+		}
+		if len(retry) == 0 {
+			return nil
+		}
+		if err == nil {
+			err = errors.Errorf("%d records not accepted", len(retry))
+		}
+		if attempt == maxAttempts {
+			return errors.Annotatef(err, "sending records to %s", client.redactedEndpoint())
+		}
+		logger.Debugf("bulk request failed (attempt %d), retrying in %v: %v", attempt, delay, err)
+		records = retry
+		<-client.clock.After(delay)
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+// endpoint returns the URL of the bulk API.
+func (client *Client) endpoint() string {
+	return strings.TrimRight(client.config.URL, "/") + "/_bulk"
+}
+
+// redactedEndpoint returns the URL of the bulk API without any
+// credentials, for use in messages.
+func (client *Client) redactedEndpoint() string {
+	u, err := url.Parse(client.endpoint())
+	if err != nil {
+		return "bulk API"
+	}
+	u.User = nil
+	return u.String()
+}
+
+// post makes a single bulk request, and returns the records that
+// should be sent again. If an error is returned with no records to
+// send again, the error is not worth retrying.
+func (client *Client) post(records []logfwd.Record) ([]logfwd.Record, error) {
+	body, err := client.bulkBody(records)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req, err := http.NewRequest("POST", client.endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			// Drop the URL, which may include credentials.
+			err = urlErr.Err
+		}
+		return records, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return records, errors.Trace(err)
+	}
+	if resp.StatusCode/100 != 2 {
+		err := errors.Errorf("bulk request failed with %s: %s", resp.Status, bytes.TrimSpace(respBody))
+		if !retryable(resp.StatusCode) {
+			return nil, err
+		}
+		return records, err
+	}
+
+	var result bulkResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, errors.Annotate(err, "decoding bulk response")
+	}
+	if !result.Errors {
+		return nil, nil
+	}
+	if len(result.Items) != len(records) {
+		return nil, errors.Errorf("bulk response has %d items, expected %d", len(result.Items), len(records))
+	}
+	var retry []logfwd.Record
+	for i, item := range result.Items {
+		switch {
+		case item.Index.Status < 300:
+		case retryable(item.Index.Status):
+			retry = append(retry, records[i])
+		default:
+			logger.Warningf("dropping log record %d: %s", records[i].ID, item.Index.Error)
+		}
+	}
+	return retry, nil
+}
+
+// retryable reports whether a request that failed with the given
+// status code may succeed if it is sent again.
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// bulkBody returns the body of a bulk request that indexes the records.
+func (client *Client) bulkBody(records []logfwd.Record) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range records {
+		action := bulkAction{Index: bulkIndex{
+			Index: client.indexFor(rec),
+			// Using a deterministic ID means that records sent again
+			// after a restart replace the copies already indexed.
+			ID: fmt.Sprintf("%s-%d", rec.Origin.ModelUUID, rec.ID),
+		}}
+		if err := enc.Encode(action); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := enc.Encode(documentFromRecord(rec)); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// indexFor returns the name of the index the record is written to.
+func (client *Client) indexFor(rec logfwd.Record) string {
+	return strings.NewReplacer(
+		dateField, rec.Timestamp.UTC().Format("2006.01.02"),
+		modelField, rec.Origin.ModelUUID,
+	).Replace(client.config.index())
+}
+
+type bulkAction struct {
+	Index bulkIndex `json:"index"`
+}
+
+type bulkIndex struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
+type bulkResponse struct {
+	Errors bool       `json:"errors"`
+	Items  []bulkItem `json:"items"`
+}
+
+type bulkItem struct {
+	Index bulkItemResult `json:"index"`
+}
+
+type bulkItemResult struct {
+	Status int             `json:"status"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+// document is the JSON representation of a log record.
+type document struct {
+	Timestamp string   `json:"@timestamp"`
+	RecordID  int64    `json:"record-id"`
+	Level     string   `json:"level"`
+	Module    string   `json:"module,omitempty"`
+	Location  string   `json:"location,omitempty"`
+	Message   string   `json:"message"`
+	Origin    origin   `json:"origin"`
+	Software  software `json:"software"`
+}
+
+type origin struct {
+	ControllerUUID string `json:"controller-uuid"`
+	ModelUUID      string `json:"model-uuid"`
+	Hostname       string `json:"hostname,omitempty"`
+	Type           string `json:"type"`
+	Name           string `json:"name"`
+}
+
+type software struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+func documentFromRecord(rec logfwd.Record) document {
+	return document{
+		Timestamp: rec.Timestamp.UTC().Format(time.RFC3339Nano),
+		RecordID:  rec.ID,
+		Level:     rec.Level.String(),
+		Module:    rec.Location.Module,
+		Location:  rec.Location.String(),
+		Message:   rec.Message,
+		Origin: origin{
+			ControllerUUID: rec.Origin.ControllerUUID,
+			ModelUUID:      rec.Origin.ModelUUID,
+			Hostname:       rec.Origin.Hostname,
+			Type:           rec.Origin.Type.String(),
+			Name:           rec.Origin.Name,
+		},
+		Software: software{
+			Name:    rec.Origin.Software.Name,
+			Version: rec.Origin.Software.Version.String(),
+		},
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package elasticsearch_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/elasticsearch"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	mu       sync.Mutex
+	requests [][]map[string]interface{}
+	handler  func(w http.ResponseWriter, lines []map[string]interface{})
+	server   *httptest.Server
+	clock    *testing.Clock
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.handler = func(w http.ResponseWriter, lines []map[string]interface{}) {
+		fmt.Fprint(w, `{"errors":false,"items":[]}`)
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/_bulk")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+		var lines []map[string]interface{}
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			var line map[string]interface{}
+			c.Check(json.Unmarshal(scanner.Bytes(), &line), jc.ErrorIsNil)
+			lines = append(lines, line)
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, lines)
+		handler := s.handler
+		s.mu.Unlock()
+		handler(w, lines)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.clock = testing.NewClock(time.Now())
+}
+
+func (s *ClientSuite) open(c *gc.C, index string) *elasticsearch.Client {
+	client, err := elasticsearch.OpenForClient(elasticsearch.RawConfig{
+		Enabled: true,
+		URL:     s.server.URL + "/",
+		Index:   index,
+	}, http.DefaultClient, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func record(id int64) logfwd.Record {
+	return logfwd.Record{
+		ID: id,
+		Origin: logfwd.Origin{
+			ControllerUUID: coretesting.ControllerTag.Id(),
+			ModelUUID:      coretesting.ModelTag.Id(),
+			Hostname:       "machine-0.example.com",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "0",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("2.4.0"),
+			},
+		},
+		Timestamp: time.Date(2018, 6, 1, 12, 0, 0, int(id), time.UTC),
+		Level:     loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.foo",
+			Filename: "foo.go",
+			Line:     42,
+		},
+		Message: fmt.Sprintf("message %d", id),
+	}
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client := s.open(c, "")
+	err := client.Send([]logfwd.Record{record(1)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, jc.DeepEquals, [][]map[string]interface{}{{{
+		"index": map[string]interface{}{
+			"_index": "juju-2018.06.01",
+			"_id":    coretesting.ModelTag.Id() + "-1",
+		},
+	}, {
+		"@timestamp": "2018-06-01T12:00:00.000000001Z",
+		"record-id":  float64(1),
+		"level":      "INFO",
+		"module":     "juju.worker.foo",
+		"location":   "foo.go:42",
+		"message":    "message 1",
+		"origin": map[string]interface{}{
+			"controller-uuid": coretesting.ControllerTag.Id(),
+			"model-uuid":      coretesting.ModelTag.Id(),
+			"hostname":        "machine-0.example.com",
+			"type":            "machine",
+			"name":            "0",
+		},
+		"software": map[string]interface{}{
+			"name":    "jujud-machine-agent",
+			"version": "2.4.0",
+		},
+	}}})
+}
+
+func (s *ClientSuite) TestSendIndexFields(c *gc.C) {
+	client := s.open(c, "logs-{model-uuid}-{date}")
+	err := client.Send([]logfwd.Record{record(1)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0][0]["index"], jc.DeepEquals, map[string]interface{}{
+		"_index": "logs-" + coretesting.ModelTag.Id() + "-2018.06.01",
+		"_id":    coretesting.ModelTag.Id() + "-1",
+	})
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	records := make([]logfwd.Record, 501)
+	for i := range records {
+		records[i] = record(int64(i))
+	}
+	client := s.open(c, "")
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[0], gc.HasLen, 1000)
+	c.Assert(s.requests[1], gc.HasLen, 2)
+}
+
+func (s *ClientSuite) TestSendRetriesRejectedItems(c *gc.C) {
+	attempt := 0
+	s.handler = func(w http.ResponseWriter, lines []map[string]interface{}) {
+		attempt++
+		if attempt == 1 {
+			fmt.Fprint(w, `{"errors":true,"items":[`+
+				`{"index":{"status":201}},`+
+				`{"index":{"status":429,"error":{"type":"es_rejected_execution_exception"}}},`+
+				`{"index":{"status":400,"error":{"type":"mapper_parsing_exception"}}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"errors":false,"items":[{"index":{"status":201}}]}`)
+	}
+	client := s.open(c, "")
+	done := make(chan error)
+	go func() {
+		done <- client.Send([]logfwd.Record{record(1), record(2), record(3)})
+	}()
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for send")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.Assert(s.requests, gc.HasLen, 2)
+	// Only the record rejected with 429 is sent again.
+	c.Assert(s.requests[1], gc.HasLen, 2)
+	c.Assert(s.requests[1][1]["message"], gc.Equals, "message 2")
+}
+
+func (s *ClientSuite) TestSendGivesUp(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, lines []map[string]interface{}) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	}
+	client := s.open(c, "")
+	done := make(chan error)
+	go func() {
+		done <- client.Send([]logfwd.Record{record(1)})
+	}()
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second} {
+		c.Assert(s.clock.WaitAdvance(delay, coretesting.LongWait, 1), jc.ErrorIsNil)
+	}
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, `sending records to http://.*/_bulk: bulk request failed with 503 Service Unavailable: overloaded`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for send")
+	}
+	c.Assert(s.requests, gc.HasLen, 5)
+}
+
+func (s *ClientSuite) TestSendNotRetryable(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, lines []map[string]interface{}) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}
+	client := s.open(c, "")
+	err := client.Send([]logfwd.Record{record(1)})
+	c.Assert(err, gc.ErrorMatches, `sending records to http://.*/_bulk: bulk request failed with 403 Forbidden: forbidden`)
+	c.Assert(s.requests, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestErrorOmitsCredentials(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, lines []map[string]interface{}) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}
+	client, err := elasticsearch.OpenForClient(elasticsearch.RawConfig{
+		Enabled: true,
+		URL:     "http://user:secret@" + s.server.Listener.Addr().String(),
+	}, http.DefaultClient, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Send([]logfwd.Record{record(1)})
+	c.Assert(err, gc.NotNil)
+	c.Assert(err.Error(), gc.Not(jc.Contains), "secret")
+}
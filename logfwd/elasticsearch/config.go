@@ -0,0 +1,141 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package elasticsearch
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+const (
+	// DefaultIndex is the index that records are written to when
+	// none is configured.
+	DefaultIndex = "juju-{date}"
+
+	// dateField is replaced in the index name with the UTC date of
+	// the record being written.
+	dateField = "{date}"
+
+	// modelField is replaced in the index name with the UUID of the
+	// model the record being written came from.
+	modelField = "{model-uuid}"
+)
+
+// RawConfig holds the raw configuration data for a connection to an
+// Elasticsearch log forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// URL is the base URL of the Elasticsearch endpoint, for example
+	// https://elastic.example.com:9200. Records are posted to its
+	// _bulk endpoint. Credentials for basic authentication may be
+	// included in the URL.
+	URL string
+
+	// Index is the name of the index that records are written to.
+	// Any "{date}" in the name is replaced with the UTC date of the
+	// record, formatted as YYYY.MM.DD, and any "{model-uuid}" with
+	// the UUID of the model the record came from. If empty,
+	// DefaultIndex is used.
+	Index string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If
+	// empty, the system roots are used.
+	CACert string
+
+	// ClientCert is the TLS certificate (x.509, PEM-encoded) to use
+	// when connecting, if any.
+	ClientCert string
+
+	// ClientKey is the TLS private key (x.509, PEM-encoded) to use
+	// when connecting, if any.
+	ClientKey string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateIndex(cfg.index()); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := cfg.tlsConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	return nil
+}
+
+// index returns the configured index name, or the default.
+func (cfg RawConfig) index() string {
+	if cfg.Index == "" {
+		return DefaultIndex
+	}
+	return cfg.Index
+}
+
+// validateIndex checks that the index name, once its fields have been
+// replaced, follows the Elasticsearch rules for index names.
+func validateIndex(index string) error {
+	name := strings.NewReplacer(
+		dateField, "2006.01.02",
+		modelField, "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	).Replace(index)
+	if name == "" || name == "." || name == ".." {
+		return errors.NotValidf("index %q", index)
+	}
+	if name != strings.ToLower(name) {
+		return errors.NotValidf("index %q with upper case characters", index)
+	}
+	if strings.ContainsAny(name[:1], "-_+") || strings.ContainsAny(name, `\/*?"<>| ,#:`) {
+		return errors.NotValidf("index %q", index)
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS configuration to use for the connection,
+// or nil if the defaults should be used.
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" && cfg.ClientCert == "" && cfg.ClientKey == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{}
+	if cfg.CACert != "" {
+		caCert, err := cert.ParseCert(cfg.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AddCert(caCert)
+	}
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,7 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The elasticsearch package holds the tools needed to perform log
+// forwarding from Juju to an HTTP endpoint that implements the
+// Elasticsearch bulk API.
+package elasticsearch
@@ -14,6 +14,8 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -25,6 +27,11 @@ import (
 type PrecheckBackend interface {
 	AgentVersion() (version.Number, error)
 	NeedsCleanup() (bool, error)
+	HasUnreleasedBatchActions() (bool, error)
+	HasActionSchedules() (bool, error)
+	HasActionOutputs() (bool, error)
+	AllRoles() ([]permission.Role, error)
+	GetApplicationUsers(appName string) (map[string]string, error)
 	Model() (PrecheckModel, error)
 	AllModelUUIDs() ([]string, error)
 	IsUpgrading() (bool, error)
@@ -74,6 +81,7 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	EgressRules() []network.EgressRule
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -142,6 +150,40 @@ func SourcePrecheck(
 		return errors.New("cleanup needed")
 	}
 
+	// Action batches are not part of the model description; the
+	// actions they have yet to release would all be released at once
+	// in the target model, ignoring the batch's limits.
+	if unreleased, err := backend.HasUnreleasedBatchActions(); err != nil {
+		return errors.Annotate(err, "checking action batches")
+	} else if unreleased {
+		return errors.New("model has action batches with unreleased actions, which cannot be migrated")
+	}
+
+	// Action schedules are not part of the model description, and
+	// would silently stop running in the target model.
+	if scheduled, err := backend.HasActionSchedules(); err != nil {
+		return errors.Annotate(err, "checking action schedules")
+	} else if scheduled {
+		return errors.New("model has action schedules, which cannot be migrated")
+	}
+
+	// Nor is the overflow output of actions held in blob storage;
+	// those actions would be left without their results.
+	if overflowed, err := backend.HasActionOutputs(); err != nil {
+		return errors.Annotate(err, "checking action outputs")
+	} else if overflowed {
+		return errors.New("model has actions with results held in blob storage, which cannot be migrated")
+	}
+
+	// Roles are not part of the model description, and neither is
+	// the access to applications granted with them; see also the
+	// application checks.
+	if roles, err := backend.AllRoles(); err != nil {
+		return errors.Annotate(err, "checking roles")
+	} else if len(roles) > 0 {
+		return errors.New("model has roles, which cannot be migrated")
+	}
+
 	// Check the source controller.
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
@@ -341,6 +383,19 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 		if app.Life() != state.Alive {
 			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
+		// Egress rules are not part of the model description, so
+		// the application would lose them when migrated.
+		if len(app.EgressRules()) > 0 {
+			return nil, errors.Errorf("application %s has egress rules, which cannot be migrated", app.Name())
+		}
+		// Neither is access granted to users on the application.
+		users, err := ctx.backend.GetApplicationUsers(app.Name())
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving users for %s", app.Name())
+		}
+		if len(users) > 0 {
+			return nil, errors.Errorf("application %s has users granted access to it, which cannot be migrated", app.Name())
+		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
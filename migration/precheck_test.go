@@ -14,6 +14,8 @@ import (
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
@@ -107,6 +109,65 @@ func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cleanup needed")
 }
 
+func (*SourcePrecheckSuite) TestActionBatchesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.unreleasedBatchActionsErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking action batches: boom")
+}
+
+func (*SourcePrecheckSuite) TestUnreleasedBatchActions(c *gc.C) {
+	backend := newFakeBackend()
+	backend.unreleasedBatchActions = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has action batches with unreleased actions, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestActionSchedulesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionSchedulesErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking action schedules: boom")
+}
+
+func (*SourcePrecheckSuite) TestActionSchedules(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionSchedules = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has action schedules, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestActionOutputsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionOutputsErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking action outputs: boom")
+}
+
+func (*SourcePrecheckSuite) TestActionOutputs(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionOutputs = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has actions with results held in blob storage, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestRolesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.rolesErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking roles: boom")
+}
+
+func (*SourcePrecheckSuite) TestRoles(c *gc.C) {
+	backend := newFakeBackend()
+	backend.roles = []permission.Role{{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Action.Enqueue"},
+	}}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has roles, which cannot be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.isUpgradingErr = errors.New("boom")
@@ -190,6 +251,39 @@ func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is below its minimum units threshold")
 }
 
+func (s *SourcePrecheckSuite) TestApplicationWithEgressRules(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:        "foo",
+				egressRules: []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has egress rules, which cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationUsersError(c *gc.C) {
+	backend := &fakeBackend{
+		apps:                []migration.PrecheckApplication{&fakeApp{name: "foo"}},
+		applicationUsersErr: errors.New("boom"),
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving users for foo: boom")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationWithUsers(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{&fakeApp{name: "foo"}},
+		applicationUsers: map[string]map[string]string{
+			"foo": {"bob": "operator"},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has users granted access to it, which cannot be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestUnitVersionsDontMatch(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -742,6 +836,21 @@ type fakeBackend struct {
 	cleanupNeeded bool
 	cleanupErr    error
 
+	unreleasedBatchActions    bool
+	unreleasedBatchActionsErr error
+
+	actionSchedules    bool
+	actionSchedulesErr error
+
+	actionOutputs    bool
+	actionOutputsErr error
+
+	roles    []permission.Role
+	rolesErr error
+
+	applicationUsers    map[string]map[string]string
+	applicationUsersErr error
+
 	isUpgrading    bool
 	isUpgradingErr error
 
@@ -778,6 +887,26 @@ func (b *fakeBackend) NeedsCleanup() (bool, error) {
 	return b.cleanupNeeded, b.cleanupErr
 }
 
+func (b *fakeBackend) HasUnreleasedBatchActions() (bool, error) {
+	return b.unreleasedBatchActions, b.unreleasedBatchActionsErr
+}
+
+func (b *fakeBackend) HasActionSchedules() (bool, error) {
+	return b.actionSchedules, b.actionSchedulesErr
+}
+
+func (b *fakeBackend) HasActionOutputs() (bool, error) {
+	return b.actionOutputs, b.actionOutputsErr
+}
+
+func (b *fakeBackend) AllRoles() ([]permission.Role, error) {
+	return b.roles, b.rolesErr
+}
+
+func (b *fakeBackend) GetApplicationUsers(appName string) (map[string]string, error) {
+	return b.applicationUsers[appName], b.applicationUsersErr
+}
+
 func (b *fakeBackend) AgentVersion() (version.Number, error) {
 	return backendVersion, b.agentVersionErr
 }
@@ -934,11 +1063,12 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 }
 
 type fakeApp struct {
-	name     string
-	life     state.Life
-	charmURL string
-	units    []migration.PrecheckUnit
-	minunits int
+	name        string
+	life        state.Life
+	charmURL    string
+	units       []migration.PrecheckUnit
+	minunits    int
+	egressRules []network.EgressRule
 }
 
 func (a *fakeApp) Name() string {
@@ -965,6 +1095,10 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) EgressRules() []network.EgressRule {
+	return a.egressRules
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
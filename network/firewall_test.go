@@ -72,3 +72,27 @@ func (*FirewallSuite) TestNewIngressRuleBadCIDR(c *gc.C) {
 	_, err := network.NewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.0/24")
 	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
 }
+
+func (*FirewallSuite) TestEgressRuleStrings(c *gc.C) {
+	rule := network.MustNewEgressRule("tcp", 443, 443)
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+	c.Assert(rule.GoString(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("tcp", 8000, 8080, "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(rule.String(), gc.Equals, "8000-8080/tcp to 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*FirewallSuite) TestSortEgressRules(c *gc.C) {
+	rule1 := network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32")
+	rule2 := network.MustNewEgressRule("tcp", 443, 443, "192.168.1.0/24")
+	rule3 := network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8")
+
+	rules := []network.EgressRule{rule1, rule2, rule3}
+	network.SortEgressRules(rules)
+	c.Assert(rules, gc.DeepEquals, []network.EgressRule{rule3, rule2, rule1})
+}
+
+func (*FirewallSuite) TestNewEgressRuleBadCIDR(c *gc.C) {
+	_, err := network.NewEgressRule("tcp", 443, 443, "10.0/8")
+	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 10.0/8")
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package netfilter provides a common interface to the iptables and
+// nftables packages, which render the commands that manage the
+// firewall rules Juju adds to a machine, and detects which of them a
+// machine should use.
+package netfilter
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/iptables"
+	"github.com/juju/juju/network/nftables"
+)
+
+const (
+	// IPTables is the name of the iptables backend.
+	IPTables = "iptables"
+
+	// NFTables is the name of the nftables backend.
+	NFTables = "nftables"
+)
+
+// Backend renders the shell commands that manage the firewall rules
+// Juju adds to a machine, and parses the ingress rules they list.
+type Backend interface {
+	// Name returns the name of the backend, IPTables or NFTables.
+	Name() string
+
+	// DropCommand returns the command that drops new connections to
+	// the destination address, or arriving on the interface.
+	DropCommand(destinationAddress, iface string) string
+
+	// AcceptInternalCommand returns the command that accepts
+	// connections to the destination address and port.
+	AcceptInternalCommand(protocol, destinationAddress string, destinationPort int) string
+
+	// IngressRuleCommand returns the command that adds, or deletes,
+	// the rules accepting packets to the destination address that
+	// match the ingress rule.
+	IngressRuleCommand(rule network.IngressRule, destinationAddress string, delete bool) string
+
+	// ListIngressRulesCommand returns the command that lists the
+	// rules parsed by ParseIngressRules.
+	ListIngressRulesCommand() string
+
+	// ParseIngressRules parses the output of the command returned
+	// by ListIngressRulesCommand.
+	ParseIngressRules(r io.Reader) ([]network.IngressRule, error)
+}
+
+// DetectCommand is a shell command that prints the name of the backend
+// a machine should use. That is nftables if iptables is not installed,
+// or if the machine has an nftables ruleset and iptables is the legacy
+// iptables, whose rules would be applied alongside the nftables ones
+// rather than with them. Otherwise, it is iptables, which covers
+// iptables implemented with nftables too.
+const DetectCommand = `
+if ! command -v iptables >/dev/null 2>&1; then
+    echo nftables
+elif iptables --version 2>/dev/null | grep -q nf_tables; then
+    echo iptables
+elif command -v nft >/dev/null 2>&1 && [ -n "$(sudo nft list ruleset 2>/dev/null)" ]; then
+    echo nftables
+else
+    echo iptables
+fi
+`
+
+// NewBackend returns the backend with the given name.
+func NewBackend(name string) (Backend, error) {
+	switch name {
+	case IPTables:
+		return iptablesBackend{}, nil
+	case NFTables:
+		return nftablesBackend{}, nil
+	}
+	return nil, errors.NotValidf("firewall backend %q", name)
+}
+
+// Detect runs DetectCommand with the given function, which runs a
+// shell command on the machine and returns its output, and returns the
+// backend the machine should use.
+func Detect(runCommand func(string) (string, error)) (Backend, error) {
+	output, err := runCommand(DetectCommand)
+	if err != nil {
+		return nil, errors.Annotate(err, "detecting firewall backend")
+	}
+	backend, err := NewBackend(strings.TrimSpace(output))
+	if err != nil {
+		return nil, errors.Annotate(err, "detecting firewall backend")
+	}
+	return backend, nil
+}
+
+type iptablesBackend struct{}
+
+func (iptablesBackend) Name() string {
+	return IPTables
+}
+
+func (iptablesBackend) DropCommand(destinationAddress, iface string) string {
+	return iptables.DropCommand{
+		DestinationAddress: destinationAddress,
+		Interface:          iface,
+	}.Render()
+}
+
+func (iptablesBackend) AcceptInternalCommand(protocol, destinationAddress string, destinationPort int) string {
+	return iptables.AcceptInternalCommand{
+		Protocol:           protocol,
+		DestinationAddress: destinationAddress,
+		DestinationPort:    destinationPort,
+	}.Render()
+}
+
+func (iptablesBackend) IngressRuleCommand(rule network.IngressRule, destinationAddress string, delete bool) string {
+	return iptables.IngressRuleCommand{
+		Rule:               rule,
+		DestinationAddress: destinationAddress,
+		Delete:             delete,
+	}.Render()
+}
+
+func (iptablesBackend) ListIngressRulesCommand() string {
+	return "sudo iptables -L INPUT -n"
+}
+
+func (iptablesBackend) ParseIngressRules(r io.Reader) ([]network.IngressRule, error) {
+	return iptables.ParseIngressRules(r)
+}
+
+type nftablesBackend struct{}
+
+func (nftablesBackend) Name() string {
+	return NFTables
+}
+
+func (nftablesBackend) DropCommand(destinationAddress, iface string) string {
+	return nftables.DropCommand{
+		DestinationAddress: destinationAddress,
+		Interface:          iface,
+	}.Render()
+}
+
+func (nftablesBackend) AcceptInternalCommand(protocol, destinationAddress string, destinationPort int) string {
+	return nftables.AcceptInternalCommand{
+		Protocol:           protocol,
+		DestinationAddress: destinationAddress,
+		DestinationPort:    destinationPort,
+	}.Render()
+}
+
+func (nftablesBackend) IngressRuleCommand(rule network.IngressRule, destinationAddress string, delete bool) string {
+	return nftables.IngressRuleCommand{
+		Rule:               rule,
+		DestinationAddress: destinationAddress,
+		Delete:             delete,
+	}.Render()
+}
+
+func (nftablesBackend) ListIngressRulesCommand() string {
+	return nftables.ListCommand{}.Render()
+}
+
+func (nftablesBackend) ParseIngressRules(r io.Reader) ([]network.IngressRule, error) {
+	return nftables.ParseIngressRules(r)
+}
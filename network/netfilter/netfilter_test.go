@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package netfilter_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/iptables"
+	"github.com/juju/juju/network/netfilter"
+	"github.com/juju/juju/network/nftables"
+)
+
+type NetfilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&NetfilterSuite{})
+
+func (*NetfilterSuite) TestNewBackendNotValid(c *gc.C) {
+	_, err := netfilter.NewBackend("ipchains")
+	c.Assert(err, gc.ErrorMatches, `firewall backend "ipchains" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (*NetfilterSuite) TestDetect(c *gc.C) {
+	for _, name := range []string{netfilter.IPTables, netfilter.NFTables} {
+		backend, err := netfilter.Detect(func(command string) (string, error) {
+			c.Check(command, gc.Equals, netfilter.DetectCommand)
+			return name + "\n", nil
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(backend.Name(), gc.Equals, name)
+	}
+}
+
+func (*NetfilterSuite) TestDetectError(c *gc.C) {
+	_, err := netfilter.Detect(func(string) (string, error) {
+		return "", errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "detecting firewall backend: boom")
+}
+
+func (*NetfilterSuite) TestDetectUnexpectedOutput(c *gc.C) {
+	_, err := netfilter.Detect(func(string) (string, error) {
+		return "sudo: a password is required\n", nil
+	})
+	c.Assert(err, gc.ErrorMatches, `detecting firewall backend: firewall backend "sudo: a password is required" not valid`)
+}
+
+func (*NetfilterSuite) TestIPTables(c *gc.C) {
+	backend, err := netfilter.NewBackend(netfilter.IPTables)
+	c.Assert(err, jc.ErrorIsNil)
+	rule := network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24")
+	c.Check(backend.DropCommand("1.2.3.4", "eth0"), gc.Equals,
+		iptables.DropCommand{DestinationAddress: "1.2.3.4", Interface: "eth0"}.Render())
+	c.Check(backend.AcceptInternalCommand("tcp", "1.2.3.4", 17070), gc.Equals,
+		iptables.AcceptInternalCommand{Protocol: "tcp", DestinationAddress: "1.2.3.4", DestinationPort: 17070}.Render())
+	c.Check(backend.IngressRuleCommand(rule, "1.2.3.4", true), gc.Equals,
+		iptables.IngressRuleCommand{Rule: rule, DestinationAddress: "1.2.3.4", Delete: true}.Render())
+	c.Check(backend.ListIngressRulesCommand(), gc.Equals, "sudo iptables -L INPUT -n")
+
+	rules, err := backend.ParseIngressRules(strings.NewReader(
+		"ACCEPT     tcp  --  10.0.0.0/24          0.0.0.0/0            tcp dpt:80 /* juju ingress */\n",
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{rule})
+}
+
+func (*NetfilterSuite) TestNFTables(c *gc.C) {
+	backend, err := netfilter.NewBackend(netfilter.NFTables)
+	c.Assert(err, jc.ErrorIsNil)
+	rule := network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24")
+	c.Check(backend.DropCommand("1.2.3.4", "eth0"), gc.Equals,
+		nftables.DropCommand{DestinationAddress: "1.2.3.4", Interface: "eth0"}.Render())
+	c.Check(backend.AcceptInternalCommand("tcp", "1.2.3.4", 17070), gc.Equals,
+		nftables.AcceptInternalCommand{Protocol: "tcp", DestinationAddress: "1.2.3.4", DestinationPort: 17070}.Render())
+	c.Check(backend.IngressRuleCommand(rule, "1.2.3.4", true), gc.Equals,
+		nftables.IngressRuleCommand{Rule: rule, DestinationAddress: "1.2.3.4", Delete: true}.Render())
+	c.Check(backend.ListIngressRulesCommand(), gc.Equals, nftables.ListCommand{}.Render())
+
+	rules, err := backend.ParseIngressRules(strings.NewReader(
+		`ip saddr 10.0.0.0/24 tcp dport 80 accept comment "juju ingress 12345678"` + "\n",
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{rule})
+}
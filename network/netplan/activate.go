@@ -3,6 +3,7 @@ package netplan
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -31,10 +32,15 @@ type ActivationResult struct {
 	Code   int
 }
 
+// bridgeWaitSeconds is how long to wait for the kernel to create the new
+// bridges after the netplan configuration has been applied.
+const bridgeWaitSeconds = 30
+
 // BridgeAndActivate will parse a set of netplan yaml files in a directory,
 // create a new netplan config with the provided interfaces bridged
-// bridged, then reconfigure the network using the ifupdown package
-// for the new bridges.
+// bridged, then reconfigure the network using netplan apply, waiting
+// for the new bridges to appear. If the activation fails, the original
+// netplan config is restored and applied again.
 func BridgeAndActivate(params ActivationParams) (*ActivationResult, error) {
 	if len(params.Devices) == 0 {
 		return nil, errors.Errorf("no devices specified")
@@ -46,6 +52,8 @@ func BridgeAndActivate(params ActivationParams) (*ActivationResult, error) {
 		return nil, err
 	}
 
+	bridgesBefore := len(netplan.Network.Bridges)
+	var bridgeNames []string
 	for _, device := range params.Devices {
 		var deviceId string
 		deviceId, deviceType, err := netplan.FindDeviceByNameOrMAC(device.DeviceName, device.MACAddress)
@@ -71,7 +79,13 @@ func BridgeAndActivate(params ActivationParams) (*ActivationResult, error) {
 		default:
 			return nil, errors.Errorf("unable to create bridge for %q, unknown device type %q", deviceId, deviceType)
 		}
+		bridgeNames = append(bridgeNames, device.BridgeName)
+	}
+	if len(netplan.Network.Bridges) == bridgesBefore {
+		logger.Infof("devices already bridged, not changing netplan configuration")
+		return nil, nil
 	}
+
 	_, err = netplan.Write("")
 	if err != nil {
 		return nil, err
@@ -83,12 +97,16 @@ func BridgeAndActivate(params ActivationParams) (*ActivationResult, error) {
 		return nil, err
 	}
 
-	environ := os.Environ()
-	// TODO(wpk) 2017-06-21 Is there a way to verify that apply is finished?
-	// https://bugs.launchpad.net/netplan/+bug/1701436
-	command := fmt.Sprintf("%snetplan generate && netplan apply && sleep 10", params.RunPrefix)
+	// netplan apply can return before the devices it configures exist
+	// (https://bugs.launchpad.net/netplan/+bug/1701436), so wait for the
+	// bridges to appear before declaring success.
+	command := fmt.Sprintf("%snetplan generate && netplan apply && %s", params.RunPrefix, waitForDevicesCommand(bridgeNames))
 
-	result, err := scriptrunner.RunCommand(command, environ, params.Clock, params.Timeout)
+	result, err := scriptrunner.RunCommand(command, os.Environ(), params.Clock, params.Timeout)
+	if result == nil {
+		rollback(&netplan, params)
+		return nil, errors.Errorf("bridge activation error: %s", err)
+	}
 
 	activationResult := ActivationResult{
 		Stderr: string(result.Stderr),
@@ -99,12 +117,39 @@ func BridgeAndActivate(params ActivationParams) (*ActivationResult, error) {
 	logger.Debugf("Netplan activation result %q %q %d", result.Stderr, result.Stdout, result.Code)
 
 	if err != nil {
-		netplan.Rollback()
+		rollback(&netplan, params)
 		return &activationResult, errors.Errorf("bridge activation error: %s", err)
 	}
 	if result.Code != 0 {
-		netplan.Rollback()
+		rollback(&netplan, params)
 		return &activationResult, errors.Errorf("bridge activation error code %d", result.Code)
 	}
 	return nil, nil
 }
+
+// waitForDevicesCommand returns a shell command that waits for each of
+// the named network devices to exist, failing if any of them does not
+// appear within bridgeWaitSeconds.
+func waitForDevicesCommand(deviceNames []string) string {
+	return fmt.Sprintf(
+		`for dev in %s; do n=0; until [ -e "/sys/class/net/$dev" ]; do n=$((n+1)); `+
+			`if [ $n -gt %d ]; then echo "device $dev did not appear" >&2; exit 1; fi; sleep 1; done; done`,
+		strings.Join(deviceNames, " "), bridgeWaitSeconds,
+	)
+}
+
+// rollback restores the netplan configuration that was in place before
+// bridging and applies it again, so that a failed activation does not
+// leave the host with a partially applied configuration.
+func rollback(np *Netplan, params ActivationParams) {
+	np.Rollback()
+	command := fmt.Sprintf("%snetplan generate && netplan apply", params.RunPrefix)
+	result, err := scriptrunner.RunCommand(command, os.Environ(), params.Clock, params.Timeout)
+	if err != nil {
+		logger.Errorf("cannot reapply netplan configuration after failed bridge activation: %v", err)
+		return
+	}
+	if result.Code != 0 {
+		logger.Errorf("cannot reapply netplan configuration after failed bridge activation: exit code %d: %s", result.Code, result.Stderr)
+	}
+}
@@ -1,6 +1,7 @@
 package netplan_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"path"
 	"strings"
@@ -159,6 +160,65 @@ func (s *ActivateSuite) TestActivateFailure(c *gc.C) {
 	c.Check(yamlCount, gc.Equals, len(files))
 }
 
+func (s *ActivateSuite) TestActivateFailureReappliesConfig(c *gc.C) {
+	coretesting.SkipIfWindowsBug(c, "lp:1771077")
+	tempDir := c.MkDir()
+	runs := path.Join(c.MkDir(), "runs")
+	params := netplan.ActivationParams{
+		Devices: []netplan.DeviceToBridge{
+			{
+				DeviceName: "eno1",
+				MACAddress: "00:11:22:33:44:55",
+				BridgeName: "br-eno1",
+			},
+		},
+		Directory: tempDir,
+		RunPrefix: fmt.Sprintf(`ls %s | grep -c juju.yaml >> %s; exit 1 && `, tempDir, runs),
+	}
+	for _, file := range []string{"00.yaml", "01.yaml"} {
+		content, err := ioutil.ReadFile(path.Join("testdata/TestReadWriteBackup", file))
+		c.Assert(err, jc.ErrorIsNil)
+		err = ioutil.WriteFile(path.Join(tempDir, file), content, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err := netplan.BridgeAndActivate(params)
+	c.Check(err, gc.ErrorMatches, "bridge activation error code 1")
+
+	// The bridged config was applied first, then the original config
+	// was restored and applied again.
+	content, err := ioutil.ReadFile(runs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "1\n0\n")
+}
+
+func (s *ActivateSuite) TestActivateAlreadyBridged(c *gc.C) {
+	tempDir := c.MkDir()
+	params := netplan.ActivationParams{
+		Devices: []netplan.DeviceToBridge{
+			{
+				DeviceName: "id2",
+				BridgeName: "some-bridge",
+			},
+		},
+		Directory: tempDir,
+		RunPrefix: "exit 1 && ",
+	}
+	content, err := ioutil.ReadFile("testdata/TestReadWriteBackup/01.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path.Join(tempDir, "01.yaml"), content, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := netplan.BridgeAndActivate(params)
+	c.Check(result, gc.IsNil)
+	c.Check(err, jc.ErrorIsNil)
+
+	// Nothing was changed.
+	fileInfos, err := ioutil.ReadDir(tempDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fileInfos, gc.HasLen, 1)
+	c.Check(fileInfos[0].Name(), gc.Equals, "01.yaml")
+}
+
 func (s *ActivateSuite) TestActivateTimeout(c *gc.C) {
 	//	coretesting.SkipIfWindowsBug(c, "lp:1771077")
 	tempDir := c.MkDir()
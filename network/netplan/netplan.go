@@ -346,6 +346,22 @@ func ReadDirectory(dirPath string) (np Netplan, err error) {
 	return np, nil
 }
 
+// IsManaged reports whether the netplan configuration in the given
+// directory defines any network devices, which means that netplan is
+// managing the host's network.
+func IsManaged(dirPath string) (bool, error) {
+	np, err := ReadDirectory(dirPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	network := np.Network
+	devices := len(network.Ethernets) + len(network.Wifis) + len(network.Bridges) + len(network.Bonds) + len(network.VLANs)
+	return devices > 0, nil
+}
+
 // MoveYamlsToBak moves source .yaml files in a directory to .yaml.bak.(timestamp), except
 func (np *Netplan) MoveYamlsToBak() (err error) {
 	if np.backedFiles != nil {
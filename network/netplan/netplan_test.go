@@ -1493,6 +1493,37 @@ func (s *NetplanSuite) TestReadDirectoryBrokenYaml(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
 }
 
+func (s *NetplanSuite) TestIsManaged(c *gc.C) {
+	managed, err := netplan.IsManaged("testdata/TestReadWriteBackup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(managed, jc.IsTrue)
+}
+
+func (s *NetplanSuite) TestIsManagedNoDevices(c *gc.C) {
+	tempDir := c.MkDir()
+	err := ioutil.WriteFile(path.Join(tempDir, "00-file.yaml"), []byte("network:\n  version: 2\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	managed, err := netplan.IsManaged(tempDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(managed, jc.IsFalse)
+}
+
+func (s *NetplanSuite) TestIsManagedMissingDirectory(c *gc.C) {
+	tempDir := c.MkDir()
+	os.RemoveAll(tempDir)
+	managed, err := netplan.IsManaged(tempDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(managed, jc.IsFalse)
+}
+
+func (s *NetplanSuite) TestIsManagedBrokenYaml(c *gc.C) {
+	tempDir := c.MkDir()
+	err := ioutil.WriteFile(path.Join(tempDir, "00-file.yaml"), []byte("I am not a yaml file!\nreally!\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = netplan.IsManaged(tempDir)
+	c.Check(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
+}
+
 func (s *NetplanSuite) TestWritePermissionDenied(c *gc.C) {
 	coretesting.SkipIfWindowsBug(c, "lp:1771077")
 	tempDir := c.MkDir()
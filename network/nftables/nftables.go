@@ -0,0 +1,278 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package nftables renders the nftables commands that manage the
+// firewall rules Juju adds to a machine, and parses the rules they list.
+//
+// Juju's rules are kept in a base chain of their own, in Table, rather
+// than in the machine's existing chains, whose names and layout are up
+// to whoever configured the machine. The chain's policy is accept, so it
+// never drops anything other than what Juju's own rules drop.
+//
+// In nftables, unlike a drop, an accept verdict only ends the chain it's
+// in: a packet accepted by Juju's chain is still passed to the machine's
+// other input chains, which could drop it. So Juju's chain runs first,
+// and its accept rules mark the packets they accept with AcceptMark.
+// A rule accepting marked packets is inserted at the top of each of the
+// machine's own input filter chains, so that ports Juju opens are open
+// whatever the machine's ruleset would otherwise do with them, as they
+// are when Juju inserts its rules at the top of the iptables INPUT chain.
+package nftables
+
+import (
+	"fmt"
+	"hash/crc32"
+	"net"
+	"strings"
+
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/network"
+)
+
+var logger = loggo.GetLogger("juju.network.nftables")
+
+const (
+	// Table is the name of the inet table that holds the rules
+	// Juju adds.
+	Table = "juju"
+
+	// Chain is the name of the base chain in Table that filters
+	// incoming packets.
+	Chain = "input"
+
+	// ChainPriority is the priority of Chain. It's lower than that
+	// of the standard filter chains, so that Juju's rules are
+	// evaluated first.
+	ChainPriority = -10
+
+	// AcceptMark is the packet mark bit set on packets accepted by
+	// Juju's rules. The machine's own input chains accept packets
+	// with it set.
+	AcceptMark = "0x4a550000"
+
+	nftablesAcceptedComment = "juju accepted"
+
+	nftablesIngressComment = "juju ingress"
+
+	nftablesInternalComment = "juju internal"
+)
+
+// ensureChainCommand returns the command that creates Juju's table and
+// chain if they do not already exist, and makes sure the machine's own
+// input chains accept the packets Juju's rules accept. The chain
+// accepts whatever its rules don't drop, leaving the machine's own
+// chains to filter it.
+func ensureChainCommand() string {
+	return fmt.Sprintf(
+		"sudo nft add table inet %[1]s && sudo nft add chain inet %[1]s %[2]s '{ type filter hook input priority %[3]d; policy accept; }' && %[4]s",
+		Table, Chain, ChainPriority, acceptMarkedCommand(),
+	)
+}
+
+// acceptMarkedCommand returns the command that inserts a rule
+// accepting packets marked by Juju's rules at the top of every input
+// filter chain of the machine's own ip, ip6 and inet tables, unless
+// it's there already. Chains added to the machine's ruleset later get
+// the rule the next time any of Juju's rules are changed.
+func acceptMarkedCommand() string {
+	listChains := fmt.Sprintf(
+		`sudo nft list chains | awk '$1 == "table" { family = $2; table = $3 } $1 == "chain" { chain = $2 } `+
+			`/type filter hook input / && (family == "ip" || family == "ip6" || family == "inet") && !(family == "inet" && table == "%s") `+
+			`{ print family, table, chain }'`,
+		Table,
+	)
+	return fmt.Sprintf(
+		`%s | while read family table chain; do `+
+			`sudo nft list chain $family $table $chain | grep -qF 'comment "%[2]s"' || `+
+			`sudo nft insert rule $family $table $chain meta mark and %[3]s == %[3]s accept comment '"%[2]s"' || exit 1; done`,
+		listChains, nftablesAcceptedComment, AcceptMark,
+	)
+}
+
+// ruleCommand returns the command that adds a rule made up of the given
+// statements to Juju's chain, inserting it before any existing rules.
+func ruleCommand(statements ...string) string {
+	return fmt.Sprintf("sudo nft insert rule inet %s %s %s", Table, Chain, strings.Join(statements, " "))
+}
+
+// accept returns the statements that accept a packet, marking it so
+// that the machine's own chains accept it too.
+func accept() []string {
+	return []string{"meta mark set meta mark or", AcceptMark, "accept"}
+}
+
+func comment(text string) string {
+	return fmt.Sprintf(`comment '"%s"'`, text)
+}
+
+// addressFamily returns the nftables payload protocol, "ip" or "ip6",
+// matching the family of the given address or CIDR.
+func addressFamily(address string) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		ip, _, _ = net.ParseCIDR(address)
+	}
+	if ip != nil && ip.To4() == nil {
+		return "ip6"
+	}
+	return "ip"
+}
+
+type DropCommand struct {
+	DestinationAddress string
+	Interface          string
+}
+
+func (c DropCommand) Render() string {
+	var statements []string
+	if c.Interface != "" {
+		statements = append(statements, "iifname", c.Interface)
+	}
+	if c.DestinationAddress != "" {
+		statements = append(statements, addressFamily(c.DestinationAddress), "daddr", c.DestinationAddress)
+	}
+	statements = append(statements, "ct state new drop", comment(nftablesInternalComment))
+	return ensureChainCommand() + " && " + ruleCommand(statements...)
+}
+
+type AcceptInternalCommand struct {
+	DestinationAddress string
+	DestinationPort    int
+	Protocol           string
+}
+
+func (c AcceptInternalCommand) Render() string {
+	var statements []string
+	if c.DestinationAddress != "" {
+		statements = append(statements, addressFamily(c.DestinationAddress), "daddr", c.DestinationAddress)
+	}
+	if c.Protocol != "" {
+		if c.DestinationPort > 0 {
+			statements = append(statements, c.Protocol, "dport", fmt.Sprint(c.DestinationPort))
+		} else {
+			statements = append(statements, "meta l4proto", c.Protocol)
+		}
+	}
+	statements = append(statements, accept()...)
+	statements = append(statements, comment(nftablesInternalComment))
+	return ensureChainCommand() + " && " + ruleCommand(statements...)
+}
+
+// ListCommand lists the rules in Juju's chain, in the format parsed by
+// ParseIngressRules.
+type ListCommand struct{}
+
+func (ListCommand) Render() string {
+	return fmt.Sprintf("%s && sudo nft list chain inet %s %s", ensureChainCommand(), Table, Chain)
+}
+
+// IngressRuleCommand adds or deletes the rules that accept packets
+// matching an ingress rule.
+//
+// nftables has no equivalent of "iptables -C" to check for a rule, and
+// rules can only be deleted by handle, so the rules are identified by a
+// comment holding a checksum of the ingress rule and destination. An
+// ingress rule with both IPv4 and IPv6 source CIDRs is rendered as one
+// rule per address family, all with the same comment.
+type IngressRuleCommand struct {
+	Rule               network.IngressRule
+	DestinationAddress string
+	Delete             bool
+}
+
+func (c IngressRuleCommand) Render() string {
+	ruleComment := c.comment()
+	if c.Delete {
+		return fmt.Sprintf(
+			`for handle in $(sudo nft -a list chain inet %[1]s %[2]s 2>/dev/null | sed -n 's/.*comment "%[3]s" # handle \([0-9]*\)$/\1/p'); do sudo nft delete rule inet %[1]s %[2]s handle $handle; done`,
+			Table, Chain, ruleComment,
+		)
+	}
+	var inserts []string
+	for _, statements := range c.ruleStatements() {
+		statements = append(statements, accept()...)
+		statements = append(statements, comment(ruleComment))
+		inserts = append(inserts, ruleCommand(statements...))
+	}
+	if len(inserts) == 0 {
+		return "true"
+	}
+	return fmt.Sprintf(
+		`%s && (sudo nft list chain inet %s %s | grep -qF 'comment "%s"' || (%s))`,
+		ensureChainCommand(), Table, Chain, ruleComment, strings.Join(inserts, " && "),
+	)
+}
+
+// comment returns the comment that identifies the rules for the
+// ingress rule.
+func (c IngressRuleCommand) comment() string {
+	key := fmt.Sprintf("%s %d-%d %s %s",
+		c.Rule.Protocol, c.Rule.FromPort, c.Rule.ToPort,
+		strings.Join(c.Rule.SourceCIDRs, ","), c.DestinationAddress,
+	)
+	return fmt.Sprintf("%s %08x", nftablesIngressComment, crc32.ChecksumIEEE([]byte(key)))
+}
+
+// ruleStatements returns the statements that match packets for each of
+// the rules needed for the ingress rule. Source CIDRs in a different
+// address family from the destination address can never match, so are
+// left out.
+func (c IngressRuleCommand) ruleStatements() [][]string {
+	var destination []string
+	destinationFamily := ""
+	if c.DestinationAddress != "" {
+		destinationFamily = addressFamily(c.DestinationAddress)
+		destination = []string{destinationFamily, "daddr", c.DestinationAddress}
+	}
+
+	if len(c.Rule.SourceCIDRs) == 0 {
+		if c.Rule.Protocol == "icmp" && destinationFamily == "" {
+			// ICMP and ICMPv6 are different protocols, so each
+			// needs a rule of its own.
+			return [][]string{c.protocolStatements("ip"), c.protocolStatements("ip6")}
+		}
+		return [][]string{append(destination, c.protocolStatements(destinationFamily)...)}
+	}
+	var families []string
+	sourcesByFamily := make(map[string][]string)
+	for _, cidr := range c.Rule.SourceCIDRs {
+		family := addressFamily(cidr)
+		if destinationFamily != "" && family != destinationFamily {
+			logger.Debugf("not matching %s source %q for %s destination %q", family, cidr, destinationFamily, c.DestinationAddress)
+			continue
+		}
+		if _, ok := sourcesByFamily[family]; !ok {
+			families = append(families, family)
+		}
+		sourcesByFamily[family] = append(sourcesByFamily[family], cidr)
+	}
+	var rules [][]string
+	for _, family := range families {
+		sources := sourcesByFamily[family]
+		source := sources[0]
+		if len(sources) > 1 {
+			source = fmt.Sprintf("'{ %s }'", strings.Join(sources, ", "))
+		}
+		statements := append([]string{}, destination...)
+		statements = append(statements, family, "saddr", source)
+		rules = append(rules, append(statements, c.protocolStatements(family)...))
+	}
+	return rules
+}
+
+// protocolStatements returns the statements that match the protocol
+// and ports of the ingress rule, in packets of the given address
+// family, "ip", "ip6" or "" for either.
+func (c IngressRuleCommand) protocolStatements(family string) []string {
+	switch {
+	case c.Rule.Protocol == "icmp" && family == "ip6":
+		return []string{"icmpv6 type echo-request"}
+	case c.Rule.Protocol == "icmp":
+		return []string{"icmp type echo-request"}
+	case c.Rule.ToPort-c.Rule.FromPort > 0:
+		return []string{c.Rule.Protocol, "dport", fmt.Sprintf("%d-%d", c.Rule.FromPort, c.Rule.ToPort)}
+	default:
+		return []string{c.Rule.Protocol, "dport", fmt.Sprint(c.Rule.FromPort)}
+	}
+}
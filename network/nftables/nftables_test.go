@@ -0,0 +1,330 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package nftables_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/nftables"
+)
+
+type NftablesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&NftablesSuite{})
+
+const ensureChain = "sudo nft add table inet juju && " +
+	"sudo nft add chain inet juju input '{ type filter hook input priority -10; policy accept; }' && " +
+	`sudo nft list chains | awk '$1 == "table" { family = $2; table = $3 } $1 == "chain" { chain = $2 } ` +
+	`/type filter hook input / && (family == "ip" || family == "ip6" || family == "inet") && !(family == "inet" && table == "juju") ` +
+	`{ print family, table, chain }' | while read family table chain; do ` +
+	`sudo nft list chain $family $table $chain | grep -qF 'comment "juju accepted"' || ` +
+	`sudo nft insert rule $family $table $chain meta mark and 0x4a550000 == 0x4a550000 meta mark set meta mark or 0x4a550000 accept comment '"juju accepted"' || exit 1; done`
+
+func (*NftablesSuite) TestDropCommand(c *gc.C) {
+	assertRender(c,
+		nftables.DropCommand{},
+		ensureChain+` && sudo nft insert rule inet juju input ct state new drop comment '"juju internal"'`,
+	)
+	assertRender(c,
+		nftables.DropCommand{DestinationAddress: "1.2.3.4"},
+		ensureChain+` && sudo nft insert rule inet juju input ip daddr 1.2.3.4 ct state new drop comment '"juju internal"'`,
+	)
+	assertRender(c,
+		nftables.DropCommand{DestinationAddress: "2001:db8::1"},
+		ensureChain+` && sudo nft insert rule inet juju input ip6 daddr 2001:db8::1 ct state new drop comment '"juju internal"'`,
+	)
+	assertRender(c,
+		nftables.DropCommand{Interface: "eth0"},
+		ensureChain+` && sudo nft insert rule inet juju input iifname eth0 ct state new drop comment '"juju internal"'`,
+	)
+}
+
+func (*NftablesSuite) TestAcceptInternalPortCommand(c *gc.C) {
+	assertRender(c,
+		nftables.AcceptInternalCommand{},
+		ensureChain+` && sudo nft insert rule inet juju input meta mark set meta mark or 0x4a550000 accept comment '"juju internal"'`,
+	)
+	assertRender(c,
+		nftables.AcceptInternalCommand{Protocol: "tcp"},
+		ensureChain+` && sudo nft insert rule inet juju input meta l4proto tcp meta mark set meta mark or 0x4a550000 accept comment '"juju internal"'`,
+	)
+	assertRender(c,
+		nftables.AcceptInternalCommand{
+			DestinationAddress: "1.2.3.4",
+			DestinationPort:    17070,
+			Protocol:           "tcp",
+		},
+		ensureChain+` && sudo nft insert rule inet juju input ip daddr 1.2.3.4 tcp dport 17070 meta mark set meta mark or 0x4a550000 accept comment '"juju internal"'`,
+	)
+}
+
+func (*NftablesSuite) TestListCommand(c *gc.C) {
+	assertRender(c, nftables.ListCommand{}, ensureChain+" && sudo nft list chain inet juju input")
+}
+
+func (*NftablesSuite) TestIngressRuleCommand(c *gc.C) {
+	assertRender(c,
+		nftables.IngressRuleCommand{
+			Rule: network.IngressRule{
+				PortRange: network.PortRange{Protocol: "icmp"},
+			},
+		},
+		ensureChain+` && (sudo nft list chain inet juju input | grep -qF 'comment "juju ingress 7244b445"' || `+
+			`(sudo nft insert rule inet juju input icmp type echo-request meta mark set meta mark or 0x4a550000 accept comment '"juju ingress 7244b445"' && `+
+			`sudo nft insert rule inet juju input icmpv6 type echo-request meta mark set meta mark or 0x4a550000 accept comment '"juju ingress 7244b445"'))`,
+	)
+
+	// ICMPv6 is matched for IPv6 sources.
+	assertRender(c,
+		nftables.IngressRuleCommand{
+			Rule: network.IngressRule{
+				PortRange:   network.PortRange{Protocol: "icmp"},
+				SourceCIDRs: []string{"2001:db8::/32"},
+			},
+		},
+		ensureChain+` && (sudo nft list chain inet juju input | grep -qF 'comment "juju ingress 00751401"' || `+
+			`(sudo nft insert rule inet juju input ip6 saddr 2001:db8::/32 icmpv6 type echo-request meta mark set meta mark or 0x4a550000 accept comment '"juju ingress 00751401"'))`,
+	)
+
+	// Rules are deleted by looking up the handles of the rules
+	// with the ingress rule's comment.
+	assertRender(c,
+		nftables.IngressRuleCommand{
+			Rule: network.IngressRule{
+				PortRange: network.PortRange{Protocol: "icmp"},
+			},
+			Delete: true,
+		},
+		`for handle in $(sudo nft -a list chain inet juju input 2>/dev/null | `+
+			`sed -n 's/.*comment "juju ingress 7244b445" # handle \([0-9]*\)$/\1/p'); `+
+			`do sudo nft delete rule inet juju input handle $handle; done`,
+	)
+
+	// A port range with source CIDRs in both address families is
+	// rendered as a rule for each family.
+	assertRender(c,
+		nftables.IngressRuleCommand{
+			Rule: network.IngressRule{
+				PortRange: network.PortRange{
+					Protocol: "tcp",
+					FromPort: 80,
+					ToPort:   90,
+				},
+				SourceCIDRs: []string{"10.0.0.0/24", "2001:db8::/32"},
+			},
+		},
+		ensureChain+` && (sudo nft list chain inet juju input | grep -qF 'comment "juju ingress ffaec885"' || (`+
+			`sudo nft insert rule inet juju input ip saddr 10.0.0.0/24 tcp dport 80-90 meta mark set meta mark or 0x4a550000 accept comment '"juju ingress ffaec885"' && `+
+			`sudo nft insert rule inet juju input ip6 saddr 2001:db8::/32 tcp dport 80-90 meta mark set meta mark or 0x4a550000 accept comment '"juju ingress ffaec885"'))`,
+	)
+
+	// Multiple source CIDRs in a family are matched with a set.
+	assertRender(c,
+		nftables.IngressRuleCommand{
+			Rule: network.IngressRule{
+				PortRange: network.PortRange{
+					Protocol: "udp",
+					FromPort: 53,
+					ToPort:   53,
+				},
+				SourceCIDRs: []string{"1.2.3.0/24", "5.6.7.8/32"},
+			},
+			DestinationAddress: "10.0.0.1",
+		},
+		ensureChain+` && (sudo nft list chain inet juju input | grep -qF 'comment "juju ingress 5754ff45"' || (`+
+			`sudo nft insert rule inet juju input ip daddr 10.0.0.1 ip saddr '{ 1.2.3.0/24, 5.6.7.8/32 }' udp dport 53 meta mark set meta mark or 0x4a550000 accept comment '"juju ingress 5754ff45"'))`,
+	)
+}
+
+// restrictiveRuleset is the output of "nft list chains" on a machine
+// whose own ruleset drops any incoming packets it doesn't accept.
+const restrictiveRuleset = `
+table inet filter {
+	chain input {
+		type filter hook input priority filter; policy drop;
+	}
+	chain forward {
+		type filter hook forward priority filter; policy drop;
+	}
+}
+table ip legacy {
+	chain INPUT {
+		type filter hook input priority 0; policy drop;
+	}
+}
+table bridge lxd {
+	chain input {
+		type filter hook input priority 0; policy drop;
+	}
+}
+table inet juju {
+	chain input {
+		type filter hook input priority -10; policy accept;
+	}
+}
+`
+
+// runWithFakeNft runs the command with a fake nft, which lists the
+// restrictive ruleset's chains and records the commands it's given.
+// The legacy table's chain already accepts packets accepted by Juju.
+func (s *NftablesSuite) runWithFakeNft(c *gc.C, command string) []string {
+	if runtime.GOOS == "windows" {
+		c.Skip("nftables commands are run by bash")
+	}
+	dir := c.MkDir()
+	testing.PatchExecutable(c, s, "sudo", "#!/bin/bash\nexec \"$@\"\n")
+	testing.PatchExecutable(c, s, "nft", fmt.Sprintf(`#!/bin/bash
+echo "$*" >> %[1]s/nft.log
+case "$*" in
+"list chains")
+    cat <<'EOF'
+%[2]sEOF
+    ;;
+"list chain ip legacy INPUT")
+    echo 'meta mark & 0x4a550000 == 0x4a550000 accept comment "juju accepted"'
+    ;;
+esac
+`, dir, restrictiveRuleset[1:]))
+	output, err := exec.Command("bash", "-c", command).CombinedOutput()
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("%s", output))
+	data, err := ioutil.ReadFile(filepath.Join(dir, "nft.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func (s *NftablesSuite) TestCommandsAcceptInMachineChains(c *gc.C) {
+	// Juju's chain runs before the machine's own chains, and marks
+	// the packets it accepts; the machine's input filter chains are
+	// given a rule to accept them, so that they don't drop them.
+	rule := network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24")
+	nft := s.runWithFakeNft(c, nftables.IngressRuleCommand{Rule: rule}.Render())
+	c.Assert(nft, jc.DeepEquals, []string{
+		"add table inet juju",
+		"add chain inet juju input { type filter hook input priority -10; policy accept; }",
+		"list chains",
+		"list chain inet filter input",
+		`insert rule inet filter input meta mark and 0x4a550000 == 0x4a550000 accept comment "juju accepted"`,
+		"list chain ip legacy INPUT",
+		"list chain inet juju input",
+		`insert rule inet juju input ip saddr 10.0.0.0/24 tcp dport 80 meta mark set meta mark or 0x4a550000 accept comment "juju ingress e9cb324a"`,
+	})
+}
+
+func (s *NftablesSuite) TestCommandsOnlyDropInJujuChain(c *gc.C) {
+	// Juju's chain accepts whatever its rules don't drop, and nothing
+	// is dropped by the rules added to the machine's chains.
+	nft := s.runWithFakeNft(c, nftables.DropCommand{DestinationAddress: "10.0.0.1"}.Render())
+	c.Assert(nft[len(nft)-1], gc.Equals, `insert rule inet juju input ip daddr 10.0.0.1 ct state new drop comment "juju internal"`)
+	for _, command := range nft {
+		if strings.Contains(command, "drop") {
+			c.Check(command, jc.HasPrefix, "insert rule inet juju input ")
+		}
+	}
+}
+
+func (*NftablesSuite) TestParseIngressRulesEmpty(c *gc.C) {
+	assertParseIngressRules(c, ``, []network.IngressRule{})
+}
+
+func (*NftablesSuite) TestParseIngressRulesGarbage(c *gc.C) {
+	assertParseIngressRules(c, `a
+b
+zing accept comment "juju ingress 12345678"
+blargh
+
+`, []network.IngressRule{})
+}
+
+func (*NftablesSuite) TestParseIngressRulesChecksComment(c *gc.C) {
+	assertParseIngressRules(c, `
+table inet juju {
+	chain input {
+		type filter hook input priority 0; policy accept;
+		tcp dport 53 accept comment "managed by lxd"
+		tcp dport 53 accept comment "juju ingress 11111111"
+		ct state new drop comment "juju internal"
+		udp dport 67 accept
+	}
+}
+`[1:], []network.IngressRule{{
+		PortRange: network.PortRange{
+			Protocol: "tcp",
+			FromPort: 53,
+			ToPort:   53,
+		},
+		SourceCIDRs: []string{"0.0.0.0/0"},
+	}})
+}
+
+func (*NftablesSuite) TestParseIngressRules(c *gc.C) {
+	assertParseIngressRules(c, `
+table inet juju {
+	chain input {
+		type filter hook input priority 0; policy accept;
+		tcp dport 3456-3458 meta mark set meta mark | 0x4a550000 accept comment "juju ingress 11111111" # handle 4
+		ip daddr 10.0.0.1 ip saddr 1.2.3.4/20 tcp dport 12345 accept comment "juju ingress 22222222" # handle 5
+		ip saddr { 1.2.3.0/24, 5.6.7.8 } udp dport 12345 accept comment "juju ingress 33333333" # handle 6
+		ip6 saddr 2001:db8::/32 udp dport 12345 accept comment "juju ingress 33333333" # handle 7
+		icmp type echo-request meta mark set meta mark | 0x4a550000 accept comment "juju ingress 44444444" # handle 8
+		icmpv6 type echo-request meta mark set meta mark | 0x4a550000 accept comment "juju ingress 44444444" # handle 9
+	}
+}
+`[1:],
+		[]network.IngressRule{{
+			PortRange: network.PortRange{
+				Protocol: "tcp",
+				FromPort: 3456,
+				ToPort:   3458,
+			},
+			SourceCIDRs: []string{"0.0.0.0/0"},
+		}, {
+			PortRange: network.PortRange{
+				Protocol: "tcp",
+				FromPort: 12345,
+				ToPort:   12345,
+			},
+			SourceCIDRs: []string{"1.2.3.4/20"},
+		}, {
+			PortRange: network.PortRange{
+				Protocol: "udp",
+				FromPort: 12345,
+				ToPort:   12345,
+			},
+			SourceCIDRs: []string{"1.2.3.0/24", "5.6.7.8/32", "2001:db8::/32"},
+		}, {
+			PortRange: network.PortRange{
+				Protocol: "icmp",
+				FromPort: -1,
+				ToPort:   -1,
+			},
+			SourceCIDRs: []string{"0.0.0.0/0"},
+		}},
+	)
+}
+
+func assertParseIngressRules(c *gc.C, in string, expect []network.IngressRule) {
+	rules, err := nftables.ParseIngressRules(strings.NewReader(in))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expect)
+}
+
+type renderer interface {
+	Render() string
+}
+
+func assertRender(c *gc.C, r renderer, expect string) {
+	c.Assert(r.Render(), gc.Equals, expect)
+}
@@ -0,0 +1,197 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package nftables
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
+
+// ParseIngressRules parses the output of ListCommand, returning the
+// ingress rules that were added by IngressRuleCommand. Rules with the
+// same comment, which were rendered from a single ingress rule, are
+// combined.
+func ParseIngressRules(r io.Reader) ([]network.IngressRule, error) {
+	var rules []network.IngressRule
+	ruleIndex := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		rule, ruleComment, ok, err := parseIngressRule(strings.TrimSpace(line))
+		if err != nil {
+			logger.Warningf("failed to parse nftables line %q: %v", line, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if i, found := ruleIndex[ruleComment]; found {
+			// The ICMP and ICMPv6 rules for an ingress rule with no
+			// source CIDRs both match any source.
+			existing := set.NewStrings(rules[i].SourceCIDRs...)
+			for _, cidr := range rule.SourceCIDRs {
+				if !existing.Contains(cidr) {
+					existing.Add(cidr)
+					rules[i].SourceCIDRs = append(rules[i].SourceCIDRs, cidr)
+				}
+			}
+			continue
+		}
+		ruleIndex[ruleComment] = len(rules)
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Annotate(err, "reading nftables output")
+	}
+	return rules, nil
+}
+
+func parseIngressRule(line string) (network.IngressRule, string, bool, error) {
+	fail := func(err error) (network.IngressRule, string, bool, error) {
+		return network.IngressRule{}, "", false, err
+	}
+	if i := strings.LastIndex(line, " # handle "); i != -1 {
+		line = line[:i]
+	}
+	if !strings.HasSuffix(line, `"`) {
+		return network.IngressRule{}, "", false, nil
+	}
+	commentStart := strings.LastIndex(line, ` comment "`)
+	if commentStart == -1 {
+		return network.IngressRule{}, "", false, nil
+	}
+	line, ruleComment := line[:commentStart], line[commentStart+len(` comment "`):len(line)-1]
+	if !strings.HasPrefix(ruleComment, nftablesIngressComment+" ") {
+		return network.IngressRule{}, "", false, nil
+	}
+	if !strings.HasSuffix(line, " accept") {
+		return network.IngressRule{}, "", false, nil
+	}
+	line = strings.TrimSuffix(line, " accept")
+	// Drop the statement marking accepted packets.
+	if i := strings.Index(line, " meta mark set "); i != -1 {
+		line = line[:i]
+	}
+	fields := strings.Fields(line)
+
+	var (
+		proto            string
+		fromPort, toPort int
+		sources          []string
+	)
+	for len(fields) > 0 {
+		field := fields[0]
+		fields = fields[1:]
+		switch field {
+		case "ip", "ip6":
+			if len(fields) < 2 {
+				return fail(errors.Errorf("could not extract %s address", field))
+			}
+			direction := fields[0]
+			addresses, remainder, err := popAddresses(fields[1:])
+			if err != nil {
+				return fail(errors.Trace(err))
+			}
+			fields = remainder
+			if direction != "saddr" {
+				continue
+			}
+			for _, address := range addresses {
+				sources = append(sources, toCIDR(field, address))
+			}
+		case "icmp", "icmpv6":
+			if len(fields) < 2 || fields[0] != "type" || fields[1] != "echo-request" {
+				return fail(errors.Errorf("unexpected %s match", field))
+			}
+			fields = fields[2:]
+			proto, fromPort, toPort = "icmp", -1, -1
+		case "tcp", "udp":
+			if len(fields) < 2 || fields[0] != "dport" {
+				return fail(errors.New("could not extract destination port"))
+			}
+			var err error
+			fromPort, toPort, err = parsePortRange(fields[1])
+			if err != nil {
+				return fail(errors.Trace(err))
+			}
+			fields = fields[2:]
+			proto = field
+		default:
+			return fail(errors.Errorf("unexpected field %q", field))
+		}
+	}
+	if proto == "" {
+		return fail(errors.New("could not extract protocol"))
+	}
+	if len(sources) == 0 {
+		sources = []string{"0.0.0.0/0"}
+	}
+	rule, err := network.NewIngressRule(proto, fromPort, toPort, sources...)
+	if err != nil {
+		return fail(errors.Trace(err))
+	}
+	return rule, ruleComment, true, nil
+}
+
+// popAddresses returns the address, or the addresses in the anonymous
+// set, at the start of fields, and the remaining fields.
+func popAddresses(fields []string) ([]string, []string, error) {
+	if fields[0] != "{" {
+		return fields[:1], fields[1:], nil
+	}
+	var addresses []string
+	for i, field := range fields[1:] {
+		if field == "}" {
+			return addresses, fields[i+2:], nil
+		}
+		addresses = append(addresses, strings.TrimSuffix(field, ","))
+	}
+	return nil, nil, errors.New("unterminated address set")
+}
+
+// toCIDR returns the address as a CIDR; nft lists CIDRs for single
+// addresses without a prefix length.
+func toCIDR(family, address string) string {
+	if strings.Contains(address, "/") {
+		return address
+	}
+	if family == "ip6" {
+		return address + "/128"
+	}
+	return address + "/32"
+}
+
+func parsePortRange(s string) (int, int, error) {
+	fields := strings.Split(s, "-")
+	if len(fields) > 2 {
+		return -1, -1, errors.New("expected N or M-N")
+	}
+	from, err := parsePort(fields[0])
+	if err != nil {
+		return -1, -1, errors.Trace(err)
+	}
+	if len(fields) == 1 {
+		return from, from, nil
+	}
+	to, err := parsePort(fields[1])
+	if err != nil {
+		return -1, -1, errors.Trace(err)
+	}
+	return from, to, nil
+}
+
+func parsePort(s string) (int, error) {
+	n, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return -1, errors.Trace(err)
+	}
+	return int(n), nil
+}
@@ -37,6 +37,20 @@ const (
 
 	// SuperuserAccess allows user unrestricted permissions in the subject.
 	SuperuserAccess Access = "superuser"
+
+	// Application permissions
+
+	// ConfigAccess allows a user to change the charm configuration of
+	// an application.
+	ConfigAccess Access = "config"
+
+	// RunActionAccess allows a user to run actions on the units of an
+	// application.
+	RunActionAccess Access = "run-action"
+
+	// UpgradeCharmAccess allows a user to upgrade the charm of an
+	// application.
+	UpgradeCharmAccess Access = "upgrade-charm"
 )
 
 // Validate returns error if the current is not a valid access level.
@@ -69,6 +83,16 @@ func ValidateOfferAccess(access Access) error {
 	return errors.NotValidf("%q offer access", access)
 }
 
+// ValidateApplicationAccess returns error if the passed access is not a
+// valid application access level.
+func ValidateApplicationAccess(access Access) error {
+	switch access {
+	case ConfigAccess, RunActionAccess, UpgradeCharmAccess:
+		return nil
+	}
+	return errors.NotValidf("%q application access", access)
+}
+
 //ValidateControllerAccess returns error if the passed access is not a valid
 // controller access level.
 func ValidateControllerAccess(access Access) error {
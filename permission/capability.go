@@ -0,0 +1,96 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// Capability names a facade method, as "Facade.Method", that a user may
+// be allowed to call on an application, by way of a role granted to them
+// on it, without write access to the model.
+type Capability string
+
+// applicationCapabilities holds the capabilities making up each of the
+// application access levels. Every capability that may be granted on an
+// application is part of one of them.
+var applicationCapabilities = map[Access][]Capability{
+	ConfigAccess: {
+		"Application.Set",
+		"Application.SetApplicationsConfig",
+		"Application.Unset",
+		"Application.UnsetApplicationsConfig",
+	},
+	RunActionAccess: {
+		"Action.Enqueue",
+		"Action.EnqueueApplicationActions",
+	},
+	UpgradeCharmAccess: {
+		"Application.SetCharm",
+		"Client.AddCharm",
+		"Client.AddCharmWithAuthorization",
+	},
+}
+
+// ApplicationCapabilities returns the capabilities making up the given
+// application access level.
+func ApplicationCapabilities(access Access) []Capability {
+	capabilities := applicationCapabilities[access]
+	result := make([]Capability, len(capabilities))
+	copy(result, capabilities)
+	return result
+}
+
+// AllApplicationCapabilities returns, sorted, all of the capabilities
+// that may be granted on applications.
+func AllApplicationCapabilities() []Capability {
+	var result []Capability
+	for _, capabilities := range applicationCapabilities {
+		result = append(result, capabilities...)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// ValidateCapability returns an error if the capability may not be
+// granted on applications.
+func ValidateCapability(capability Capability) error {
+	for _, capabilities := range applicationCapabilities {
+		for _, c := range capabilities {
+			if c == capability {
+				return nil
+			}
+		}
+	}
+	return errors.NotValidf("%q application capability", capability)
+}
+
+// ParseCapabilities returns the capabilities named, each of which may
+// be a capability or an application access level standing for all of
+// its capabilities. Duplicate capabilities are only returned once.
+func ParseCapabilities(names ...string) ([]Capability, error) {
+	var result []Capability
+	seen := make(map[Capability]bool)
+	add := func(capability Capability) {
+		if !seen[capability] {
+			seen[capability] = true
+			result = append(result, capability)
+		}
+	}
+	for _, name := range names {
+		if err := ValidateApplicationAccess(Access(name)); err == nil {
+			for _, capability := range applicationCapabilities[Access(name)] {
+				add(capability)
+			}
+			continue
+		}
+		if err := ValidateCapability(Capability(name)); err != nil {
+			return nil, errors.Trace(err)
+		}
+		add(Capability(name))
+	}
+	return result, nil
+}
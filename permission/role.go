@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+)
+
+// Role is a named set of capabilities defined in a model. Granting a
+// user a role on an application allows them to call each of the role's
+// facade methods on that application.
+type Role struct {
+	// Name is the name of the role, unique within the model.
+	Name string
+
+	// Capabilities holds the facade methods making up the role.
+	Capabilities []Capability
+}
+
+var validRoleName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// ValidateRoleName returns an error if the given name is not a valid
+// role name. The application access levels are implicitly roles
+// granting just their capabilities, so no role may be named after one.
+func ValidateRoleName(name string) error {
+	if !validRoleName.MatchString(name) {
+		return errors.NotValidf("role name %q", name)
+	}
+	if err := ValidateApplicationAccess(Access(name)); err == nil {
+		return errors.Errorf("role name %q clashes with an application access level", name)
+	}
+	return nil
+}
+
+// Validate returns an error if the role's name is not valid, or it
+// does not hold only capabilities that may be granted on applications.
+func (r Role) Validate() error {
+	if err := ValidateRoleName(r.Name); err != nil {
+		return errors.Trace(err)
+	}
+	if len(r.Capabilities) == 0 {
+		return errors.NotValidf("role %q with no capabilities", r.Name)
+	}
+	for _, capability := range r.Capabilities {
+		if err := ValidateCapability(capability); err != nil {
+			return errors.Annotatef(err, "role %q", r.Name)
+		}
+	}
+	return nil
+}
+
+// HasCapability reports whether the role includes the given capability.
+func (r Role) HasCapability(capability Capability) bool {
+	for _, c := range r.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/permission"
+)
+
+type roleSuite struct{}
+
+var _ = gc.Suite(&roleSuite{})
+
+func (*roleSuite) TestValidateApplicationAccess(c *gc.C) {
+	for _, access := range []permission.Access{
+		permission.ConfigAccess,
+		permission.RunActionAccess,
+		permission.UpgradeCharmAccess,
+	} {
+		c.Check(permission.ValidateApplicationAccess(access), jc.ErrorIsNil)
+	}
+	for _, access := range []permission.Access{
+		permission.NoAccess,
+		permission.ReadAccess,
+		permission.WriteAccess,
+		permission.AdminAccess,
+	} {
+		c.Check(permission.ValidateApplicationAccess(access), jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (*roleSuite) TestValidateRoleName(c *gc.C) {
+	for _, name := range []string{"operator", "app-team", "team2", "a-b-c"} {
+		c.Check(permission.ValidateRoleName(name), jc.ErrorIsNil)
+	}
+	for _, name := range []string{"", "Operator", "2team", "app-", "app--team", "app_team"} {
+		c.Check(permission.ValidateRoleName(name), gc.ErrorMatches, `role name ".*" not valid`)
+	}
+	c.Check(permission.ValidateRoleName("run-action"), gc.ErrorMatches,
+		`role name "run-action" clashes with an application access level`)
+}
+
+func (*roleSuite) TestValidate(c *gc.C) {
+	role := permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"},
+	}
+	c.Assert(role.Validate(), jc.ErrorIsNil)
+
+	role.Capabilities = nil
+	c.Assert(role.Validate(), gc.ErrorMatches, `role "operator" with no capabilities not valid`)
+
+	role.Capabilities = []permission.Capability{"Application.Deploy"}
+	c.Assert(role.Validate(), gc.ErrorMatches, `role "operator": "Application.Deploy" application capability not valid`)
+}
+
+func (*roleSuite) TestHasCapability(c *gc.C) {
+	role := permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"},
+	}
+	c.Check(role.HasCapability("Action.Enqueue"), jc.IsTrue)
+	c.Check(role.HasCapability("Application.SetApplicationsConfig"), jc.IsTrue)
+	c.Check(role.HasCapability("Action.EnqueueApplicationActions"), jc.IsFalse)
+	c.Check(role.HasCapability("Application.SetCharm"), jc.IsFalse)
+}
+
+func (*roleSuite) TestApplicationCapabilities(c *gc.C) {
+	c.Check(permission.ApplicationCapabilities(permission.RunActionAccess), jc.DeepEquals, []permission.Capability{
+		"Action.Enqueue",
+		"Action.EnqueueApplicationActions",
+	})
+	c.Check(permission.ApplicationCapabilities(permission.WriteAccess), gc.HasLen, 0)
+
+	all := permission.AllApplicationCapabilities()
+	c.Check(all, gc.HasLen, 9)
+	for _, capability := range all {
+		c.Check(permission.ValidateCapability(capability), jc.ErrorIsNil)
+	}
+	c.Check(permission.ValidateCapability("Application.Deploy"), jc.Satisfies, errors.IsNotValid)
+}
+
+func (*roleSuite) TestParseCapabilities(c *gc.C) {
+	capabilities, err := permission.ParseCapabilities("run-action", "Application.SetCharm", "Action.Enqueue")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capabilities, jc.DeepEquals, []permission.Capability{
+		"Action.Enqueue",
+		"Action.EnqueueApplicationActions",
+		"Application.SetCharm",
+	})
+
+	_, err = permission.ParseCapabilities("config", "write")
+	c.Assert(err, gc.ErrorMatches, `"write" application capability not valid`)
+}
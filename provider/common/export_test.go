@@ -3,8 +3,20 @@
 
 package common
 
+import (
+	"github.com/juju/utils/ssh"
+)
+
 var (
 	ConnectSSH                          = &connectSSH
 	InternalAvailabilityZoneAllocations = &internalAvailabilityZoneAllocations
 	FormatHardware                      = formatHardware
 )
+
+// NewSshInstanceConfiguratorWithClient returns an InstanceConfigurator
+// for the host that uses the given SSH client.
+func NewSshInstanceConfiguratorWithClient(host string, client ssh.Client) InstanceConfigurator {
+	configurator := NewSshInstanceConfigurator(host).(*sshInstanceConfigurator)
+	configurator.client = client
+	return configurator
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/juju/utils/ssh"
 
 	"github.com/juju/juju/network"
-	"github.com/juju/juju/network/iptables"
+	"github.com/juju/juju/network/netfilter"
 )
 
 const (
@@ -40,6 +40,10 @@ type sshInstanceConfigurator struct {
 	client  ssh.Client
 	host    string
 	options *ssh.Options
+
+	// backend manages the instance's firewall rules. It is detected
+	// the first time it is needed.
+	backend netfilter.Backend
 }
 
 // NewSshInstanceConfigurator creates new sshInstanceConfigurator.
@@ -74,17 +78,32 @@ func (c *sshInstanceConfigurator) runCommand(cmd string) (string, error) {
 	return string(output), nil
 }
 
+// netfilter returns the backend that manages the instance's firewall
+// rules, detecting whether the instance uses iptables or nftables the
+// first time it is called.
+func (c *sshInstanceConfigurator) netfilter() (netfilter.Backend, error) {
+	if c.backend == nil {
+		backend, err := netfilter.Detect(c.runCommand)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		logger.Debugf("using %s to manage firewall rules on %s", backend.Name(), c.host)
+		c.backend = backend
+	}
+	return c.backend, nil
+}
+
 // DropAllPorts implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) DropAllPorts(exceptPorts []int, addr string) error {
+	backend, err := c.netfilter()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	cmds := []string{
-		iptables.DropCommand{DestinationAddress: addr}.Render(),
+		backend.DropCommand(addr, ""),
 	}
 	for _, port := range exceptPorts {
-		cmds = append(cmds, iptables.AcceptInternalCommand{
-			Protocol:           "tcp",
-			DestinationAddress: addr,
-			DestinationPort:    port,
-		}.Render())
+		cmds = append(cmds, backend.AcceptInternalCommand("tcp", addr, port))
 	}
 
 	output, err := c.runCommand(strings.Join(cmds, "\n"))
@@ -96,25 +115,26 @@ func (c *sshInstanceConfigurator) DropAllPorts(exceptPorts []int, addr string) e
 }
 
 // ConfigureExternalIpAddressCommands returns the commands to run to configure
-// the external IP address
-func ConfigureExternalIpAddressCommands(apiPort int) []string {
+// the external IP address, using the given backend to manage firewall rules.
+func ConfigureExternalIpAddressCommands(backend netfilter.Backend, apiPort int) []string {
 	commands := []string{
 		`printf 'auto eth1\niface eth1 inet dhcp' | sudo tee -a /etc/network/interfaces.d/eth1.cfg`,
 		"sudo ifup eth1",
-		iptables.DropCommand{Interface: "eth1"}.Render(),
+		backend.DropCommand("", "eth1"),
 	}
 	if apiPort > 0 {
-		commands = append(commands, iptables.AcceptInternalCommand{
-			Protocol:        "tcp",
-			DestinationPort: apiPort,
-		}.Render())
+		commands = append(commands, backend.AcceptInternalCommand("tcp", "", apiPort))
 	}
 	return commands
 }
 
 // ConfigureExternalIpAddress implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) ConfigureExternalIpAddress(apiPort int) error {
-	cmds := ConfigureExternalIpAddressCommands(apiPort)
+	backend, err := c.netfilter()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cmds := ConfigureExternalIpAddressCommands(backend, apiPort)
 	output, err := c.runCommand(strings.Join(cmds, "\n"))
 	if err != nil {
 		return errors.Errorf("failed to drop all ports: %s", output)
@@ -128,13 +148,13 @@ func (c *sshInstanceConfigurator) ConfigureExternalIpAddress(apiPort int) error
 
 // ChangeIngressRules implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bool, rules []network.IngressRule) error {
+	backend, err := c.netfilter()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	var cmds []string
 	for _, rule := range rules {
-		cmds = append(cmds, iptables.IngressRuleCommand{
-			Rule:               rule,
-			DestinationAddress: ipAddress,
-			Delete:             !insert,
-		}.Render())
+		cmds = append(cmds, backend.IngressRuleCommand(rule, ipAddress, !insert))
 	}
 
 	output, err := c.runCommand(strings.Join(cmds, "\n"))
@@ -147,10 +167,14 @@ func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bo
 
 // FindIngressRules implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) FindIngressRules() ([]network.IngressRule, error) {
-	output, err := c.runCommand("sudo iptables -L INPUT -n")
+	backend, err := c.netfilter()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	output, err := c.runCommand(backend.ListIngressRulesCommand())
 	if err != nil {
 		return nil, errors.Errorf("failed to list open ports: %s", output)
 	}
 	logger.Tracef("find open ports output: %s", output)
-	return iptables.ParseIngressRules(strings.NewReader(output))
+	return backend.ParseIngressRules(strings.NewReader(output))
 }
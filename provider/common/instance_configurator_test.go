@@ -0,0 +1,105 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/ssh"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/netfilter"
+	"github.com/juju/juju/provider/common"
+)
+
+type InstanceConfiguratorSuite struct {
+	testing.IsolationSuite
+
+	dir string
+}
+
+var _ = gc.Suite(&InstanceConfiguratorSuite{})
+
+func (s *InstanceConfiguratorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+}
+
+// newConfigurator returns a configurator whose commands are run by a
+// fake ssh, which records them and reports that the machine should use
+// the named firewall backend.
+func (s *InstanceConfiguratorSuite) newConfigurator(c *gc.C, backend string) common.InstanceConfigurator {
+	testing.PatchExecutable(c, s, "ssh", fmt.Sprintf(`#!/bin/bash
+input=$(cat)
+printf '%%s\n--\n' "$input" >> %[1]s/commands
+if [[ "$input" == *"command -v iptables"* ]]; then
+    echo %[2]s
+fi
+`, s.dir, backend))
+	client, err := ssh.NewOpenSSHClient()
+	c.Assert(err, jc.ErrorIsNil)
+	return common.NewSshInstanceConfiguratorWithClient("10.0.0.1", client)
+}
+
+// commands returns the commands run by the fake ssh.
+func (s *InstanceConfiguratorSuite) commands(c *gc.C) []string {
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, "commands"))
+	c.Assert(err, jc.ErrorIsNil)
+	commands := strings.Split(strings.TrimSuffix(string(data), "\n--\n"), "\n--\n")
+	for i, command := range commands {
+		commands[i] = strings.TrimSpace(command)
+	}
+	return commands
+}
+
+func (s *InstanceConfiguratorSuite) TestIPTablesBackend(c *gc.C) {
+	configurator := s.newConfigurator(c, "iptables")
+	err := configurator.DropAllPorts([]int{17070}, "10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	rule := network.MustNewIngressRule("tcp", 80, 80)
+	err = configurator.ChangeIngressRules("10.0.0.1", true, []network.IngressRule{rule})
+	c.Assert(err, jc.ErrorIsNil)
+
+	backend, err := netfilter.NewBackend(netfilter.IPTables)
+	c.Assert(err, jc.ErrorIsNil)
+	commands := s.commands(c)
+	c.Assert(commands, gc.HasLen, 3)
+	c.Check(commands[0], gc.Equals, strings.TrimSpace(netfilter.DetectCommand))
+	c.Check(commands[1], gc.Equals, backend.DropCommand("10.0.0.1", "")+"\n"+
+		backend.AcceptInternalCommand("tcp", "10.0.0.1", 17070))
+	c.Check(commands[1], jc.HasPrefix, "sudo iptables")
+	c.Check(commands[2], gc.Equals, backend.IngressRuleCommand(rule, "10.0.0.1", false))
+}
+
+func (s *InstanceConfiguratorSuite) TestNFTablesBackend(c *gc.C) {
+	configurator := s.newConfigurator(c, "nftables")
+	err := configurator.DropAllPorts([]int{17070}, "10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	rule := network.MustNewIngressRule("tcp", 80, 80)
+	err = configurator.ChangeIngressRules("10.0.0.1", false, []network.IngressRule{rule})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The backend is only detected once.
+	backend, err := netfilter.NewBackend(netfilter.NFTables)
+	c.Assert(err, jc.ErrorIsNil)
+	commands := s.commands(c)
+	c.Assert(commands, gc.HasLen, 3)
+	c.Check(commands[0], gc.Equals, strings.TrimSpace(netfilter.DetectCommand))
+	c.Check(commands[1], gc.Equals, backend.DropCommand("10.0.0.1", "")+"\n"+
+		backend.AcceptInternalCommand("tcp", "10.0.0.1", 17070))
+	c.Check(commands[1], jc.HasPrefix, "sudo nft")
+	c.Check(commands[2], gc.Equals, backend.IngressRuleCommand(rule, "10.0.0.1", true))
+}
+
+func (s *InstanceConfiguratorSuite) TestUnknownBackend(c *gc.C) {
+	configurator := s.newConfigurator(c, "ipchains")
+	err := configurator.DropAllPorts(nil, "10.0.0.1")
+	c.Assert(err, gc.ErrorMatches, `detecting firewall backend: firewall backend "ipchains" not valid`)
+}
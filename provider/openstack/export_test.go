@@ -530,6 +530,9 @@ func ResolveNetwork(e environs.Environ, networkName string, external bool) (stri
 var PortsToRuleInfo = rulesToRuleInfo
 var SecGroupMatchesIngressRule = secGroupMatchesIngressRule
 
+var SecGroupMatchesEgressRule = secGroupMatchesEgressRule
+var EgressRulesToRuleInfo = egressRulesToRuleInfo
+
 var MakeServiceURL = &makeServiceURL
 
 var GetVolumeEndpointURL = getVolumeEndpointURL
@@ -84,6 +84,21 @@ type Firewaller interface {
 	InstanceIngressRules(ctx context.ProviderCallContext, inst instance.Instance, machineId string) ([]network.IngressRule, error)
 }
 
+// EgressFirewaller is implemented by Firewallers that can restrict the
+// egress of instances. An instance without egress rules may send traffic
+// to any destination; once it has egress rules, it may only send the
+// traffic they allow.
+type EgressFirewaller interface {
+	// AllowInstanceEgress allows the given egress from the specified instance.
+	AllowInstanceEgress(ctx context.ProviderCallContext, inst instance.Instance, machineId string, rules []network.EgressRule) error
+
+	// DisallowInstanceEgress removes the given egress rules from the specified instance.
+	DisallowInstanceEgress(ctx context.ProviderCallContext, inst instance.Instance, machineId string, rules []network.EgressRule) error
+
+	// InstanceEgressRules returns the egress rules applied to the specified instance.
+	InstanceEgressRules(ctx context.ProviderCallContext, inst instance.Instance, machineId string) ([]network.EgressRule, error)
+}
+
 type firewallerFactory struct {
 }
 
@@ -205,6 +220,41 @@ func (f *switchingFirewaller) InstanceIngressRules(ctx context.ProviderCallConte
 	return f.fw.InstanceIngressRules(ctx, inst, machineId)
 }
 
+func (f *switchingFirewaller) egressFirewaller() (EgressFirewaller, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	fw, ok := f.fw.(EgressFirewaller)
+	if !ok {
+		return nil, errors.NotSupportedf("egress rules without Neutron")
+	}
+	return fw, nil
+}
+
+func (f *switchingFirewaller) AllowInstanceEgress(ctx context.ProviderCallContext, inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	fw, err := f.egressFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.AllowInstanceEgress(ctx, inst, machineId, rules)
+}
+
+func (f *switchingFirewaller) DisallowInstanceEgress(ctx context.ProviderCallContext, inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	fw, err := f.egressFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.DisallowInstanceEgress(ctx, inst, machineId, rules)
+}
+
+func (f *switchingFirewaller) InstanceEgressRules(ctx context.ProviderCallContext, inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	fw, err := f.egressFirewaller()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return fw.InstanceEgressRules(ctx, inst, machineId)
+}
+
 type firewallerBase struct {
 	environ          *Environ
 	ensureGroupMutex sync.Mutex
@@ -809,6 +859,54 @@ func (c *neutronFirewaller) InstanceIngressRules(ctx context.ProviderCallContext
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// AllowInstanceEgress implements EgressFirewaller.
+func (c *neutronFirewaller) AllowInstanceEgress(ctx context.ProviderCallContext, inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for allowing egress on instance",
+			c.environ.Config().FirewallMode())
+	}
+	// As for ingress, no security groups exist if the network used to
+	// boot the instance has PortSecurityEnabled set to false.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return nil
+	}
+	nameRegexp := c.machineGroupRegexp(machineId)
+	if err := c.allowEgressInGroup(nameRegexp, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("allowed egress in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// DisallowInstanceEgress implements EgressFirewaller.
+func (c *neutronFirewaller) DisallowInstanceEgress(ctx context.ProviderCallContext, inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for disallowing egress on instance",
+			c.environ.Config().FirewallMode())
+	}
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return nil
+	}
+	nameRegexp := c.machineGroupRegexp(machineId)
+	if err := c.disallowEgressInGroup(nameRegexp, rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("disallowed egress in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// InstanceEgressRules implements EgressFirewaller.
+func (c *neutronFirewaller) InstanceEgressRules(ctx context.ProviderCallContext, inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			c.environ.Config().FirewallMode())
+	}
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return []network.EgressRule{}, nil
+	}
+	return c.egressRulesInGroup(c.machineGroupRegexp(machineId))
+}
+
 // Matching a security group by name only works if each name is unqiue.  Neutron
 // security groups are not required to have unique names.  Juju constructs unique
 // names, but there are frequently multiple matches to 'default'
@@ -856,7 +954,8 @@ func (c *neutronFirewaller) openPortsInGroup(nameRegExp string, rules []network.
 
 // secGroupMatchesIngressRule checks if supplied nova security group rule matches the ingress rule
 func secGroupMatchesIngressRule(secGroupRule neutron.SecurityGroupRuleV2, rule network.IngressRule) bool {
-	if secGroupRule.IPProtocol == nil ||
+	if secGroupRule.Direction == "egress" ||
+		secGroupRule.IPProtocol == nil ||
 		secGroupRule.PortRangeMax == nil || *secGroupRule.PortRangeMax == 0 ||
 		secGroupRule.PortRangeMin == nil || *secGroupRule.PortRangeMin == 0 {
 		return false
@@ -880,6 +979,199 @@ func secGroupMatchesIngressRule(secGroupRule neutron.SecurityGroupRuleV2, rule n
 	return false
 }
 
+// isDefaultEgressRule reports whether the security group rule is one of
+// the rules, allowing all egress, that Neutron creates in new groups.
+func isDefaultEgressRule(secGroupRule neutron.SecurityGroupRuleV2) bool {
+	return secGroupRule.Direction == "egress" &&
+		secGroupRule.IPProtocol == nil &&
+		secGroupRule.RemoteIPPrefix == ""
+}
+
+// defaultEgressRules returns the rules, allowing all egress, that Neutron
+// creates in new groups.
+func defaultEgressRules(groupId string) []neutron.RuleInfoV2 {
+	return []neutron.RuleInfoV2{{
+		Direction:     "egress",
+		EthernetType:  "IPv4",
+		ParentGroupId: groupId,
+	}, {
+		Direction:     "egress",
+		EthernetType:  "IPv6",
+		ParentGroupId: groupId,
+	}}
+}
+
+// anyDestinationCIDRs holds the destinations of an egress rule with no
+// explicit destinations: all IPv4 and all IPv6 addresses.
+var anyDestinationCIDRs = []string{"0.0.0.0/0", "::/0"}
+
+// egressRulesToRuleInfo returns the security group rules for the egress
+// rules, one for each destination.
+func egressRulesToRuleInfo(groupId string, rules []network.EgressRule) []neutron.RuleInfoV2 {
+	var result []neutron.RuleInfoV2
+	for _, r := range rules {
+		ruleInfo := neutron.RuleInfoV2{
+			Direction:     "egress",
+			ParentGroupId: groupId,
+			PortRangeMin:  r.FromPort,
+			PortRangeMax:  r.ToPort,
+			IPProtocol:    r.Protocol,
+		}
+		destinationCIDRs := r.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = anyDestinationCIDRs
+		}
+		for _, cidr := range destinationCIDRs {
+			ruleInfo.RemoteIPPrefix = cidr
+			ruleInfo.EthernetType = "IPv4"
+			if strings.Contains(cidr, ":") {
+				ruleInfo.EthernetType = "IPv6"
+			}
+			result = append(result, ruleInfo)
+		}
+	}
+	return result
+}
+
+// groupHasEgressRule reports whether the security group rules already
+// include the egress rule.
+func groupHasEgressRule(secGroupRules []neutron.SecurityGroupRuleV2, rule neutron.RuleInfoV2) bool {
+	for _, p := range secGroupRules {
+		if p.Direction != "egress" ||
+			p.IPProtocol == nil || *p.IPProtocol != rule.IPProtocol ||
+			p.PortRangeMin == nil || *p.PortRangeMin != rule.PortRangeMin ||
+			p.PortRangeMax == nil || *p.PortRangeMax != rule.PortRangeMax ||
+			p.RemoteIPPrefix != rule.RemoteIPPrefix {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// secGroupMatchesEgressRule checks if supplied security group rule matches the egress rule
+func secGroupMatchesEgressRule(secGroupRule neutron.SecurityGroupRuleV2, rule network.EgressRule) bool {
+	if secGroupRule.Direction != "egress" {
+		return false
+	}
+	if len(rule.DestinationCIDRs) == 0 && secGroupRule.RemoteIPPrefix == "::/0" {
+		rule.DestinationCIDRs = anyDestinationCIDRs
+	}
+	ingressRule := network.IngressRule{PortRange: rule.PortRange, SourceCIDRs: rule.DestinationCIDRs}
+	secGroupRule.Direction = "ingress"
+	return secGroupMatchesIngressRule(secGroupRule, ingressRule)
+}
+
+// allowEgressInGroup adds the egress rules to the group. Once the group
+// has egress rules, Neutron's default rules allowing all egress are
+// removed so that only the allowed egress is possible.
+func (c *neutronFirewaller) allowEgressInGroup(nameRegExp string, rules []network.EgressRule) error {
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	for _, rule := range egressRulesToRuleInfo(group.Id, rules) {
+		if groupHasEgressRule(group.Rules, rule) {
+			continue
+		}
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(rule); err != nil {
+			return errors.Annotate(err, "cannot create egress security group rule")
+		}
+	}
+	for _, p := range group.Rules {
+		if !isDefaultEgressRule(p) {
+			continue
+		}
+		if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+			return errors.Annotate(err, "cannot delete default egress security group rule")
+		}
+	}
+	return nil
+}
+
+// disallowEgressInGroup removes the egress rules from the group. If the
+// group is left with no egress rules, Neutron's default rules allowing
+// all egress are restored.
+func (c *neutronFirewaller) disallowEgressInGroup(nameRegExp string, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	deleted := make(map[string]bool)
+	for _, rule := range rules {
+		for _, p := range group.Rules {
+			if deleted[p.Id] || !secGroupMatchesEgressRule(p, rule) {
+				continue
+			}
+			if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+				return errors.Trace(err)
+			}
+			deleted[p.Id] = true
+		}
+	}
+	for _, p := range group.Rules {
+		if p.Direction == "egress" && !deleted[p.Id] {
+			// The group still restricts egress, or already allows
+			// all of it.
+			return nil
+		}
+	}
+	for _, rule := range defaultEgressRules(group.Id) {
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(rule); err != nil {
+			return errors.Annotate(err, "cannot restore default egress security group rule")
+		}
+	}
+	return nil
+}
+
+func (c *neutronFirewaller) egressRulesInGroup(nameRegexp string) ([]network.EgressRule, error) {
+	group, err := c.matchingGroup(nameRegexp)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Keep track of all the RemoteIPPrefixes for each port range.
+	portDestinationCIDRs := make(map[network.PortRange][]string)
+	for _, p := range group.Rules {
+		// Skip ingress rules and the default egress rules created by Neutron.
+		if p.Direction != "egress" || p.IPProtocol == nil {
+			continue
+		}
+		portRange := network.PortRange{
+			Protocol: *p.IPProtocol,
+		}
+		if p.PortRangeMin != nil {
+			portRange.FromPort = *p.PortRangeMin
+		}
+		if p.PortRangeMax != nil {
+			portRange.ToPort = *p.PortRangeMax
+		}
+		remotePrefix := p.RemoteIPPrefix
+		if remotePrefix == "" {
+			remotePrefix = "0.0.0.0/0"
+		}
+		portDestinationCIDRs[portRange] = append(portDestinationCIDRs[portRange], remotePrefix)
+	}
+	var rules []network.EgressRule
+	for portRange, destinationCIDRs := range portDestinationCIDRs {
+		rule, err := network.NewEgressRule(
+			portRange.Protocol,
+			portRange.FromPort,
+			portRange.ToPort,
+			destinationCIDRs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
 func (c *neutronFirewaller) closePortsInGroup(nameRegExp string, rules []network.IngressRule) error {
 	if len(rules) == 0 {
 		return nil
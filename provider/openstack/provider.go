@@ -304,6 +304,7 @@ var _ environs.NetworkingEnviron = (*Environ)(nil)
 var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.EgressFirewaller = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -467,6 +468,30 @@ func (inst *openstackInstance) IngressRules(ctx context.ProviderCallContext, mac
 	return inst.e.firewaller.InstanceIngressRules(ctx, inst, machineId)
 }
 
+func (inst *openstackInstance) AllowEgress(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error {
+	fw, ok := inst.e.firewaller.(EgressFirewaller)
+	if !ok {
+		return errors.NotSupportedf("egress rules")
+	}
+	return fw.AllowInstanceEgress(ctx, inst, machineId, rules)
+}
+
+func (inst *openstackInstance) DisallowEgress(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error {
+	fw, ok := inst.e.firewaller.(EgressFirewaller)
+	if !ok {
+		return errors.NotSupportedf("egress rules")
+	}
+	return fw.DisallowInstanceEgress(ctx, inst, machineId, rules)
+}
+
+func (inst *openstackInstance) EgressRules(ctx context.ProviderCallContext, machineId string) ([]network.EgressRule, error) {
+	fw, ok := inst.e.firewaller.(EgressFirewaller)
+	if !ok {
+		return nil, errors.NotSupportedf("egress rules")
+	}
+	return fw.InstanceEgressRules(ctx, inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -1760,6 +1785,14 @@ func (e *Environ) SupportsSpaces(ctx context.ProviderCallContext) (bool, error)
 	return false, nil
 }
 
+// SupportsEgressRules is specified on environs.EgressFirewaller.
+// Egress can only be restricted when security groups are managed
+// through Neutron.
+func (e *Environ) SupportsEgressRules(ctx context.ProviderCallContext) (bool, error) {
+	_, ok := e.firewaller.(EgressFirewaller)
+	return ok, nil
+}
+
 // SupportsSpaceDiscovery is specified on environs.Networking.
 func (e *Environ) SupportsSpaceDiscovery(ctx context.ProviderCallContext) (bool, error) {
 	return false, nil
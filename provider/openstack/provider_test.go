@@ -369,6 +369,16 @@ func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 			RemoteIPPrefix: "192.168.100.0/24",
 		},
 		expected: false,
+	}, {
+		about: "egress rule",
+		rule:  network.MustNewIngressRule(proto_tcp, 80, 80),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:    "egress",
+			IPProtocol:   &proto_tcp,
+			PortRangeMin: &port_80,
+			PortRangeMax: &port_80,
+		},
+		expected: false,
 	}}
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
@@ -376,6 +386,113 @@ func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 	}
 }
 
+func (*localTests) TestSecGroupMatchesEgressRule(c *gc.C) {
+	proto_tcp := "tcp"
+	port_443 := 443
+
+	testCases := []struct {
+		about        string
+		rule         network.EgressRule
+		secGroupRule neutron.SecurityGroupRuleV2
+		expected     bool
+	}{{
+		about: "default destination",
+		rule:  network.MustNewEgressRule(proto_tcp, 443, 443),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:      "egress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_443,
+			PortRangeMax:   &port_443,
+			RemoteIPPrefix: "0.0.0.0/0",
+		},
+		expected: true,
+	}, {
+		about: "default IPv6 destination",
+		rule:  network.MustNewEgressRule(proto_tcp, 443, 443),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:      "egress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_443,
+			PortRangeMax:   &port_443,
+			RemoteIPPrefix: "::/0",
+		},
+		expected: true,
+	}, {
+		about: "matching destination",
+		rule:  network.MustNewEgressRule(proto_tcp, 443, 443, "10.0.0.0/8"),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:      "egress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_443,
+			PortRangeMax:   &port_443,
+			RemoteIPPrefix: "10.0.0.0/8",
+		},
+		expected: true,
+	}, {
+		about: "ingress rule",
+		rule:  network.MustNewEgressRule(proto_tcp, 443, 443),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:    "ingress",
+			IPProtocol:   &proto_tcp,
+			PortRangeMin: &port_443,
+			PortRangeMax: &port_443,
+		},
+		expected: false,
+	}, {
+		about: "default egress rule",
+		rule:  network.MustNewEgressRule(proto_tcp, 443, 443),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction: "egress",
+		},
+		expected: false,
+	}}
+	for i, t := range testCases {
+		c.Logf("test %d: %s", i, t.about)
+		c.Check(SecGroupMatchesEgressRule(t.secGroupRule, t.rule), gc.Equals, t.expected)
+	}
+}
+
+func (*localTests) TestEgressRulesToRuleInfo(c *gc.C) {
+	rules := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8", "2001:db8::/32"),
+	}
+	ruleInfo := EgressRulesToRuleInfo("group-id", rules)
+	c.Assert(ruleInfo, jc.DeepEquals, []neutron.RuleInfoV2{{
+		Direction:      "egress",
+		IPProtocol:     "tcp",
+		PortRangeMin:   443,
+		PortRangeMax:   443,
+		RemoteIPPrefix: "0.0.0.0/0",
+		EthernetType:   "IPv4",
+		ParentGroupId:  "group-id",
+	}, {
+		Direction:      "egress",
+		IPProtocol:     "tcp",
+		PortRangeMin:   443,
+		PortRangeMax:   443,
+		RemoteIPPrefix: "::/0",
+		EthernetType:   "IPv6",
+		ParentGroupId:  "group-id",
+	}, {
+		Direction:      "egress",
+		IPProtocol:     "udp",
+		PortRangeMin:   53,
+		PortRangeMax:   53,
+		RemoteIPPrefix: "10.0.0.0/8",
+		EthernetType:   "IPv4",
+		ParentGroupId:  "group-id",
+	}, {
+		Direction:      "egress",
+		IPProtocol:     "udp",
+		PortRangeMin:   53,
+		PortRangeMax:   53,
+		RemoteIPPrefix: "2001:db8::/32",
+		EthernetType:   "IPv6",
+		ParentGroupId:  "group-id",
+	}})
+}
+
 func (s *localTests) TestDetectRegionsNoRegionName(c *gc.C) {
 	_, err := s.detectRegions(c)
 	c.Assert(err, gc.ErrorMatches, "OS_REGION_NAME environment variable not set")
@@ -13,6 +13,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state/storage"
 )
 
 const (
@@ -104,6 +106,33 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// ResultsOverflow is true when the results were too large to be
+	// stored with the action and are held in blob storage instead.
+	ResultsOverflow bool `bson:"results-overflow,omitempty"`
+
+	// Logs holds the progress messages logged by the action.
+	Logs []ActionMessage `bson:"messages"`
+
+	// Batch is the id of the action batch that queued the action,
+	// if any.
+	Batch string `bson:"batch,omitempty"`
+}
+
+// ActionMessage represents a progress message logged by an action.
+type ActionMessage struct {
+	MessageValue   string    `bson:"message" json:"message"`
+	TimestampValue time.Time `bson:"timestamp" json:"timestamp"`
+}
+
+// Timestamp returns the message timestamp.
+func (m ActionMessage) Timestamp() time.Time {
+	return m.TimestampValue
+}
+
+// Message returns the message string.
+func (m ActionMessage) Message() string {
+	return m.MessageValue
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +191,11 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -210,6 +244,39 @@ func (a *action) Begin() (Action, error) {
 	return m.Action(a.Id())
 }
 
+// maxActionMessages is the number of progress messages kept for an
+// action; older messages are discarded as new ones are logged.
+var maxActionMessages = 1000
+
+// Log adds a timestamped progress message to the action, keeping only
+// the most recent maxActionMessages messages.
+// It asserts that the action is currently running.
+func (a *action) Log(message string) error {
+	m, err := a.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = m.st.db().RunTransaction([]txn.Op{
+		{
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: bson.D{{"status", ActionRunning}},
+			Update: bson.D{{"$push", bson.D{
+				{"messages", bson.D{
+					{"$each", []ActionMessage{{
+						MessageValue:   message,
+						TimestampValue: a.st.clock().Now().UTC(),
+					}}},
+					{"$slice", -maxActionMessages},
+				}},
+			}}},
+		}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message to action %q: action not running", a.Id())
+	}
+	return errors.Trace(err)
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
@@ -225,28 +292,68 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 		return nil, errors.Trace(err)
 	}
 
-	err = m.st.db().RunTransaction([]txn.Op{
-		{
-			C:  actionsC,
-			Id: a.doc.DocId,
-			Assert: bson.D{{"status", bson.D{
-				{"$nin", []interface{}{
-					ActionCompleted,
-					ActionCancelled,
-					ActionFailed,
-				}}}}},
-			Update: bson.D{{"$set", bson.D{
-				{"status", finalStatus},
-				{"message", message},
-				{"results", results},
-				{"completed", a.st.nowToTheSecond()},
-			}}},
-		}, {
-			C:      actionNotificationsC,
-			Id:     m.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
-			Remove: true,
-		}})
+	outputOps, outputPath, err := a.storeActionOutput(results)
 	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	overflow := outputPath != ""
+	if overflow {
+		results = nil
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			current, err := m.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			switch current.Status() {
+			case ActionCompleted, ActionCancelled, ActionFailed:
+				return nil, errors.Errorf("action %q already finished", a.Id())
+			}
+		}
+		ops := []txn.Op{
+			{
+				C:  actionsC,
+				Id: a.doc.DocId,
+				Assert: bson.D{{"status", bson.D{
+					{"$nin", []interface{}{
+						ActionCompleted,
+						ActionCancelled,
+						ActionFailed,
+					}}}}},
+				Update: bson.D{{"$set", bson.D{
+					{"status", finalStatus},
+					{"message", message},
+					{"results", results},
+					{"results-overflow", overflow},
+					{"completed", a.st.nowToTheSecond()},
+				}}},
+			}, {
+				C:      actionNotificationsC,
+				Id:     m.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
+				Remove: true,
+			}}
+		ops = append(ops, outputOps...)
+		if a.doc.Batch != "" {
+			// The batch is advanced in the same transaction, so
+			// the action never finishes without releasing the
+			// batch's next actions.
+			batchOps, err := m.st.advanceActionBatchOps(a.doc.Batch, a.Id(), finalStatus)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, batchOps...)
+		}
+		return ops, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		if overflow {
+			stor := storage.NewStorage(a.st.ModelUUID(), a.st.MongoSession())
+			if err := stor.Remove(outputPath); err != nil {
+				actionLogger.Errorf("cannot remove output of action %q: %v", a.Id(), err)
+			}
+		}
 		return nil, err
 	}
 	return m.Action(a.Id())
@@ -483,8 +590,60 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 // PruneActions removes action entries until
 // only logs newer than <maxLogTime> remain and also ensures
 // that the collection is smaller than <maxLogsMB> after the
-// deletion.
+// deletion. Actions whose names have a retention override in
+// the model config are pruned by that age instead; an override
+// of zero keeps them until the collection is pruned by size.
+// The stored output of pruned actions is removed too.
 func PruneActions(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
-	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", GoTime)
-	return errors.Trace(err)
+	retention, err := actionRetention(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(retention) == 0 {
+		err = pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", GoTime)
+	} else {
+		err = pruneActionsWithRetention(st, maxHistoryTime, maxHistoryMB, retention)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(pruneActionOutputs(st))
+}
+
+func pruneActionsWithRetention(st *State, maxHistoryTime time.Duration, maxHistoryMB int, retention map[string]time.Duration) error {
+	entries, closer := st.db().GetRawCollection(actionsC)
+	defer closer()
+
+	var names []string
+	for name, maxAge := range retention {
+		names = append(names, name)
+		p := collectionPruner{
+			st:       st,
+			coll:     entries,
+			maxAge:   maxAge,
+			ageField: "completed",
+			timeUnit: GoTime,
+			filter:   bson.D{{"name", name}},
+		}
+		if err := p.pruneByAge(); err != nil {
+			return errors.Annotatef(err, "pruning %q actions", name)
+		}
+	}
+
+	p := collectionPruner{
+		st:       st,
+		coll:     entries,
+		maxAge:   maxHistoryTime,
+		maxSize:  maxHistoryMB,
+		ageField: "completed",
+		timeUnit: GoTime,
+		filter:   bson.D{{"name", bson.D{{"$nin", names}}}},
+	}
+	if err := p.validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.pruneByAge(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(p.pruneBySize())
 }
@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -484,6 +486,323 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestLogMessage(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Logging to a pending action fails.
+	err = a.Log("hello")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("hello")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("world")
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message(), gc.Equals, "hello")
+	c.Assert(messages[1].Message(), gc.Equals, "world")
+	c.Assert(messages[0].Timestamp().IsZero(), jc.IsFalse)
+
+	// Logging to a completed action fails.
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action not running`)
+}
+
+func (s *ActionSuite) TestLogMessageKeepsMostRecent(c *gc.C) {
+	s.PatchValue(state.MaxActionMessages, 2)
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, message := range []string{"one", "two", "three"} {
+		err = a.Log(message)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message(), gc.Equals, "two")
+	c.Assert(messages[1].Message(), gc.Equals, "three")
+}
+
+func (s *ActionSuite) TestFinishStoresLargeResultsInBlobStorage(c *gc.C) {
+	err := s.model.UpdateModelConfig(map[string]interface{}{
+		"max-action-output-size": "1K",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	small, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	small, err = small.Finish(state.ActionResults{
+		Status:  state.ActionCompleted,
+		Results: map[string]interface{}{"out": "small"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(small.ResultsOverflow(), jc.IsFalse)
+	results, _ := small.Results()
+	c.Assert(results, jc.DeepEquals, map[string]interface{}{"out": "small"})
+	overflowed, err := s.State.HasActionOutputs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overflowed, jc.IsFalse)
+
+	large, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	output := strings.Repeat("x", 2048)
+	large, err = large.Finish(state.ActionResults{
+		Status:  state.ActionCompleted,
+		Results: map[string]interface{}{"out": output},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(large.ResultsOverflow(), jc.IsTrue)
+	results, _ = large.Results()
+	c.Assert(results, gc.HasLen, 0)
+	overflowed, err = s.State.HasActionOutputs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overflowed, jc.IsTrue)
+
+	results, err = large.FullResults()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, map[string]interface{}{"out": output})
+}
+
+func (s *ActionSuite) TestPruneActionsRemovesOverflowOutput(c *gc.C) {
+	clock := test.NewClock(coretesting.NonZeroTime())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.model.UpdateModelConfig(map[string]interface{}{
+		"max-action-output-size": "1K",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Finish(state.ActionResults{
+		Status:  state.ActionCompleted,
+		Results: map[string]interface{}{"out": strings.Repeat("x", 2048)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.ResultsOverflow(), jc.IsTrue)
+
+	clock.Advance(2 * time.Hour)
+	err = state.PruneActions(s.State, time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.model.Action(a.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	_, _, err = stor.Get("actions/" + a.Id() + "/output")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchActionLogs(a.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+
+	decode := func(changes []string) []string {
+		var messages []string
+		for _, change := range changes {
+			var m state.ActionMessage
+			err := json.Unmarshal([]byte(change), &m)
+			c.Assert(err, jc.ErrorIsNil)
+			messages = append(messages, m.Message())
+		}
+		return messages
+	}
+
+	select {
+	case changes, ok := <-w.Changes():
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(decode(changes), jc.DeepEquals, []string{"first"})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("watcher did not send initial event")
+	}
+	wc.AssertNoChange()
+
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+	select {
+	case changes, ok := <-w.Changes():
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(decode(changes), jc.DeepEquals, []string{"second"})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("watcher did not send change")
+	}
+	wc.AssertNoChange()
+}
+
+func (s *ActionSuite) assertActionNotifications(c *gc.C, u *state.Unit, ids ...string) {
+	w := u.WatchActionNotifications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(ids...)
+	wc.AssertNoChange()
+}
+
+func (s *ActionSuite) TestAddActionBatchReleasesWithConcurrency(c *gc.C) {
+	batch, err := s.application.AddActionBatch(state.ActionBatchArgs{
+		Name:           "snapshot",
+		MaxConcurrency: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(batch.Application(), gc.Equals, "dummy")
+	c.Assert(batch.Name(), gc.Equals, "snapshot")
+	c.Assert(batch.MaxConcurrency(), gc.Equals, 2)
+
+	actions, err := batch.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 3)
+	c.Assert(actions[0].Receiver(), gc.Equals, s.unit.Name())
+	c.Assert(actions[1].Receiver(), gc.Equals, s.unit2.Name())
+	c.Assert(actions[2].Receiver(), gc.Equals, s.charmlessUnit.Name())
+
+	// Only the first two actions are released to their units.
+	s.assertActionNotifications(c, s.unit, actions[0].Id())
+	s.assertActionNotifications(c, s.unit2, actions[1].Id())
+	s.assertActionNotifications(c, s.charmlessUnit)
+
+	// Finishing one releases the next.
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionNotifications(c, s.charmlessUnit, actions[2].Id())
+}
+
+func (s *ActionSuite) TestAddActionBatchConcurrentFinish(c *gc.C) {
+	batch, err := s.application.AddActionBatch(state.ActionBatchArgs{
+		Name:           "snapshot",
+		MaxConcurrency: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	actions, err := batch.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 3)
+
+	// Another action in the batch finishing while the first does is
+	// seen by the first, and the last action is released just once.
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := actions[1].Finish(state.ActionResults{Status: state.ActionCompleted})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertActionNotifications(c, s.unit)
+	s.assertActionNotifications(c, s.unit2)
+	s.assertActionNotifications(c, s.charmlessUnit, actions[2].Id())
+	actions, err = batch.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions[0].Status(), gc.Equals, state.ActionCompleted)
+	c.Assert(actions[1].Status(), gc.Equals, state.ActionCompleted)
+	c.Assert(actions[2].Status(), gc.Equals, state.ActionPending)
+}
+
+func (s *ActionSuite) TestFinishActionTwice(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := a.Finish(state.ActionResults{Status: state.ActionCompleted})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+	_, err = a.Finish(state.ActionResults{Status: state.ActionFailed})
+	c.Assert(err, gc.ErrorMatches, `action ".*" already finished`)
+}
+
+func (s *ActionSuite) TestAddActionBatchStopOnFailures(c *gc.C) {
+	batch, err := s.application.AddActionBatch(state.ActionBatchArgs{
+		Name:           "snapshot",
+		MaxConcurrency: 1,
+		StopOnFailures: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	actions, err := batch.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 3)
+
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionFailed, Message: "oops"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	batch, err = s.model.ActionBatch(batch.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(batch.Aborted(), jc.IsTrue)
+	actions, err = batch.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions[0].Status(), gc.Equals, state.ActionFailed)
+	c.Assert(actions[1].Status(), gc.Equals, state.ActionCancelled)
+	c.Assert(actions[2].Status(), gc.Equals, state.ActionCancelled)
+	s.assertActionNotifications(c, s.unit2)
+	s.assertActionNotifications(c, s.charmlessUnit)
+}
+
+func (s *ActionSuite) TestHasUnreleasedBatchActions(c *gc.C) {
+	unreleased, err := s.State.HasUnreleasedBatchActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unreleased, jc.IsFalse)
+
+	batch, err := s.application.AddActionBatch(state.ActionBatchArgs{
+		Name:           "snapshot",
+		MaxConcurrency: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unreleased, err = s.State.HasUnreleasedBatchActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unreleased, jc.IsTrue)
+
+	actions, err := batch.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	unreleased, err = s.State.HasUnreleasedBatchActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unreleased, jc.IsFalse)
+}
+
+func (s *ActionSuite) TestAddActionBatchValidates(c *gc.C) {
+	_, err := s.application.AddActionBatch(state.ActionBatchArgs{
+		Name:           "snapshot",
+		MaxConcurrency: -1,
+	})
+	c.Assert(err, gc.ErrorMatches, "negative max concurrency -1 not valid")
+
+	_, err = s.application.AddActionBatch(state.ActionBatchArgs{Name: "nope"})
+	c.Assert(err, gc.ErrorMatches, `action "nope" not defined on application "dummy"`)
+
+	_, err = s.actionlessApplication.AddActionBatch(state.ActionBatchArgs{Name: "snapshot"})
+	c.Assert(err, gc.ErrorMatches, `no actions defined on charm .*`)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -1085,6 +1404,38 @@ func (s *ActionPruningSuite) TestPruneActionByAge(c *gc.C) {
 	c.Assert(actionsLen, gc.Equals, numCurrentActionEntries)
 }
 
+func (s *ActionPruningSuite) TestPruneActionsWithRetention(c *gc.C) {
+	clock := test.NewClock(time.Now())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"action-retention": "backup=20h,status=0s",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+
+	now := clock.Now()
+	state.PrimeNamedActions(c, now.Add(-10*time.Hour), unit, "backup", 2)
+	state.PrimeNamedActions(c, now.Add(-30*time.Hour), unit, "backup", 3)
+	state.PrimeNamedActions(c, now.Add(-30*time.Hour), unit, "status", 4)
+	state.PrimeNamedActions(c, now.Add(-10*time.Hour), unit, "restore", 5)
+
+	err = state.PruneActions(s.State, 1*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := unit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	remaining := make(map[string]int)
+	for _, a := range actions {
+		remaining[a.Name()]++
+	}
+	c.Assert(remaining, jc.DeepEquals, map[string]int{
+		"backup": 2,
+		"status": 4,
+	})
+}
+
 // Pruner should not prune actions with age of epoch time since the epoch is a
 // special value denoting an incomplete action.
 func (s *ActionPruningSuite) TestDoNotPruneIncompleteActions(c *gc.C) {
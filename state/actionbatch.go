@@ -0,0 +1,426 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// ActionBatchArgs holds the arguments for running an action on all
+// the units of an application.
+type ActionBatchArgs struct {
+	// Name is the name of the action to run.
+	Name string
+
+	// Parameters holds the action's parameters, if any.
+	Parameters map[string]interface{}
+
+	// MaxConcurrency is the maximum number of units that may run the
+	// action at the same time. Zero means no limit.
+	MaxConcurrency int
+
+	// StopOnFailures is the number of failed actions after which the
+	// actions not yet released to units are cancelled. Zero means that
+	// failures never stop the batch.
+	StopOnFailures int
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args ActionBatchArgs) Validate() error {
+	if args.Name == "" {
+		return errors.NotValidf("empty action name")
+	}
+	if args.MaxConcurrency < 0 {
+		return errors.NotValidf("negative max concurrency %d", args.MaxConcurrency)
+	}
+	if args.StopOnFailures < 0 {
+		return errors.NotValidf("negative stop on failures %d", args.StopOnFailures)
+	}
+	return nil
+}
+
+// actionBatchDoc records an action run across the units of an
+// application. Actions are released to units, by creating their
+// notification documents, no more than MaxConcurrency at a time.
+type actionBatchDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	TxnRevno  int64  `bson:"txn-revno"`
+
+	Application    string    `bson:"application"`
+	Name           string    `bson:"name"`
+	MaxConcurrency int       `bson:"max-concurrency"`
+	StopOnFailures int       `bson:"stop-on-failures"`
+	Enqueued       time.Time `bson:"enqueued"`
+
+	// Actions holds the ids of the batch's actions, in the order in
+	// which they are released.
+	Actions []string `bson:"actions"`
+
+	// Released is the number of actions, from the start of Actions,
+	// that have been released to their units.
+	Released int `bson:"released"`
+
+	// Aborted is set when the failure budget has been exhausted and
+	// the unreleased actions have been cancelled.
+	Aborted bool `bson:"aborted"`
+}
+
+// ActionBatch represents an action run across the units of an
+// application.
+type ActionBatch struct {
+	st  *State
+	doc actionBatchDoc
+}
+
+// Id returns the id of the action batch.
+func (b *ActionBatch) Id() string {
+	return b.st.localID(b.doc.DocId)
+}
+
+// Application returns the name of the application whose units run
+// the batch's actions.
+func (b *ActionBatch) Application() string {
+	return b.doc.Application
+}
+
+// Name returns the name of the action run by the batch.
+func (b *ActionBatch) Name() string {
+	return b.doc.Name
+}
+
+// MaxConcurrency returns the maximum number of units that may run
+// the action at the same time; zero means no limit.
+func (b *ActionBatch) MaxConcurrency() int {
+	return b.doc.MaxConcurrency
+}
+
+// StopOnFailures returns the number of failures after which the
+// unreleased actions are cancelled; zero means no limit.
+func (b *ActionBatch) StopOnFailures() int {
+	return b.doc.StopOnFailures
+}
+
+// Enqueued returns the time the batch was created.
+func (b *ActionBatch) Enqueued() time.Time {
+	return b.doc.Enqueued
+}
+
+// Aborted reports whether the batch stopped because its failure
+// budget was exhausted.
+func (b *ActionBatch) Aborted() bool {
+	return b.doc.Aborted
+}
+
+// Actions returns the batch's actions, in the order in which they
+// are released to units.
+func (b *ActionBatch) Actions() ([]Action, error) {
+	docs, err := b.st.actionBatchActionDocs(b.doc.Actions)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Action, len(b.doc.Actions))
+	for i, id := range b.doc.Actions {
+		doc, ok := docs[id]
+		if !ok {
+			return nil, errors.NotFoundf("action %q", id)
+		}
+		result[i] = newAction(b.st, doc)
+	}
+	return result, nil
+}
+
+// ActionBatch returns the action batch with the given id.
+func (m *Model) ActionBatch(id string) (*ActionBatch, error) {
+	doc, err := m.st.actionBatchDoc(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionBatch{st: m.st, doc: *doc}, nil
+}
+
+func (st *State) actionBatchDoc(id string) (*actionBatchDoc, error) {
+	batches, closer := st.db().GetCollection(actionBatchesC)
+	defer closer()
+
+	var doc actionBatchDoc
+	err := batches.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action batch %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action batch %q", id)
+	}
+	return &doc, nil
+}
+
+// HasUnreleasedBatchActions reports whether any action batch in the
+// model still holds pending actions that have not been released to
+// their units.
+func (st *State) HasUnreleasedBatchActions() (bool, error) {
+	batches, closer := st.db().GetCollection(actionBatchesC)
+	defer closer()
+
+	var docs []actionBatchDoc
+	if err := batches.Find(bson.D{{"aborted", false}}).All(&docs); err != nil {
+		return false, errors.Annotate(err, "cannot get action batches")
+	}
+	for _, batch := range docs {
+		if batch.Released >= len(batch.Actions) {
+			continue
+		}
+		actions, err := st.actionBatchActionDocs(batch.Actions[batch.Released:])
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		for _, doc := range actions {
+			if doc.Status == ActionPending {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// actionBatchActionDocs returns the action documents with the given
+// ids, keyed by id.
+func (st *State) actionBatchActionDocs(ids []string) (map[string]actionDoc, error) {
+	actionsCollection, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	docIds := make([]string, len(ids))
+	for i, id := range ids {
+		docIds[i] = st.docID(id)
+	}
+	var docs []actionDoc
+	if err := actionsCollection.Find(bson.D{{"_id", bson.D{{"$in", docIds}}}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get actions")
+	}
+	result := make(map[string]actionDoc, len(docs))
+	for _, doc := range docs {
+		result[st.localID(doc.DocId)] = doc
+	}
+	return result, nil
+}
+
+// AddActionBatch queues the named action on every unit of the
+// application. The actions are released to at most MaxConcurrency
+// units at a time; further actions are released as earlier ones
+// finish. Once StopOnFailures actions have failed, the actions not
+// yet released are cancelled.
+func (a *Application) AddActionBatch(args ActionBatchArgs) (*ActionBatch, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	payload, err := a.actionPayloadWithDefaults(args.Name, args.Parameters)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Sort(unitsByNumber(units))
+
+	batchId, err := NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	batch := actionBatchDoc{
+		DocId:          a.st.docID(batchId.String()),
+		ModelUUID:      a.st.ModelUUID(),
+		Application:    a.doc.Name,
+		Name:           args.Name,
+		MaxConcurrency: args.MaxConcurrency,
+		StopOnFailures: args.StopOnFailures,
+		Enqueued:       a.st.nowToTheSecond(),
+	}
+	var ops []txn.Op
+	for _, u := range units {
+		if u.Life() == Dead {
+			continue
+		}
+		doc, ndoc, err := newActionDoc(a.st, u.Tag(), args.Name, payload)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc.Batch = batchId.String()
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}, txn.Op{
+			C:      actionsC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		})
+		if args.MaxConcurrency == 0 || batch.Released < args.MaxConcurrency {
+			ops = append(ops, txn.Op{
+				C:      actionNotificationsC,
+				Id:     ndoc.DocId,
+				Assert: txn.DocMissing,
+				Insert: ndoc,
+			})
+			batch.Released++
+		}
+		batch.Actions = append(batch.Actions, ndoc.ActionID)
+	}
+	if len(batch.Actions) == 0 {
+		return nil, errors.Errorf("application %q has no units", a.doc.Name)
+	}
+	ops = append(ops, txn.Op{
+		C:      actionBatchesC,
+		Id:     batch.DocId,
+		Assert: txn.DocMissing,
+		Insert: batch,
+	})
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			return nil, errors.Errorf("cannot add action batch for application %q: units changed", a.doc.Name)
+		}
+		return nil, errors.Annotatef(err, "cannot add action batch for application %q", a.doc.Name)
+	}
+	return &ActionBatch{st: a.st, doc: batch}, nil
+}
+
+// actionPayloadWithDefaults validates the action parameters against
+// the application's charm, and fills in any defaults.
+func (a *Application) actionPayloadWithDefaults(name string, payload map[string]interface{}) (map[string]interface{}, error) {
+	spec, ok := actions.PredefinedActionsSpec[name]
+	if !ok {
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		chActions := ch.Actions()
+		if chActions == nil || len(chActions.ActionSpecs) == 0 {
+			return nil, errors.Errorf("no actions defined on charm %q", ch.String())
+		}
+		spec, ok = chActions.ActionSpecs[name]
+		if !ok {
+			return nil, errors.Errorf("action %q not defined on application %q", name, a.doc.Name)
+		}
+	}
+	// Reject bad payloads before attempting to insert defaults.
+	if err := spec.ValidateParams(payload); err != nil {
+		return nil, err
+	}
+	return spec.InsertDefaults(payload)
+}
+
+// advanceActionBatchOps returns the operations that advance the batch
+// when the action with the given id finishes with the given status, to
+// be run in the same transaction. They either cancel the unreleased
+// actions, if the failure budget has been exhausted, or release further
+// actions to their units to keep up to MaxConcurrency of them in flight.
+// The operations assert that the batch is unchanged, so that actions
+// finishing at the same time each see the others' effects on it.
+func (st *State) advanceActionBatchOps(batchId, finishedId string, finishedStatus ActionStatus) ([]txn.Op, error) {
+	batch, err := st.actionBatchDoc(batchId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if batch.Aborted || batch.Released >= len(batch.Actions) {
+		return nil, nil
+	}
+	docs, err := st.actionBatchActionDocs(batch.Actions)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	status := func(id string) ActionStatus {
+		if id == finishedId {
+			return finishedStatus
+		}
+		return docs[id].Status
+	}
+	var failed, inFlight int
+	for _, id := range batch.Actions[:batch.Released] {
+		switch status(id) {
+		case ActionFailed:
+			failed++
+		case ActionPending, ActionRunning:
+			inFlight++
+		}
+	}
+	ops := []txn.Op{{
+		C:      actionBatchesC,
+		Id:     batch.DocId,
+		Assert: bson.D{{"txn-revno", batch.TxnRevno}},
+	}}
+
+	if batch.StopOnFailures > 0 && failed >= batch.StopOnFailures {
+		for _, id := range batch.Actions[batch.Released:] {
+			doc, ok := docs[id]
+			if !ok || doc.Status != ActionPending {
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      actionsC,
+				Id:     doc.DocId,
+				Assert: bson.D{{"status", ActionPending}},
+				Update: bson.D{{"$set", bson.D{
+					{"status", ActionCancelled},
+					{"message", "failure budget exhausted"},
+					{"completed", st.nowToTheSecond()},
+				}}},
+			})
+		}
+		ops[0].Update = bson.D{{"$set", bson.D{{"aborted", true}}}}
+		return ops, nil
+	}
+
+	released := batch.Released
+	for ; released < len(batch.Actions); released++ {
+		if batch.MaxConcurrency > 0 && inFlight >= batch.MaxConcurrency {
+			break
+		}
+		id := batch.Actions[released]
+		doc, ok := docs[id]
+		if !ok || doc.Status != ActionPending {
+			// The action was cancelled before it was released.
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      actionNotificationsC,
+			Id:     st.docID(ensureActionMarker(doc.Receiver) + id),
+			Assert: txn.DocMissing,
+			Insert: actionNotificationDoc{
+				DocId:     st.docID(ensureActionMarker(doc.Receiver) + id),
+				ModelUUID: st.ModelUUID(),
+				Receiver:  doc.Receiver,
+				ActionID:  id,
+			},
+		})
+		inFlight++
+	}
+	if released != batch.Released {
+		ops[0].Update = bson.D{{"$set", bson.D{{"released", released}}}}
+	}
+	return ops, nil
+}
+
+// unitsByNumber sorts units of a single application by unit number.
+type unitsByNumber []*Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i].Name()) < unitNumber(u[j].Name())
+}
+
+func unitNumber(name string) int {
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+	return n
+}
@@ -0,0 +1,175 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state/storage"
+)
+
+// actionOutputDoc records the blob storage path of action results that
+// were too large to be stored with the action itself. The document has
+// the same id as the action it belongs to, so that the blob can be
+// removed once the action has been pruned.
+type actionOutputDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Path      string `bson:"path"`
+}
+
+// actionOutputPath returns the blob storage path for the overflow
+// results of the action with the given id.
+func actionOutputPath(actionId string) string {
+	return "actions/" + actionId + "/output"
+}
+
+// maxActionOutputSize returns the maximum size in bytes of the results
+// that are stored with an action.
+func maxActionOutputSize(st *State) (uint64, error) {
+	cfg, err := getModelConfig(st.db())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return cfg.MaxActionOutputSize(), nil
+}
+
+// storeActionOutput writes the results of the action to blob storage
+// if they are larger than the model's maximum action output size. It
+// returns the operations needed to record the overflow, and the path
+// of the stored blob; if the results fit in the action document, the
+// path is empty.
+func (a *action) storeActionOutput(results map[string]interface{}) ([]txn.Op, string, error) {
+	if len(results) == 0 {
+		return nil, "", nil
+	}
+	maxSize, err := maxActionOutputSize(a.st)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	data, err := bson.Marshal(results)
+	if err != nil {
+		return nil, "", errors.Annotate(err, "marshalling action results")
+	}
+	if uint64(len(data)) <= maxSize {
+		return nil, "", nil
+	}
+	path := actionOutputPath(a.Id())
+	stor := storage.NewStorage(a.st.ModelUUID(), a.st.MongoSession())
+	if err := stor.Put(path, bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, "", errors.Annotate(err, "storing action results")
+	}
+	ops := []txn.Op{{
+		C:      actionOutputsC,
+		Id:     a.doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &actionOutputDoc{
+			DocId:     a.doc.DocId,
+			ModelUUID: a.st.ModelUUID(),
+			Path:      path,
+		},
+	}}
+	return ops, path, nil
+}
+
+// ResultsOverflow returns true if the results of the action were too
+// large to be stored with the action and are held in blob storage.
+func (a *action) ResultsOverflow() bool {
+	return a.doc.ResultsOverflow
+}
+
+// FullResults returns the structured output of the action, reading it
+// from blob storage if it was too large to be stored with the action.
+func (a *action) FullResults() (map[string]interface{}, error) {
+	if !a.doc.ResultsOverflow {
+		return a.doc.Results, nil
+	}
+	stor := storage.NewStorage(a.st.ModelUUID(), a.st.MongoSession())
+	r, _, err := stor.Get(actionOutputPath(a.Id()))
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("output for action %q", a.Id())
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "reading output for action %q", a.Id())
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading output for action %q", a.Id())
+	}
+	var results map[string]interface{}
+	if err := bson.Unmarshal(data, &results); err != nil {
+		return nil, errors.Annotatef(err, "decoding output for action %q", a.Id())
+	}
+	return results, nil
+}
+
+// HasActionOutputs reports whether any action in the model has results
+// too large to be stored with the action, which are held in blob storage.
+func (st *State) HasActionOutputs() (bool, error) {
+	outputs, closer := st.db().GetCollection(actionOutputsC)
+	defer closer()
+
+	n, err := outputs.Find(nil).Count()
+	if err != nil {
+		return false, errors.Annotate(err, "cannot count action outputs")
+	}
+	return n > 0, nil
+}
+
+// actionRetention returns the per action name overrides of the maximum
+// age of completed actions.
+func actionRetention(st *State) (map[string]time.Duration, error) {
+	cfg, err := getModelConfig(st.db())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cfg.ActionRetention(), nil
+}
+
+// pruneActionOutputs removes the stored overflow results of actions
+// that no longer exist.
+func pruneActionOutputs(st *State) error {
+	outputs, closer := st.db().GetCollection(actionOutputsC)
+	defer closer()
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	var docs []actionOutputDoc
+	if err := outputs.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "reading action outputs")
+	}
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	deleted := 0
+	for _, doc := range docs {
+		count, err := actions.FindId(doc.DocId).Count()
+		if err != nil {
+			return errors.Annotate(err, "checking for action")
+		}
+		if count > 0 {
+			continue
+		}
+		if err := stor.Remove(doc.Path); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing action output %q", doc.Path)
+		}
+		ops := []txn.Op{{
+			C:      actionOutputsC,
+			Id:     doc.DocId,
+			Remove: true,
+		}}
+		if err := st.db().RunTransaction(ops); err != nil {
+			return errors.Annotatef(err, "removing action output %q", doc.Path)
+		}
+		deleted++
+	}
+	if deleted > 0 {
+		logger.Infof("action output pruning: %d outputs deleted", deleted)
+	}
+	return nil
+}
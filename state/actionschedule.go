@@ -0,0 +1,270 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/cron"
+)
+
+var validActionScheduleName = regexp.MustCompile("^[a-z][a-z0-9]*(-[a-z0-9]+)*$")
+
+// ActionScheduleArgs holds the arguments for adding an action
+// schedule to a model.
+type ActionScheduleArgs struct {
+	// Name uniquely identifies the schedule within the model.
+	Name string
+
+	// Action is the name of the action to run.
+	Action string
+
+	// Receivers holds the tags of the entities that run the action.
+	Receivers []names.Tag
+
+	// Parameters holds the action's parameters, if any.
+	Parameters map[string]interface{}
+
+	// Schedule is a cron expression describing when the action runs.
+	Schedule string
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args ActionScheduleArgs) Validate() error {
+	if !validActionScheduleName.MatchString(args.Name) {
+		return errors.NotValidf("action schedule name %q", args.Name)
+	}
+	if args.Action == "" {
+		return errors.NotValidf("empty action name")
+	}
+	if len(args.Receivers) == 0 {
+		return errors.NotValidf("action schedule without receivers")
+	}
+	if _, err := cron.Parse(args.Schedule); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// actionScheduleDoc records an action that is enqueued on its
+// receivers whenever its cron expression fires.
+type actionScheduleDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	Name       string                 `bson:"name"`
+	Action     string                 `bson:"action"`
+	Receivers  []string               `bson:"receivers"`
+	Parameters map[string]interface{} `bson:"parameters"`
+	Schedule   string                 `bson:"schedule"`
+	Created    time.Time              `bson:"created"`
+
+	// LastRun is the time the schedule last fired, if it has.
+	LastRun time.Time `bson:"last-run"`
+
+	// NextRun is the time the schedule next fires.
+	NextRun time.Time `bson:"next-run"`
+}
+
+// ActionSchedule represents an action that runs periodically.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Name returns the name of the schedule.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Action returns the name of the action that is run.
+func (s *ActionSchedule) Action() string {
+	return s.doc.Action
+}
+
+// Receivers returns the tags of the entities that run the action.
+func (s *ActionSchedule) Receivers() []string {
+	return s.doc.Receivers
+}
+
+// Parameters returns the parameters the action is run with.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Schedule returns the cron expression describing when the action
+// runs.
+func (s *ActionSchedule) Schedule() string {
+	return s.doc.Schedule
+}
+
+// Created returns the time the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// LastRun returns the time the schedule last fired, or the zero time
+// if it has not yet fired.
+func (s *ActionSchedule) LastRun() time.Time {
+	return s.doc.LastRun
+}
+
+// NextRun returns the time the schedule next fires.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// Advance records that the schedule has fired now, and moves its next
+// run on to the following time its cron expression fires. Runs missed
+// while the schedule was not being advanced are skipped. It returns
+// false, without error, if the schedule has already been advanced past
+// its current next run by someone else, in which case the caller
+// should not run the action.
+func (s *ActionSchedule) Advance() (bool, error) {
+	schedule, err := cron.Parse(s.doc.Schedule)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	now := s.st.nowToTheSecond()
+	next := schedule.Next(now)
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"next-run", s.doc.NextRun}},
+		Update: bson.D{{"$set", bson.D{
+			{"last-run", now},
+			{"next-run", next},
+		}}},
+	}}
+	if err := s.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Annotatef(err, "cannot advance action schedule %q", s.doc.Name)
+	}
+	s.doc.LastRun = now
+	s.doc.NextRun = next
+	return true, nil
+}
+
+// AddActionSchedule adds a schedule that enqueues the action on its
+// receivers whenever the cron expression fires.
+func (m *Model) AddActionSchedule(args ActionScheduleArgs) (*ActionSchedule, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Annotate(err, "cannot add action schedule")
+	}
+	schedule, err := cron.Parse(args.Schedule)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := m.st.nowToTheSecond()
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return nil, errors.NotValidf("cron expression %q that never fires", args.Schedule)
+	}
+	doc := actionScheduleDoc{
+		DocId:      m.st.docID(args.Name),
+		ModelUUID:  m.st.ModelUUID(),
+		Name:       args.Name,
+		Action:     args.Action,
+		Parameters: args.Parameters,
+		Schedule:   args.Schedule,
+		Created:    now,
+		NextRun:    next,
+	}
+	for _, tag := range args.Receivers {
+		doc.Receivers = append(doc.Receivers, tag.String())
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.AlreadyExistsf("action schedule %q", args.Name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot add action schedule %q", args.Name)
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given name.
+func (m *Model) ActionSchedule(name string) (*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", name)
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// AllActionSchedules returns all the action schedules in the model,
+// ordered by name.
+func (m *Model) AllActionSchedules() ([]*ActionSchedule, error) {
+	return m.st.actionSchedules(nil)
+}
+
+// DueActionSchedules returns the action schedules whose next run is
+// not after the current time, ordered by name.
+func (m *Model) DueActionSchedules() ([]*ActionSchedule, error) {
+	return m.st.actionSchedules(bson.D{{"next-run", bson.D{{"$lte", m.st.clock().Now()}}}})
+}
+
+func (st *State) actionSchedules(query bson.D) ([]*ActionSchedule, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(query).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	result := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionSchedule{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// HasActionSchedules reports whether the model has any action
+// schedules.
+func (st *State) HasActionSchedules() (bool, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	n, err := schedules.Find(nil).Count()
+	if err != nil {
+		return false, errors.Annotate(err, "cannot count action schedules")
+	}
+	return n > 0, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given
+// name. Actions already enqueued by the schedule are not affected.
+func (m *Model) RemoveActionSchedule(name string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     m.st.docID(name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 6, 15, 10, 30, 0, 0, time.UTC))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, name, expr string) *state.ActionSchedule {
+	schedule, err := s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:       name,
+		Action:     "backup",
+		Receivers:  []names.Tag{names.NewUnitTag("mysql/0")},
+		Parameters: map[string]interface{}{"full": true},
+		Schedule:   expr,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	added := s.addSchedule(c, "nightly-backup", "0 2 * * *")
+	c.Assert(added.Name(), gc.Equals, "nightly-backup")
+	c.Assert(added.NextRun(), gc.Equals, time.Date(2018, 6, 16, 2, 0, 0, 0, time.UTC))
+
+	schedule, err := s.Model.ActionSchedule("nightly-backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Action(), gc.Equals, "backup")
+	c.Assert(schedule.Receivers(), jc.DeepEquals, []string{"unit-mysql-0"})
+	c.Assert(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"full": true})
+	c.Assert(schedule.Schedule(), gc.Equals, "0 2 * * *")
+	c.Assert(schedule.Created().Equal(s.clock.Now()), jc.IsTrue)
+	c.Assert(schedule.LastRun().IsZero(), jc.IsTrue)
+	c.Assert(schedule.NextRun().Equal(added.NextRun()), jc.IsTrue)
+
+	_, err = s.Model.AddActionSchedule(state.ActionScheduleArgs{
+		Name:      "nightly-backup",
+		Action:    "backup",
+		Receivers: []names.Tag{names.NewUnitTag("mysql/0")},
+		Schedule:  "@daily",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleValidates(c *gc.C) {
+	for i, test := range []struct {
+		args state.ActionScheduleArgs
+		err  string
+	}{{
+		args: state.ActionScheduleArgs{Name: "Bad Name"},
+		err:  `cannot add action schedule: action schedule name "Bad Name" not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "backup"},
+		err:  `cannot add action schedule: empty action name not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "backup", Action: "backup"},
+		err:  `cannot add action schedule: action schedule without receivers not valid`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Name:      "backup",
+			Action:    "backup",
+			Receivers: []names.Tag{names.NewUnitTag("mysql/0")},
+			Schedule:  "every day",
+		},
+		err: `cannot add action schedule: cron expression "every day" with 2 fields, expected 5 not valid`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Name:      "backup",
+			Action:    "backup",
+			Receivers: []names.Tag{names.NewUnitTag("mysql/0")},
+			Schedule:  "0 0 30 2 *",
+		},
+		err: `cron expression "0 0 30 2 \*" that never fires not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.Model.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestAllActionSchedules(c *gc.C) {
+	s.addSchedule(c, "weekly", "@weekly")
+	s.addSchedule(c, "daily", "@daily")
+
+	schedules, err := s.Model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 2)
+	c.Assert(schedules[0].Name(), gc.Equals, "daily")
+	c.Assert(schedules[1].Name(), gc.Equals, "weekly")
+}
+
+func (s *ActionScheduleSuite) TestHasActionSchedules(c *gc.C) {
+	scheduled, err := s.State.HasActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsFalse)
+
+	s.addSchedule(c, "daily", "@daily")
+	scheduled, err = s.State.HasActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scheduled, jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	s.addSchedule(c, "daily", "@daily")
+
+	err := s.Model.RemoveActionSchedule("daily")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Model.ActionSchedule("daily")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.Model.RemoveActionSchedule("daily")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestDueActionSchedulesAndAdvance(c *gc.C) {
+	s.addSchedule(c, "hourly", "@hourly")
+	s.addSchedule(c, "daily", "@daily")
+
+	due, err := s.Model.DueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 0)
+
+	s.clock.Advance(45 * time.Minute)
+	due, err = s.Model.DueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 1)
+	c.Assert(due[0].Name(), gc.Equals, "hourly")
+
+	// Only one caller may advance the schedule past a given run.
+	stale, err := s.Model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	advanced, err := due[0].Advance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(advanced, jc.IsTrue)
+	advanced, err = stale.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(advanced, jc.IsFalse)
+
+	schedule, err := s.Model.ActionSchedule("hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastRun().Equal(s.clock.Now()), jc.IsTrue)
+	c.Assert(schedule.NextRun().Equal(time.Date(2018, 6, 15, 12, 0, 0, 0, time.UTC)), jc.IsTrue)
+
+	due, err = s.Model.DueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(due, gc.HasLen, 0)
+}
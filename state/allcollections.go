@@ -199,6 +199,16 @@ func allCollections() collectionSchema {
 			global: true,
 		},
 
+		// This collection records the access to controllers and models
+		// granted to users by their identity provider group membership,
+		// so that it can be revoked when they leave the groups.
+		groupAccessGrantsC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"user"},
+			}},
+		},
+
 		// This collection holds information cached by autocert certificate
 		// acquisition.
 		autocertCacheC: {
@@ -217,6 +227,10 @@ func allCollections() collectionSchema {
 		// Local collections
 		// =================
 
+		// This collection holds the named sets of capabilities that
+		// may be granted to users on the model's applications. The grants themselves are in permissionsC.
+		rolesC: {},
+
 		// This collection holds users related to a model and will be used as one
 		// of the intersection axis of permissionsC
 		modelUsersC: {
@@ -386,6 +400,18 @@ func allCollections() collectionSchema {
 			}},
 		},
 		actionNotificationsC: {},
+		actionBatchesC:       {},
+		actionOutputsC:       {},
+		actionSchedulesC:     {},
+
+		// This collection holds the bounded history of the hooks,
+		// actions and commands each unit has run.
+		hookExecutionsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "-started"},
+			}},
+		},
 
 		// -----
 
@@ -505,7 +531,10 @@ func allCollections() collectionSchema {
 // it in allCollections, above; and please keep this list sorted for easy
 // inspection.
 const (
+	actionBatchesC             = "actionbatches"
 	actionNotificationsC       = "actionnotifications"
+	actionOutputsC             = "actionoutputs"
+	actionSchedulesC           = "actionschedules"
 	actionresultsC             = "actionresults"
 	actionsC                   = "actions"
 	annotationsC               = "annotations"
@@ -530,9 +559,11 @@ const (
 	filesystemsC               = "filesystems"
 	globalClockC               = "globalclock"
 	globalRefcountsC           = "globalRefcounts"
+	groupAccessGrantsC         = "groupAccessGrants"
 	globalSettingsC            = "globalSettings"
 	guimetadataC               = "guimetadata"
 	guisettingsC               = "guisettings"
+	hookExecutionsC            = "hookexecutions"
 	instanceDataC              = "instanceData"
 	leasesC                    = "leases"
 	machinesC                  = "machines"
@@ -559,6 +590,7 @@ const (
 	relationScopesC            = "relationscopes"
 	relationsC                 = "relations"
 	restoreInfoC               = "restoreInfo"
+	rolesC                     = "roles"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
 	endpointBindingsC          = "endpointbindings"
@@ -44,24 +44,25 @@ type Application struct {
 // applicationDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string       `bson:"_id"`
-	Name                 string       `bson:"name"`
-	ModelUUID            string       `bson:"model-uuid"`
-	Series               string       `bson:"series"`
-	Subordinate          bool         `bson:"subordinate"`
-	CharmURL             *charm.URL   `bson:"charmurl"`
-	Channel              string       `bson:"cs-channel"`
-	CharmModifiedVersion int          `bson:"charmmodifiedversion"`
-	ForceCharm           bool         `bson:"forcecharm"`
-	Life                 Life         `bson:"life"`
-	UnitCount            int          `bson:"unitcount"`
-	RelationCount        int          `bson:"relationcount"`
-	Exposed              bool         `bson:"exposed"`
-	MinUnits             int          `bson:"minunits"`
-	Tools                *tools.Tools `bson:",omitempty"`
-	TxnRevno             int64        `bson:"txn-revno"`
-	MetricCredentials    []byte       `bson:"metric-credentials"`
-	PasswordHash         string       `bson:"passwordhash"`
+	DocID                string          `bson:"_id"`
+	Name                 string          `bson:"name"`
+	ModelUUID            string          `bson:"model-uuid"`
+	Series               string          `bson:"series"`
+	Subordinate          bool            `bson:"subordinate"`
+	CharmURL             *charm.URL      `bson:"charmurl"`
+	Channel              string          `bson:"cs-channel"`
+	CharmModifiedVersion int             `bson:"charmmodifiedversion"`
+	ForceCharm           bool            `bson:"forcecharm"`
+	Life                 Life            `bson:"life"`
+	UnitCount            int             `bson:"unitcount"`
+	RelationCount        int             `bson:"relationcount"`
+	Exposed              bool            `bson:"exposed"`
+	MinUnits             int             `bson:"minunits"`
+	EgressRules          []egressRuleDoc `bson:"egress-rules,omitempty"`
+	Tools                *tools.Tools    `bson:",omitempty"`
+	TxnRevno             int64           `bson:"txn-revno"`
+	MetricCredentials    []byte          `bson:"metric-credentials"`
+	PasswordHash         string          `bson:"passwordhash"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -378,6 +379,13 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	}
 	ops = append(ops, removeOfferOps...)
 
+	// Revoke the roles granted on the application.
+	removeAccessOps, err := removeApplicationAccessOps(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, removeAccessOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -1815,6 +1815,54 @@ func (s *ApplicationSuite) TestApplicationExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestApplicationEgressRules(c *gc.C) {
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+
+	rules := []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.1.0/24"),
+	}
+	err := s.mysql.SetEgressRules(rules)
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []network.EgressRule{rules[1], rules[0]}
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+
+	app, err := s.State.Application(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.EgressRules(), jc.DeepEquals, expected)
+
+	err = s.mysql.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.EgressRules(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesInvalid(c *gc.C) {
+	err := s.mysql.SetEgressRules([]network.EgressRule{{
+		PortRange: network.PortRange{Protocol: "tcp", FromPort: 443, ToPort: 80},
+	}})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": egress rule 443-80/tcp \(invalid port range 443-80/tcp\) not valid`)
+	c.Assert(errors.IsNotValid(err), jc.IsTrue)
+
+	err = s.mysql.SetEgressRules([]network.EgressRule{{
+		PortRange:        network.PortRange{Protocol: "tcp", FromPort: 443, ToPort: 443},
+		DestinationCIDRs: []string{"10.0/8"},
+	}})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": egress rule 443/tcp to 10.0/8 \(invalid CIDR address: 10.0/8\) not valid`)
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesNotAlive(c *gc.C) {
+	// Keep the application Dying by giving it a unit.
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetEgressRules([]network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)})
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/network"
+)
+
+// egressRuleDoc is the persistent representation of a network.EgressRule.
+type egressRuleDoc struct {
+	Protocol         string   `bson:"protocol"`
+	FromPort         int      `bson:"from-port"`
+	ToPort           int      `bson:"to-port"`
+	DestinationCIDRs []string `bson:"destination-cidrs,omitempty"`
+}
+
+// EgressRules returns the rules that allow outgoing traffic from the
+// application's units. If there are none, outgoing traffic is not
+// restricted. See SetEgressRules.
+func (a *Application) EgressRules() []network.EgressRule {
+	if len(a.doc.EgressRules) == 0 {
+		return nil
+	}
+	rules := make([]network.EgressRule, len(a.doc.EgressRules))
+	for i, doc := range a.doc.EgressRules {
+		rules[i] = network.EgressRule{
+			PortRange: network.PortRange{
+				Protocol: doc.Protocol,
+				FromPort: doc.FromPort,
+				ToPort:   doc.ToPort,
+			},
+			DestinationCIDRs: doc.DestinationCIDRs,
+		}
+	}
+	return rules
+}
+
+// SetEgressRules replaces the rules that allow outgoing traffic from the
+// application's units. Once an application has egress rules, the machines
+// hosting its units may only send traffic allowed by the egress rules of
+// the applications deployed to them, in providers that support it.
+// Setting no rules lifts the restriction.
+func (a *Application) SetEgressRules(rules []network.EgressRule) error {
+	docs, err := egressRuleDocs(rules)
+	if err != nil {
+		return errors.Annotatef(err, "cannot set egress rules for application %q", a)
+	}
+	var update bson.D
+	if len(docs) > 0 {
+		update = bson.D{{"$set", bson.D{{"egress-rules", docs}}}}
+	} else {
+		update = bson.D{{"$unset", bson.D{{"egress-rules", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set egress rules for application %q: %v", a, onAbort(err, applicationNotAliveErr))
+	}
+	a.doc.EgressRules = docs
+	return nil
+}
+
+// egressRuleDocs validates the egress rules and returns their persistent
+// representation, in a consistent order.
+func egressRuleDocs(rules []network.EgressRule) ([]egressRuleDoc, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	sorted := make([]network.EgressRule, len(rules))
+	copy(sorted, rules)
+	network.SortEgressRules(sorted)
+	docs := make([]egressRuleDoc, len(sorted))
+	for i, rule := range sorted {
+		if err := rule.PortRange.Validate(); err != nil {
+			return nil, errors.NotValidf("egress rule %v (%v)", rule, err)
+		}
+		// Validate the CIDRs.
+		if _, err := network.NewEgressRule(rule.Protocol, rule.FromPort, rule.ToPort, rule.DestinationCIDRs...); err != nil {
+			return nil, errors.NotValidf("egress rule %v (%v)", rule, err)
+		}
+		docs[i] = egressRuleDoc{
+			Protocol:         rule.Protocol,
+			FromPort:         rule.FromPort,
+			ToPort:           rule.ToPort,
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	return docs, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// applicationAccessKeyPrefix returns the prefix of the permission object
+// keys of all the applications in the model. Keeping the model key at the
+// front means the permissions are removed along with the model.
+func applicationAccessKeyPrefix(modelUUID string) string {
+	return modelKey(modelUUID) + "#a"
+}
+
+// applicationAccessKey returns the permission object key for the named
+// application in the model.
+func applicationAccessKey(modelUUID, appName string) string {
+	return modelKey(modelUUID) + "#" + applicationGlobalKey(appName)
+}
+
+// GetApplicationAccess returns the name of the role granted to the user on
+// the application.
+func (st *State) GetApplicationAccess(appName string, user names.UserTag) (string, error) {
+	perm, err := st.userPermission(applicationAccessKey(st.ModelUUID(), appName), userGlobalKey(userAccessID(user)))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return perm.doc.Access, nil
+}
+
+// GetApplicationUsers returns the names of the roles granted on the
+// application, keyed by user name.
+func (st *State) GetApplicationUsers(appName string) (map[string]string, error) {
+	perms, err := st.usersPermissions(applicationAccessKey(st.ModelUUID(), appName))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string)
+	for _, p := range perms {
+		result[userIDFromGlobalKey(p.doc.SubjectGlobalKey)] = p.doc.Access
+	}
+	return result, nil
+}
+
+// ApplicationUserCapabilities returns the capabilities the user has been
+// granted on the application, by way of the role granted to them.
+func (st *State) ApplicationUserCapabilities(appName string, user names.UserTag) ([]permission.Capability, error) {
+	roleName, err := st.GetApplicationAccess(appName, user)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	role, err := st.Role(roleName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return role.Capabilities, nil
+}
+
+// SetApplicationAccess grants the named role to the user on the
+// application, replacing any role they were previously granted on it. The
+// role may be one added to the model, or an application access level.
+func (st *State) SetApplicationAccess(appName string, user names.UserTag, roleName string) error {
+	// Local users must exist.
+	if user.IsLocal() {
+		_, err := st.User(user)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return errors.Annotatef(err, "user %q does not exist locally", user.Name())
+			}
+			return errors.Trace(err)
+		}
+	}
+	objectKey := applicationAccessKey(st.ModelUUID(), appName)
+	subjectKey := userGlobalKey(userAccessID(user))
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		app, err := st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", appName)
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		if err := permission.ValidateApplicationAccess(permission.Access(roleName)); err != nil {
+			if _, err := st.Role(roleName); err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, txn.Op{
+				C:      rolesC,
+				Id:     st.docID(roleName),
+				Assert: txn.DocExists,
+			})
+		}
+		_, err = st.userPermission(objectKey, subjectKey)
+		if errors.IsNotFound(err) {
+			ops = append(ops, createPermissionOp(objectKey, subjectKey, permission.Access(roleName)))
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		} else {
+			ops = append(ops, updatePermissionOp(objectKey, subjectKey, permission.Access(roleName)))
+		}
+		return ops, nil
+	}
+	err := st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot grant %q on application %q to %q", roleName, appName, user.Id())
+}
+
+// RemoveApplicationAccess revokes the role granted to the user on the
+// application.
+func (st *State) RemoveApplicationAccess(appName string, user names.UserTag) error {
+	ops := []txn.Op{
+		removePermissionOp(applicationAccessKey(st.ModelUUID(), appName), userGlobalKey(userAccessID(user))),
+	}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("access on application %q for user %q", appName, user.Id())
+	}
+	return errors.Trace(err)
+}
+
+// removeApplicationAccessOps returns the operations to revoke all the
+// roles granted on the application.
+func removeApplicationAccessOps(st *State, appName string) ([]txn.Op, error) {
+	perms, err := st.usersPermissions(applicationAccessKey(st.ModelUUID(), appName))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(perms))
+	for i, p := range perms {
+		ops[i] = removePermissionOp(p.doc.ObjectGlobalKey, p.doc.SubjectGlobalKey)
+	}
+	return ops, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ApplicationUserSuite struct {
+	ConnSuite
+	app  *state.Application
+	user names.UserTag
+}
+
+var _ = gc.Suite(&ApplicationUserSuite{})
+
+func (s *ApplicationUserSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.app = s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	s.user = s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"}).UserTag()
+	err := s.State.AddRole(permission.Role{
+		Name:         "operator",
+		Capabilities: []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationUserSuite) TestNoAccess(c *gc.C) {
+	_, err := s.State.GetApplicationAccess("mysql", s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	capabilities, err := s.State.ApplicationUserCapabilities("mysql", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capabilities, gc.HasLen, 0)
+}
+
+func (s *ApplicationUserSuite) TestSetApplicationAccessRole(c *gc.C) {
+	err := s.State.SetApplicationAccess("mysql", s.user, "operator")
+	c.Assert(err, jc.ErrorIsNil)
+
+	role, err := s.State.GetApplicationAccess("mysql", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, "operator")
+
+	capabilities, err := s.State.ApplicationUserCapabilities("mysql", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capabilities, jc.DeepEquals, []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"})
+
+	users, err := s.State.GetApplicationUsers("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(users, jc.DeepEquals, map[string]string{"bob": "operator"})
+}
+
+func (s *ApplicationUserSuite) TestSetApplicationAccessReplaces(c *gc.C) {
+	err := s.State.SetApplicationAccess("mysql", s.user, "operator")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetApplicationAccess("mysql", s.user, "upgrade-charm")
+	c.Assert(err, jc.ErrorIsNil)
+
+	capabilities, err := s.State.ApplicationUserCapabilities("mysql", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capabilities, jc.DeepEquals, permission.ApplicationCapabilities(permission.UpgradeCharmAccess))
+}
+
+func (s *ApplicationUserSuite) TestSetApplicationAccessUnknownRole(c *gc.C) {
+	err := s.State.SetApplicationAccess("mysql", s.user, "janitor")
+	c.Assert(err, gc.ErrorMatches, `cannot grant "janitor" on application "mysql" to "bob": role "janitor" not found`)
+}
+
+func (s *ApplicationUserSuite) TestSetApplicationAccessUnknownApplication(c *gc.C) {
+	err := s.State.SetApplicationAccess("wordpress", s.user, "config")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationUserSuite) TestSetApplicationAccessUnknownUser(c *gc.C) {
+	err := s.State.SetApplicationAccess("mysql", names.NewUserTag("alice"), "config")
+	c.Assert(err, gc.ErrorMatches, `user "alice" does not exist locally: user "alice" not found`)
+}
+
+func (s *ApplicationUserSuite) TestRemoveApplicationAccess(c *gc.C) {
+	err := s.State.SetApplicationAccess("mysql", s.user, "operator")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveApplicationAccess("mysql", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.GetApplicationAccess("mysql", s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveApplicationAccess("mysql", s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationUserSuite) TestAccessRemovedWithApplication(c *gc.C) {
+	err := s.State.SetApplicationAccess("mysql", s.user, "operator")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.GetApplicationAccess("mysql", s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -606,6 +606,9 @@ func (st *State) cleanupRemovedUnit(unitId string) error {
 	if err := Apply(st.database, change); err != nil {
 		return errors.Trace(err)
 	}
+	if err := removeHookExecutions(st, unitId); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
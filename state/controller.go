@@ -43,6 +43,7 @@ type Controller struct {
 	policy                 Policy
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
+	newRaftLeaseClient     NewLeaseClientFunc
 }
 
 // Close the connection to the database.
@@ -66,6 +67,7 @@ func (ctlr *Controller) NewState(modelTag names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	st.newRaftLeaseClient = ctlr.newRaftLeaseClient
 	if err := st.start(ctlr.controllerTag, nil); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -107,6 +107,38 @@ func (s *ControllerSuite) TestUpdateControllerConfigRemoveYieldsDefaults(c *gc.C
 	c.Assert(newCfg.AuditLogCaptureArgs(), gc.Equals, false)
 }
 
+func (s *ControllerSuite) TestUpdateControllerConfigAuditLogSinks(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditLogSinks:      []string{"file", "webhook"},
+		controller.AuditLogWebhookURL: "https://siem.example.com/audit",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newCfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newCfg.AuditLogSinks(), jc.DeepEquals, set.NewStrings("file", "webhook"))
+	c.Assert(newCfg.AuditLogWebhookURL(), gc.Equals, "https://siem.example.com/audit")
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigOIDC(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.OIDCIssuerURL:     "https://sso.example.com",
+		controller.OIDCClientID:      "juju",
+		controller.OIDCGroupsClaim:   "roles",
+		controller.OIDCUsernameClaim: "preferred_username",
+		controller.OIDCUserDomain:    "sso",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newCfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newCfg.OIDCIssuerURL(), gc.Equals, "https://sso.example.com")
+	c.Assert(newCfg.OIDCClientID(), gc.Equals, "juju")
+	c.Assert(newCfg.OIDCGroupsClaim(), gc.Equals, "roles")
+	c.Assert(newCfg.OIDCUsernameClaim(), gc.Equals, "preferred_username")
+	c.Assert(newCfg.OIDCUserDomain(), gc.Equals, "sso")
+}
+
 func (s *ControllerSuite) TestUpdateControllerConfigRejectsDisallowedUpdates(c *gc.C) {
 	// Sanity check.
 	c.Assert(controller.AllowedUpdateConfigAttributes.Contains(controller.APIPort), jc.IsFalse)
@@ -52,6 +52,7 @@ const (
 var (
 	BinarystorageNew                     = &binarystorageNew
 	ImageStorageNewStorage               = &imageStorageNewStorage
+	MaxActionMessages                    = &maxActionMessages
 	MachineIdLessThan                    = machineIdLessThan
 	GetOrCreatePorts                     = getOrCreatePorts
 	GetPorts                             = getPorts
@@ -648,6 +649,12 @@ func PrimeUnitStatusHistory(
 // approximate size of the entry and limit the number of entries that
 // must be generated for size related tests.
 func PrimeActions(c *gc.C, age time.Time, unit *Unit, count int) {
+	PrimeNamedActions(c, age, unit, "", count)
+}
+
+// PrimeNamedActions is like PrimeActions, but the generated actions
+// have the given name.
+func PrimeNamedActions(c *gc.C, age time.Time, unit *Unit, name string, count int) {
 	actionCollection, closer := unit.st.db().GetCollection(actionsC)
 	defer closer()
 
@@ -663,6 +670,7 @@ func PrimeActions(c *gc.C, age time.Time, unit *Unit, count int) {
 			DocId:     id.String(),
 			ModelUUID: unit.st.ModelUUID(),
 			Receiver:  unit.Name(),
+			Name:      name,
 			Completed: age,
 			Status:    ActionCompleted,
 			Message:   string(padding[:numBytes]),
@@ -788,3 +796,15 @@ func (st *State) ModelQueryForUser(user names.UserTag, isSuperuser bool) (mongo.
 func UnitsHaveChanged(m *Machine, unitNames []string) (bool, error) {
 	return m.unitsHaveChanged(unitNames)
 }
+
+// SetNewRaftLeaseClient sets the function the State uses to make raft
+// lease clients.
+func SetNewRaftLeaseClient(st *State, newClient NewLeaseClientFunc) {
+	st.newRaftLeaseClient = newClient
+}
+
+// LeaseClient returns the lease client the State uses for the
+// namespace.
+func LeaseClient(st *State, namespace string) (lease.Client, error) {
+	return st.getLeaseClient(namespace)
+}
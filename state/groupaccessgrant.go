@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// GroupAccessGrant records access to a controller or model granted to a
+// user because of their membership of identity provider groups, so that
+// it can be revoked once they are no longer members.
+type GroupAccessGrant struct {
+	// User is the user the access is granted to.
+	User names.UserTag
+
+	// Target is the controller or model the access is granted on.
+	Target names.Tag
+
+	// Access is the access granted by the user's groups.
+	Access permission.Access
+
+	// PreviousAccess is the access the user had on the target before
+	// it was granted, which is restored when it is revoked. NoAccess
+	// means the user was not a user of the target.
+	PreviousAccess permission.Access
+}
+
+// groupAccessGrantDoc is the persistent form of a GroupAccessGrant.
+type groupAccessGrantDoc struct {
+	DocID          string `bson:"_id"`
+	User           string `bson:"user"`
+	Target         string `bson:"target"`
+	Access         string `bson:"access"`
+	PreviousAccess string `bson:"previous-access"`
+}
+
+func groupAccessGrantID(user names.UserTag, target names.Tag) string {
+	return userGlobalKey(userAccessID(user)) + "#" + target.String()
+}
+
+func (doc groupAccessGrantDoc) grant(user names.UserTag) (GroupAccessGrant, error) {
+	target, err := names.ParseTag(doc.Target)
+	if err != nil {
+		return GroupAccessGrant{}, errors.Trace(err)
+	}
+	return GroupAccessGrant{
+		User:           user,
+		Target:         target,
+		Access:         permission.Access(doc.Access),
+		PreviousAccess: permission.Access(doc.PreviousAccess),
+	}, nil
+}
+
+// GroupAccessGrants returns the access granted to the user by their
+// group membership, sorted by target.
+func (st *State) GroupAccessGrants(user names.UserTag) ([]GroupAccessGrant, error) {
+	coll, closer := st.db().GetCollection(groupAccessGrantsC)
+	defer closer()
+
+	var docs []groupAccessGrantDoc
+	if err := coll.Find(bson.D{{"user", userAccessID(user)}}).Sort("target").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get group access grants for %q", user.Id())
+	}
+	result := make([]GroupAccessGrant, len(docs))
+	for i, doc := range docs {
+		grant, err := doc.grant(user)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid group access grant %q", doc.DocID)
+		}
+		result[i] = grant
+	}
+	return result, nil
+}
+
+// SetGroupAccessGrant records the access granted to a user by their group
+// membership, replacing any grant already recorded for the user on the
+// same target. It does not change the user's access itself.
+func (st *State) SetGroupAccessGrant(grant GroupAccessGrant) error {
+	switch grant.Target.Kind() {
+	case names.ControllerTagKind, names.ModelTagKind:
+	default:
+		return errors.NotValidf("%q as a target", grant.Target.Kind())
+	}
+	if err := grant.Access.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := grant.PreviousAccess.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	coll, closer := st.db().GetCollection(groupAccessGrantsC)
+	defer closer()
+
+	id := groupAccessGrantID(grant.User, grant.Target)
+	buildTxn := func(int) ([]txn.Op, error) {
+		n, err := coll.FindId(id).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return []txn.Op{{
+				C:      groupAccessGrantsC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &groupAccessGrantDoc{
+					DocID:          id,
+					User:           userAccessID(grant.User),
+					Target:         grant.Target.String(),
+					Access:         string(grant.Access),
+					PreviousAccess: string(grant.PreviousAccess),
+				},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      groupAccessGrantsC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"access", string(grant.Access)},
+				{"previous-access", string(grant.PreviousAccess)},
+			}}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot record group access grant for %q on %s", grant.User.Id(), names.ReadableString(grant.Target))
+	}
+	return nil
+}
+
+// RemoveGroupAccessGrant removes the record of access granted to the user
+// on the target by their group membership, if there is one. It does not
+// change the user's access itself.
+func (st *State) RemoveGroupAccessGrant(user names.UserTag, target names.Tag) error {
+	ops := []txn.Op{{
+		C:      groupAccessGrantsC,
+		Id:     groupAccessGrantID(user, target),
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot remove group access grant for %q on %s", user.Id(), names.ReadableString(target))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+type GroupAccessGrantSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&GroupAccessGrantSuite{})
+
+func (s *GroupAccessGrantSuite) TestSetGroupAccessGrant(c *gc.C) {
+	alice := names.NewUserTag("alice@oidc")
+	modelGrant := state.GroupAccessGrant{
+		User:           alice,
+		Target:         s.State.ModelTag(),
+		Access:         permission.WriteAccess,
+		PreviousAccess: permission.ReadAccess,
+	}
+	controllerGrant := state.GroupAccessGrant{
+		User:   alice,
+		Target: s.State.ControllerTag(),
+		Access: permission.LoginAccess,
+	}
+	err := s.State.SetGroupAccessGrant(modelGrant)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccessGrant(controllerGrant)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccessGrant(state.GroupAccessGrant{
+		User:   names.NewUserTag("bob@oidc"),
+		Target: s.State.ControllerTag(),
+		Access: permission.SuperuserAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	grants, err := s.State.GroupAccessGrants(alice)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, jc.DeepEquals, []state.GroupAccessGrant{controllerGrant, modelGrant})
+}
+
+func (s *GroupAccessGrantSuite) TestSetGroupAccessGrantReplaces(c *gc.C) {
+	grant := state.GroupAccessGrant{
+		User:   names.NewUserTag("alice@oidc"),
+		Target: s.State.ModelTag(),
+		Access: permission.WriteAccess,
+	}
+	err := s.State.SetGroupAccessGrant(grant)
+	c.Assert(err, jc.ErrorIsNil)
+	grant.Access = permission.AdminAccess
+	err = s.State.SetGroupAccessGrant(grant)
+	c.Assert(err, jc.ErrorIsNil)
+
+	grants, err := s.State.GroupAccessGrants(grant.User)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, jc.DeepEquals, []state.GroupAccessGrant{grant})
+}
+
+func (s *GroupAccessGrantSuite) TestSetGroupAccessGrantInvalidTarget(c *gc.C) {
+	err := s.State.SetGroupAccessGrant(state.GroupAccessGrant{
+		User:   names.NewUserTag("alice@oidc"),
+		Target: names.NewApplicationTag("mysql"),
+		Access: permission.ReadAccess,
+	})
+	c.Assert(err, gc.ErrorMatches, `"application" as a target not valid`)
+}
+
+func (s *GroupAccessGrantSuite) TestRemoveGroupAccessGrant(c *gc.C) {
+	alice := names.NewUserTag("alice@oidc")
+	err := s.State.SetGroupAccessGrant(state.GroupAccessGrant{
+		User:   alice,
+		Target: s.State.ModelTag(),
+		Access: permission.WriteAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveGroupAccessGrant(alice, s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	grants, err := s.State.GroupAccessGrants(alice)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grants, gc.HasLen, 0)
+
+	// Removing a grant that is not recorded is not an error.
+	err = s.State.RemoveGroupAccessGrant(alice, s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
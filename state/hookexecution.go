@@ -0,0 +1,178 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxHookExecutions is the number of hook executions kept for each
+// unit; older executions are discarded as new ones are recorded.
+const maxHookExecutions = 100
+
+// HookExecutionKind identifies what sort of execution a unit ran.
+type HookExecutionKind string
+
+const (
+	// HookExecutionHook is the execution of a charm hook.
+	HookExecutionHook HookExecutionKind = "hook"
+
+	// HookExecutionAction is the execution of an action.
+	HookExecutionAction HookExecutionKind = "action"
+
+	// HookExecutionCommands is the execution of commands sent with
+	// juju run.
+	HookExecutionCommands HookExecutionKind = "commands"
+)
+
+// HookExecution records a single execution of a hook, action or
+// commands by a unit.
+type HookExecution struct {
+	Kind HookExecutionKind
+
+	// Name is the name of the hook or action. It's empty for
+	// commands.
+	Name string
+
+	// Relation identifies the relation a relation hook ran for, as
+	// "<endpoint>:<relation id>", and RemoteUnit the remote unit if
+	// there was one.
+	Relation   string
+	RemoteUnit string
+
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode is the exit code of the hook or action, and Error
+	// describes any failure.
+	ExitCode int
+	Error    string
+}
+
+// Validate returns an error if the execution record is not valid.
+func (e HookExecution) Validate() error {
+	switch e.Kind {
+	case HookExecutionHook, HookExecutionAction:
+		if e.Name == "" {
+			return errors.NotValidf("%s execution without name", e.Kind)
+		}
+	case HookExecutionCommands:
+	default:
+		return errors.NotValidf("hook execution kind %q", e.Kind)
+	}
+	if e.Started.IsZero() || e.Finished.Before(e.Started) {
+		return errors.NotValidf("hook execution times")
+	}
+	return nil
+}
+
+// hookExecutionDoc records a hook, action or commands execution by
+// a unit.
+type hookExecutionDoc struct {
+	ModelUUID  string `bson:"model-uuid"`
+	Unit       string `bson:"unit"`
+	Kind       string `bson:"kind"`
+	Name       string `bson:"name,omitempty"`
+	Relation   string `bson:"relation,omitempty"`
+	RemoteUnit string `bson:"remote-unit,omitempty"`
+	Started    int64  `bson:"started"`
+	Finished   int64  `bson:"finished"`
+	ExitCode   int    `bson:"exit-code"`
+	Error      string `bson:"error,omitempty"`
+}
+
+type recordedHookExecutionDoc struct {
+	ID               bson.ObjectId `bson:"_id"`
+	hookExecutionDoc `bson:",inline"`
+}
+
+// AddHookExecutions records the given hook executions in the unit's
+// hook history, discarding the oldest records once the history holds
+// more than a fixed number.
+func (u *Unit) AddHookExecutions(executions ...HookExecution) error {
+	if len(executions) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(executions))
+	for i, e := range executions {
+		if err := e.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		docs[i] = &hookExecutionDoc{
+			Unit:       u.Name(),
+			Kind:       string(e.Kind),
+			Name:       e.Name,
+			Relation:   e.Relation,
+			RemoteUnit: e.RemoteUnit,
+			Started:    e.Started.UnixNano(),
+			Finished:   e.Finished.UnixNano(),
+			ExitCode:   e.ExitCode,
+			Error:      e.Error,
+		}
+	}
+
+	coll, closer := u.st.db().GetCollection(hookExecutionsC)
+	defer closer()
+	if err := coll.Writeable().Insert(docs...); err != nil {
+		return errors.Annotatef(err, "recording hook executions for unit %q", u.Name())
+	}
+
+	// Discard the records beyond the newest maxHookExecutions.
+	var old []recordedHookExecutionDoc
+	err := coll.Find(bson.D{{"unit", u.Name()}}).
+		Sort("-started", "-_id").
+		Skip(maxHookExecutions).
+		Select(bson.D{{"_id", 1}}).
+		All(&old)
+	if err != nil {
+		return errors.Annotatef(err, "reading hook executions for unit %q", u.Name())
+	}
+	if len(old) == 0 {
+		return nil
+	}
+	ids := make([]bson.ObjectId, len(old))
+	for i, doc := range old {
+		ids[i] = doc.ID
+	}
+	_, err = coll.Writeable().RemoveAll(bson.D{{"_id", bson.D{{"$in", ids}}}})
+	return errors.Annotatef(err, "pruning hook executions for unit %q", u.Name())
+}
+
+// HookExecutions returns the unit's recorded hook executions, newest
+// first.
+func (u *Unit) HookExecutions() ([]HookExecution, error) {
+	coll, closer := u.st.db().GetCollection(hookExecutionsC)
+	defer closer()
+
+	var docs []recordedHookExecutionDoc
+	err := coll.Find(bson.D{{"unit", u.Name()}}).Sort("-started", "-_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading hook executions for unit %q", u.Name())
+	}
+	executions := make([]HookExecution, len(docs))
+	for i, doc := range docs {
+		executions[i] = HookExecution{
+			Kind:       HookExecutionKind(doc.Kind),
+			Name:       doc.Name,
+			Relation:   doc.Relation,
+			RemoteUnit: doc.RemoteUnit,
+			Started:    time.Unix(0, doc.Started).UTC(),
+			Finished:   time.Unix(0, doc.Finished).UTC(),
+			ExitCode:   doc.ExitCode,
+			Error:      doc.Error,
+		}
+	}
+	return executions, nil
+}
+
+// removeHookExecutions removes the hook history of the named unit.
+func removeHookExecutions(mb modelBackend, unitName string) error {
+	coll, closer := mb.db().GetCollection(hookExecutionsC)
+	defer closer()
+	_, err := coll.Writeable().RemoveAll(bson.D{{"unit", unitName}})
+	return errors.Annotatef(err, "removing hook executions for unit %q", unitName)
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type HookExecutionSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HookExecutionSuite{})
+
+func (s *HookExecutionSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+var hookStart = time.Date(2018, 6, 15, 10, 30, 0, 0, time.UTC)
+
+func hookExecution(name string, offset time.Duration) state.HookExecution {
+	return state.HookExecution{
+		Kind:     state.HookExecutionHook,
+		Name:     name,
+		Started:  hookStart.Add(offset),
+		Finished: hookStart.Add(offset + time.Second),
+	}
+}
+
+func (s *HookExecutionSuite) TestNoHookExecutions(c *gc.C) {
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 0)
+}
+
+func (s *HookExecutionSuite) TestAddHookExecutions(c *gc.C) {
+	relationHook := state.HookExecution{
+		Kind:       state.HookExecutionHook,
+		Name:       "db-relation-changed",
+		Relation:   "db:2",
+		RemoteUnit: "mysql/0",
+		Started:    hookStart.Add(time.Minute),
+		Finished:   hookStart.Add(time.Minute + time.Second),
+		ExitCode:   1,
+		Error:      "exit status 1",
+	}
+	action := state.HookExecution{
+		Kind:     state.HookExecutionAction,
+		Name:     "backup",
+		Started:  hookStart.Add(2 * time.Minute),
+		Finished: hookStart.Add(3 * time.Minute),
+	}
+	err := s.unit.AddHookExecutions(hookExecution("install", 0), relationHook, action)
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, jc.DeepEquals, []state.HookExecution{
+		action, relationHook, hookExecution("install", 0),
+	})
+}
+
+func (s *HookExecutionSuite) TestHookExecutionsPerUnit(c *gc.C) {
+	other := s.Factory.MakeUnit(c, nil)
+	err := s.unit.AddHookExecutions(hookExecution("install", 0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := other.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 0)
+}
+
+func (s *HookExecutionSuite) TestAddHookExecutionsPrunes(c *gc.C) {
+	for i := 0; i < 105; i++ {
+		err := s.unit.AddHookExecutions(hookExecution(fmt.Sprintf("hook-%d", i), time.Duration(i)*time.Minute))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 100)
+	c.Assert(executions[0].Name, gc.Equals, "hook-104")
+	c.Assert(executions[99].Name, gc.Equals, "hook-5")
+}
+
+func (s *HookExecutionSuite) TestAddHookExecutionsInvalid(c *gc.C) {
+	for i, test := range []struct {
+		execution state.HookExecution
+		err       string
+	}{{
+		execution: state.HookExecution{Kind: "bogus", Started: hookStart, Finished: hookStart},
+		err:       `hook execution kind "bogus" not valid`,
+	}, {
+		execution: state.HookExecution{Kind: state.HookExecutionAction, Started: hookStart, Finished: hookStart},
+		err:       "action execution without name not valid",
+	}, {
+		execution: state.HookExecution{Kind: state.HookExecutionCommands},
+		err:       "hook execution times not valid",
+	}, {
+		execution: state.HookExecution{Kind: state.HookExecutionCommands, Started: hookStart, Finished: hookStart.Add(-time.Second)},
+		err:       "hook execution times not valid",
+	}} {
+		c.Logf("test %d", i)
+		err := s.unit.AddHookExecutions(test.execution)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 0)
+}
+
+func (s *HookExecutionSuite) TestRemovedUnitHookExecutions(c *gc.C) {
+	err := s.unit.AddHookExecutions(hookExecution("install", 0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	executions, err := s.unit.HookExecutions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(executions, gc.HasLen, 0)
+}
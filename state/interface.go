@@ -210,6 +210,19 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// ResultsOverflow returns true if the results of the action were
+	// too large to be stored with the action and are held in blob
+	// storage.
+	ResultsOverflow() bool
+
+	// FullResults returns the structured output of the action, reading
+	// it from blob storage if it was too large to be stored with the
+	// action.
+	FullResults() (map[string]interface{}, error)
+
+	// Messages returns the progress messages logged by the action.
+	Messages() []ActionMessage
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -221,6 +234,10 @@ type Action interface {
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// Log adds a timestamped progress message to the action.
+	// It asserts that the action is currently running.
+	Log(message string) error
 }
 
 // ApplicationEntity represents a local or remote application.
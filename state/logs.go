@@ -321,7 +321,22 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+
+	// EndTime, if set, excludes records logged after it.
+	EndTime time.Time
+
+	// AgentEntity restricts records to those from these entities,
+	// in addition to any IncludeEntity restriction. As with
+	// IncludeEntity, the values may finish with a '*'. An empty but
+	// non-nil AgentEntity matches no records.
+	AgentEntity []string
+
+	// IncludeMessage and ExcludeMessage hold regular expressions
+	// matched against the log messages.
+	IncludeMessage []string
+	ExcludeMessage []string
+
+	Oplog *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -621,6 +636,9 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 	if !params.StartTime.IsZero() {
 		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
 	}
+	if !params.EndTime.IsZero() {
+		sel = append(sel, bson.DocElem{"t", bson.M{"$lte": params.EndTime.UnixNano()}})
+	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
 	}
@@ -640,6 +658,18 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if params.AgentEntity != nil {
+		sel = append(sel,
+			bson.DocElem{"n", bson.RegEx{Pattern: makeEntityPattern(params.AgentEntity)}})
+	}
+	if len(params.IncludeMessage) > 0 {
+		sel = append(sel,
+			bson.DocElem{"x", bson.RegEx{Pattern: makeMessagePattern(params.IncludeMessage)}})
+	}
+	if len(params.ExcludeMessage) > 0 {
+		sel = append(sel,
+			bson.DocElem{"x", bson.M{"$not": bson.RegEx{Pattern: makeMessagePattern(params.ExcludeMessage)}}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -666,6 +696,10 @@ func makeModulePattern(modules []string) string {
 	return `^(` + strings.Join(patterns, "|") + `)(\..+)?$`
 }
 
+func makeMessagePattern(patterns []string) string {
+	return `(` + strings.Join(patterns, ")|(") + `)`
+}
+
 func newRecentIdTracker(maxLen int) *recentIdTracker {
 	return &recentIdTracker{
 		ids: deque.NewWithMaxLen(maxLen),
@@ -781,6 +781,73 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeExcludeMessage(c *gc.C) {
+	started := logTemplate{Message: "started worker uniter"}
+	stopped := logTemplate{Message: "stopped worker uniter"}
+	failed := logTemplate{Message: "failed to start worker"}
+	other := logTemplate{Message: "something else"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, started)
+		s.writeLogs(c, s.otherUUID, 1, stopped)
+		s.writeLogs(c, s.otherUUID, 1, failed)
+		s.writeLogs(c, s.otherUUID, 1, other)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: []string{"worker", "^something"},
+		ExcludeMessage: []string{"^stop"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, started)
+		s.assertTailer(c, tailer, 1, failed)
+		s.assertTailer(c, tailer, 1, other)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestAgentEntity(c *gc.C) {
+	machine0 := logTemplate{Entity: names.NewMachineTag("0")}
+	machine1 := logTemplate{Entity: names.NewMachineTag("1")}
+	foo0 := logTemplate{Entity: names.NewUnitTag("foo/0")}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, machine0)
+		s.writeLogs(c, s.otherUUID, 2, foo0)
+		s.writeLogs(c, s.otherUUID, 3, machine1)
+	}
+	params := state.LogTailerParams{
+		IncludeEntity: []string{"machine-1", "unit-foo-0"},
+		AgentEntity:   []string{"machine-*"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 3, machine1)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestEndTimeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT.Add(-5*time.Second), threshT, 5, want)
+	s.writeLogsT(c, s.otherUUID, threshT.Add(time.Second), threshT.Add(5*time.Second), 5,
+		logTemplate{Message: "dont want"},
+	)
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		EndTime: threshT,
+		NoTail:  true,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -37,6 +37,10 @@ type ExportConfig struct {
 	SkipSSHHostKeys        bool
 	SkipStatusHistory      bool
 	SkipLinkLayerDevices   bool
+	// SkipMachinesAndUnits exports only the model's topology: its
+	// applications, relations, config and storage pools, without any
+	// machines or units, or anything that belongs to them.
+	SkipMachinesAndUnits bool
 }
 
 // ExportPartial the current model for the State optionally skipping
@@ -55,6 +59,13 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if cfg.SkipMachinesAndUnits {
+		// These all refer to machines or units.
+		cfg.SkipActions = true
+		cfg.SkipIPAddresses = true
+		cfg.SkipLinkLayerDevices = true
+		cfg.SkipSSHHostKeys = true
+	}
 	export := exporter{
 		st:      st,
 		cfg:     cfg,
@@ -282,6 +293,9 @@ func (e *exporter) modelUsers() error {
 }
 
 func (e *exporter) machines() error {
+	if e.cfg.SkipMachinesAndUnits {
+		return nil
+	}
 	machines, err := e.st.AllMachines()
 	if err != nil {
 		return errors.Trace(err)
@@ -546,9 +560,13 @@ func (e *exporter) applications() error {
 	}
 	e.logger.Debugf("found %d applications", len(applications))
 
-	e.units, err = e.readAllUnits()
-	if err != nil {
-		return errors.Trace(err)
+	if e.cfg.SkipMachinesAndUnits {
+		e.units = make(map[string][]*Unit)
+	} else {
+		e.units, err = e.readAllUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	meterStatus, err := e.readAllMeterStatus()
@@ -591,7 +609,10 @@ func (e *exporter) applications() error {
 
 	for _, application := range applications {
 		applicationUnits := e.units[application.Name()]
-		leader := leaders[application.Name()]
+		var leader string
+		if !e.cfg.SkipMachinesAndUnits {
+			leader = leaders[application.Name()]
+		}
 		resources, err := resourcesSt.ListResources(application.Name())
 		if err != nil {
 			return errors.Trace(err)
@@ -1722,6 +1743,11 @@ func (e *exporter) addRemoteSpace(descApp description.RemoteApplication, space R
 }
 
 func (e *exporter) storage() error {
+	if e.cfg.SkipMachinesAndUnits {
+		// Storage instances, volumes and filesystems all belong to
+		// units or machines, but the pools are part of the topology.
+		return errors.Trace(e.storagePools())
+	}
 	if err := e.volumes(); err != nil {
 		return errors.Trace(err)
 	}
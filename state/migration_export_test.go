@@ -619,6 +619,34 @@ func (s *MigrationExportSuite) TestApplicationLeadership(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestMachinesAndUnitsSkipped(c *gc.C) {
+	s.makeApplicationWithLeader(c, "mysql", 2, 1)
+	wordpress := state.AddTestingApplication(c, s.State, "wordpress", state.AddTestingCharm(c, s.State, "wordpress"))
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	eps, err := s.State.InferEndpoints("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.ExportPartial(state.ExportConfig{
+		SkipMachinesAndUnits: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(model.Machines(), gc.HasLen, 0)
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 2)
+	for _, application := range applications {
+		c.Check(application.Units(), gc.HasLen, 0)
+		c.Check(application.Leader(), gc.Equals, "")
+	}
+	rels := model.Relations()
+	c.Assert(rels, gc.HasLen, 1)
+	for _, ep := range rels[0].Endpoints() {
+		c.Check(ep.UnitCount(), gc.Equals, 0)
+	}
+}
+
 func (s *MigrationExportSuite) TestUnitsOpenPorts(c *gc.C) {
 	unit := s.Factory.MakeUnit(c, nil)
 	err := unit.OpenPorts("tcp", 1234, 2345)
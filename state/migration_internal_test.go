@@ -106,6 +106,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Controller users contain extra data about users therefore
 		// are not migrated either.
 		controllerUsersC,
+		// Group access grants are controller global, and are
+		// reconciled when the user next logs in.
+		groupAccessGrantsC,
 		// userenvnameC is just to provide a unique key constraint.
 		usermodelnameC,
 		// Metrics aren't migrated.
@@ -164,6 +167,29 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
+		// Action batches only coordinate the release of queued
+		// actions to units, and are not migrated; the migration
+		// prechecks refuse models with actions still to release.
+		actionBatchesC,
+
+		// Overflow action output is held in blob storage,
+		// which is not migrated; the migration prechecks refuse
+		// models that have any.
+		actionOutputsC,
+
+		// Action schedules are not migrated; the migration
+		// prechecks refuse models that have any.
+		actionSchedulesC,
+
+		// Hook execution history is diagnostic only, like the
+		// logs, and is not migrated.
+		hookExecutionsC,
+
+		// Roles, like the application access granted with them,
+		// are not migrated; the migration prechecks refuse models
+		// that have either.
+		rolesC,
+
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
 		globalSettingsC,
@@ -371,6 +397,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Egress rules are not yet supported by the model description,
+		// so the migration prechecks refuse models with any.
+		"EgressRules",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -686,6 +715,13 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Progress messages are not migrated.
+		"Logs",
+		// Action batches are not migrated.
+		"Batch",
+		// Overflow action output is not migrated; see
+		// HasActionOutputs.
+		"ResultsOverflow",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/mongo"
 )
 
@@ -47,8 +48,17 @@ type OpenParams struct {
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
+
+	// NewRaftLeaseClient, if non-nil, is a function that will be
+	// used to create lease clients backed by raft rather than
+	// MongoDB, when the "raft-leases" controller feature is set.
+	NewRaftLeaseClient NewLeaseClientFunc
 }
 
+// NewLeaseClientFunc is the type of a function that returns a
+// lease.Client for the given lease namespace and model.
+type NewLeaseClientFunc func(namespace, modelUUID string) (lease.Client, error)
+
 // Validate validates the OpenParams.
 func (p OpenParams) Validate() error {
 	if p.Clock == nil {
@@ -90,6 +100,7 @@ func OpenController(args OpenParams) (*Controller, error) {
 		session:                session,
 		newPolicy:              args.NewPolicy,
 		runTransactionObserver: args.RunTransactionObserver,
+		newRaftLeaseClient:     args.NewRaftLeaseClient,
 	}, nil
 }
 
@@ -122,6 +133,7 @@ func Open(args OpenParams) (*State, error) {
 		}
 		return nil, mongo.MaybeUnauthorizedf(err, "cannot read model %s", args.ControllerModelTag.Id())
 	}
+	st.newRaftLeaseClient = args.NewRaftLeaseClient
 
 	// State should only be Opened on behalf of a controller environ; all
 	// other *States must be obtained via StatePool.
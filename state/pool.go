@@ -206,6 +206,7 @@ func (p *StatePool) openState(modelUUID string) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.newRaftLeaseClient = p.systemState.newRaftLeaseClient
 	if err := newSt.start(p.systemState.controllerTag, p.hub); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -64,6 +64,10 @@ type collectionPruner struct {
 
 	ageField string
 	timeUnit TimeUnit
+
+	// filter, if set, further restricts the entries
+	// that are pruned by age.
+	filter bson.D
 }
 
 func (p *collectionPruner) validate() error {
@@ -96,10 +100,12 @@ func (p *collectionPruner) pruneByAge() error {
 		notSet = time.Time{}
 	}
 
-	iter := p.coll.Find(bson.D{
+	query := bson.D{
 		{"model-uuid", p.st.modelUUID()},
 		{p.ageField, bson.M{"$gt": notSet, "$lt": age}},
-	}).Select(bson.M{"_id": 1}).Iter()
+	}
+	query = append(query, p.filter...)
+	iter := p.coll.Find(query).Select(bson.M{"_id": 1}).Iter()
 	defer iter.Close()
 
 	modelName, err := p.st.modelName()
@@ -0,0 +1,126 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// roleDoc records a named set of capabilities that may be granted to
+// users on applications in the model.
+type roleDoc struct {
+	DocID        string   `bson:"_id"`
+	ModelUUID    string   `bson:"model-uuid"`
+	Name         string   `bson:"name"`
+	Capabilities []string `bson:"capabilities"`
+}
+
+func (doc roleDoc) role() permission.Role {
+	role := permission.Role{Name: doc.Name}
+	for _, capability := range doc.Capabilities {
+		role.Capabilities = append(role.Capabilities, permission.Capability(capability))
+	}
+	return role
+}
+
+// AddRole adds a role to the model.
+func (st *State) AddRole(role permission.Role) error {
+	if err := role.Validate(); err != nil {
+		return errors.Annotate(err, "cannot add role")
+	}
+	doc := roleDoc{
+		DocID:     st.docID(role.Name),
+		ModelUUID: st.ModelUUID(),
+		Name:      role.Name,
+	}
+	for _, capability := range role.Capabilities {
+		doc.Capabilities = append(doc.Capabilities, string(capability))
+	}
+	ops := []txn.Op{{
+		C:      rolesC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.AlreadyExistsf("role %q", role.Name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot add role %q", role.Name)
+	}
+	return nil
+}
+
+// Role returns the role with the given name. Each application access
+// level is also a role granting just its capabilities.
+func (st *State) Role(name string) (permission.Role, error) {
+	if err := permission.ValidateApplicationAccess(permission.Access(name)); err == nil {
+		return permission.Role{
+			Name:         name,
+			Capabilities: permission.ApplicationCapabilities(permission.Access(name)),
+		}, nil
+	}
+	roles, closer := st.db().GetCollection(rolesC)
+	defer closer()
+
+	var doc roleDoc
+	if err := roles.FindId(name).One(&doc); err == mgo.ErrNotFound {
+		return permission.Role{}, errors.NotFoundf("role %q", name)
+	} else if err != nil {
+		return permission.Role{}, errors.Annotatef(err, "cannot get role %q", name)
+	}
+	return doc.role(), nil
+}
+
+// AllRoles returns the roles added to the model, sorted by name.
+func (st *State) AllRoles() ([]permission.Role, error) {
+	roles, closer := st.db().GetCollection(rolesC)
+	defer closer()
+
+	var docs []roleDoc
+	if err := roles.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get roles")
+	}
+	result := make([]permission.Role, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.role()
+	}
+	return result, nil
+}
+
+// RemoveRole removes the role with the given name from the model. A role
+// that is granted to any user cannot be removed.
+func (st *State) RemoveRole(name string) error {
+	if err := permission.ValidateRoleName(name); err != nil {
+		return errors.Trace(err)
+	}
+	permissions, closer := st.db().GetCollection(permissionsC)
+	defer closer()
+	granted, err := permissions.Find(bson.D{
+		{"_id", bson.D{{"$regex", "^" + permissionID(applicationAccessKeyPrefix(st.ModelUUID()), "")}}},
+		{"access", name},
+	}).Count()
+	if err != nil {
+		return errors.Annotatef(err, "cannot remove role %q", name)
+	}
+	if granted > 0 {
+		return errors.Errorf("cannot remove role %q: role is granted to %d user(s)", name, granted)
+	}
+	ops := []txn.Op{{
+		C:      rolesC,
+		Id:     st.docID(name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("role %q", name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove role %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/testing/factory"
+)
+
+type RoleSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RoleSuite{})
+
+var operatorRole = permission.Role{
+	Name:         "operator",
+	Capabilities: []permission.Capability{"Application.SetApplicationsConfig", "Action.Enqueue"},
+}
+
+func (s *RoleSuite) TestAddRole(c *gc.C) {
+	err := s.State.AddRole(operatorRole)
+	c.Assert(err, jc.ErrorIsNil)
+
+	role, err := s.State.Role("operator")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, jc.DeepEquals, operatorRole)
+}
+
+func (s *RoleSuite) TestAddRoleAlreadyExists(c *gc.C) {
+	err := s.State.AddRole(operatorRole)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddRole(operatorRole)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *RoleSuite) TestAddRoleInvalid(c *gc.C) {
+	err := s.State.AddRole(permission.Role{
+		Name:         "config",
+		Capabilities: []permission.Capability{"Application.SetApplicationsConfig"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add role: role name "config" clashes with an application access level`)
+}
+
+func (s *RoleSuite) TestRoleNotFound(c *gc.C) {
+	_, err := s.State.Role("operator")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RoleSuite) TestApplicationAccessRoles(c *gc.C) {
+	role, err := s.State.Role("upgrade-charm")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, jc.DeepEquals, permission.Role{
+		Name:         "upgrade-charm",
+		Capabilities: []permission.Capability{"Application.SetCharm", "Client.AddCharm", "Client.AddCharmWithAuthorization"},
+	})
+}
+
+func (s *RoleSuite) TestAllRoles(c *gc.C) {
+	other := permission.Role{
+		Name:         "app-team",
+		Capabilities: []permission.Capability{"Application.SetCharm"},
+	}
+	err := s.State.AddRole(operatorRole)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddRole(other)
+	c.Assert(err, jc.ErrorIsNil)
+
+	roles, err := s.State.AllRoles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roles, jc.DeepEquals, []permission.Role{other, operatorRole})
+}
+
+func (s *RoleSuite) TestRemoveRole(c *gc.C) {
+	err := s.State.AddRole(operatorRole)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveRole("operator")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Role("operator")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveRole("operator")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RoleSuite) TestRemoveRoleGranted(c *gc.C) {
+	err := s.State.AddRole(operatorRole)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err = s.State.SetApplicationAccess("mysql", user.UserTag(), "operator")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveRole("operator")
+	c.Assert(err, gc.ErrorMatches, `cannot remove role "operator": role is granted to 1 user\(s\)`)
+}
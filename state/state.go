@@ -36,6 +36,7 @@ import (
 	"github.com/juju/juju/core/application"
 	coreglobalclock "github.com/juju/juju/core/globalclock"
 	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/network"
@@ -89,6 +90,10 @@ type State struct {
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
 
+	// newRaftLeaseClient, if non-nil, is used to create lease
+	// clients when the "raft-leases" controller feature is set.
+	newRaftLeaseClient NewLeaseClientFunc
+
 	// cloudName is the name of the cloud on which the model
 	// represented by this state runs.
 	cloudName string
@@ -440,6 +445,23 @@ func (st *State) getSingularLeaseClient() (lease.Client, error) {
 }
 
 func (st *State) getLeaseClient(namespace string) (lease.Client, error) {
+	// Raft leases can't guard transactions, so they're only used for
+	// the singular controller leases; application leadership is used
+	// to guard settings writes and the like.
+	if st.newRaftLeaseClient != nil && namespace == singularControllerNamespace {
+		controllerConfig, err := st.ControllerConfig()
+		if err != nil {
+			return nil, errors.Annotate(err, "getting controller config for lease client")
+		}
+		if controllerConfig.Features().Contains(feature.RaftLeases) {
+			client, err := st.newRaftLeaseClient(namespace, st.ModelUUID())
+			if err != nil {
+				return nil, errors.Annotatef(err, "cannot create %q raft lease client", namespace)
+			}
+			return client, nil
+		}
+	}
+
 	globalClock, err := st.globalClockReader()
 	if err != nil {
 		return nil, errors.Annotate(err, "getting global clock for lease client")
@@ -31,7 +31,9 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/mongotest"
@@ -4688,3 +4690,25 @@ func (s *StateSuite) TestControllerTimestamp(c *gc.C) {
 
 	c.Assert(*got, jc.DeepEquals, now)
 }
+
+func (s *StateSuite) TestRaftLeasesOnlyUsedForSingularNamespace(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"features": []string{feature.RaftLeases},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	var namespaces []string
+	state.SetNewRaftLeaseClient(s.State, func(namespace, modelUUID string) (lease.Client, error) {
+		namespaces = append(namespaces, namespace)
+		return nil, errors.New("no raft here")
+	})
+
+	_, err = state.LeaseClient(s.State, "singular-controller")
+	c.Assert(err, gc.ErrorMatches, `cannot create "singular-controller" raft lease client: no raft here`)
+
+	// Leadership is used to guard transactions, so it stays in
+	// MongoDB.
+	client, err := state.LeaseClient(s.State, "application-leadership")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client, gc.NotNil)
+	c.Assert(namespaces, jc.DeepEquals, []string{"singular-controller"})
+}
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -1955,6 +1956,111 @@ func (st *State) WatchCleanups() NotifyWatcher {
 	return newNotifyCollWatcher(st, cleanupsC, isLocalID(st))
 }
 
+// WatchActionLogs starts and returns a StringsWatcher that notifies of
+// the progress messages logged by the action with the given id. Each
+// change is a JSON encoded ActionMessage.
+func (st *State) WatchActionLogs(actionId string) StringsWatcher {
+	return newActionLogsWatcher(st, actionId)
+}
+
+// actionLogsWatcher is a StringsWatcher that notifies of the progress
+// messages logged by a single action.
+type actionLogsWatcher struct {
+	commonWatcher
+	source   chan watcher.Change
+	sink     chan []string
+	actionId string
+	sent     int
+}
+
+var _ StringsWatcher = (*actionLogsWatcher)(nil)
+
+func newActionLogsWatcher(backend modelBackend, actionId string) StringsWatcher {
+	w := &actionLogsWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		source:        make(chan watcher.Change),
+		sink:          make(chan []string),
+		actionId:      actionId,
+	}
+	w.tomb.Go(func() error {
+		defer close(w.sink)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes returns the channel that sends the JSON encoded progress
+// messages logged by the action since the last change.
+func (w *actionLogsWatcher) Changes() <-chan []string {
+	return w.sink
+}
+
+func (w *actionLogsWatcher) loop() error {
+	docID := w.backend.docID(w.actionId)
+	filter := func(key interface{}) bool {
+		id, ok := key.(string)
+		return ok && id == docID
+	}
+	w.watcher.WatchCollectionWithFilter(actionsC, w.source, filter)
+	defer w.watcher.UnwatchCollection(actionsC, w.source)
+
+	changes, err := w.newMessages()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.sink
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case _, ok := <-w.source:
+			if !ok {
+				return tomb.ErrDying
+			}
+			messages, err := w.newMessages()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			changes = append(changes, messages...)
+			if len(changes) > 0 {
+				out = w.sink
+			}
+		case out <- changes:
+			changes = nil
+			out = nil
+		}
+	}
+}
+
+// newMessages returns the messages logged by the action that have not
+// yet been sent, encoded as JSON.
+func (w *actionLogsWatcher) newMessages() ([]string, error) {
+	actions, closer := w.db.GetCollection(actionsC)
+	defer closer()
+
+	var doc actionDoc
+	if err := actions.FindId(w.actionId).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action %q", w.actionId)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if w.sent > len(doc.Logs) {
+		w.sent = 0
+	}
+	var changes []string
+	for _, message := range doc.Logs[w.sent:] {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changes = append(changes, string(data))
+	}
+	w.sent = len(doc.Logs)
+	return changes, nil
+}
+
 // actionStatusWatcher is a StringsWatcher that filters notifications
 // to Action Id's that match the ActionReceiver and ActionStatus set
 // provided.
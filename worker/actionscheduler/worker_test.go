@@ -0,0 +1,154 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	facade := newMockFacade()
+	clock := testing.NewClock(coretesting.ZeroTime())
+	for i, test := range []struct {
+		config actionscheduler.Config
+		err    string
+	}{{
+		config: actionscheduler.Config{Clock: clock, Period: time.Minute},
+		err:    "nil Facade not valid",
+	}, {
+		config: actionscheduler.Config{Facade: facade, Period: time.Minute},
+		err:    "nil Clock not valid",
+	}, {
+		config: actionscheduler.Config{Facade: facade, Clock: clock},
+		err:    "non-positive Period not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := actionscheduler.NewWorker(test.config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestRunsImmediately(c *gc.C) {
+	fix := newFixture(time.Minute)
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
+		fix.waitNoCall(c)
+	})
+	fix.facade.stub.CheckCallNames(c, "RunDueSchedules")
+}
+
+func (s *WorkerSuite) TestRunsAfterPeriod(c *gc.C) {
+	fix := newFixture(time.Minute)
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
+		fix.clock.Advance(time.Minute - time.Nanosecond)
+		fix.waitNoCall(c)
+		if err := fix.clock.WaitAdvance(time.Nanosecond, coretesting.LongWait, 1); err != nil {
+			c.Fatal(err)
+		}
+		fix.waitCall(c)
+	})
+	fix.facade.stub.CheckCallNames(c, "RunDueSchedules", "RunDueSchedules")
+}
+
+func (s *WorkerSuite) TestRunError(c *gc.C) {
+	fix := newFixture(time.Minute)
+	fix.facade.stub.SetErrors(errors.New("boom"))
+	fix.dirtyTest(c, func(w worker.Worker) {
+		fix.waitCall(c)
+		c.Check(w.Wait(), gc.ErrorMatches, "running due action schedules: boom")
+	})
+	fix.facade.stub.CheckCallNames(c, "RunDueSchedules")
+}
+
+// workerFixture isolates an action scheduler worker for testing.
+type workerFixture struct {
+	facade mockFacade
+	clock  *testing.Clock
+	period time.Duration
+}
+
+func newFixture(period time.Duration) workerFixture {
+	return workerFixture{
+		facade: newMockFacade(),
+		clock:  testing.NewClock(coretesting.ZeroTime()),
+		period: period,
+	}
+}
+
+type testFunc func(worker.Worker)
+
+func (fix workerFixture) cleanTest(c *gc.C, test testFunc) {
+	fix.runTest(c, test, true)
+}
+
+func (fix workerFixture) dirtyTest(c *gc.C, test testFunc) {
+	fix.runTest(c, test, false)
+}
+
+func (fix workerFixture) runTest(c *gc.C, test testFunc, checkWaitErr bool) {
+	w, err := actionscheduler.NewWorker(actionscheduler.Config{
+		Facade: fix.facade,
+		Clock:  fix.clock,
+		Period: fix.period,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		err := worker.Stop(w)
+		if checkWaitErr {
+			c.Check(err, jc.ErrorIsNil)
+		}
+	}()
+	test(w)
+}
+
+func (fix workerFixture) waitCall(c *gc.C) {
+	select {
+	case <-fix.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out")
+	}
+}
+
+func (fix workerFixture) waitNoCall(c *gc.C) {
+	select {
+	case <-fix.facade.calls:
+		c.Fatalf("unexpected facade call")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// mockFacade records (and notifies of) calls made to RunDueSchedules.
+type mockFacade struct {
+	stub  *testing.Stub
+	calls chan struct{}
+}
+
+func newMockFacade() mockFacade {
+	return mockFacade{
+		stub:  &testing.Stub{},
+		calls: make(chan struct{}, 1000),
+	}
+}
+
+func (mock mockFacade) RunDueSchedules() error {
+	mock.stub.AddCall("RunDueSchedules")
+	mock.calls <- struct{}{}
+	return mock.stub.NextErr()
+}
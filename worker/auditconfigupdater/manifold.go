@@ -73,14 +73,24 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 		}
 	}()
 
-	logDir := agent.CurrentConfig().LogDir()
+	agentConfig := agent.CurrentConfig()
 
 	st := statePool.SystemState()
 
+	// The same target is handed out when the sinks are reconfigured,
+	// so that connections already recording to it write to the new
+	// sinks.
+	var target *auditlog.SwitchLog
 	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		log := newAuditLog(agentConfig, cfg)
+		if target == nil {
+			target = auditlog.NewSwitchLog(log)
+		} else if err := target.Switch(log); err != nil {
+			logger.Warningf("closing previous audit log: %v", err)
+		}
+		return target
 	}
-	auditConfig, err := initialConfig(st)
+	auditConfig, err := configFromSource(st)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -114,18 +124,3 @@ func output(in worker.Worker, out interface{}) error {
 	*target = w.CurrentConfig
 	return nil
 }
-
-func initialConfig(source ConfigSource) (auditlog.Config, error) {
-	cfg, err := source.ControllerConfig()
-	if err != nil {
-		return auditlog.Config{}, errors.Trace(err)
-	}
-	result := auditlog.Config{
-		Enabled:        cfg.AuditingEnabled(),
-		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
-		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
-		MaxBackups:     cfg.AuditLogMaxBackups(),
-		ExcludeMethods: cfg.AuditLogExcludeMethods(),
-	}
-	return result, nil
-}
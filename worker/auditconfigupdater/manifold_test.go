@@ -4,6 +4,8 @@
 package auditconfigupdater_test
 
 import (
+	"time"
+
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -126,6 +128,11 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 		ExcludeMethods: set.NewStrings("This.Method"),
 		MaxSizeMB:      10,
 		MaxBackups:     10,
+		Sinks:          set.NewStrings("file"),
+		Webhook: auditlog.WebhookConfig{
+			BatchSize:     100,
+			FlushInterval: 5 * time.Second,
+		},
 	})
 
 	c.Assert(args[2], gc.NotNil)
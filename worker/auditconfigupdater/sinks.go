@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditconfigupdater
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	jujuagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+	jujuversion "github.com/juju/juju/version"
+)
+
+var logger = loggo.GetLogger("juju.worker.auditconfigupdater")
+
+// webhookTimeout is the time allowed for a single request to the
+// audit log webhook.
+const webhookTimeout = 30 * time.Second
+
+// newAuditLog returns an audit log which writes to each of the sinks
+// named in the config. A sink that can't be created is logged and
+// skipped, so that a misconfigured remote sink doesn't stop records
+// being written to the others.
+func newAuditLog(agentConfig jujuagent.Config, cfg auditlog.Config) auditlog.AuditLog {
+	var logs []auditlog.AuditLog
+	for _, name := range cfg.Sinks.SortedValues() {
+		switch name {
+		case controller.AuditLogSinkFile:
+			logs = append(logs, auditlog.NewLogFile(agentConfig.LogDir(), cfg.MaxSizeMB, cfg.MaxBackups))
+		case controller.AuditLogSinkSyslog:
+			sink, err := newSyslogSink(agentConfig, cfg.Syslog)
+			if err != nil {
+				logger.Errorf("not auditing to syslog: %v", err)
+				continue
+			}
+			logs = append(logs, auditlog.NewSinkLog(sink))
+		case controller.AuditLogSinkWebhook:
+			client := &http.Client{Timeout: webhookTimeout}
+			sink, err := auditlog.NewWebhookSink(cfg.Webhook, client, clock.WallClock)
+			if err != nil {
+				logger.Errorf("not auditing to webhook: %v", err)
+				continue
+			}
+			logs = append(logs, auditlog.NewSinkLog(sink))
+		default:
+			logger.Warningf("ignoring unknown audit log sink %q", name)
+		}
+	}
+	if len(logs) == 1 {
+		return logs[0]
+	}
+	return auditlog.NewTeeLog(logs...)
+}
+
+func newSyslogSink(agentConfig jujuagent.Config, cfg syslog.RawConfig) (auditlog.Sink, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tag, ok := agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected machine agent, got %s", agentConfig.Tag())
+	}
+	origin := logfwd.OriginForMachineAgent(
+		tag,
+		agentConfig.Controller().Id(),
+		agentConfig.Model().Id(),
+		jujuversion.Current,
+	)
+	open := func() (auditlog.SyslogSender, error) {
+		client, err := syslog.Open(cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return client, nil
+	}
+	return auditlog.NewSyslogSink(open, origin, clock.WallClock), nil
+}
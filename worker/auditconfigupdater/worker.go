@@ -4,6 +4,7 @@
 package auditconfigupdater
 
 import (
+	"reflect"
 	"sync"
 
 	"github.com/juju/errors"
@@ -30,9 +31,10 @@ type AuditLogFactory func(auditlog.Config) auditlog.AuditLog
 // New returns a worker that will keep an up-to-date audit log config.
 func New(source ConfigSource, initial auditlog.Config, logFactory AuditLogFactory) (worker.Worker, error) {
 	u := &updater{
-		source:     source,
-		current:    initial,
-		logFactory: logFactory,
+		source:       source,
+		current:      initial,
+		targetConfig: initial,
+		logFactory:   logFactory,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -50,6 +52,9 @@ type updater struct {
 	source     ConfigSource
 	current    auditlog.Config
 	logFactory AuditLogFactory
+
+	// targetConfig is the config the current target was made from.
+	targetConfig auditlog.Config
 }
 
 // Kill is part of the worker.Worker interface.
@@ -85,19 +90,13 @@ func (u *updater) loop() error {
 }
 
 func (u *updater) newConfig() (auditlog.Config, error) {
-	cfg, err := u.source.ControllerConfig()
+	result, err := configFromSource(u.source)
 	if err != nil {
 		return auditlog.Config{}, errors.Trace(err)
 	}
-	result := auditlog.Config{
-		Enabled:        cfg.AuditingEnabled(),
-		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
-		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
-		MaxBackups:     cfg.AuditLogMaxBackups(),
-		ExcludeMethods: cfg.AuditLogExcludeMethods(),
-	}
-	if result.Enabled && u.current.Target == nil {
+	if result.Enabled && (u.current.Target == nil || sinksChanged(u.targetConfig, result)) {
 		result.Target = u.logFactory(result)
+		u.targetConfig = result
 	} else {
 		// Keep the existing target to avoid file handle leaks from
 		// disabling and enabling auditing - we'll still stop logging
@@ -107,6 +106,44 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 	return result, nil
 }
 
+// sinksChanged returns whether the configs describe different audit
+// log destinations, so that the target needs to be remade.
+func sinksChanged(old, new auditlog.Config) bool {
+	sinks := func(cfg auditlog.Config) auditlog.Config {
+		return auditlog.Config{
+			MaxSizeMB:  cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			Sinks:      cfg.Sinks,
+			Syslog:     cfg.Syslog,
+			Webhook:    cfg.Webhook,
+		}
+	}
+	return !reflect.DeepEqual(sinks(old), sinks(new))
+}
+
+// configFromSource returns the audit log config described by the
+// source's controller config. The Target isn't set.
+func configFromSource(source ConfigSource) (auditlog.Config, error) {
+	cfg, err := source.ControllerConfig()
+	if err != nil {
+		return auditlog.Config{}, errors.Trace(err)
+	}
+	return auditlog.Config{
+		Enabled:        cfg.AuditingEnabled(),
+		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
+		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
+		MaxBackups:     cfg.AuditLogMaxBackups(),
+		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Sinks:          cfg.AuditLogSinks(),
+		Syslog:         cfg.AuditLogSyslogConfig(),
+		Webhook: auditlog.WebhookConfig{
+			URL:           cfg.AuditLogWebhookURL(),
+			BatchSize:     cfg.AuditLogWebhookBatchSize(),
+			FlushInterval: cfg.AuditLogWebhookFlushInterval(),
+		},
+	}, nil
+}
+
 func (u *updater) update(newConfig auditlog.Config) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
@@ -79,10 +79,10 @@ func waitForConfig(c *gc.C, w worker.Worker, predicate func(auditlog.Config) boo
 
 func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withSinks(auditlog.Config{
 		Enabled: true,
 		Target:  &apitesting.FakeAuditLog{},
-	}
+	}, makeControllerConfig(true, false))
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(true, false),
@@ -107,10 +107,10 @@ func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 
 func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withSinks(auditlog.Config{
 		Enabled: false,
 		Target:  &apitesting.FakeAuditLog{},
-	}
+	}, makeControllerConfig(false, false))
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(false, false),
@@ -135,11 +135,11 @@ func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
 
 func (s *updaterSuite) TestChangingExcludeMethod(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withSinks(auditlog.Config{
 		Enabled:        true,
 		ExcludeMethods: set.NewStrings("Pink.Floyd"),
 		Target:         &apitesting.FakeAuditLog{},
-	}
+	}, makeControllerConfig(true, false, "Pink.Floyd"))
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(true, false, "Pink.Floyd"),
@@ -166,11 +166,11 @@ func (s *updaterSuite) TestChangingExcludeMethod(c *gc.C) {
 
 func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withSinks(auditlog.Config{
 		Enabled:        true,
 		CaptureAPIArgs: false,
 		Target:         &apitesting.FakeAuditLog{},
-	}
+	}, makeControllerConfig(true, false, "Pink.Floyd"))
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(true, false, "Pink.Floyd"),
@@ -188,6 +188,58 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestRemakesTargetWhenSinksChange(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	initial := withSinks(auditlog.Config{
+		Enabled: true,
+		Target:  &apitesting.FakeAuditLog{},
+	}, makeControllerConfig(true, false))
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return &newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-sinks"] = []interface{}{"file", "webhook"}
+	cfg["audit-log-webhook-url"] = "https://siem.example.com/audit"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Sinks.Contains("webhook")
+	})
+	c.Assert(newConfig.Target, gc.Equals, auditlog.AuditLog(&newTarget))
+	c.Assert(newConfig.Webhook.URL, gc.Equals, "https://siem.example.com/audit")
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].Sinks, gc.DeepEquals, set.NewStrings("file", "webhook"))
+}
+
+// withSinks returns the audit log config with the sink settings from
+// the controller config, as if its target had been made from them.
+func withSinks(cfg auditlog.Config, controllerConfig controller.Config) auditlog.Config {
+	cfg.MaxSizeMB = controllerConfig.AuditLogMaxSizeMB()
+	cfg.MaxBackups = controllerConfig.AuditLogMaxBackups()
+	cfg.Sinks = controllerConfig.AuditLogSinks()
+	cfg.Syslog = controllerConfig.AuditLogSyslogConfig()
+	cfg.Webhook = auditlog.WebhookConfig{
+		URL:           controllerConfig.AuditLogWebhookURL(),
+		BatchSize:     controllerConfig.AuditLogWebhookBatchSize(),
+		FlushInterval: controllerConfig.AuditLogWebhookFlushInterval(),
+	}
+	return cfg
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
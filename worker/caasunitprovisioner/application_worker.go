@@ -167,13 +167,14 @@ func (aw *applicationWorker) loop() error {
 					}
 				}
 				args.Units = append(args.Units, params.ApplicationUnitParams{
-					ProviderId: u.Id,
-					UnitTag:    u.UnitTag,
-					Address:    u.Address,
-					Ports:      u.Ports,
-					Status:     unitStatus.Status.String(),
-					Info:       unitStatus.Message,
-					Data:       unitStatus.Data,
+					ProviderId:       u.Id,
+					LegacyProviderId: u.LegacyId,
+					UnitTag:          u.UnitTag,
+					Address:          u.Address,
+					Ports:            u.Ports,
+					Status:           unitStatus.Status.String(),
+					Info:             unitStatus.Message,
+					Data:             unitStatus.Data,
 				})
 			}
 			if err := aw.unitUpdater.UpdateUnits(args); err != nil {
@@ -5,6 +5,7 @@ package charmrunner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -47,3 +48,25 @@ func IsMissingHookError(err error) bool {
 func NewMissingHookError(hookName string) error {
 	return &missingHookError{hookName}
 }
+
+type hookTimedOutError struct {
+	hookName string
+	timeout  time.Duration
+}
+
+func (e *hookTimedOutError) Error() string {
+	return fmt.Sprintf("hook timed out after %v", e.timeout)
+}
+
+// IsHookTimedOutError returns whether err was returned because a hook
+// ran for longer than its application's hook-timeout allows.
+func IsHookTimedOutError(err error) bool {
+	_, ok := err.(*hookTimedOutError)
+	return ok
+}
+
+// NewHookTimedOutError returns an error reporting that the named hook
+// was killed after running for the given timeout.
+func NewHookTimedOutError(hookName string, timeout time.Duration) error {
+	return &hookTimedOutError{hookName, timeout}
+}
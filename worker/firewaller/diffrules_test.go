@@ -129,3 +129,47 @@ func (s *DiffRulesSuite) TestDiffRangesClosesPortsIfRulesAreDisjoint(c *gc.C) {
 	c.Assert(toOpen, gc.DeepEquals, wanted)
 	c.Assert(toClose, gc.DeepEquals, current)
 }
+
+func (s *DiffRulesSuite) TestDiffEgressRules(c *gc.C) {
+	current := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	}
+	wanted := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewEgressRule("tcp", 5432, 5432),
+	}
+	toAllow, toDisallow := diffEgressRules(current, wanted)
+	c.Assert(toAllow, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.1.0/24"),
+		network.MustNewEgressRule("tcp", 5432, 5432, "0.0.0.0/0", "::/0"),
+	})
+	c.Assert(toDisallow, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+}
+
+func (s *DiffRulesSuite) TestDiffEgressRulesUnchanged(c *gc.C) {
+	rules := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	}
+	toAllow, toDisallow := diffEgressRules(rules, rules)
+	c.Assert(toAllow, gc.HasLen, 0)
+	c.Assert(toDisallow, gc.HasLen, 0)
+}
+
+func (s *DiffRulesSuite) TestDiffEgressRulesAnyDestination(c *gc.C) {
+	current := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0", "::/0"),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	}
+	wanted := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+		network.MustNewEgressRule("udp", 53, 53),
+	}
+	toAllow, toDisallow := diffEgressRules(current, wanted)
+	c.Assert(toAllow, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "::/0"),
+	})
+	c.Assert(toDisallow, gc.HasLen, 0)
+}
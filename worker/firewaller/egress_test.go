@@ -0,0 +1,330 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewaller_test
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/juju/collections/set"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/firewaller"
+)
+
+// egressEnviron wraps the instances of an environ so that they record
+// the egress rules applied to them.
+type egressEnviron struct {
+	firewaller.EnvironInstances
+
+	mu    sync.Mutex
+	rules map[string]map[network.PortRange]set.Strings
+
+	// openedUp records the machines whose instances were left with no
+	// egress rules, and so allowed all egress, after having had some.
+	openedUp set.Strings
+}
+
+func newEgressEnviron(env firewaller.EnvironInstances) *egressEnviron {
+	return &egressEnviron{
+		EnvironInstances: env,
+		rules:            make(map[string]map[network.PortRange]set.Strings),
+		openedUp:         set.NewStrings(),
+	}
+}
+
+// Instances is part of the firewaller.EnvironInstances interface.
+func (e *egressEnviron) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instance.Instance, error) {
+	insts, err := e.EnvironInstances.Instances(ctx, ids)
+	for i, inst := range insts {
+		if inst != nil {
+			insts[i] = &egressInstance{
+				Instance:           inst,
+				InstanceFirewaller: inst.(instance.InstanceFirewaller),
+				env:                e,
+			}
+		}
+	}
+	return insts, err
+}
+
+func egressRuleCIDRs(rule network.EgressRule) []string {
+	if len(rule.DestinationCIDRs) == 0 {
+		return []string{"0.0.0.0/0", "::/0"}
+	}
+	return rule.DestinationCIDRs
+}
+
+func (e *egressEnviron) allow(machineId string, rules []network.EgressRule) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	machineRules, ok := e.rules[machineId]
+	if !ok {
+		machineRules = make(map[network.PortRange]set.Strings)
+		e.rules[machineId] = machineRules
+	}
+	for _, rule := range rules {
+		if _, ok := machineRules[rule.PortRange]; !ok {
+			machineRules[rule.PortRange] = set.NewStrings()
+		}
+		for _, cidr := range egressRuleCIDRs(rule) {
+			machineRules[rule.PortRange].Add(cidr)
+		}
+	}
+}
+
+func (e *egressEnviron) disallow(machineId string, rules []network.EgressRule) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	machineRules := e.rules[machineId]
+	if len(machineRules) == 0 {
+		return
+	}
+	for _, rule := range rules {
+		cidrs, ok := machineRules[rule.PortRange]
+		if !ok {
+			continue
+		}
+		for _, cidr := range egressRuleCIDRs(rule) {
+			cidrs.Remove(cidr)
+		}
+		if cidrs.IsEmpty() {
+			delete(machineRules, rule.PortRange)
+		}
+	}
+	if len(machineRules) == 0 {
+		e.openedUp.Add(machineId)
+	}
+}
+
+func (e *egressEnviron) egressRules(machineId string) []network.EgressRule {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var rules []network.EgressRule
+	for portRange, cidrs := range e.rules[machineId] {
+		rules = append(rules, network.EgressRule{
+			PortRange:        portRange,
+			DestinationCIDRs: cidrs.SortedValues(),
+		})
+	}
+	network.SortEgressRules(rules)
+	return rules
+}
+
+func (e *egressEnviron) wasOpenedUp(machineId string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.openedUp.Contains(machineId)
+}
+
+// egressInstance is an instance whose egress rules are recorded by its
+// egressEnviron.
+type egressInstance struct {
+	instance.Instance
+	instance.InstanceFirewaller
+	env *egressEnviron
+}
+
+var _ instance.InstanceEgressFirewaller = (*egressInstance)(nil)
+
+// AllowEgress is part of the instance.InstanceEgressFirewaller interface.
+func (inst *egressInstance) AllowEgress(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error {
+	inst.env.allow(machineId, rules)
+	return nil
+}
+
+// DisallowEgress is part of the instance.InstanceEgressFirewaller interface.
+func (inst *egressInstance) DisallowEgress(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error {
+	inst.env.disallow(machineId, rules)
+	return nil
+}
+
+// EgressRules is part of the instance.InstanceEgressFirewaller interface.
+func (inst *egressInstance) EgressRules(ctx context.ProviderCallContext, machineId string) ([]network.EgressRule, error) {
+	return inst.env.egressRules(machineId), nil
+}
+
+type EgressSuite struct {
+	firewallerBaseSuite
+	env *egressEnviron
+}
+
+var _ = gc.Suite(&EgressSuite{})
+
+// controllerRule is the egress rule allowing machines to connect to the
+// controller, always wanted along with any other egress rules.
+var controllerRule = network.MustNewEgressRule("tcp", 17070, 17070, "10.0.0.1/32")
+
+func (s *EgressSuite) SetUpTest(c *gc.C) {
+	s.firewallerBaseSuite.setUpTest(c, config.FwInstance)
+	err := s.State.SetAPIHostPorts([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.env = newEgressEnviron(s.Environ)
+}
+
+func (s *EgressSuite) TearDownTest(c *gc.C) {
+	s.firewallerBaseSuite.JujuConnSuite.TearDownTest(c)
+}
+
+func (s *EgressSuite) newFirewaller(c *gc.C) worker.Worker {
+	fwEnv, ok := s.Environ.(environs.Firewaller)
+	c.Assert(ok, gc.Equals, true)
+
+	cfg := firewaller.Config{
+		ModelUUID:          s.State.ModelUUID(),
+		Mode:               config.FwInstance,
+		EnvironFirewaller:  fwEnv,
+		EnvironInstances:   s.env,
+		FirewallerAPI:      s.firewaller,
+		RemoteRelationsApi: s.remoteRelations,
+		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
+			return s.crossmodelFirewaller, nil
+		},
+		Clock:         &mockClock{c: c},
+		CredentialAPI: s.credentialsFacade,
+	}
+	fw, err := firewaller.NewFirewaller(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return fw
+}
+
+// assertEgress waits for the egress rules of the machine's instance to
+// be the expected rules.
+func (s *EgressSuite) assertEgress(c *gc.C, machineId string, expected ...network.EgressRule) {
+	s.BackingState.StartSync()
+	network.SortEgressRules(expected)
+	start := time.Now()
+	for {
+		got := s.env.egressRules(machineId)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+func (s *EgressSuite) TestSetChangeClearEgressRules(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	_, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+
+	err := app.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, m.Id(),
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0", "::/0"),
+		controllerRule,
+	)
+
+	err = app.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, m.Id(),
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+		controllerRule,
+	)
+
+	err = app.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, m.Id())
+}
+
+func (s *EgressSuite) TestSharedMachine(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app1 := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app1.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, m := s.addUnit(c, app1)
+	s.startInstance(c, m)
+
+	app2 := s.AddTestingApplication(c, "mysql", s.charm)
+	err = app2.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.1.0/24"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u2, err := app2.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEgress(c, m.Id(),
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+		controllerRule,
+	)
+
+	// Clearing one application's rules leaves the other's in place.
+	err = app1.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, m.Id(),
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.1.0/24"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+		controllerRule,
+	)
+	c.Assert(s.env.wasOpenedUp(m.Id()), jc.IsFalse)
+}
+
+func (s *EgressSuite) TestRestartReconcilesEgressRules(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+
+	fw := s.newFirewaller(c)
+	s.assertEgress(c, m.Id(),
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		controllerRule,
+	)
+	statetesting.AssertKillAndWait(c, fw)
+
+	// Change the rules while the firewaller is not running.
+	err = app.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw = s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+	s.assertEgress(c, m.Id(),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+		controllerRule,
+	)
+
+	// The instance's egress was never left unrestricted.
+	c.Assert(s.env.wasOpenedUp(m.Id()), jc.IsFalse)
+}
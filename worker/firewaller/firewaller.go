@@ -5,6 +5,8 @@ package firewaller
 
 import (
 	"io"
+	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -136,6 +138,7 @@ type Firewaller struct {
 	unitds               map[names.UnitTag]*unitData
 	applicationids       map[names.ApplicationTag]*applicationData
 	exposedChange        chan *exposedChange
+	egressRulesChange    chan *egressRulesChange
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
 
@@ -172,6 +175,7 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		unitds:                     make(map[names.UnitTag]*unitData),
 		applicationids:             make(map[names.ApplicationTag]*applicationData),
 		exposedChange:              make(chan *exposedChange),
+		egressRulesChange:          make(chan *egressRulesChange),
 		relationIngress:            make(map[names.RelationTag]*remoteRelationData),
 		localRelationsChange:       make(chan *remoteRelationNetworkChange),
 		pollClock:                  clk,
@@ -311,6 +315,15 @@ func (fw *Firewaller) loop() error {
 			if err := fw.flushUnits(unitds); err != nil {
 				return errors.Annotate(err, "cannot change firewall ports")
 			}
+		case change := <-fw.egressRulesChange:
+			change.applicationd.egressRules = change.egressRules
+			unitds := []*unitData{}
+			for _, unitd := range change.applicationd.unitds {
+				unitds = append(unitds, unitd)
+			}
+			if err := fw.flushUnits(unitds); err != nil {
+				return errors.Annotate(err, "cannot change firewall egress rules")
+			}
 		}
 	}
 }
@@ -345,6 +358,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
 		ingressRules: make([]network.IngressRule, 0),
+		egressRules:  make([]network.EgressRule, 0),
 		definedPorts: make(map[names.UnitTag]portRanges),
 	}
 	m, err := machined.machine()
@@ -472,10 +486,15 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	egressRules, err := app.EgressRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	applicationd := &applicationData{
 		fw:          fw,
 		application: app,
 		exposed:     exposed,
+		egressRules: egressRules,
 		unitds:      make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
@@ -483,7 +502,7 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, egressRules)
 		},
 	})
 	if err != nil {
@@ -586,10 +605,40 @@ func (fw *Firewaller) reconcileInstances() error {
 				return err
 			}
 		}
+
+		if err := fw.reconcileInstanceEgress(machined, instances[0]); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// reconcileInstanceEgress compares the egress rules of the instance with
+// those wanted for the machine, and allows and disallows egress to match.
+func (fw *Firewaller) reconcileInstanceEgress(machined *machineData, inst instance.Instance) error {
+	fwInstance, ok := inst.(instance.InstanceEgressFirewaller)
+	if !ok {
+		return nil
+	}
+	initialRules, err := fwInstance.EgressRules(fw.cloudCallContext, machined.tag.Id())
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not enforcing egress rules on %q: %v", machined.tag, err)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	// Compare with the rules gathered from the machine's units now, so
+	// that rules the instance should keep are never disallowed, however
+	// the machine's units were loaded.
+	want, err := fw.gatherEgressRules(machined)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machined.egressRules = want
+	toAllow, toDisallow := diffEgressRules(initialRules, machined.egressRules)
+	return fw.applyInstanceEgress(fwInstance, machined, toAllow, toDisallow)
+}
+
 // unitsChanged responds to changes to the assigned units.
 func (fw *Firewaller) unitsChanged(change *unitsChange) error {
 	changed := []*unitData{}
@@ -733,10 +782,97 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 	}
 	toOpen, toClose := diffRanges(machined.ingressRules, want)
 	machined.ingressRules = want
+	wantEgress, err := fw.gatherEgressRules(machined)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	toAllow, toDisallow := diffEgressRules(machined.egressRules, wantEgress)
+	machined.egressRules = wantEgress
 	if fw.globalMode {
+		if len(toAllow) > 0 {
+			logger.Warningf("egress rules %v for %q are not enforced in global firewall mode", toAllow, machined.tag)
+		}
 		return fw.flushGlobalPorts(toOpen, toClose)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	if err := fw.flushInstancePorts(machined, toOpen, toClose); err != nil {
+		return err
+	}
+	return fw.flushInstanceEgress(machined, toAllow, toDisallow)
+}
+
+// gatherEgressRules returns the egress rules wanted for the specified
+// machine: those of the applications with units on the machine and, if
+// there are any, those allowing the machine to reach the controller.
+func (fw *Firewaller) gatherEgressRules(machined *machineData) ([]network.EgressRule, error) {
+	want := applicationEgressRules(machined)
+	if len(want) == 0 || fw.globalMode {
+		return want, nil
+	}
+	controllerRules, err := fw.controllerEgressRules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(want, controllerRules...), nil
+}
+
+// applicationEgressRules returns the egress rules of the applications
+// with units on the specified machine.
+func applicationEgressRules(machined *machineData) []network.EgressRule {
+	var want []network.EgressRule
+	applications := set.NewStrings()
+	for _, unitd := range machined.unitds {
+		name := unitd.applicationd.application.Name()
+		if applications.Contains(name) {
+			continue
+		}
+		applications.Add(name)
+		want = append(want, unitd.applicationd.egressRules...)
+	}
+	return want
+}
+
+// controllerEgressRules returns the egress rules allowing machines to
+// connect to the controller's API servers, which must always be allowed
+// for the machine agents to keep working.
+func (fw *Firewaller) controllerEgressRules() ([]network.EgressRule, error) {
+	apiInfo, err := fw.firewallerApi.ControllerAPIInfoForModel(fw.modelUUID)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller API addresses")
+	}
+	portCIDRs := make(map[int]set.Strings)
+	for _, addr := range apiInfo.Addrs {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid controller API address %q", addr)
+		}
+		portNum, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, errors.NotValidf("controller API address %q", addr)
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			logger.Debugf("not allowing egress to controller API address %q which is not an IP address", addr)
+			continue
+		}
+		cidr := ip.String() + "/128"
+		if ip.To4() != nil {
+			cidr = ip.String() + "/32"
+		}
+		if _, ok := portCIDRs[portNum]; !ok {
+			portCIDRs[portNum] = set.NewStrings()
+		}
+		portCIDRs[portNum].Add(cidr)
+	}
+	var rules []network.EgressRule
+	for port, cidrs := range portCIDRs {
+		rule, err := network.NewEgressRule("tcp", port, port, cidrs.SortedValues()...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
 }
 
 // gatherIngressRules returns the ingress rules to open and close
@@ -885,29 +1021,14 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if len(toOpen) == 0 && len(toClose) == 0 {
 		return nil
 	}
-	m, err := machined.machine()
-	if params.IsCodeNotFound(err) {
-		return nil
-	}
-	if err != nil {
+	inst, err := fw.machineInstance(machined)
+	if err != nil || inst == nil {
 		return err
 	}
 	machineId := machined.tag.Id()
-	instanceId, err := m.InstanceId()
-	if params.IsCodeNotProvisioned(err) {
-		// Not provisioned yet, so nothing to do for this instance
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-	instances, err := fw.environInstances.Instances(fw.cloudCallContext, []instance.Id{instanceId})
-	if err != nil {
-		return err
-	}
-	fwInstance, ok := instances[0].(instance.InstanceFirewaller)
+	fwInstance, ok := inst.(instance.InstanceFirewaller)
 	if !ok {
-		logger.Infof("flushInstancePorts called on an instance of type %T which doesn't support firewall.", instances[0])
+		logger.Infof("flushInstancePorts called on an instance of type %T which doesn't support firewall.", inst)
 		return nil
 	}
 
@@ -931,6 +1052,77 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	return nil
 }
 
+// flushInstanceEgress allows and disallows egress on the machine.
+func (fw *Firewaller) flushInstanceEgress(machined *machineData, toAllow, toDisallow []network.EgressRule) error {
+	logger.Debugf("flush instance egress: to allow %v, to disallow %v", toAllow, toDisallow)
+	if len(toAllow) == 0 && len(toDisallow) == 0 {
+		return nil
+	}
+	inst, err := fw.machineInstance(machined)
+	if err != nil || inst == nil {
+		return err
+	}
+	fwInstance, ok := inst.(instance.InstanceEgressFirewaller)
+	if !ok {
+		logger.Infof("egress rules for %q not enforced: instances of type %T don't support egress rules", machined.tag, inst)
+		return nil
+	}
+	return fw.applyInstanceEgress(fwInstance, machined, toAllow, toDisallow)
+}
+
+// applyInstanceEgress allows and disallows egress on the instance of the
+// machine. Egress is allowed first so that the instance is never left
+// without the egress it needs.
+func (fw *Firewaller) applyInstanceEgress(fwInstance instance.InstanceEgressFirewaller, machined *machineData, toAllow, toDisallow []network.EgressRule) error {
+	machineId := machined.tag.Id()
+	if len(toAllow) > 0 {
+		err := fwInstance.AllowEgress(fw.cloudCallContext, machineId, toAllow)
+		if errors.IsNotSupported(err) {
+			logger.Infof("not enforcing egress rules on %q: %v", machined.tag, err)
+			return nil
+		} else if err != nil {
+			return err
+		}
+		logger.Infof("allowed egress %v on %q", toAllow, machined.tag)
+	}
+	if len(toDisallow) > 0 {
+		err := fwInstance.DisallowEgress(fw.cloudCallContext, machineId, toDisallow)
+		if errors.IsNotSupported(err) {
+			logger.Infof("not enforcing egress rules on %q: %v", machined.tag, err)
+			return nil
+		} else if err != nil {
+			return err
+		}
+		logger.Infof("disallowed egress %v on %q", toDisallow, machined.tag)
+	}
+	return nil
+}
+
+// machineInstance returns the instance of the machine, or nil if the
+// machine has been removed or is not yet provisioned.
+func (fw *Firewaller) machineInstance(machined *machineData) (instance.Instance, error) {
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		// Not provisioned yet, so nothing to do for this instance
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	instances, err := fw.environInstances.Instances(fw.cloudCallContext, []instance.Id{instanceId})
+	if err != nil {
+		return nil, err
+	}
+	return instances[0], nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -1025,6 +1217,7 @@ type machineData struct {
 	tag          names.MachineTag
 	unitds       map[names.UnitTag]*unitData
 	ingressRules []network.IngressRule
+	egressRules  []network.EgressRule
 	// ports defined by units on this machine
 	definedPorts map[names.UnitTag]portRanges
 }
@@ -1080,17 +1273,27 @@ type exposedChange struct {
 	exposed      bool
 }
 
-// applicationData holds application details and watches exposure changes.
+// egressRulesChange contains the changed egress rules for one specific
+// application.
+type egressRulesChange struct {
+	applicationd *applicationData
+	egressRules  []network.EgressRule
+}
+
+// applicationData holds application details and watches exposure and
+// egress rule changes.
 type applicationData struct {
 	catacomb    catacomb.Catacomb
 	fw          *Firewaller
 	application *firewaller.Application
 	exposed     bool
+	egressRules []network.EgressRule
 	unitds      map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed flag and egress rules for
+// changes.
+func (ad *applicationData) watchLoop(exposed bool, egressRules []network.EgressRule) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1115,6 +1318,9 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
+			if err := ad.checkEgressRules(&egressRules); err != nil {
+				return errors.Trace(err)
+			}
 			change, err := ad.application.IsExposed()
 			if err != nil {
 				return errors.Trace(err)
@@ -1133,6 +1339,37 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 	}
 }
 
+// checkEgressRules notifies the firewaller if the application's egress
+// rules differ from the given rules, which it updates.
+func (ad *applicationData) checkEgressRules(egressRules *[]network.EgressRule) error {
+	change, err := ad.application.EgressRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if egressRulesEqual(change, *egressRules) {
+		return nil
+	}
+	*egressRules = change
+	select {
+	case <-ad.catacomb.Dying():
+		return ad.catacomb.ErrDying()
+	case ad.fw.egressRulesChange <- &egressRulesChange{ad, change}:
+	}
+	return nil
+}
+
+func egressRulesEqual(a, b []network.EgressRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].String() != b[i].String() {
+			return false
+		}
+	}
+	return true
+}
+
 // Kill is part of the worker.Worker interface.
 func (ad *applicationData) Kill() {
 	ad.catacomb.Kill(nil)
@@ -1219,6 +1456,33 @@ func diffRanges(currentRules, wantedRules []network.IngressRule) (toOpen, toClos
 	return toOpen, toClose
 }
 
+// diffEgressRules returns the egress rules to allow and to disallow to
+// turn the current rules into the wanted rules. Like ingress rules, the
+// rules are compared by port range, then destination CIDR. A rule with
+// no destinations allows egress to all IPv4 and IPv6 addresses.
+func diffEgressRules(currentRules, wantedRules []network.EgressRule) (toAllow, toDisallow []network.EgressRule) {
+	asIngress := func(rules []network.EgressRule) []network.IngressRule {
+		result := make([]network.IngressRule, len(rules))
+		for i, rule := range rules {
+			cidrs := rule.DestinationCIDRs
+			if len(cidrs) == 0 {
+				cidrs = []string{"0.0.0.0/0", "::/0"}
+			}
+			result[i] = network.IngressRule{PortRange: rule.PortRange, SourceCIDRs: cidrs}
+		}
+		return result
+	}
+	asEgress := func(rules []network.IngressRule) []network.EgressRule {
+		var result []network.EgressRule
+		for _, rule := range rules {
+			result = append(result, network.EgressRule{PortRange: rule.PortRange, DestinationCIDRs: rule.SourceCIDRs})
+		}
+		return result
+	}
+	toOpen, toClose := diffRanges(asIngress(currentRules), asIngress(wantedRules))
+	return asEgress(toOpen), asEgress(toClose)
+}
+
 // relationLifeChanged manages the workers to process ingress changes for
 // the specified relation.
 func (fw *Firewaller) relationLifeChanged(tag names.RelationTag) error {
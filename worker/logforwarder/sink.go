@@ -4,6 +4,7 @@
 package logforwarder
 
 import (
+	"github.com/juju/juju/logfwd/elasticsearch"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/watcher"
 )
@@ -14,20 +15,37 @@ type LogForwardConfig interface {
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
+	// LogForwardConfig returns the current syslog log forward configuration.
 	LogForwardConfig() (*syslog.RawConfig, bool, error)
+
+	// LogForwardElasticsearchConfig returns the current Elasticsearch
+	// log forward configuration.
+	LogForwardElasticsearchConfig() (*elasticsearch.RawConfig, bool, error)
+}
+
+// SinkConfig is the configuration of a single log sink.
+type SinkConfig interface {
+	// Validate ensures that the config is valid.
+	Validate() error
 }
 
 type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
 
+	// Config is a function that returns the log sink's config.
+	Config LogSinkConfigFn
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
+// LogSinkConfigFn is a function that returns the current config of a
+// log sink, and whether forwarding to the sink is enabled.
+type LogSinkConfigFn func(api LogForwardConfig) (cfg SinkConfig, enabled bool, err error)
+
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
@@ -0,0 +1,43 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/elasticsearch"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// ElasticsearchConfig returns the current Elasticsearch forwarding
+// config, and whether forwarding to Elasticsearch is enabled.
+func ElasticsearchConfig(api logforwarder.LogForwardConfig) (logforwarder.SinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardElasticsearchConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	return cfg, cfg.Enabled, nil
+}
+
+// OpenElasticsearch returns a sink used to receive log messages to be
+// forwarded to Elasticsearch.
+func OpenElasticsearch(sinkCfg logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*elasticsearch.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected elasticsearch config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := elasticsearch.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
@@ -11,8 +11,25 @@ import (
 	"github.com/juju/juju/worker/logforwarder"
 )
 
+// SyslogConfig returns the current syslog forwarding config, and
+// whether forwarding to syslog is enabled.
+func SyslogConfig(api logforwarder.LogForwardConfig) (logforwarder.SinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	return cfg, cfg.Enabled, nil
+}
+
 // OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+func OpenSyslog(sinkCfg logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*syslog.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected syslog config, got %T", sinkCfg)
+	}
 	if !cfg.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/netplan"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/watcher"
 	workercommon "github.com/juju/juju/worker/common"
@@ -223,12 +224,24 @@ func observeNetwork() ([]params.NetworkConfig, error) {
 	return getObservedNetworkConfig(common.DefaultNetworkConfigSource())
 }
 
+// defaultBridger returns a netplan Bridger if the host's network is
+// managed by netplan. Otherwise, it returns an /etc/network/interfaces
+// Bridger if ifupdown is installed, falling back to netplan if it isn't.
+// Checking for netplan first means that hosts which have ifupdown
+// installed but are configured with netplan, as on Bionic and later,
+// are bridged with netplan.
 func defaultBridger() (network.Bridger, error) {
+	managed, err := netplan.IsManaged(systemNetplanDirectory)
+	if err != nil {
+		logger.Warningf("cannot read netplan configuration in %q: %v", systemNetplanDirectory, err)
+	}
+	if managed {
+		return network.DefaultNetplanBridger(activateBridgesTimeout, systemNetplanDirectory)
+	}
 	if _, err := os.Stat(systemSbinIfup); err == nil {
 		return network.DefaultEtcNetworkInterfacesBridger(activateBridgesTimeout, systemNetworkInterfacesFile)
-	} else {
-		return network.DefaultNetplanBridger(activateBridgesTimeout, systemNetplanDirectory)
 	}
+	return network.DefaultNetplanBridger(activateBridgesTimeout, systemNetplanDirectory)
 }
 
 func (cs *ContainerSetup) prepareHost(containerTag names.MachineTag, log loggo.Logger) error {
@@ -0,0 +1,90 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig holds the resources needed to run a raft forwarder
+// worker in a dependency.Engine.
+type ManifoldConfig struct {
+	ClockName      string
+	RaftName       string
+	CentralHubName string
+
+	ApplyTimeout time.Duration
+	Logger       Logger
+	NewWorker    func(Config) (worker.Worker, error)
+}
+
+// Validate checks that the config has all the required values.
+func (config ManifoldConfig) Validate() error {
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.RaftName == "" {
+		return errors.NotValidf("empty RaftName")
+	}
+	if config.CentralHubName == "" {
+		return errors.NotValidf("empty CentralHubName")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clk clock.Clock
+	if err := context.Get(config.ClockName, &clk); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var r *raft.Raft
+	if err := context.Get(config.RaftName, &r); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var hub *pubsub.StructuredHub
+	if err := context.Get(config.CentralHubName, &hub); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return config.NewWorker(Config{
+		Raft:         r,
+		Hub:          hub,
+		Clock:        clk,
+		Logger:       config.Logger,
+		ApplyTimeout: config.ApplyTimeout,
+	})
+}
+
+// Manifold builds a dependency.Manifold for running a raft forwarder
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.ClockName,
+			config.RaftName,
+			config.CentralHubName,
+		},
+		Start: config.start,
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder_test
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/raft/raftforwarder"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+
+	context  dependency.Context
+	manifold dependency.Manifold
+	config   raftforwarder.ManifoldConfig
+	clock    *testing.Clock
+	raft     *raft.Raft
+	hub      *pubsub.StructuredHub
+	logger   loggo.Logger
+	worker   worker.Worker
+	stub     testing.Stub
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub.ResetCalls()
+	s.clock = testing.NewClock(time.Time{})
+	s.raft = &raft.Raft{}
+	s.hub = &pubsub.StructuredHub{}
+	s.logger = loggo.GetLogger("raftforwarder_test")
+
+	type mockWorker struct {
+		worker.Worker
+	}
+	s.worker = &mockWorker{}
+
+	s.context = s.newContext(nil)
+	s.config = raftforwarder.ManifoldConfig{
+		ClockName:      "clock",
+		RaftName:       "raft",
+		CentralHubName: "hub",
+		ApplyTimeout:   time.Second,
+		Logger:         &s.logger,
+		NewWorker:      s.newWorker,
+	}
+	s.manifold = raftforwarder.Manifold(s.config)
+}
+
+func (s *manifoldSuite) newContext(overlay map[string]interface{}) dependency.Context {
+	resources := map[string]interface{}{
+		"clock": s.clock,
+		"raft":  s.raft,
+		"hub":   s.hub,
+	}
+	for k, v := range overlay {
+		resources[k] = v
+	}
+	return dt.StubContext(nil, resources)
+}
+
+func (s *manifoldSuite) newWorker(config raftforwarder.Config) (worker.Worker, error) {
+	s.stub.MethodCall(s, "NewWorker", config)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.worker, nil
+}
+
+var expectedInputs = []string{"clock", "raft", "hub"}
+
+func (s *manifoldSuite) TestInputs(c *gc.C) {
+	c.Assert(s.manifold.Inputs, jc.SameContents, expectedInputs)
+}
+
+func (s *manifoldSuite) TestMissingInputs(c *gc.C) {
+	for _, input := range expectedInputs {
+		context := s.newContext(map[string]interface{}{
+			input: dependency.ErrMissing,
+		})
+		_, err := s.manifold.Start(context)
+		c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	}
+}
+
+func (s *manifoldSuite) TestStart(c *gc.C) {
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w, gc.Equals, s.worker)
+
+	s.stub.CheckCallNames(c, "NewWorker")
+	args := s.stub.Calls()[0].Args
+	c.Assert(args, gc.HasLen, 1)
+	c.Assert(args[0], gc.FitsTypeOf, raftforwarder.Config{})
+	config := args[0].(raftforwarder.Config)
+
+	c.Assert(config, jc.DeepEquals, raftforwarder.Config{
+		Raft:         s.raft,
+		Hub:          s.hub,
+		Clock:        s.clock,
+		Logger:       &s.logger,
+		ApplyTimeout: time.Second,
+	})
+}
+
+func (s *manifoldSuite) TestValidate(c *gc.C) {
+	s.config.Logger = nil
+	_, err := raftforwarder.Manifold(s.config).Start(s.context)
+	c.Assert(err, gc.ErrorMatches, "nil Logger not valid")
+}
@@ -0,0 +1,163 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// Logger represents the logging methods called.
+type Logger interface {
+	Debugf(message string, args ...interface{})
+	Warningf(message string, args ...interface{})
+}
+
+// RaftApplier allows applying a command to the raft FSM.
+type RaftApplier interface {
+	Apply(cmd []byte, timeout time.Duration) raft.ApplyFuture
+}
+
+// Config defines the resources the worker needs to run.
+type Config struct {
+	Raft   RaftApplier
+	Hub    *pubsub.StructuredHub
+	Clock  clock.Clock
+	Logger Logger
+
+	// ApplyTimeout is how long to wait for raft to apply a command.
+	ApplyTimeout time.Duration
+}
+
+// Validate checks that this config can be used.
+func (config Config) Validate() error {
+	if config.Raft == nil {
+		return errors.NotValidf("nil Raft")
+	}
+	if config.Hub == nil {
+		return errors.NotValidf("nil Hub")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.ApplyTimeout <= 0 {
+		return errors.NotValidf("non-positive ApplyTimeout")
+	}
+	return nil
+}
+
+// NewWorker creates a new worker which applies lease commands
+// published on the central hub to the raft log. It must only be run
+// on the raft leader.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:   config,
+		requests: make(chan raftlease.ForwardRequest),
+	}
+	unsubscribe, err := config.Hub.Subscribe(raftlease.RequestTopic, w.requestReceived)
+	if err != nil {
+		return nil, errors.Annotatef(err, "subscribing to %q", raftlease.RequestTopic)
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: func() error {
+			defer unsubscribe()
+			return w.loop()
+		},
+	}); err != nil {
+		unsubscribe()
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker forwards lease commands from the central hub to raft.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	requests chan raftlease.ForwardRequest
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) requestReceived(_ string, req raftlease.ForwardRequest, err error) {
+	if err != nil {
+		w.config.Logger.Warningf("invalid lease request: %v", err)
+		return
+	}
+	select {
+	case w.requests <- req:
+	case <-w.catacomb.Dying():
+	}
+}
+
+func (w *Worker) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case req := <-w.requests:
+			if err := w.processRequest(req); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *Worker) processRequest(req raftlease.ForwardRequest) error {
+	applyErr := w.apply(req.Command)
+	if applyErr != nil {
+		w.config.Logger.Debugf("lease command failed: %v", applyErr)
+	}
+	_, err := w.config.Hub.Publish(req.ResponseTopic, raftlease.ForwardResponse{
+		Error: raftlease.AsResponseError(applyErr),
+	})
+	return errors.Annotatef(err, "publishing response to %q", req.ResponseTopic)
+}
+
+func (w *Worker) apply(data string) error {
+	command, err := raftlease.UnmarshalCommand([]byte(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The raft leader's clock is the authority on lease expiry;
+	// stamping the command here means that every FSM applying it
+	// sees the same time.
+	command.Time = w.config.Clock.Now()
+	bytes, err := command.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	future := w.config.Raft.Apply(bytes, w.config.ApplyTimeout)
+	if err := future.Error(); err != nil {
+		return errors.Trace(err)
+	}
+	response, ok := future.Response().(raftlease.FSMResponse)
+	if !ok {
+		return errors.Errorf("expected FSMResponse, got %T", future.Response())
+	}
+	return response.Error()
+}
@@ -0,0 +1,160 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder_test
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/pubsub/centralhub"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/raft/raftforwarder"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	raft   *fakeRaft
+	hub    *pubsub.StructuredHub
+	clock  *testing.Clock
+	config raftforwarder.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC))
+	s.raft = &fakeRaft{fsm: raftlease.NewFSM()}
+	s.hub = centralhub.New(names.NewMachineTag("0"))
+	s.config = raftforwarder.Config{
+		Raft:         s.raft,
+		Hub:          s.hub,
+		Clock:        s.clock,
+		Logger:       loggo.GetLogger("test"),
+		ApplyTimeout: time.Second,
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	type test struct {
+		f      func(*raftforwarder.Config)
+		expect string
+	}
+	tests := []test{{
+		func(cfg *raftforwarder.Config) { cfg.Raft = nil },
+		"nil Raft not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.Hub = nil },
+		"nil Hub not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.ApplyTimeout = 0 },
+		"non-positive ApplyTimeout not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test #%d (%s)", i, test.expect)
+		config := s.config
+		test.f(&config)
+		_, err := raftforwarder.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := raftforwarder.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *workerSuite) forwarder() *raftlease.PubsubForwarder {
+	return raftlease.NewPubsubForwarder(s.hub, clock.WallClock, "machine-1", coretesting.LongWait)
+}
+
+func (s *workerSuite) TestForwardsCommands(c *gc.C) {
+	s.startWorker(c)
+	err := s.forwarder().ForwardRequest(&raftlease.Command{
+		Version:   raftlease.CommandVersion,
+		Operation: raftlease.OperationClaim,
+		Namespace: "leadership",
+		ModelUUID: "model-uuid",
+		Lease:     "mysql",
+		Holder:    "mysql/0",
+		Duration:  time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The command is stamped with the leader's time.
+	leases := s.raft.fsm.Leases("leadership", "model-uuid")
+	c.Assert(leases["mysql"].Holder, gc.Equals, "mysql/0")
+	c.Assert(leases["mysql"].Expiry, gc.Equals, s.clock.Now().Add(time.Minute))
+}
+
+func (s *workerSuite) TestForwardsInvalid(c *gc.C) {
+	s.startWorker(c)
+	err := s.forwarder().ForwardRequest(&raftlease.Command{
+		Version:   raftlease.CommandVersion,
+		Operation: raftlease.OperationExpire,
+		Namespace: "leadership",
+		ModelUUID: "model-uuid",
+		Lease:     "mysql",
+	})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
+
+func (s *workerSuite) TestForwardsRaftErrors(c *gc.C) {
+	s.raft.err = raft.ErrNotLeader
+	s.startWorker(c)
+	err := s.forwarder().ForwardRequest(&raftlease.Command{
+		Version:   raftlease.CommandVersion,
+		Operation: raftlease.OperationExpire,
+		Namespace: "leadership",
+		ModelUUID: "model-uuid",
+		Lease:     "mysql",
+	})
+	c.Assert(err, gc.ErrorMatches, "node is not the leader")
+}
+
+type fakeRaft struct {
+	fsm *raftlease.FSM
+	err error
+}
+
+func (r *fakeRaft) Apply(cmd []byte, timeout time.Duration) raft.ApplyFuture {
+	if r.err != nil {
+		return &fakeApplyFuture{err: r.err}
+	}
+	return &fakeApplyFuture{response: r.fsm.Apply(&raft.Log{Data: cmd})}
+}
+
+type fakeApplyFuture struct {
+	raft.IndexFuture
+	response interface{}
+	err      error
+}
+
+func (f *fakeApplyFuture) Error() error {
+	return errors.Trace(f.err)
+}
+
+func (f *fakeApplyFuture) Response() interface{} {
+	return f.response
+}
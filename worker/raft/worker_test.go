@@ -14,6 +14,7 @@ import (
 	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/raftlease"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/raft"
 	"github.com/juju/juju/worker/raft/rafttest"
@@ -231,6 +232,46 @@ func (s *WorkerSuite) TestRestoreSnapshot(c *gc.C) {
 	})
 }
 
+func (s *WorkerSuite) TestUpgradeFromSimpleFSM(c *gc.C) {
+	// Controllers upgraded from before leases were kept in raft have
+	// snapshots and logs written with the SimpleFSM.
+	r := s.waitLeader(c)
+	f := r.Apply([]byte("command1"), time.Minute)
+	c.Assert(f.Error(), jc.ErrorIsNil)
+	c.Assert(r.Snapshot().Error(), jc.ErrorIsNil)
+	f = r.Apply([]byte("command2"), time.Minute)
+	c.Assert(f.Error(), jc.ErrorIsNil)
+	workertest.CleanKill(c, s.worker)
+
+	fsm := raftlease.NewFSM()
+	s.config.FSM = fsm
+	s.config.Transport = s.newTransport("123")
+	worker, err := raft.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		workertest.DirtyKill(c, worker)
+	})
+	s.worker = worker.(*raft.Worker)
+	r = s.waitLeader(c)
+
+	command := raftlease.Command{
+		Version:   raftlease.CommandVersion,
+		Operation: raftlease.OperationClaim,
+		Namespace: "leadership",
+		ModelUUID: "model-uuid",
+		Lease:     "mysql",
+		Holder:    "mysql/0",
+		Duration:  time.Minute,
+		Time:      time.Now(),
+	}
+	data, err := command.Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	f = r.Apply(data, time.Minute)
+	c.Assert(f.Error(), jc.ErrorIsNil)
+	c.Assert(f.Response().(raftlease.FSMResponse).Error(), jc.ErrorIsNil)
+	c.Assert(fsm.Leases("leadership", "model-uuid")["mysql"].Holder, gc.Equals, "mysql/0")
+}
+
 func (s *WorkerSuite) TestStartStop(c *gc.C) {
 	workertest.CleanKill(c, s.worker)
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -91,6 +92,29 @@ func (opc *operationCallbacks) NotifyHookFailed(hook string, ctx runner.Context)
 	}
 }
 
+// RecordHookExecution is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) RecordHookExecution(execution operation.HookExecution, ctx runner.Context) {
+	arg := params.HookExecution{
+		Kind:     execution.Kind,
+		Name:     execution.Name,
+		Started:  execution.Started,
+		Finished: execution.Finished,
+		ExitCode: execution.ExitCode,
+		Error:    execution.Error,
+	}
+	if r, err := ctx.HookRelation(); err == nil {
+		arg.Relation = r.FakeId()
+		arg.RemoteUnit, _ = ctx.RemoteUnitName()
+	}
+	err := opc.u.unit.RecordHookExecutions([]params.HookExecution{arg})
+	if errors.IsNotImplemented(err) {
+		// The controller doesn't keep hook history.
+		return
+	} else if err != nil {
+		logger.Warningf("cannot record %s execution: %v", execution.Kind, err)
+	}
+}
+
 // FailAction is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	if !names.IsValidAction(actionId) {
@@ -0,0 +1,70 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// HookExecutionHook identifies the run of a charm hook.
+	HookExecutionHook = "hook"
+
+	// HookExecutionAction identifies the run of an action.
+	HookExecutionAction = "action"
+
+	// HookExecutionCommands identifies the run of commands sent with
+	// juju run.
+	HookExecutionCommands = "commands"
+)
+
+// HookExecution describes a single run of a hook, action or commands,
+// to be recorded in the unit's hook history.
+type HookExecution struct {
+	// Kind is one of HookExecutionHook, HookExecutionAction or
+	// HookExecutionCommands.
+	Kind string
+
+	// Name is the name of the hook or action; it's empty for commands.
+	Name string
+
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode is the exit code of the hook, action or commands, and
+	// Error describes any failure.
+	ExitCode int
+	Error    string
+}
+
+// newHookExecution returns a HookExecution started at the given time,
+// finishing now, with the outcome described by err.
+func newHookExecution(kind, name string, started time.Time, err error) HookExecution {
+	execution := HookExecution{
+		Kind:     kind,
+		Name:     name,
+		Started:  started,
+		Finished: time.Now(),
+	}
+	if err != nil {
+		execution.ExitCode = exitCode(err)
+		execution.Error = err.Error()
+	}
+	return execution
+}
+
+// exitCode returns the exit status of the process that failed with the
+// given error. Failures other than a process exiting are reported as 1.
+func exitCode(err error) int {
+	if exitErr, ok := errors.Cause(err).(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus()
+		}
+	}
+	return 1
+}
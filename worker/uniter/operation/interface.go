@@ -149,6 +149,13 @@ type Callbacks interface {
 	NotifyHookCompleted(string, runner.Context)
 	NotifyHookFailed(string, runner.Context)
 
+	// RecordHookExecution adds the run of a hook, action or commands to
+	// the unit's hook history, taking any relation context from the
+	// supplied runner context. Recording is best-effort, so it doesn't
+	// return an error. It's used by RunHook, RunAction and RunCommands
+	// operations.
+	RecordHookExecution(HookExecution, runner.Context)
+
 	// The following methods exist primarily to allow us to test operation code
 	// without using a live api connection.
 
@@ -5,6 +5,8 @@ package operation
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/juju/errors"
 
@@ -73,7 +75,9 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
+	started := time.Now()
 	err := ra.runner.RunAction(ra.name)
+	ra.recordExecution(started, err)
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
@@ -87,6 +91,25 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// recordExecution records the run of the action, started at the given
+// time, in the unit's hook history. An action that failed inside the
+// runner reports its exit code and message in the action results.
+func (ra *runAction) recordExecution(started time.Time, err error) {
+	execution := newHookExecution(HookExecutionAction, ra.name, started, err)
+	if err == nil {
+		if actionData, err := ra.runner.Context().ActionData(); err == nil && actionData.Failed {
+			execution.Error = actionData.ResultsMessage
+			execution.ExitCode = 1
+			if code, ok := actionData.ResultsMap["Code"].(string); ok {
+				if n, err := strconv.Atoi(code); err == nil {
+					execution.ExitCode = n
+				}
+			}
+		}
+	}
+	ra.callbacks.RecordHookExecution(execution, ra.runner.Context())
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
@@ -224,9 +224,36 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 		c.Assert(newState, jc.DeepEquals, &test.after)
 		c.Assert(callbacks.executingMessage, gc.Equals, "running action some-action-name")
 		c.Assert(*runnerFactory.MockNewActionRunner.runner.MockRunAction.gotName, gc.Equals, "some-action-name")
+		c.Assert(callbacks.executions, gc.HasLen, 1)
+		c.Assert(callbacks.executions[0].Kind, gc.Equals, operation.HookExecutionAction)
+		c.Assert(callbacks.executions[0].Name, gc.Equals, "some-action-name")
+		c.Assert(callbacks.executions[0].ExitCode, gc.Equals, 0)
 	}
 }
 
+func (s *RunActionSuite) TestExecuteRecordsFailedAction(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	actionData := runnerFactory.MockNewActionRunner.runner.context.(*MockContext).actionData
+	actionData.Failed = true
+	actionData.ResultsMessage = "exit status 3"
+	actionData.ResultsMap = map[string]interface{}{"Code": "3"}
+	callbacks := &RunActionCallbacks{}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(callbacks.executions, gc.HasLen, 1)
+	c.Assert(callbacks.executions[0].ExitCode, gc.Equals, 3)
+	c.Assert(callbacks.executions[0].Error, gc.Equals, "exit status 3")
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
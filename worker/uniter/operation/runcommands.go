@@ -5,8 +5,10 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
+	utilexec "github.com/juju/utils/exec"
 
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -66,7 +68,9 @@ func (rc *runCommands) Execute(state State) (*State, error) {
 		return nil, errors.Trace(err)
 	}
 
+	started := time.Now()
 	response, err := rc.runner.RunCommands(rc.args.Commands)
+	rc.recordExecution(started, response, err)
 	switch err {
 	case context.ErrRequeueAndReboot:
 		logger.Warningf("cannot requeue external commands")
@@ -80,6 +84,20 @@ func (rc *runCommands) Execute(state State) (*State, error) {
 	return nil, err
 }
 
+// recordExecution records the run of the commands, started at the
+// given time, in the unit's hook history.
+func (rc *runCommands) recordExecution(started time.Time, response *utilexec.ExecResponse, err error) {
+	if err == context.ErrReboot || err == context.ErrRequeueAndReboot {
+		err = nil
+	}
+	execution := newHookExecution(HookExecutionCommands, "", started, err)
+	if err == nil && response != nil && response.Code != 0 {
+		execution.ExitCode = response.Code
+		execution.Error = fmt.Sprintf("exit status %d", response.Code)
+	}
+	rc.callbacks.RecordHookExecution(execution, rc.runner.Context())
+}
+
 // Commit does nothing.
 // Commit is part of the Operation interface.
 func (rc *runCommands) Commit(state State) (*State, error) {
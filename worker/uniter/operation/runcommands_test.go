@@ -137,6 +137,9 @@ func (s *RunCommandsSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(*runnerFactory.MockNewCommandRunner.runner.MockRunCommands.gotCommands, gc.Equals, "do something")
 	c.Assert(*sendResponse.gotResponse, gc.IsNil)
 	c.Assert(*sendResponse.gotErr, gc.ErrorMatches, "sneh")
+	c.Assert(callbacks.executions, gc.HasLen, 1)
+	c.Assert(callbacks.executions[0].Kind, gc.Equals, operation.HookExecutionCommands)
+	c.Assert(callbacks.executions[0].Error, gc.Equals, "sneh")
 }
 
 func (s *RunCommandsSuite) TestExecuteSuccess(c *gc.C) {
@@ -160,6 +163,10 @@ func (s *RunCommandsSuite) TestExecuteSuccess(c *gc.C) {
 	c.Assert(*runnerFactory.MockNewCommandRunner.runner.MockRunCommands.gotCommands, gc.Equals, "do something")
 	c.Assert(*sendResponse.gotResponse, gc.DeepEquals, &utilexec.ExecResponse{Code: 222})
 	c.Assert(*sendResponse.gotErr, jc.ErrorIsNil)
+	c.Assert(callbacks.executions, gc.HasLen, 1)
+	c.Assert(callbacks.executions[0].Kind, gc.Equals, operation.HookExecutionCommands)
+	c.Assert(callbacks.executions[0].ExitCode, gc.Equals, 222)
+	c.Assert(callbacks.executions[0].Error, gc.Equals, "exit status 222")
 }
 
 func (s *RunCommandsSuite) TestCommit(c *gc.C) {
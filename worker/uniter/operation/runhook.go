@@ -5,6 +5,7 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -98,6 +99,7 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	ranHook := true
 	step := Done
 
+	started := time.Now()
 	err := rh.runner.RunHook(rh.name)
 	cause := errors.Cause(err)
 	switch {
@@ -112,12 +114,28 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case err == nil:
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
+		rh.recordExecution(started, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		if charmrunner.IsHookTimedOutError(cause) {
+			// Record the timeout, so the unit's error status can
+			// say why the hook failed.
+			return stateChange{
+				Kind:         RunHook,
+				Step:         Pending,
+				Hook:         &rh.info,
+				HookTimedOut: true,
+			}.apply(state), ErrHookFailed
+		}
 		return nil, ErrHookFailed
 	}
 
 	if ranHook {
 		logger.Infof("ran %q hook", rh.name)
+		// As with the executing status, successful runs of the
+		// update-status hook aren't recorded.
+		if hooks.Kind(rh.name) != hooks.UpdateStatus {
+			rh.recordExecution(started, nil)
+		}
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
 		logger.Infof("skipped %q hook (missing)", rh.name)
@@ -136,6 +154,13 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// recordExecution records the run of the hook, started at the given
+// time, in the unit's hook history.
+func (rh *runHook) recordExecution(started time.Time, err error) {
+	execution := newHookExecution(HookExecutionHook, rh.name, started, err)
+	rh.callbacks.RecordHookExecution(execution, rh.runner.Context())
+}
+
 func (rh *runHook) beforeHook(state State) error {
 	var err error
 	switch rh.info.Kind {
@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -214,6 +216,7 @@ func (s *RunHookSuite) TestExecuteMissingHookError(c *gc.C) {
 		c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
 		c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 		c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+		c.Assert(callbacks.executions, gc.HasLen, 0)
 
 		status, err := runnerFactory.MockNewHookRunner.runner.Context().UnitStatus()
 		c.Assert(err, jc.ErrorIsNil)
@@ -272,6 +275,58 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+	c.Assert(callbacks.executions, gc.HasLen, 1)
+	execution := callbacks.executions[0]
+	c.Assert(execution.Kind, gc.Equals, operation.HookExecutionHook)
+	c.Assert(execution.Name, gc.Equals, "some-hook-name")
+	c.Assert(execution.ExitCode, gc.Equals, 1)
+	c.Assert(execution.Error, gc.Equals, "graaargh")
+	c.Assert(execution.Finished.Before(execution.Started), jc.IsFalse)
+}
+
+func (s *RunHookSuite) TestExecuteTimedOut(c *gc.C) {
+	runErr := charmrunner.NewHookTimedOutError("some-hook-name", 30*time.Minute)
+	op, callbacks, _ := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.Install, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:         operation.RunHook,
+		Step:         operation.Pending,
+		Hook:         &hook.Info{Kind: hooks.Install},
+		HookTimedOut: true,
+	})
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.executions, gc.HasLen, 1)
+	c.Assert(callbacks.executions[0].Error, gc.Equals, "hook timed out after 30m0s")
+}
+
+func (s *RunHookSuite) TestExecuteRecordsHookExecution(c *gc.C) {
+	op, callbacks, _ := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.ConfigChanged, nil)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(callbacks.executions, gc.HasLen, 1)
+	execution := callbacks.executions[0]
+	c.Assert(execution.Kind, gc.Equals, operation.HookExecutionHook)
+	c.Assert(execution.Name, gc.Equals, "some-hook-name")
+	c.Assert(execution.ExitCode, gc.Equals, 0)
+	c.Assert(execution.Error, gc.Equals, "")
+}
+
+func (s *RunHookSuite) TestExecuteUpdateStatusNotRecorded(c *gc.C) {
+	op, callbacks, _ := s.getExecuteRunnerTest(c, (operation.Factory).NewRunHook, hooks.UpdateStatus, nil)
+	callbacks.MockPrepareHook.name = "update-status"
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(callbacks.executions, gc.HasLen, 0)
 }
 
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
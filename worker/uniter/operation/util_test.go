@@ -117,6 +117,7 @@ type RunActionCallbacks struct {
 	operation.Callbacks
 	*MockFailAction
 	executingMessage string
+	executions       []operation.HookExecution
 }
 
 func (cb *RunActionCallbacks) FailAction(actionId, message string) error {
@@ -128,9 +129,14 @@ func (cb *RunActionCallbacks) SetExecutingStatus(message string) error {
 	return nil
 }
 
+func (cb *RunActionCallbacks) RecordHookExecution(execution operation.HookExecution, ctx runner.Context) {
+	cb.executions = append(cb.executions, execution)
+}
+
 type RunCommandsCallbacks struct {
 	operation.Callbacks
 	executingMessage string
+	executions       []operation.HookExecution
 }
 
 func (cb *RunCommandsCallbacks) SetExecutingStatus(message string) error {
@@ -138,6 +144,10 @@ func (cb *RunCommandsCallbacks) SetExecutingStatus(message string) error {
 	return nil
 }
 
+func (cb *RunCommandsCallbacks) RecordHookExecution(execution operation.HookExecution, ctx runner.Context) {
+	cb.executions = append(cb.executions, execution)
+}
+
 type MockPrepareHook struct {
 	gotHook *hook.Info
 	name    string
@@ -153,6 +163,7 @@ type PrepareHookCallbacks struct {
 	operation.Callbacks
 	*MockPrepareHook
 	executingMessage string
+	executions       []operation.HookExecution
 }
 
 func (cb *PrepareHookCallbacks) PrepareHook(hookInfo hook.Info) (string, error) {
@@ -164,6 +175,10 @@ func (cb *PrepareHookCallbacks) SetExecutingStatus(message string) error {
 	return nil
 }
 
+func (cb *PrepareHookCallbacks) RecordHookExecution(execution operation.HookExecution, ctx runner.Context) {
+	cb.executions = append(cb.executions, execution)
+}
+
 type MockNotify struct {
 	gotName    *string
 	gotContext *runner.Context
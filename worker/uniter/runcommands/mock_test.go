@@ -51,3 +51,7 @@ func (c *mockCallbacks) SetExecutingStatus(status string) error {
 	c.MethodCall(c, "SetExecutingStatus", status)
 	return c.NextErr()
 }
+
+func (c *mockCallbacks) RecordHookExecution(execution operation.HookExecution, ctx runner.Context) {
+	c.MethodCall(c, "RecordHookExecution", execution, ctx)
+}
@@ -229,6 +229,10 @@ type HookContext struct {
 	//  slaLevel contains the current SLA level.
 	slaLevel string
 
+	// hookTimeout is how long the unit's hooks may run for before
+	// they are killed; zero means there is no limit.
+	hookTimeout time.Duration
+
 	// The cloud specification
 	cloudSpec *params.CloudSpec
 }
@@ -408,6 +412,12 @@ func (ctx *HookContext) ResetExecutionSetUnitStatus() {
 	ctx.hasRunStatusSet = false
 }
 
+// HookTimeout returns how long hooks run in this context may run for
+// before they are killed. Zero means there is no limit.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *HookContext) PublicAddress() (string, error) {
 	if ctx.publicAddress == "" {
 		return "", errors.NotFoundf("public address")
@@ -559,6 +569,14 @@ func (ctx *HookContext) SetActionMessage(message string) error {
 	return nil
 }
 
+// LogActionMessage logs a progress message for the Action.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.LogActionMessage(ctx.actionData.Tag, message)
+}
+
 // SetActionFailed sets the fail state of the action.
 func (ctx *HookContext) SetActionFailed() error {
 	if ctx.actionData == nil {
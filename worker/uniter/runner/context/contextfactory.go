@@ -297,6 +297,14 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 	}
 	ctx.slaLevel = sla
 
+	ctx.hookTimeout, err = f.unit.HookTimeout()
+	if errors.IsNotImplemented(err) {
+		// The controller can't tell us, so hooks run unbounded.
+		err = nil
+	} else if err != nil {
+		return errors.Annotate(err, "could not retrieve the hook timeout")
+	}
+
 	// TODO(fwereade) 23-10-2014 bug 1384572
 	// Nothing here should ever be getting the environ config directly.
 	modelConfig, err := f.state.ModelConfig()
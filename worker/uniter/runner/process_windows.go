@@ -0,0 +1,20 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// setProcessGroup does nothing on Windows, where killProcessGroup
+// finds the process's children itself.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the given process and every process it
+// started.
+func killProcessGroup(p *os.Process) error {
+	return exec.Command("taskkill", "/F", "/T", "/PID", strconv.Itoa(p.Pid)).Run()
+}
@@ -53,6 +53,7 @@ type Context interface {
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
+	HookTimeout() time.Duration
 
 	Prepare() error
 	Flush(badge string, failure error) error
@@ -196,15 +197,19 @@ func (runner *runner) RunAction(actionName string) error {
 	if actionName == actions.JujuRunActionName {
 		return runner.runJujuRunAction()
 	}
-	return runner.runCharmHookWithLocation(actionName, "actions")
+	return runner.runCharmHookWithLocation(actionName, "actions", 0)
 }
 
 // RunHook exists to satisfy the Runner interface.
 func (runner *runner) RunHook(hookName string) error {
-	return runner.runCharmHookWithLocation(hookName, "hooks")
+	return runner.runCharmHookWithLocation(hookName, "hooks", runner.context.HookTimeout())
 }
 
-func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
+// runCharmHookWithLocation runs the named hook or action found in the
+// charm's charmLocation directory. If timeout is non-zero, the hook is
+// killed if it's still running once the timeout has elapsed; hooks run
+// via debug-hooks are never killed.
+func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, timeout time.Duration) error {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return err
@@ -227,12 +232,12 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		logger.Infof("executing %s via debug-hooks", hookName)
 		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	} else {
-		err = runner.runCharmHook(hookName, env, charmLocation)
+		err = runner.runCharmHook(hookName, env, charmLocation, timeout)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
-func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
+func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string, timeout time.Duration) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
 	if err != nil {
@@ -250,18 +255,47 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps.Stderr = outWriter
 	hookLogger := charmrunner.NewHookLogger(runner.getLogger(hookName), outReader)
 	go hookLogger.Run()
+	if timeout > 0 {
+		// Run the hook in its own process group, so that anything
+		// it starts is killed along with it if it times out.
+		setProcessGroup(ps)
+	}
 	err = ps.Start()
 	outWriter.Close()
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
 		// Block until execution finishes
-		err = ps.Wait()
+		err = waitWithTimeout(ps, hookName, timeout, clock.WallClock)
 	}
 	hookLogger.Stop()
 	return errors.Trace(err)
 }
 
+// waitWithTimeout waits for the hook process to finish. If the timeout
+// is non-zero and elapses first, the process and everything in its
+// process group are killed, and a hook timed out error is returned.
+func waitWithTimeout(ps *exec.Cmd, hookName string, timeout time.Duration, clock clock.Clock) error {
+	if timeout <= 0 {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-clock.After(timeout):
+	}
+	logger.Warningf("%s hook still running after %v; killing it", hookName, timeout)
+	if err := killProcessGroup(ps.Process); err != nil {
+		logger.Errorf("cannot kill %s hook: %v", hookName, err)
+	}
+	<-done
+	return charmrunner.NewHookTimedOutError(hookName, timeout)
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
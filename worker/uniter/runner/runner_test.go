@@ -156,6 +156,7 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	hookTimeout     time.Duration
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -181,6 +182,10 @@ func (ctx *MockContext) Prepare() error {
 	return nil
 }
 
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *MockContext) Flush(badge string, failure error) error {
 	ctx.flushBadge = badge
 	ctx.flushFailure = failure
@@ -253,6 +258,31 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook scripts can't sleep on windows")
+	}
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+	}
+	makeCharm(c, hookSpec{
+		dir:        "hooks",
+		name:       hookName,
+		perm:       0700,
+		background: "not printed",
+		sleep:      10,
+	}, s.paths.GetCharmDir())
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(charmrunner.IsHookTimedOutError(errors.Cause(ctx.flushFailure)), jc.IsTrue)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "hook timed out after 100ms")
+	if time.Now().Sub(t0) > 5*time.Second {
+		c.Errorf("hook not killed after timing out")
+	}
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{